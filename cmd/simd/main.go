@@ -27,6 +27,9 @@ import (
 // Env name for limiting candidate_run_ids to top N by score (0 or unset = all).
 const envTopCandidates = "SIMD_OPTIMIZATION_TOP_CANDIDATES"
 
+// Env name for the directory that scenario replay_file paths resolve in (unset = replay_file disabled).
+const envReplayDir = "SIMD_REPLAY_DIR"
+
 // Env name for optional comma-separated hostnames or IPs allowed for callback URL (even if private).
 const envCallbackWhitelist = "SIMULATION_CALLBACK_WHITELIST"
 
@@ -253,6 +256,7 @@ func (a *optimizationRunnerAdapter) RunExperiment(ctx context.Context, runID str
 }
 
 func main() {
+	config.SetReplayDir(os.Getenv(envReplayDir))
 	if len(os.Args) > 1 {
 		switch os.Args[1] {
 		case "validate":
@@ -275,7 +279,18 @@ func main() {
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 
-	store := simd.NewRunStore()
+	backend, err := simd.RunStoreBackendFromEnv()
+	if err != nil {
+		logger.Error("failed to open run store", "error", err)
+		stop()
+		os.Exit(1)
+	}
+	store, err := simd.NewRunStoreWithBackend(backend)
+	if err != nil {
+		logger.Error("failed to load run store", "error", err)
+		stop()
+		os.Exit(1)
+	}
 	store.SetOnlineLimits(simd.OnlineRunLimitsFromEnv())
 	executor := simd.NewRunExecutor(store, getCallbackWhitelist())
 	executor.SetOptimizationRunner(&optimizationRunnerAdapter{store: store, executor: executor})
@@ -323,13 +338,23 @@ func main() {
 	<-ctx.Done()
 	logger.Info("shutdown requested")
 	stop()
-	store.Stop()
 
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	// Stop accepting requests and drain runs before the final store flush, so no run
+	// writes to the store after it has stopped.
 	grpcServer.GracefulStop()
 	if err := httpSrv.Shutdown(shutdownCtx); err != nil {
 		logger.Error("HTTP shutdown error", "error", err)
 	}
+	if err := executor.Shutdown(shutdownCtx); err != nil {
+		logger.Error("run executor shutdown error", "error", err)
+	}
+	store.Stop()
+	if backend != nil {
+		if err := backend.Close(); err != nil {
+			logger.Error("run store close error", "error", err)
+		}
+	}
 }
@@ -87,6 +87,8 @@ const (
 	BatchScalingAction_QUEUE_SCALE_DOWN_CONCURRENCY            BatchScalingAction = 14
 	BatchScalingAction_TOPIC_SUBSCRIBER_SCALE_UP_CONCURRENCY   BatchScalingAction = 15
 	BatchScalingAction_TOPIC_SUBSCRIBER_SCALE_DOWN_CONCURRENCY BatchScalingAction = 16
+	// Switch a host to another host_types entry (its cores, memory, speed and price).
+	BatchScalingAction_HOST_CHANGE_TYPE BatchScalingAction = 17
 )
 
 // Enum value maps for BatchScalingAction.
@@ -109,6 +111,7 @@ var (
 		14: "QUEUE_SCALE_DOWN_CONCURRENCY",
 		15: "TOPIC_SUBSCRIBER_SCALE_UP_CONCURRENCY",
 		16: "TOPIC_SUBSCRIBER_SCALE_DOWN_CONCURRENCY",
+		17: "HOST_CHANGE_TYPE",
 	}
 	BatchScalingAction_value = map[string]int32{
 		"BATCH_SCALING_ACTION_UNSPECIFIED":        0,
@@ -128,6 +131,7 @@ var (
 		"QUEUE_SCALE_DOWN_CONCURRENCY":            14,
 		"TOPIC_SUBSCRIBER_SCALE_UP_CONCURRENCY":   15,
 		"TOPIC_SUBSCRIBER_SCALE_DOWN_CONCURRENCY": 16,
+		"HOST_CHANGE_TYPE":                        17,
 	}
 )
 
@@ -1375,7 +1379,7 @@ type InstancePlacementEntry struct {
 	InstanceId string                 `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
 	ServiceId  string                 `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
 	HostId     string                 `protobuf:"bytes,3,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
-	// ACTIVE or DRAINING
+	// ACTIVE, DRAINING or STARTING
 	Lifecycle         string  `protobuf:"bytes,4,opt,name=lifecycle,proto3" json:"lifecycle,omitempty"`
 	CpuCores          float64 `protobuf:"fixed64,5,opt,name=cpu_cores,json=cpuCores,proto3" json:"cpu_cores,omitempty"`
 	MemoryMb          float64 `protobuf:"fixed64,6,opt,name=memory_mb,json=memoryMb,proto3" json:"memory_mb,omitempty"`
@@ -1695,8 +1699,17 @@ type RunInput struct {
 	CallbackUrl string `protobuf:"bytes,7,opt,name=callback_url,json=callbackUrl,proto3" json:"callback_url,omitempty"`
 	// Optional secret sent as X-Simulation-Callback-Secret header for callback auth.
 	CallbackSecret string `protobuf:"bytes,8,opt,name=callback_secret,json=callbackSecret,proto3" json:"callback_secret,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Independent replications of a standard run (0 or 1 => single run). Replications run in parallel,
+	// each with its own seed derived from seed; the run reports mean, stddev and 95% confidence
+	// intervals across replications.
+	Replications int32 `protobuf:"varint,9,opt,name=replications,proto3" json:"replications,omitempty"`
+	// Stopping rule: when > 0, replications are added until the 95% CI half-width of every headline
+	// metric is within this fraction of its mean (e.g. 0.05 => +/-5%), or max_replications is reached.
+	CiRelativePrecision float64 `protobuf:"fixed64,10,opt,name=ci_relative_precision,json=ciRelativePrecision,proto3" json:"ci_relative_precision,omitempty"`
+	// Upper bound on replications for the stopping rule (0 => server limit).
+	MaxReplications int32 `protobuf:"varint,11,opt,name=max_replications,json=maxReplications,proto3" json:"max_replications,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *RunInput) Reset() {
@@ -1785,6 +1798,27 @@ func (x *RunInput) GetCallbackSecret() string {
 	return ""
 }
 
+func (x *RunInput) GetReplications() int32 {
+	if x != nil {
+		return x.Replications
+	}
+	return 0
+}
+
+func (x *RunInput) GetCiRelativePrecision() float64 {
+	if x != nil {
+		return x.CiRelativePrecision
+	}
+	return 0
+}
+
+func (x *RunInput) GetMaxReplications() int32 {
+	if x != nil {
+		return x.MaxReplications
+	}
+	return 0
+}
+
 // OptimizationConfig configures an optimization run (multi-run experiment).
 type OptimizationConfig struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -2313,8 +2347,12 @@ type BatchCostWeights struct {
 	HostCpu         float64                `protobuf:"fixed64,5,opt,name=host_cpu,json=hostCpu,proto3" json:"host_cpu,omitempty"`
 	HostMemoryGb    float64                `protobuf:"fixed64,6,opt,name=host_memory_gb,json=hostMemoryGb,proto3" json:"host_memory_gb,omitempty"`
 	Churn           float64                `protobuf:"fixed64,7,opt,name=churn,proto3" json:"churn,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Weight of the summed host_types price_per_hour of all hosts; 1 when unset.
+	// A host whose type has a price is costed by that price instead of host_cpu
+	// and host_memory_gb.
+	HostPrice     *float64 `protobuf:"fixed64,8,opt,name=host_price,json=hostPrice,proto3,oneof" json:"host_price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *BatchCostWeights) Reset() {
@@ -2396,6 +2434,13 @@ func (x *BatchCostWeights) GetChurn() float64 {
 	return 0
 }
 
+func (x *BatchCostWeights) GetHostPrice() float64 {
+	if x != nil && x.HostPrice != nil {
+		return *x.HostPrice
+	}
+	return 0
+}
+
 type BatchPenaltyWeights struct {
 	state                protoimpl.MessageState `protogen:"open.v1"`
 	P95                  float64                `protobuf:"fixed64,1,opt,name=p95,proto3" json:"p95,omitempty"`
@@ -3229,8 +3274,37 @@ type RunMetrics struct {
 	ExternalLatencyMsMean          float64 `protobuf:"fixed64,51,opt,name=external_latency_ms_mean,json=externalLatencyMsMean,proto3" json:"external_latency_ms_mean,omitempty"`
 	TopologyLatencyPenaltyMsTotal  float64 `protobuf:"fixed64,52,opt,name=topology_latency_penalty_ms_total,json=topologyLatencyPenaltyMsTotal,proto3" json:"topology_latency_penalty_ms_total,omitempty"`
 	TopologyLatencyPenaltyMsMean   float64 `protobuf:"fixed64,53,opt,name=topology_latency_penalty_ms_mean,json=topologyLatencyPenaltyMsMean,proto3" json:"topology_latency_penalty_ms_mean,omitempty"`
-	unknownFields                  protoimpl.UnknownFields
-	sizeCache                      protoimpl.SizeCache
+	// Per-flow session outcomes when the scenario defines flows.
+	FlowStats []*FlowStats `protobuf:"bytes,54,rep,name=flow_stats,json=flowStats,proto3" json:"flow_stats,omitempty"`
+	// Hedged request rollups (downstream calls with a hedge policy).
+	HedgedCallsTotal       int64   `protobuf:"varint,55,opt,name=hedged_calls_total,json=hedgedCallsTotal,proto3" json:"hedged_calls_total,omitempty"`
+	HedgeRequestsTotal     int64   `protobuf:"varint,56,opt,name=hedge_requests_total,json=hedgeRequestsTotal,proto3" json:"hedge_requests_total,omitempty"`
+	HedgeRate              float64 `protobuf:"fixed64,57,opt,name=hedge_rate,json=hedgeRate,proto3" json:"hedge_rate,omitempty"`
+	HedgeWinsTotal         int64   `protobuf:"varint,58,opt,name=hedge_wins_total,json=hedgeWinsTotal,proto3" json:"hedge_wins_total,omitempty"`
+	HedgeCancelledTotal    int64   `protobuf:"varint,59,opt,name=hedge_cancelled_total,json=hedgeCancelledTotal,proto3" json:"hedge_cancelled_total,omitempty"`
+	HedgeWastedCpuMsTotal  float64 `protobuf:"fixed64,60,opt,name=hedge_wasted_cpu_ms_total,json=hedgeWastedCpuMsTotal,proto3" json:"hedge_wasted_cpu_ms_total,omitempty"`
+	HedgeLatencyGainMsMean float64 `protobuf:"fixed64,61,opt,name=hedge_latency_gain_ms_mean,json=hedgeLatencyGainMsMean,proto3" json:"hedge_latency_gain_ms_mean,omitempty"`
+	HedgeLatencyGainMsP95  float64 `protobuf:"fixed64,62,opt,name=hedge_latency_gain_ms_p95,json=hedgeLatencyGainMsP95,proto3" json:"hedge_latency_gain_ms_p95,omitempty"`
+	// Memory limit enforcement (policies.oom): instances OOM-killed and restarts after a kill.
+	OomKillsTotal         int64 `protobuf:"varint,63,opt,name=oom_kills_total,json=oomKillsTotal,proto3" json:"oom_kills_total,omitempty"`
+	InstanceRestartsTotal int64 `protobuf:"varint,64,opt,name=instance_restarts_total,json=instanceRestartsTotal,proto3" json:"instance_restarts_total,omitempty"`
+	// Host failure injection (scenario host_failures). outage_availability is the fraction of
+	// outage_requests (ingress arrivals while a host was down) that did not fail.
+	HostFailuresTotal         int64   `protobuf:"varint,65,opt,name=host_failures_total,json=hostFailuresTotal,proto3" json:"host_failures_total,omitempty"`
+	InstancesLostTotal        int64   `protobuf:"varint,66,opt,name=instances_lost_total,json=instancesLostTotal,proto3" json:"instances_lost_total,omitempty"`
+	InstancesRescheduledTotal int64   `protobuf:"varint,67,opt,name=instances_rescheduled_total,json=instancesRescheduledTotal,proto3" json:"instances_rescheduled_total,omitempty"`
+	OutageRequests            int64   `protobuf:"varint,68,opt,name=outage_requests,json=outageRequests,proto3" json:"outage_requests,omitempty"`
+	OutageFailedRequests      int64   `protobuf:"varint,69,opt,name=outage_failed_requests,json=outageFailedRequests,proto3" json:"outage_failed_requests,omitempty"`
+	OutageAvailability        float64 `protobuf:"fixed64,70,opt,name=outage_availability,json=outageAvailability,proto3" json:"outage_availability,omitempty"`
+	// CFS throttling of services with a cpu_limit: time work waited with its quota used up, and
+	// throttled periods, in total and per instance.
+	CpuThrottledMsTotal      float64                     `protobuf:"fixed64,71,opt,name=cpu_throttled_ms_total,json=cpuThrottledMsTotal,proto3" json:"cpu_throttled_ms_total,omitempty"`
+	CpuThrottledPeriodsTotal int64                       `protobuf:"varint,72,opt,name=cpu_throttled_periods_total,json=cpuThrottledPeriodsTotal,proto3" json:"cpu_throttled_periods_total,omitempty"`
+	InstanceCpuThrottleStats []*InstanceCPUThrottleStats `protobuf:"bytes,73,rep,name=instance_cpu_throttle_stats,json=instanceCpuThrottleStats,proto3" json:"instance_cpu_throttle_stats,omitempty"`
+	// Ingress requests, failures, load shedding and latency per scenario priority class.
+	PriorityClassStats []*PriorityClassStats `protobuf:"bytes,74,rep,name=priority_class_stats,json=priorityClassStats,proto3" json:"priority_class_stats,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *RunMetrics) Reset() {
@@ -3634,6 +3708,153 @@ func (x *RunMetrics) GetTopologyLatencyPenaltyMsMean() float64 {
 	return 0
 }
 
+func (x *RunMetrics) GetFlowStats() []*FlowStats {
+	if x != nil {
+		return x.FlowStats
+	}
+	return nil
+}
+
+func (x *RunMetrics) GetHedgedCallsTotal() int64 {
+	if x != nil {
+		return x.HedgedCallsTotal
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetHedgeRequestsTotal() int64 {
+	if x != nil {
+		return x.HedgeRequestsTotal
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetHedgeRate() float64 {
+	if x != nil {
+		return x.HedgeRate
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetHedgeWinsTotal() int64 {
+	if x != nil {
+		return x.HedgeWinsTotal
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetHedgeCancelledTotal() int64 {
+	if x != nil {
+		return x.HedgeCancelledTotal
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetHedgeWastedCpuMsTotal() float64 {
+	if x != nil {
+		return x.HedgeWastedCpuMsTotal
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetHedgeLatencyGainMsMean() float64 {
+	if x != nil {
+		return x.HedgeLatencyGainMsMean
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetHedgeLatencyGainMsP95() float64 {
+	if x != nil {
+		return x.HedgeLatencyGainMsP95
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetOomKillsTotal() int64 {
+	if x != nil {
+		return x.OomKillsTotal
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetInstanceRestartsTotal() int64 {
+	if x != nil {
+		return x.InstanceRestartsTotal
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetHostFailuresTotal() int64 {
+	if x != nil {
+		return x.HostFailuresTotal
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetInstancesLostTotal() int64 {
+	if x != nil {
+		return x.InstancesLostTotal
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetInstancesRescheduledTotal() int64 {
+	if x != nil {
+		return x.InstancesRescheduledTotal
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetOutageRequests() int64 {
+	if x != nil {
+		return x.OutageRequests
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetOutageFailedRequests() int64 {
+	if x != nil {
+		return x.OutageFailedRequests
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetOutageAvailability() float64 {
+	if x != nil {
+		return x.OutageAvailability
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetCpuThrottledMsTotal() float64 {
+	if x != nil {
+		return x.CpuThrottledMsTotal
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetCpuThrottledPeriodsTotal() int64 {
+	if x != nil {
+		return x.CpuThrottledPeriodsTotal
+	}
+	return 0
+}
+
+func (x *RunMetrics) GetInstanceCpuThrottleStats() []*InstanceCPUThrottleStats {
+	if x != nil {
+		return x.InstanceCpuThrottleStats
+	}
+	return nil
+}
+
+func (x *RunMetrics) GetPriorityClassStats() []*PriorityClassStats {
+	if x != nil {
+		return x.PriorityClassStats
+	}
+	return nil
+}
+
 // EndpointRequestStats mirrors pkg/models.EndpointRequestStats (optional latencies use proto3 optional).
 type EndpointRequestStats struct {
 	state                   protoimpl.MessageState `protogen:"open.v1"`
@@ -3907,6 +4128,395 @@ func (x *InstanceRouteStats) GetSelectionCount() int64 {
 	return 0
 }
 
+// InstanceCPUThrottleStats mirrors pkg/models.InstanceCPUThrottleStats.
+type InstanceCPUThrottleStats struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ServiceName      string                 `protobuf:"bytes,1,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	InstanceId       string                 `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	ThrottledMs      float64                `protobuf:"fixed64,3,opt,name=throttled_ms,json=throttledMs,proto3" json:"throttled_ms,omitempty"`
+	ThrottledPeriods int64                  `protobuf:"varint,4,opt,name=throttled_periods,json=throttledPeriods,proto3" json:"throttled_periods,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *InstanceCPUThrottleStats) Reset() {
+	*x = InstanceCPUThrottleStats{}
+	mi := &file_simulation_v1_simulation_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InstanceCPUThrottleStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InstanceCPUThrottleStats) ProtoMessage() {}
+
+func (x *InstanceCPUThrottleStats) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_v1_simulation_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InstanceCPUThrottleStats.ProtoReflect.Descriptor instead.
+func (*InstanceCPUThrottleStats) Descriptor() ([]byte, []int) {
+	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *InstanceCPUThrottleStats) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *InstanceCPUThrottleStats) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *InstanceCPUThrottleStats) GetThrottledMs() float64 {
+	if x != nil {
+		return x.ThrottledMs
+	}
+	return 0
+}
+
+func (x *InstanceCPUThrottleStats) GetThrottledPeriods() int64 {
+	if x != nil {
+		return x.ThrottledPeriods
+	}
+	return 0
+}
+
+// PriorityClassStats mirrors pkg/models.PriorityClassStats (ingress traffic of one priority class).
+type PriorityClassStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PriorityClass  string                 `protobuf:"bytes,1,opt,name=priority_class,json=priorityClass,proto3" json:"priority_class,omitempty"`
+	Requests       int64                  `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`
+	FailedRequests int64                  `protobuf:"varint,3,opt,name=failed_requests,json=failedRequests,proto3" json:"failed_requests,omitempty"`
+	ErrorRate      float64                `protobuf:"fixed64,4,opt,name=error_rate,json=errorRate,proto3" json:"error_rate,omitempty"`
+	ShedAttempts   int64                  `protobuf:"varint,5,opt,name=shed_attempts,json=shedAttempts,proto3" json:"shed_attempts,omitempty"`
+	LatencyP50Ms   float64                `protobuf:"fixed64,6,opt,name=latency_p50_ms,json=latencyP50Ms,proto3" json:"latency_p50_ms,omitempty"`
+	LatencyP95Ms   float64                `protobuf:"fixed64,7,opt,name=latency_p95_ms,json=latencyP95Ms,proto3" json:"latency_p95_ms,omitempty"`
+	LatencyP99Ms   float64                `protobuf:"fixed64,8,opt,name=latency_p99_ms,json=latencyP99Ms,proto3" json:"latency_p99_ms,omitempty"`
+	LatencyMeanMs  float64                `protobuf:"fixed64,9,opt,name=latency_mean_ms,json=latencyMeanMs,proto3" json:"latency_mean_ms,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PriorityClassStats) Reset() {
+	*x = PriorityClassStats{}
+	mi := &file_simulation_v1_simulation_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriorityClassStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriorityClassStats) ProtoMessage() {}
+
+func (x *PriorityClassStats) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_v1_simulation_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriorityClassStats.ProtoReflect.Descriptor instead.
+func (*PriorityClassStats) Descriptor() ([]byte, []int) {
+	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *PriorityClassStats) GetPriorityClass() string {
+	if x != nil {
+		return x.PriorityClass
+	}
+	return ""
+}
+
+func (x *PriorityClassStats) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *PriorityClassStats) GetFailedRequests() int64 {
+	if x != nil {
+		return x.FailedRequests
+	}
+	return 0
+}
+
+func (x *PriorityClassStats) GetErrorRate() float64 {
+	if x != nil {
+		return x.ErrorRate
+	}
+	return 0
+}
+
+func (x *PriorityClassStats) GetShedAttempts() int64 {
+	if x != nil {
+		return x.ShedAttempts
+	}
+	return 0
+}
+
+func (x *PriorityClassStats) GetLatencyP50Ms() float64 {
+	if x != nil {
+		return x.LatencyP50Ms
+	}
+	return 0
+}
+
+func (x *PriorityClassStats) GetLatencyP95Ms() float64 {
+	if x != nil {
+		return x.LatencyP95Ms
+	}
+	return 0
+}
+
+func (x *PriorityClassStats) GetLatencyP99Ms() float64 {
+	if x != nil {
+		return x.LatencyP99Ms
+	}
+	return 0
+}
+
+func (x *PriorityClassStats) GetLatencyMeanMs() float64 {
+	if x != nil {
+		return x.LatencyMeanMs
+	}
+	return 0
+}
+
+// FlowStats mirrors pkg/models.FlowStats (sessions of one flow started during the run).
+type FlowStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	FlowId            string                 `protobuf:"bytes,1,opt,name=flow_id,json=flowId,proto3" json:"flow_id,omitempty"`
+	Sessions          int64                  `protobuf:"varint,2,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	CompletedSessions int64                  `protobuf:"varint,3,opt,name=completed_sessions,json=completedSessions,proto3" json:"completed_sessions,omitempty"`
+	AbandonedSessions int64                  `protobuf:"varint,4,opt,name=abandoned_sessions,json=abandonedSessions,proto3" json:"abandoned_sessions,omitempty"`
+	FailedSessions    int64                  `protobuf:"varint,5,opt,name=failed_sessions,json=failedSessions,proto3" json:"failed_sessions,omitempty"`
+	CompletionRate    float64                `protobuf:"fixed64,6,opt,name=completion_rate,json=completionRate,proto3" json:"completion_rate,omitempty"`
+	// Duration of completed sessions.
+	SessionDurationP50Ms  float64          `protobuf:"fixed64,7,opt,name=session_duration_p50_ms,json=sessionDurationP50Ms,proto3" json:"session_duration_p50_ms,omitempty"`
+	SessionDurationP95Ms  float64          `protobuf:"fixed64,8,opt,name=session_duration_p95_ms,json=sessionDurationP95Ms,proto3" json:"session_duration_p95_ms,omitempty"`
+	SessionDurationP99Ms  float64          `protobuf:"fixed64,9,opt,name=session_duration_p99_ms,json=sessionDurationP99Ms,proto3" json:"session_duration_p99_ms,omitempty"`
+	SessionDurationMeanMs float64          `protobuf:"fixed64,10,opt,name=session_duration_mean_ms,json=sessionDurationMeanMs,proto3" json:"session_duration_mean_ms,omitempty"`
+	Steps                 []*FlowStepStats `protobuf:"bytes,11,rep,name=steps,proto3" json:"steps,omitempty"`
+	// Sessions still running when the run ended.
+	InProgressSessions int64 `protobuf:"varint,12,opt,name=in_progress_sessions,json=inProgressSessions,proto3" json:"in_progress_sessions,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *FlowStats) Reset() {
+	*x = FlowStats{}
+	mi := &file_simulation_v1_simulation_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlowStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowStats) ProtoMessage() {}
+
+func (x *FlowStats) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_v1_simulation_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowStats.ProtoReflect.Descriptor instead.
+func (*FlowStats) Descriptor() ([]byte, []int) {
+	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *FlowStats) GetFlowId() string {
+	if x != nil {
+		return x.FlowId
+	}
+	return ""
+}
+
+func (x *FlowStats) GetSessions() int64 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+func (x *FlowStats) GetCompletedSessions() int64 {
+	if x != nil {
+		return x.CompletedSessions
+	}
+	return 0
+}
+
+func (x *FlowStats) GetAbandonedSessions() int64 {
+	if x != nil {
+		return x.AbandonedSessions
+	}
+	return 0
+}
+
+func (x *FlowStats) GetFailedSessions() int64 {
+	if x != nil {
+		return x.FailedSessions
+	}
+	return 0
+}
+
+func (x *FlowStats) GetCompletionRate() float64 {
+	if x != nil {
+		return x.CompletionRate
+	}
+	return 0
+}
+
+func (x *FlowStats) GetSessionDurationP50Ms() float64 {
+	if x != nil {
+		return x.SessionDurationP50Ms
+	}
+	return 0
+}
+
+func (x *FlowStats) GetSessionDurationP95Ms() float64 {
+	if x != nil {
+		return x.SessionDurationP95Ms
+	}
+	return 0
+}
+
+func (x *FlowStats) GetSessionDurationP99Ms() float64 {
+	if x != nil {
+		return x.SessionDurationP99Ms
+	}
+	return 0
+}
+
+func (x *FlowStats) GetSessionDurationMeanMs() float64 {
+	if x != nil {
+		return x.SessionDurationMeanMs
+	}
+	return 0
+}
+
+func (x *FlowStats) GetSteps() []*FlowStepStats {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *FlowStats) GetInProgressSessions() int64 {
+	if x != nil {
+		return x.InProgressSessions
+	}
+	return 0
+}
+
+type FlowStepStats struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Step            string                 `protobuf:"bytes,1,opt,name=step,proto3" json:"step,omitempty"`
+	Requests        int64                  `protobuf:"varint,2,opt,name=requests,proto3" json:"requests,omitempty"`
+	Abandoned       int64                  `protobuf:"varint,3,opt,name=abandoned,proto3" json:"abandoned,omitempty"`
+	Failed          int64                  `protobuf:"varint,4,opt,name=failed,proto3" json:"failed,omitempty"`
+	AbandonmentRate float64                `protobuf:"fixed64,5,opt,name=abandonment_rate,json=abandonmentRate,proto3" json:"abandonment_rate,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *FlowStepStats) Reset() {
+	*x = FlowStepStats{}
+	mi := &file_simulation_v1_simulation_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlowStepStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlowStepStats) ProtoMessage() {}
+
+func (x *FlowStepStats) ProtoReflect() protoreflect.Message {
+	mi := &file_simulation_v1_simulation_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlowStepStats.ProtoReflect.Descriptor instead.
+func (*FlowStepStats) Descriptor() ([]byte, []int) {
+	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *FlowStepStats) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *FlowStepStats) GetRequests() int64 {
+	if x != nil {
+		return x.Requests
+	}
+	return 0
+}
+
+func (x *FlowStepStats) GetAbandoned() int64 {
+	if x != nil {
+		return x.Abandoned
+	}
+	return 0
+}
+
+func (x *FlowStepStats) GetFailed() int64 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *FlowStepStats) GetAbandonmentRate() float64 {
+	if x != nil {
+		return x.AbandonmentRate
+	}
+	return 0
+}
+
 type HostMetrics struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	HostId            string                 `protobuf:"bytes,1,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
@@ -3918,7 +4528,7 @@ type HostMetrics struct {
 
 func (x *HostMetrics) Reset() {
 	*x = HostMetrics{}
-	mi := &file_simulation_v1_simulation_proto_msgTypes[39]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3930,7 +4540,7 @@ func (x *HostMetrics) String() string {
 func (*HostMetrics) ProtoMessage() {}
 
 func (x *HostMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_v1_simulation_proto_msgTypes[39]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3943,7 +4553,7 @@ func (x *HostMetrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HostMetrics.ProtoReflect.Descriptor instead.
 func (*HostMetrics) Descriptor() ([]byte, []int) {
-	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{39}
+	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *HostMetrics) GetHostId() string {
@@ -3998,7 +4608,7 @@ type ServiceMetrics struct {
 
 func (x *ServiceMetrics) Reset() {
 	*x = ServiceMetrics{}
-	mi := &file_simulation_v1_simulation_proto_msgTypes[40]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4010,7 +4620,7 @@ func (x *ServiceMetrics) String() string {
 func (*ServiceMetrics) ProtoMessage() {}
 
 func (x *ServiceMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_v1_simulation_proto_msgTypes[40]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4023,7 +4633,7 @@ func (x *ServiceMetrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceMetrics.ProtoReflect.Descriptor instead.
 func (*ServiceMetrics) Descriptor() ([]byte, []int) {
-	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{40}
+	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *ServiceMetrics) GetServiceName() string {
@@ -4184,7 +4794,7 @@ type RunEvent struct {
 
 func (x *RunEvent) Reset() {
 	*x = RunEvent{}
-	mi := &file_simulation_v1_simulation_proto_msgTypes[41]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4196,7 +4806,7 @@ func (x *RunEvent) String() string {
 func (*RunEvent) ProtoMessage() {}
 
 func (x *RunEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_v1_simulation_proto_msgTypes[41]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4209,7 +4819,7 @@ func (x *RunEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunEvent.ProtoReflect.Descriptor instead.
 func (*RunEvent) Descriptor() ([]byte, []int) {
-	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{41}
+	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *RunEvent) GetAtUnixMs() int64 {
@@ -4307,7 +4917,7 @@ type RunStatusChanged struct {
 
 func (x *RunStatusChanged) Reset() {
 	*x = RunStatusChanged{}
-	mi := &file_simulation_v1_simulation_proto_msgTypes[42]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4319,7 +4929,7 @@ func (x *RunStatusChanged) String() string {
 func (*RunStatusChanged) ProtoMessage() {}
 
 func (x *RunStatusChanged) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_v1_simulation_proto_msgTypes[42]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4332,7 +4942,7 @@ func (x *RunStatusChanged) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunStatusChanged.ProtoReflect.Descriptor instead.
 func (*RunStatusChanged) Descriptor() ([]byte, []int) {
-	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{42}
+	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *RunStatusChanged) GetPrevious() RunStatus {
@@ -4358,7 +4968,7 @@ type MetricsSnapshot struct {
 
 func (x *MetricsSnapshot) Reset() {
 	*x = MetricsSnapshot{}
-	mi := &file_simulation_v1_simulation_proto_msgTypes[43]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4370,7 +4980,7 @@ func (x *MetricsSnapshot) String() string {
 func (*MetricsSnapshot) ProtoMessage() {}
 
 func (x *MetricsSnapshot) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_v1_simulation_proto_msgTypes[43]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4383,7 +4993,7 @@ func (x *MetricsSnapshot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricsSnapshot.ProtoReflect.Descriptor instead.
 func (*MetricsSnapshot) Descriptor() ([]byte, []int) {
-	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{43}
+	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *MetricsSnapshot) GetMetrics() *RunMetrics {
@@ -4409,7 +5019,7 @@ type OptimizationProgress struct {
 
 func (x *OptimizationProgress) Reset() {
 	*x = OptimizationProgress{}
-	mi := &file_simulation_v1_simulation_proto_msgTypes[44]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4421,7 +5031,7 @@ func (x *OptimizationProgress) String() string {
 func (*OptimizationProgress) ProtoMessage() {}
 
 func (x *OptimizationProgress) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_v1_simulation_proto_msgTypes[44]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4434,7 +5044,7 @@ func (x *OptimizationProgress) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OptimizationProgress.ProtoReflect.Descriptor instead.
 func (*OptimizationProgress) Descriptor() ([]byte, []int) {
-	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{44}
+	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *OptimizationProgress) GetIteration() int32 {
@@ -4488,7 +5098,7 @@ type OptimizationStep struct {
 
 func (x *OptimizationStep) Reset() {
 	*x = OptimizationStep{}
-	mi := &file_simulation_v1_simulation_proto_msgTypes[45]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -4500,7 +5110,7 @@ func (x *OptimizationStep) String() string {
 func (*OptimizationStep) ProtoMessage() {}
 
 func (x *OptimizationStep) ProtoReflect() protoreflect.Message {
-	mi := &file_simulation_v1_simulation_proto_msgTypes[45]
+	mi := &file_simulation_v1_simulation_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4513,7 +5123,7 @@ func (x *OptimizationStep) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OptimizationStep.ProtoReflect.Descriptor instead.
 func (*OptimizationStep) Descriptor() ([]byte, []int) {
-	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{45}
+	return file_simulation_v1_simulation_proto_rawDescGZIP(), []int{49}
 }
 
 func (x *OptimizationStep) GetIterationIndex() int32 {
@@ -4653,7 +5263,7 @@ const file_simulation_v1_simulation_proto_rawDesc = "" +
 	"\x0fHostConfigEntry\x12\x17\n" +
 	"\ahost_id\x18\x01 \x01(\tR\x06hostId\x12\x1b\n" +
 	"\tcpu_cores\x18\x02 \x01(\x05R\bcpuCores\x12\x1b\n" +
-	"\tmemory_gb\x18\x03 \x01(\x05R\bmemoryGb\"\xbe\x02\n" +
+	"\tmemory_gb\x18\x03 \x01(\x05R\bmemoryGb\"\xc1\x03\n" +
 	"\bRunInput\x12#\n" +
 	"\rscenario_yaml\x18\x01 \x01(\tR\fscenarioYaml\x12\x1f\n" +
 	"\vconfig_yaml\x18\x02 \x01(\tR\n" +
@@ -4664,7 +5274,11 @@ const file_simulation_v1_simulation_proto_rawDesc = "" +
 	"\x0ereal_time_mode\x18\x05 \x01(\bR\frealTimeMode\x12E\n" +
 	"\foptimization\x18\x06 \x01(\v2!.simulation.v1.OptimizationConfigR\foptimization\x12!\n" +
 	"\fcallback_url\x18\a \x01(\tR\vcallbackUrl\x12'\n" +
-	"\x0fcallback_secret\x18\b \x01(\tR\x0ecallbackSecret\"\x94\r\n" +
+	"\x0fcallback_secret\x18\b \x01(\tR\x0ecallbackSecret\x12\"\n" +
+	"\freplications\x18\t \x01(\x05R\freplications\x122\n" +
+	"\x15ci_relative_precision\x18\n" +
+	" \x01(\x01R\x13ciRelativePrecision\x12)\n" +
+	"\x10max_replications\x18\v \x01(\x05R\x0fmaxReplications\"\x94\r\n" +
 	"\x12OptimizationConfig\x12\x1c\n" +
 	"\tobjective\x18\x01 \x01(\tR\tobjective\x12%\n" +
 	"\x0emax_iterations\x18\x02 \x01(\x05R\rmaxIterations\x12\x1b\n" +
@@ -4710,7 +5324,7 @@ const file_simulation_v1_simulation_proto_rawDesc = "" +
 	"\x14service_memory_ratio\x18\x03 \x01(\x01R\x12serviceMemoryRatio\x12&\n" +
 	"\x0fhost_count_step\x18\x04 \x01(\x05R\rhostCountStep\x12-\n" +
 	"\x13host_cpu_step_cores\x18\x05 \x01(\x05R\x10hostCpuStepCores\x12-\n" +
-	"\x13host_memory_step_gb\x18\x06 \x01(\x05R\x10hostMemoryStepGb\"\xe8\x01\n" +
+	"\x13host_memory_step_gb\x18\x06 \x01(\x05R\x10hostMemoryStepGb\"\x9b\x02\n" +
 	"\x10BatchCostWeights\x12\x1f\n" +
 	"\vservice_cpu\x18\x01 \x01(\x01R\n" +
 	"serviceCpu\x12*\n" +
@@ -4719,7 +5333,10 @@ const file_simulation_v1_simulation_proto_rawDesc = "" +
 	"\x05hosts\x18\x04 \x01(\x01R\x05hosts\x12\x19\n" +
 	"\bhost_cpu\x18\x05 \x01(\x01R\ahostCpu\x12$\n" +
 	"\x0ehost_memory_gb\x18\x06 \x01(\x01R\fhostMemoryGb\x12\x14\n" +
-	"\x05churn\x18\a \x01(\x01R\x05churn\"\xcd\x05\n" +
+	"\x05churn\x18\a \x01(\x01R\x05churn\x12\"\n" +
+	"\n" +
+	"host_price\x18\b \x01(\x01H\x00R\thostPrice\x88\x01\x01B\r\n" +
+	"\v_host_price\"\xcd\x05\n" +
 	"\x13BatchPenaltyWeights\x12\x10\n" +
 	"\x03p95\x18\x01 \x01(\x01R\x03p95\x12\x10\n" +
 	"\x03p99\x18\x02 \x01(\x01R\x03p99\x12\x1d\n" +
@@ -4822,7 +5439,7 @@ const file_simulation_v1_simulation_proto_rawDesc = "" +
 	"\x1dbatch_recommendation_feasible\x18\f \x01(\bR\x1bbatchRecommendationFeasible\x122\n" +
 	"\x15batch_violation_score\x18\r \x01(\x01R\x13batchViolationScore\x124\n" +
 	"\x16batch_efficiency_score\x18\x0e \x01(\x01R\x14batchEfficiencyScore\x12@\n" +
-	"\x1cbatch_recommendation_summary\x18\x0f \x01(\tR\x1abatchRecommendationSummary\"\x82\x17\n" +
+	"\x1cbatch_recommendation_summary\x18\x0f \x01(\tR\x1abatchRecommendationSummary\"\x8b \n" +
 	"\n" +
 	"RunMetrics\x12%\n" +
 	"\x0etotal_requests\x18\x01 \x01(\x03R\rtotalRequests\x12/\n" +
@@ -4878,7 +5495,30 @@ const file_simulation_v1_simulation_proto_rawDesc = "" +
 	"\x19external_latency_ms_total\x182 \x01(\x01R\x16externalLatencyMsTotal\x127\n" +
 	"\x18external_latency_ms_mean\x183 \x01(\x01R\x15externalLatencyMsMean\x12H\n" +
 	"!topology_latency_penalty_ms_total\x184 \x01(\x01R\x1dtopologyLatencyPenaltyMsTotal\x12F\n" +
-	" topology_latency_penalty_ms_mean\x185 \x01(\x01R\x1ctopologyLatencyPenaltyMsMean\"\xe8\n" +
+	" topology_latency_penalty_ms_mean\x185 \x01(\x01R\x1ctopologyLatencyPenaltyMsMean\x127\n" +
+	"\n" +
+	"flow_stats\x186 \x03(\v2\x18.simulation.v1.FlowStatsR\tflowStats\x12,\n" +
+	"\x12hedged_calls_total\x187 \x01(\x03R\x10hedgedCallsTotal\x120\n" +
+	"\x14hedge_requests_total\x188 \x01(\x03R\x12hedgeRequestsTotal\x12\x1d\n" +
+	"\n" +
+	"hedge_rate\x189 \x01(\x01R\thedgeRate\x12(\n" +
+	"\x10hedge_wins_total\x18: \x01(\x03R\x0ehedgeWinsTotal\x122\n" +
+	"\x15hedge_cancelled_total\x18; \x01(\x03R\x13hedgeCancelledTotal\x128\n" +
+	"\x19hedge_wasted_cpu_ms_total\x18< \x01(\x01R\x15hedgeWastedCpuMsTotal\x12:\n" +
+	"\x1ahedge_latency_gain_ms_mean\x18= \x01(\x01R\x16hedgeLatencyGainMsMean\x128\n" +
+	"\x19hedge_latency_gain_ms_p95\x18> \x01(\x01R\x15hedgeLatencyGainMsP95\x12&\n" +
+	"\x0foom_kills_total\x18? \x01(\x03R\roomKillsTotal\x126\n" +
+	"\x17instance_restarts_total\x18@ \x01(\x03R\x15instanceRestartsTotal\x12.\n" +
+	"\x13host_failures_total\x18A \x01(\x03R\x11hostFailuresTotal\x120\n" +
+	"\x14instances_lost_total\x18B \x01(\x03R\x12instancesLostTotal\x12>\n" +
+	"\x1binstances_rescheduled_total\x18C \x01(\x03R\x19instancesRescheduledTotal\x12'\n" +
+	"\x0foutage_requests\x18D \x01(\x03R\x0eoutageRequests\x124\n" +
+	"\x16outage_failed_requests\x18E \x01(\x03R\x14outageFailedRequests\x12/\n" +
+	"\x13outage_availability\x18F \x01(\x01R\x12outageAvailability\x123\n" +
+	"\x16cpu_throttled_ms_total\x18G \x01(\x01R\x13cpuThrottledMsTotal\x12=\n" +
+	"\x1bcpu_throttled_periods_total\x18H \x01(\x03R\x18cpuThrottledPeriodsTotal\x12f\n" +
+	"\x1binstance_cpu_throttle_stats\x18I \x03(\v2'.simulation.v1.InstanceCPUThrottleStatsR\x18instanceCpuThrottleStats\x12S\n" +
+	"\x14priority_class_stats\x18J \x03(\v2!.simulation.v1.PriorityClassStatsR\x12priorityClassStats\"\xe8\n" +
 	"\n" +
 	"\x14EndpointRequestStats\x12!\n" +
 	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12#\n" +
@@ -4926,7 +5566,44 @@ const file_simulation_v1_simulation_proto_rawDesc = "" +
 	"\vinstance_id\x18\x03 \x01(\tR\n" +
 	"instanceId\x12\x1a\n" +
 	"\bstrategy\x18\x04 \x01(\tR\bstrategy\x12'\n" +
-	"\x0fselection_count\x18\x05 \x01(\x03R\x0eselectionCount\"~\n" +
+	"\x0fselection_count\x18\x05 \x01(\x03R\x0eselectionCount\"\xae\x01\n" +
+	"\x18InstanceCPUThrottleStats\x12!\n" +
+	"\fservice_name\x18\x01 \x01(\tR\vserviceName\x12\x1f\n" +
+	"\vinstance_id\x18\x02 \x01(\tR\n" +
+	"instanceId\x12!\n" +
+	"\fthrottled_ms\x18\x03 \x01(\x01R\vthrottledMs\x12+\n" +
+	"\x11throttled_periods\x18\x04 \x01(\x03R\x10throttledPeriods\"\xde\x02\n" +
+	"\x12PriorityClassStats\x12%\n" +
+	"\x0epriority_class\x18\x01 \x01(\tR\rpriorityClass\x12\x1a\n" +
+	"\brequests\x18\x02 \x01(\x03R\brequests\x12'\n" +
+	"\x0ffailed_requests\x18\x03 \x01(\x03R\x0efailedRequests\x12\x1d\n" +
+	"\n" +
+	"error_rate\x18\x04 \x01(\x01R\terrorRate\x12#\n" +
+	"\rshed_attempts\x18\x05 \x01(\x03R\fshedAttempts\x12$\n" +
+	"\x0elatency_p50_ms\x18\x06 \x01(\x01R\flatencyP50Ms\x12$\n" +
+	"\x0elatency_p95_ms\x18\a \x01(\x01R\flatencyP95Ms\x12$\n" +
+	"\x0elatency_p99_ms\x18\b \x01(\x01R\flatencyP99Ms\x12&\n" +
+	"\x0flatency_mean_ms\x18\t \x01(\x01R\rlatencyMeanMs\"\xb4\x04\n" +
+	"\tFlowStats\x12\x17\n" +
+	"\aflow_id\x18\x01 \x01(\tR\x06flowId\x12\x1a\n" +
+	"\bsessions\x18\x02 \x01(\x03R\bsessions\x12-\n" +
+	"\x12completed_sessions\x18\x03 \x01(\x03R\x11completedSessions\x12-\n" +
+	"\x12abandoned_sessions\x18\x04 \x01(\x03R\x11abandonedSessions\x12'\n" +
+	"\x0ffailed_sessions\x18\x05 \x01(\x03R\x0efailedSessions\x12'\n" +
+	"\x0fcompletion_rate\x18\x06 \x01(\x01R\x0ecompletionRate\x125\n" +
+	"\x17session_duration_p50_ms\x18\a \x01(\x01R\x14sessionDurationP50Ms\x125\n" +
+	"\x17session_duration_p95_ms\x18\b \x01(\x01R\x14sessionDurationP95Ms\x125\n" +
+	"\x17session_duration_p99_ms\x18\t \x01(\x01R\x14sessionDurationP99Ms\x127\n" +
+	"\x18session_duration_mean_ms\x18\n" +
+	" \x01(\x01R\x15sessionDurationMeanMs\x122\n" +
+	"\x05steps\x18\v \x03(\v2\x1c.simulation.v1.FlowStepStatsR\x05steps\x120\n" +
+	"\x14in_progress_sessions\x18\f \x01(\x03R\x12inProgressSessions\"\xa0\x01\n" +
+	"\rFlowStepStats\x12\x12\n" +
+	"\x04step\x18\x01 \x01(\tR\x04step\x12\x1a\n" +
+	"\brequests\x18\x02 \x01(\x03R\brequests\x12\x1c\n" +
+	"\tabandoned\x18\x03 \x01(\x03R\tabandoned\x12\x16\n" +
+	"\x06failed\x18\x04 \x01(\x03R\x06failed\x12)\n" +
+	"\x10abandonment_rate\x18\x05 \x01(\x01R\x0fabandonmentRate\"~\n" +
 	"\vHostMetrics\x12\x17\n" +
 	"\ahost_id\x18\x01 \x01(\tR\x06hostId\x12'\n" +
 	"\x0fcpu_utilization\x18\x02 \x01(\x01R\x0ecpuUtilization\x12-\n" +
@@ -4985,7 +5662,7 @@ const file_simulation_v1_simulation_proto_rawDesc = "" +
 	"\x0ecurrent_config\x18\x06 \x01(\v2\x1f.simulation.v1.RunConfigurationR\rcurrentConfig*\\\n" +
 	"\x13BatchSearchStrategy\x12%\n" +
 	"!BATCH_SEARCH_STRATEGY_UNSPECIFIED\x10\x00\x12\x1e\n" +
-	"\x1aBATCH_SEARCH_STRATEGY_BEAM\x10\x01*\x80\x04\n" +
+	"\x1aBATCH_SEARCH_STRATEGY_BEAM\x10\x01*\x96\x04\n" +
 	"\x12BatchScalingAction\x12$\n" +
 	" BATCH_SCALING_ACTION_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11SERVICE_SCALE_OUT\x10\x01\x12\x14\n" +
@@ -5004,7 +5681,8 @@ const file_simulation_v1_simulation_proto_rawDesc = "" +
 	"\x1aQUEUE_SCALE_UP_CONCURRENCY\x10\r\x12 \n" +
 	"\x1cQUEUE_SCALE_DOWN_CONCURRENCY\x10\x0e\x12)\n" +
 	"%TOPIC_SUBSCRIBER_SCALE_UP_CONCURRENCY\x10\x0f\x12+\n" +
-	"'TOPIC_SUBSCRIBER_SCALE_DOWN_CONCURRENCY\x10\x10*\xba\x01\n" +
+	"'TOPIC_SUBSCRIBER_SCALE_DOWN_CONCURRENCY\x10\x10\x12\x14\n" +
+	"\x10HOST_CHANGE_TYPE\x10\x11*\xba\x01\n" +
 	"\tRunStatus\x12\x1a\n" +
 	"\x16RUN_STATUS_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12RUN_STATUS_PENDING\x10\x01\x12\x16\n" +
@@ -5039,7 +5717,7 @@ func file_simulation_v1_simulation_proto_rawDescGZIP() []byte {
 }
 
 var file_simulation_v1_simulation_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_simulation_v1_simulation_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
+var file_simulation_v1_simulation_proto_msgTypes = make([]protoimpl.MessageInfo, 50)
 var file_simulation_v1_simulation_proto_goTypes = []any{
 	(BatchSearchStrategy)(0),               // 0: simulation.v1.BatchSearchStrategy
 	(BatchScalingAction)(0),                // 1: simulation.v1.BatchScalingAction
@@ -5083,13 +5761,17 @@ var file_simulation_v1_simulation_proto_goTypes = []any{
 	(*RunMetrics)(nil),                     // 39: simulation.v1.RunMetrics
 	(*EndpointRequestStats)(nil),           // 40: simulation.v1.EndpointRequestStats
 	(*InstanceRouteStats)(nil),             // 41: simulation.v1.InstanceRouteStats
-	(*HostMetrics)(nil),                    // 42: simulation.v1.HostMetrics
-	(*ServiceMetrics)(nil),                 // 43: simulation.v1.ServiceMetrics
-	(*RunEvent)(nil),                       // 44: simulation.v1.RunEvent
-	(*RunStatusChanged)(nil),               // 45: simulation.v1.RunStatusChanged
-	(*MetricsSnapshot)(nil),                // 46: simulation.v1.MetricsSnapshot
-	(*OptimizationProgress)(nil),           // 47: simulation.v1.OptimizationProgress
-	(*OptimizationStep)(nil),               // 48: simulation.v1.OptimizationStep
+	(*InstanceCPUThrottleStats)(nil),       // 42: simulation.v1.InstanceCPUThrottleStats
+	(*PriorityClassStats)(nil),             // 43: simulation.v1.PriorityClassStats
+	(*FlowStats)(nil),                      // 44: simulation.v1.FlowStats
+	(*FlowStepStats)(nil),                  // 45: simulation.v1.FlowStepStats
+	(*HostMetrics)(nil),                    // 46: simulation.v1.HostMetrics
+	(*ServiceMetrics)(nil),                 // 47: simulation.v1.ServiceMetrics
+	(*RunEvent)(nil),                       // 48: simulation.v1.RunEvent
+	(*RunStatusChanged)(nil),               // 49: simulation.v1.RunStatusChanged
+	(*MetricsSnapshot)(nil),                // 50: simulation.v1.MetricsSnapshot
+	(*OptimizationProgress)(nil),           // 51: simulation.v1.OptimizationProgress
+	(*OptimizationStep)(nil),               // 52: simulation.v1.OptimizationStep
 }
 var file_simulation_v1_simulation_proto_depIdxs = []int32{
 	31, // 0: simulation.v1.CreateRunRequest.input:type_name -> simulation.v1.RunInput
@@ -5099,7 +5781,7 @@ var file_simulation_v1_simulation_proto_depIdxs = []int32{
 	38, // 4: simulation.v1.GetRunResponse.run:type_name -> simulation.v1.Run
 	38, // 5: simulation.v1.ListRunsResponse.runs:type_name -> simulation.v1.Run
 	39, // 6: simulation.v1.GetRunMetricsResponse.metrics:type_name -> simulation.v1.RunMetrics
-	44, // 7: simulation.v1.StreamRunEventsResponse.event:type_name -> simulation.v1.RunEvent
+	48, // 7: simulation.v1.StreamRunEventsResponse.event:type_name -> simulation.v1.RunEvent
 	38, // 8: simulation.v1.UpdateWorkloadRateResponse.run:type_name -> simulation.v1.Run
 	20, // 9: simulation.v1.UpdateRunConfigurationRequest.services:type_name -> simulation.v1.ServiceReplicasUpdate
 	38, // 10: simulation.v1.UpdateRunConfigurationResponse.run:type_name -> simulation.v1.Run
@@ -5121,46 +5803,50 @@ var file_simulation_v1_simulation_proto_depIdxs = []int32{
 	35, // 26: simulation.v1.BatchOptimizationConfig.cost_weights:type_name -> simulation.v1.BatchCostWeights
 	36, // 27: simulation.v1.BatchOptimizationConfig.penalty_weights:type_name -> simulation.v1.BatchPenaltyWeights
 	2,  // 28: simulation.v1.Run.status:type_name -> simulation.v1.RunStatus
-	43, // 29: simulation.v1.RunMetrics.service_metrics:type_name -> simulation.v1.ServiceMetrics
-	42, // 30: simulation.v1.RunMetrics.host_metrics:type_name -> simulation.v1.HostMetrics
+	47, // 29: simulation.v1.RunMetrics.service_metrics:type_name -> simulation.v1.ServiceMetrics
+	46, // 30: simulation.v1.RunMetrics.host_metrics:type_name -> simulation.v1.HostMetrics
 	40, // 31: simulation.v1.RunMetrics.endpoint_request_stats:type_name -> simulation.v1.EndpointRequestStats
 	41, // 32: simulation.v1.RunMetrics.instance_route_stats:type_name -> simulation.v1.InstanceRouteStats
-	45, // 33: simulation.v1.RunEvent.status_changed:type_name -> simulation.v1.RunStatusChanged
-	46, // 34: simulation.v1.RunEvent.metrics_snapshot:type_name -> simulation.v1.MetricsSnapshot
-	47, // 35: simulation.v1.RunEvent.optimization_progress:type_name -> simulation.v1.OptimizationProgress
-	48, // 36: simulation.v1.RunEvent.optimization_step:type_name -> simulation.v1.OptimizationStep
-	2,  // 37: simulation.v1.RunStatusChanged.previous:type_name -> simulation.v1.RunStatus
-	2,  // 38: simulation.v1.RunStatusChanged.current:type_name -> simulation.v1.RunStatus
-	39, // 39: simulation.v1.MetricsSnapshot.metrics:type_name -> simulation.v1.RunMetrics
-	26, // 40: simulation.v1.OptimizationStep.previous_config:type_name -> simulation.v1.RunConfiguration
-	26, // 41: simulation.v1.OptimizationStep.current_config:type_name -> simulation.v1.RunConfiguration
-	3,  // 42: simulation.v1.SimulationService.CreateRun:input_type -> simulation.v1.CreateRunRequest
-	5,  // 43: simulation.v1.SimulationService.StartRun:input_type -> simulation.v1.StartRunRequest
-	7,  // 44: simulation.v1.SimulationService.StopRun:input_type -> simulation.v1.StopRunRequest
-	9,  // 45: simulation.v1.SimulationService.GetRun:input_type -> simulation.v1.GetRunRequest
-	11, // 46: simulation.v1.SimulationService.ListRuns:input_type -> simulation.v1.ListRunsRequest
-	13, // 47: simulation.v1.SimulationService.GetRunMetrics:input_type -> simulation.v1.GetRunMetricsRequest
-	15, // 48: simulation.v1.SimulationService.StreamRunEvents:input_type -> simulation.v1.StreamRunEventsRequest
-	17, // 49: simulation.v1.SimulationService.UpdateWorkloadRate:input_type -> simulation.v1.UpdateWorkloadRateRequest
-	19, // 50: simulation.v1.SimulationService.UpdateRunConfiguration:input_type -> simulation.v1.UpdateRunConfigurationRequest
-	22, // 51: simulation.v1.SimulationService.GetRunConfiguration:input_type -> simulation.v1.GetRunConfigurationRequest
-	24, // 52: simulation.v1.SimulationService.RenewOnlineLease:input_type -> simulation.v1.RenewOnlineLeaseRequest
-	4,  // 53: simulation.v1.SimulationService.CreateRun:output_type -> simulation.v1.CreateRunResponse
-	6,  // 54: simulation.v1.SimulationService.StartRun:output_type -> simulation.v1.StartRunResponse
-	8,  // 55: simulation.v1.SimulationService.StopRun:output_type -> simulation.v1.StopRunResponse
-	10, // 56: simulation.v1.SimulationService.GetRun:output_type -> simulation.v1.GetRunResponse
-	12, // 57: simulation.v1.SimulationService.ListRuns:output_type -> simulation.v1.ListRunsResponse
-	14, // 58: simulation.v1.SimulationService.GetRunMetrics:output_type -> simulation.v1.GetRunMetricsResponse
-	16, // 59: simulation.v1.SimulationService.StreamRunEvents:output_type -> simulation.v1.StreamRunEventsResponse
-	18, // 60: simulation.v1.SimulationService.UpdateWorkloadRate:output_type -> simulation.v1.UpdateWorkloadRateResponse
-	21, // 61: simulation.v1.SimulationService.UpdateRunConfiguration:output_type -> simulation.v1.UpdateRunConfigurationResponse
-	23, // 62: simulation.v1.SimulationService.GetRunConfiguration:output_type -> simulation.v1.GetRunConfigurationResponse
-	25, // 63: simulation.v1.SimulationService.RenewOnlineLease:output_type -> simulation.v1.RenewOnlineLeaseResponse
-	53, // [53:64] is the sub-list for method output_type
-	42, // [42:53] is the sub-list for method input_type
-	42, // [42:42] is the sub-list for extension type_name
-	42, // [42:42] is the sub-list for extension extendee
-	0,  // [0:42] is the sub-list for field type_name
+	44, // 33: simulation.v1.RunMetrics.flow_stats:type_name -> simulation.v1.FlowStats
+	42, // 34: simulation.v1.RunMetrics.instance_cpu_throttle_stats:type_name -> simulation.v1.InstanceCPUThrottleStats
+	43, // 35: simulation.v1.RunMetrics.priority_class_stats:type_name -> simulation.v1.PriorityClassStats
+	45, // 36: simulation.v1.FlowStats.steps:type_name -> simulation.v1.FlowStepStats
+	49, // 37: simulation.v1.RunEvent.status_changed:type_name -> simulation.v1.RunStatusChanged
+	50, // 38: simulation.v1.RunEvent.metrics_snapshot:type_name -> simulation.v1.MetricsSnapshot
+	51, // 39: simulation.v1.RunEvent.optimization_progress:type_name -> simulation.v1.OptimizationProgress
+	52, // 40: simulation.v1.RunEvent.optimization_step:type_name -> simulation.v1.OptimizationStep
+	2,  // 41: simulation.v1.RunStatusChanged.previous:type_name -> simulation.v1.RunStatus
+	2,  // 42: simulation.v1.RunStatusChanged.current:type_name -> simulation.v1.RunStatus
+	39, // 43: simulation.v1.MetricsSnapshot.metrics:type_name -> simulation.v1.RunMetrics
+	26, // 44: simulation.v1.OptimizationStep.previous_config:type_name -> simulation.v1.RunConfiguration
+	26, // 45: simulation.v1.OptimizationStep.current_config:type_name -> simulation.v1.RunConfiguration
+	3,  // 46: simulation.v1.SimulationService.CreateRun:input_type -> simulation.v1.CreateRunRequest
+	5,  // 47: simulation.v1.SimulationService.StartRun:input_type -> simulation.v1.StartRunRequest
+	7,  // 48: simulation.v1.SimulationService.StopRun:input_type -> simulation.v1.StopRunRequest
+	9,  // 49: simulation.v1.SimulationService.GetRun:input_type -> simulation.v1.GetRunRequest
+	11, // 50: simulation.v1.SimulationService.ListRuns:input_type -> simulation.v1.ListRunsRequest
+	13, // 51: simulation.v1.SimulationService.GetRunMetrics:input_type -> simulation.v1.GetRunMetricsRequest
+	15, // 52: simulation.v1.SimulationService.StreamRunEvents:input_type -> simulation.v1.StreamRunEventsRequest
+	17, // 53: simulation.v1.SimulationService.UpdateWorkloadRate:input_type -> simulation.v1.UpdateWorkloadRateRequest
+	19, // 54: simulation.v1.SimulationService.UpdateRunConfiguration:input_type -> simulation.v1.UpdateRunConfigurationRequest
+	22, // 55: simulation.v1.SimulationService.GetRunConfiguration:input_type -> simulation.v1.GetRunConfigurationRequest
+	24, // 56: simulation.v1.SimulationService.RenewOnlineLease:input_type -> simulation.v1.RenewOnlineLeaseRequest
+	4,  // 57: simulation.v1.SimulationService.CreateRun:output_type -> simulation.v1.CreateRunResponse
+	6,  // 58: simulation.v1.SimulationService.StartRun:output_type -> simulation.v1.StartRunResponse
+	8,  // 59: simulation.v1.SimulationService.StopRun:output_type -> simulation.v1.StopRunResponse
+	10, // 60: simulation.v1.SimulationService.GetRun:output_type -> simulation.v1.GetRunResponse
+	12, // 61: simulation.v1.SimulationService.ListRuns:output_type -> simulation.v1.ListRunsResponse
+	14, // 62: simulation.v1.SimulationService.GetRunMetrics:output_type -> simulation.v1.GetRunMetricsResponse
+	16, // 63: simulation.v1.SimulationService.StreamRunEvents:output_type -> simulation.v1.StreamRunEventsResponse
+	18, // 64: simulation.v1.SimulationService.UpdateWorkloadRate:output_type -> simulation.v1.UpdateWorkloadRateResponse
+	21, // 65: simulation.v1.SimulationService.UpdateRunConfiguration:output_type -> simulation.v1.UpdateRunConfigurationResponse
+	23, // 66: simulation.v1.SimulationService.GetRunConfiguration:output_type -> simulation.v1.GetRunConfigurationResponse
+	25, // 67: simulation.v1.SimulationService.RenewOnlineLease:output_type -> simulation.v1.RenewOnlineLeaseResponse
+	57, // [57:68] is the sub-list for method output_type
+	46, // [46:57] is the sub-list for method input_type
+	46, // [46:46] is the sub-list for extension type_name
+	46, // [46:46] is the sub-list for extension extendee
+	0,  // [0:46] is the sub-list for field type_name
 }
 
 func init() { file_simulation_v1_simulation_proto_init() }
@@ -5168,9 +5854,10 @@ func file_simulation_v1_simulation_proto_init() {
 	if File_simulation_v1_simulation_proto != nil {
 		return
 	}
+	file_simulation_v1_simulation_proto_msgTypes[32].OneofWrappers = []any{}
 	file_simulation_v1_simulation_proto_msgTypes[34].OneofWrappers = []any{}
 	file_simulation_v1_simulation_proto_msgTypes[37].OneofWrappers = []any{}
-	file_simulation_v1_simulation_proto_msgTypes[41].OneofWrappers = []any{
+	file_simulation_v1_simulation_proto_msgTypes[45].OneofWrappers = []any{
 		(*RunEvent_StatusChanged)(nil),
 		(*RunEvent_MetricsSnapshot)(nil),
 		(*RunEvent_OptimizationProgress)(nil),
@@ -5182,7 +5869,7 @@ func file_simulation_v1_simulation_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_simulation_v1_simulation_proto_rawDesc), len(file_simulation_v1_simulation_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   46,
+			NumMessages:   50,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -45,6 +45,344 @@ func ConfigHash(s *config.Scenario) uint64 {
 			panic(err)
 		}
 	}
+	// writeOptB tells an omitted override flag, which inherits, from an explicit false.
+	writeOptB := func(x *bool) {
+		if x == nil {
+			writeStr("inherit")
+			return
+		}
+		writeB(*x)
+	}
+	// writeLatency leaves the hash of a plain mean/sigma spec unchanged.
+	writeLatency := func(l config.LatencySpec) {
+		writeF(l.Mean)
+		writeF(l.Sigma)
+		if l.Distribution == "" && len(l.Histogram) == 0 && len(l.Percentiles) == 0 {
+			return
+		}
+		writeStr("dist")
+		writeStr(l.Distribution)
+		writeI(len(l.Histogram))
+		for _, b := range l.Histogram {
+			writeF(b.UpToMs)
+			writeF(b.Weight)
+		}
+		writeI(len(l.Percentiles))
+		for _, pt := range l.Percentiles {
+			writeF(pt.P)
+			writeF(pt.Ms)
+		}
+	}
+	// writeQueueLimits leaves the hash of a service or endpoint without queue limits unchanged.
+	writeQueueLimits := func(q config.QueueLimits) {
+		if q == (config.QueueLimits{}) {
+			return
+		}
+		writeStr("queue_limits")
+		writeI(q.MaxQueueLength)
+		writeF(q.MaxQueueWaitMs)
+		writeStr(q.OnQueueFull)
+	}
+	writeAutoscaling := func(a *config.AutoscalingPolicy) {
+		if a == nil {
+			writeStr("as_nil")
+			return
+		}
+		writeStr("as")
+		writeB(a.Enabled)
+		writeF(a.TargetCPUUtil)
+		writeI(a.ScaleStep)
+		writeI(a.MinReplicas)
+		writeI(a.MaxReplicas)
+		writeI(a.SyncPeriodMs)
+		writeI(a.StabilizationWindowMs)
+		writeI(a.StartupDelayMs)
+	}
+	writeRateProfile := func(p *config.RateProfile) {
+		if p == nil {
+			writeStr("rp_nil")
+			return
+		}
+		writeStr("rp")
+		writeI(len(p.Points))
+		for _, pt := range p.Points {
+			writeF(pt.AtSeconds)
+			writeF(pt.RateRPS)
+		}
+		writeStr(p.Interpolation)
+		if d := p.Diurnal; d == nil {
+			writeStr("di_nil")
+		} else {
+			writeStr("di")
+			writeF(d.PeriodSeconds)
+			writeF(d.PeakRPS)
+			writeF(d.TroughRPS)
+			writeF(d.PeakAtSeconds)
+		}
+		writeI(len(p.Spikes))
+		for _, sp := range p.Spikes {
+			writeF(sp.AtSeconds)
+			writeF(sp.DurationSeconds)
+			writeF(sp.Multiplier)
+		}
+	}
+	// writeReplayTrace hashes the records of a replay trace rather than where they come from, so
+	// an edited replay_file changes the hash. Arrivals without a trace keep their older hash.
+	writeReplayTrace := func(a config.ArrivalSpec) {
+		if a.ReplayFile == "" && a.ReplayData == "" {
+			writeStr("")
+			writeStr("")
+			return
+		}
+		records, err := config.LoadReplayTrace(a)
+		if err != nil {
+			writeStr("trace_err")
+			writeStr(a.ReplayFile)
+			writeStr(a.ReplayData)
+			return
+		}
+		writeStr("trace")
+		writeI(len(records))
+		for _, r := range records {
+			writeI64(int64(r.Offset))
+			writeStr(r.ServiceID)
+			writeStr(r.EndpointPath)
+			keys := make([]string, 0, len(r.Metadata))
+			for k := range r.Metadata {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			writeI(len(keys))
+			for _, k := range keys {
+				writeStr(k)
+				writeStr(r.Metadata[k])
+			}
+		}
+	}
+	writeArrival := func(a config.ArrivalSpec) {
+		writeStr(a.Type)
+		writeF(a.RateRPS)
+		writeF(a.StdDevRPS)
+		writeF(a.BurstRateRPS)
+		writeF(a.BurstDurationSeconds)
+		writeF(a.QuietDurationSeconds)
+		writeReplayTrace(a)
+		writeStr(a.ReplayFormat)
+		writeF(a.TimeScale)
+		writeF(a.Amplification)
+		writeRateProfile(a.RateProfile)
+		writeI(a.Users)
+		writeLatency(a.ThinkTimeMs)
+		writeF(a.RampUpSeconds)
+		writeF(a.ParetoShape)
+		writeF(a.InterarrivalCV)
+		if m := a.MMPP; m == nil {
+			writeStr("mmpp_nil")
+		} else {
+			writeStr("mmpp")
+			writeI(len(m.States))
+			for _, st := range m.States {
+				writeF(st.RateRPS)
+				writeF(st.MeanDurationSeconds)
+			}
+			writeI(len(m.Transitions))
+			for _, row := range m.Transitions {
+				writeI(len(row))
+				for _, p := range row {
+					writeF(p)
+				}
+			}
+			writeI(m.InitialState)
+		}
+		if ss := a.SelfSimilar; ss == nil {
+			writeStr("ss_nil")
+		} else {
+			writeStr("ss")
+			writeF(ss.Hurst)
+			writeI(ss.Sources)
+			writeF(ss.MeanPeriodSeconds)
+		}
+	}
+	writeStringMap := func(m map[string]string) {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		writeI(len(keys))
+		for _, k := range keys {
+			writeStr(k)
+			writeStr(m[k])
+		}
+	}
+	// writeWeightedMetadata and writeConditions write nothing when unset, keeping older hashes.
+	writeWeightedMetadata := func(m map[string]map[string]float64) {
+		if len(m) == 0 {
+			return
+		}
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		writeStr("wmd")
+		writeI(len(keys))
+		for _, k := range keys {
+			values := make([]string, 0, len(m[k]))
+			for v := range m[k] {
+				values = append(values, v)
+			}
+			sort.Strings(values)
+			writeStr(k)
+			writeI(len(values))
+			for _, v := range values {
+				writeStr(v)
+				writeF(m[k][v])
+			}
+		}
+	}
+	writeOptF := func(x *float64) {
+		if x == nil {
+			writeStr("nil")
+			return
+		}
+		writeF(*x)
+	}
+	writeConditions := func(conds []config.MetadataCondition) {
+		if len(conds) == 0 {
+			return
+		}
+		writeStr("when")
+		writeI(len(conds))
+		for _, c := range conds {
+			writeStr(c.Key)
+			if c.Equals == nil {
+				writeStr("eq_nil")
+			} else {
+				writeStr("eq")
+				writeStr(*c.Equals)
+			}
+			writeI(len(c.In))
+			for _, v := range c.In {
+				writeStr(v)
+			}
+			writeStr(c.Regex)
+			writeOptF(c.GT)
+			writeOptF(c.GTE)
+			writeOptF(c.LT)
+			writeOptF(c.LTE)
+		}
+	}
+	// writeHedge writes nothing when unset, keeping older hashes.
+	writeHedge := func(h *config.HedgePolicy) {
+		if h == nil {
+			return
+		}
+		writeStr("hedge")
+		writeF(h.DelayMs)
+		writeF(h.DelayPercentile)
+		writeI(h.MaxHedges)
+		if h.CancelLosers == nil {
+			writeStr("cl_nil")
+		} else {
+			writeB(*h.CancelLosers)
+		}
+	}
+	// writeExecution writes nothing when unset, keeping older hashes.
+	writeExecution := func(stages []config.ExecutionStage) {
+		if len(stages) == 0 {
+			return
+		}
+		writeStr("exec")
+		writeI(len(stages))
+		for i := range stages {
+			groups := stages[i].StageGroups()
+			writeI(len(groups))
+			for _, g := range groups {
+				writeI(len(g.Calls))
+				for _, to := range g.Calls {
+					writeStr(to)
+				}
+				writeI(g.Quorum)
+			}
+		}
+	}
+	writeRetries := func(r *config.RetryPolicy) {
+		if r == nil {
+			writeStr("ret_nil")
+			return
+		}
+		writeStr("ret")
+		writeB(r.Enabled)
+		writeI(r.MaxRetries)
+		writeStr(r.Backoff)
+		writeI(r.BaseMs)
+	}
+	writeCircuitBreaker := func(cb *config.CircuitBreakerPolicy) {
+		if cb == nil {
+			writeStr("cb_nil")
+			return
+		}
+		writeStr("cb")
+		writeB(cb.Enabled)
+		writeI(cb.FailureThreshold)
+		writeI(cb.SuccessThreshold)
+		writeI(cb.TimeoutMs)
+	}
+	writeRateLimiting := func(rl *config.RateLimitingPolicy) {
+		if rl == nil {
+			writeStr("rl_nil")
+			return
+		}
+		writeStr("rl")
+		writeB(rl.Enabled)
+		writeI(rl.RateLimitPerSecond)
+	}
+	writePolicyOverrides := func(p *config.PolicyOverrides) {
+		if p == nil {
+			writeStr("po_nil")
+			return
+		}
+		writeStr("po")
+		if a := p.Autoscaling; a != nil {
+			writeStr("as")
+			writeOptB(a.Enabled)
+			writeF(a.TargetCPUUtil)
+			writeI(a.ScaleStep)
+			writeI(a.MinReplicas)
+			writeI(a.MaxReplicas)
+			writeI(a.SyncPeriodMs)
+			writeI(a.StabilizationWindowMs)
+			writeI(a.StartupDelayMs)
+		} else {
+			writeStr("as_nil")
+		}
+		if r := p.Retries; r != nil {
+			writeStr("ret")
+			writeOptB(r.Enabled)
+			writeI(r.MaxRetries)
+			writeStr(r.Backoff)
+			writeI(r.BaseMs)
+		} else {
+			writeStr("ret_nil")
+		}
+		if cb := p.CircuitBreaker; cb != nil {
+			writeStr("cb")
+			writeOptB(cb.Enabled)
+			writeI(cb.FailureThreshold)
+			writeI(cb.SuccessThreshold)
+			writeI(cb.TimeoutMs)
+		} else {
+			writeStr("cb_nil")
+		}
+		if rl := p.RateLimiting; rl != nil {
+			writeStr("rl")
+			writeOptB(rl.Enabled)
+			writeI(rl.RateLimitPerSecond)
+		} else {
+			writeStr("rl_nil")
+		}
+	}
 
 	// --- metadata ---
 	if s.Metadata == nil {
@@ -69,14 +407,10 @@ func ConfigHash(s *config.Scenario) uint64 {
 	} else {
 		writeStr("net")
 		writeB(s.Network.SymmetricCrossZoneLatency)
-		writeF(s.Network.SameHostLatencyMs.Mean)
-		writeF(s.Network.SameHostLatencyMs.Sigma)
-		writeF(s.Network.SameZoneLatencyMs.Mean)
-		writeF(s.Network.SameZoneLatencyMs.Sigma)
-		writeF(s.Network.DefaultCrossZoneLatencyMs.Mean)
-		writeF(s.Network.DefaultCrossZoneLatencyMs.Sigma)
-		writeF(s.Network.ExternalLatencyMs.Mean)
-		writeF(s.Network.ExternalLatencyMs.Sigma)
+		writeLatency(s.Network.SameHostLatencyMs)
+		writeLatency(s.Network.SameZoneLatencyMs)
+		writeLatency(s.Network.DefaultCrossZoneLatencyMs)
+		writeLatency(s.Network.ExternalLatencyMs)
 		var fromKeys []string
 		for k := range s.Network.CrossZoneLatencyMs {
 			fromKeys = append(fromKeys, k)
@@ -93,8 +427,7 @@ func ConfigHash(s *config.Scenario) uint64 {
 			for _, to := range toKeys {
 				writeStr(to)
 				ls := m[to]
-				writeF(ls.Mean)
-				writeF(ls.Sigma)
+				writeLatency(ls)
 			}
 		}
 	}
@@ -119,6 +452,10 @@ func ConfigHash(s *config.Scenario) uint64 {
 		writeI(hh.Cores)
 		writeI(hh.MemoryGB)
 		writeStr(hh.Zone)
+		if hh.Type != "" {
+			writeStr("type")
+			writeStr(hh.Type)
+		}
 		if len(hh.Labels) == 0 {
 			writeI(0)
 		} else {
@@ -135,6 +472,17 @@ func ConfigHash(s *config.Scenario) uint64 {
 		}
 	}
 
+	// --- host types (catalog order) ---
+	for i := range s.HostTypes {
+		t := &s.HostTypes[i]
+		writeStr("host_type")
+		writeStr(t.ID)
+		writeI(t.Cores)
+		writeI(t.MemoryGB)
+		writeF(t.SpeedFactor)
+		writeF(t.PricePerHour)
+	}
+
 	// --- services (canonical: by service ID) ---
 	svcIDs := make([]string, len(s.Services))
 	for i := range s.Services {
@@ -157,13 +505,40 @@ func ConfigHash(s *config.Scenario) uint64 {
 		writeI(sv.Replicas)
 		writeStr(sv.Model)
 		writeF(sv.CPUCores)
+		if sv.CPULimit > 0 {
+			writeStr("cpu_limit")
+			writeF(sv.CPULimit)
+		}
 		writeF(sv.MemoryMB)
 		if sv.ExternalNetworkLatencyMs == nil {
 			writeStr("extnetlat_nil")
 		} else {
 			writeStr("extnetlat")
-			writeF(sv.ExternalNetworkLatencyMs.Mean)
-			writeF(sv.ExternalNetworkLatencyMs.Sigma)
+			writeLatency(*sv.ExternalNetworkLatencyMs)
+		}
+		if st := sv.Startup; st != nil {
+			writeStr("startup")
+			writeLatency(st.DelayMs)
+			writeF(st.ReadinessDelayMs)
+			writeF(st.WarmupMs)
+			writeF(st.WarmupCPUMultiplier)
+		}
+		if sc := sv.Scheduling; sc != nil {
+			writeStr("scheduling")
+			writeStr(sc.Discipline)
+			writeF(sc.TargetDelayMs)
+			writeF(sc.IntervalMs)
+			writeStr(sc.PriorityKey)
+		}
+		writeQueueLimits(sv.QueueLimits)
+		if len(sv.LoadShedding) > 0 {
+			writeStr("load_shedding")
+			writeI(len(sv.LoadShedding))
+			for _, r := range sv.LoadShedding {
+				writeStr(r.PriorityClass)
+				writeF(r.UtilizationThreshold)
+				writeI(r.QueueThreshold)
+			}
 		}
 		if sv.Scaling == nil {
 			writeStr("scaling_nil")
@@ -261,6 +636,7 @@ func ConfigHash(s *config.Scenario) uint64 {
 				}
 			}
 		}
+		writePolicyOverrides(sv.Policies)
 		if sv.Behavior == nil {
 			writeStr("beh_nil")
 		} else {
@@ -274,10 +650,24 @@ func ConfigHash(s *config.Scenario) uint64 {
 			} else {
 				writeStr("cache")
 				writeF(b.Cache.HitRate)
-				writeF(b.Cache.HitLatencyMs.Mean)
-				writeF(b.Cache.HitLatencyMs.Sigma)
-				writeF(b.Cache.MissLatencyMs.Mean)
-				writeF(b.Cache.MissLatencyMs.Sigma)
+				writeLatency(b.Cache.HitLatencyMs)
+				writeLatency(b.Cache.MissLatencyMs)
+				if k := b.Cache.KeySpace; k != nil {
+					writeStr("key_space")
+					writeI(k.Keys)
+					writeF(k.ZipfS)
+					writeI(k.CapacityKeys)
+					writeF(k.TTLMs)
+					writeStr(k.Eviction)
+					writeStr(k.KeyFrom)
+				}
+				if b.Cache.MissCall != "" {
+					writeStr("miss_call")
+					writeStr(b.Cache.MissCall)
+				}
+				if b.Cache.CoalesceMisses {
+					writeStr("coalesce_misses")
+				}
 			}
 			if b.Queue == nil {
 				writeStr("queue_nil")
@@ -289,8 +679,7 @@ func ConfigHash(s *config.Scenario) uint64 {
 				writeI(q.MinConsumerConcurrency)
 				writeI(q.MaxConsumerConcurrency)
 				writeStr(q.ConsumerTarget)
-				writeF(q.DeliveryLatencyMs.Mean)
-				writeF(q.DeliveryLatencyMs.Sigma)
+				writeLatency(q.DeliveryLatencyMs)
 				writeF(q.AckTimeoutMs)
 				writeI(q.MaxRedeliveries)
 				writeStr(q.DLQTarget)
@@ -305,8 +694,7 @@ func ConfigHash(s *config.Scenario) uint64 {
 				writeI(t.Partitions)
 				writeI64(t.RetentionMs)
 				writeI(t.Capacity)
-				writeF(t.DeliveryLatencyMs.Mean)
-				writeF(t.DeliveryLatencyMs.Sigma)
+				writeLatency(t.DeliveryLatencyMs)
 				writeStr(t.PublishAck)
 				writeB(t.AsyncFireAndForget)
 				subs := config.CanonicalTopicSubscribersForHash(t)
@@ -342,13 +730,12 @@ func ConfigHash(s *config.Scenario) uint64 {
 			ep := sv.Endpoints[ei]
 			writeStr("ep")
 			writeStr(ep.Path)
-			writeF(ep.MeanCPUMs)
-			writeF(ep.CPUSigmaMs)
+			writeLatency(ep.CPUSpec())
 			writeF(ep.DefaultMemoryMB)
 			writeF(ep.FailureRate)
 			writeF(ep.TimeoutMs)
-			writeF(ep.IOMs.Mean)
-			writeF(ep.IOMs.Sigma)
+			writeQueueLimits(ep.QueueLimits)
+			writeLatency(ep.IOMs)
 			writeI(ep.ConnectionPool)
 			if ep.Routing == nil {
 				writeStr("ep_routing_nil")
@@ -372,8 +759,8 @@ func ConfigHash(s *config.Scenario) uint64 {
 					}
 				}
 			}
-			writeF(ep.NetLatencyMs.Mean)
-			writeF(ep.NetLatencyMs.Sigma)
+			writeLatency(ep.NetLatencyMs)
+			writePolicyOverrides(ep.Policies)
 
 			dsIdx := make([]int, len(ep.Downstream))
 			for i := range dsIdx {
@@ -402,6 +789,9 @@ func ConfigHash(s *config.Scenario) uint64 {
 				if a.CallLatencyMs.Sigma != b.CallLatencyMs.Sigma {
 					return a.CallLatencyMs.Sigma < b.CallLatencyMs.Sigma
 				}
+				if a.CallLatencyMs.Distribution != b.CallLatencyMs.Distribution {
+					return a.CallLatencyMs.Distribution < b.CallLatencyMs.Distribution
+				}
 				if a.TimeoutMs != b.TimeoutMs {
 					return a.TimeoutMs < b.TimeoutMs
 				}
@@ -438,8 +828,7 @@ func ConfigHash(s *config.Scenario) uint64 {
 				writeStr(d.Kind)
 				writeF(d.Probability)
 				writeF(d.CallCountMean)
-				writeF(d.CallLatencyMs.Mean)
-				writeF(d.CallLatencyMs.Sigma)
+				writeLatency(d.CallLatencyMs)
 				writeF(d.TimeoutMs)
 				writeF(d.FailureRate)
 				if d.Retryable == nil {
@@ -450,7 +839,11 @@ func ConfigHash(s *config.Scenario) uint64 {
 				writeF(d.DownstreamFractionCPU)
 				writeStr(d.PartitionKey)
 				writeStr(d.PartitionKeyFrom)
+				writePolicyOverrides(d.Policies)
+				writeConditions(d.When)
+				writeHedge(d.Hedge)
 			}
+			writeExecution(ep.Execution)
 		}
 	}
 
@@ -514,6 +907,12 @@ func ConfigHash(s *config.Scenario) uint64 {
 		if a.Arrival.QuietDurationSeconds != b.Arrival.QuietDurationSeconds {
 			return a.Arrival.QuietDurationSeconds < b.Arrival.QuietDurationSeconds
 		}
+		if a.Arrival.ReplayFile != b.Arrival.ReplayFile {
+			return a.Arrival.ReplayFile < b.Arrival.ReplayFile
+		}
+		if a.Arrival.ReplayData != b.Arrival.ReplayData {
+			return a.Arrival.ReplayData < b.Arrival.ReplayData
+		}
 		return wlIdx[i] < wlIdx[j]
 	})
 	for _, wi := range wlIdx {
@@ -522,27 +921,45 @@ func ConfigHash(s *config.Scenario) uint64 {
 		writeStr(w.From)
 		writeStr(w.SourceKind)
 		writeStr(w.TrafficClass)
-		if len(w.Metadata) == 0 {
-			writeI(0)
-		} else {
-			keys := make([]string, 0, len(w.Metadata))
-			for k := range w.Metadata {
-				keys = append(keys, k)
-			}
-			sort.Strings(keys)
-			writeI(len(keys))
-			for _, k := range keys {
-				writeStr(k)
-				writeStr(w.Metadata[k])
-			}
+		if w.PriorityClass != "" {
+			writeStr("priority_class")
+			writeStr(w.PriorityClass)
 		}
+		writeStringMap(w.Metadata)
 		writeStr(w.To)
-		writeStr(w.Arrival.Type)
-		writeF(w.Arrival.RateRPS)
-		writeF(w.Arrival.StdDevRPS)
-		writeF(w.Arrival.BurstRateRPS)
-		writeF(w.Arrival.BurstDurationSeconds)
-		writeF(w.Arrival.QuietDurationSeconds)
+		writeArrival(w.Arrival)
+		writeWeightedMetadata(w.WeightedMetadata)
+	}
+
+	// --- flows (ids are unique, so sorted by id) ---
+	flIdx := make([]int, len(s.Flows))
+	for i := range flIdx {
+		flIdx[i] = i
+	}
+	sort.Slice(flIdx, func(i, j int) bool { return s.Flows[flIdx[i]].ID < s.Flows[flIdx[j]].ID })
+	for _, fi := range flIdx {
+		f := &s.Flows[fi]
+		writeStr("flow")
+		writeStr(f.ID)
+		writeStr(f.From)
+		writeStr(f.SourceKind)
+		writeStr(f.TrafficClass)
+		if f.PriorityClass != "" {
+			writeStr("priority_class")
+			writeStr(f.PriorityClass)
+		}
+		writeStringMap(f.Metadata)
+		writeWeightedMetadata(f.WeightedMetadata)
+		writeArrival(f.Arrival)
+		writeI(len(f.Steps))
+		for j := range f.Steps {
+			st := &f.Steps[j]
+			writeStr(st.Name)
+			writeStr(st.To)
+			writeF(st.ContinueProbability())
+			writeLatency(st.ThinkTimeMs)
+			writeStringMap(st.Metadata)
+		}
 	}
 
 	// --- policies ---
@@ -550,25 +967,60 @@ func ConfigHash(s *config.Scenario) uint64 {
 		writeStr("pol_nil")
 	} else {
 		writeStr("pol")
-		if s.Policies.Autoscaling == nil {
-			writeStr("as_nil")
-		} else {
-			a := s.Policies.Autoscaling
-			writeStr("as")
-			writeB(a.Enabled)
-			writeF(a.TargetCPUUtil)
-			writeI(a.ScaleStep)
+		writeAutoscaling(s.Policies.Autoscaling)
+		writeRetries(s.Policies.Retries)
+		writeCircuitBreaker(s.Policies.CircuitBreaker)
+		writeRateLimiting(s.Policies.RateLimiting)
+		if oom := s.Policies.OOM; oom != nil {
+			writeStr("oom")
+			writeB(oom.Enabled)
+			writeI(oom.RestartBackoffMs)
+			writeI(oom.MaxRestartBackoffMs)
+			writeI(oom.BackoffResetMs)
 		}
-		if s.Policies.Retries == nil {
-			writeStr("ret_nil")
-		} else {
-			r := s.Policies.Retries
-			writeStr("ret")
-			writeB(r.Enabled)
-			writeI(r.MaxRetries)
-			writeStr(r.Backoff)
-			writeI(r.BaseMs)
+	}
+
+	// --- host failures (timeline order) ---
+	for i := range s.HostFailures {
+		f := &s.HostFailures[i]
+		writeStr("host_failure")
+		writeStr(f.Host)
+		writeStr(f.Zone)
+		writeF(f.AtMs)
+		writeF(f.RecoverAfterMs)
+		writeF(f.RescheduleDelayMs)
+	}
+
+	// --- faults (timeline order) ---
+	for i := range s.Faults {
+		f := &s.Faults[i]
+		writeStr("fault")
+		writeStr(f.EffectiveID(i))
+		writeStr(f.Type)
+		writeF(f.AtMs)
+		writeF(f.DurationMs)
+		writeStr(f.Target.Service)
+		writeStr(f.Target.Endpoint)
+		writeStr(f.Target.From)
+		writeStr(f.Target.Host)
+		writeI(len(f.Target.Zones))
+		for _, z := range f.Target.Zones {
+			writeStr(z)
 		}
+		writeF(f.LatencyMs)
+		writeF(f.FailureRate)
+		writeI(f.Instances)
+		writeF(f.CPUSteal)
+		writeI(f.Messages)
+	}
+
+	// --- priority classes (names are unique, so sorted by name) ---
+	classes := append([]config.PriorityClass(nil), s.PriorityClasses...)
+	sort.Slice(classes, func(i, j int) bool { return classes[i].Name < classes[j].Name })
+	for _, pc := range classes {
+		writeStr("priority_class")
+		writeStr(pc.Name)
+		writeI(pc.Priority)
 	}
 
 	return binary.LittleEndian.Uint64(h.Sum(nil))
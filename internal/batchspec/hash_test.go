@@ -2,6 +2,8 @@ package batchspec
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
@@ -246,6 +248,33 @@ func TestConfigHashWorkloadMetadataChange(t *testing.T) {
 	assertHashDiffers(t, a, b, "workload metadata")
 }
 
+func TestConfigHashReplayTraceContents(t *testing.T) {
+	dir := t.TempDir()
+	config.SetReplayDir(dir)
+	t.Cleanup(func() { config.SetReplayDir("") })
+	withTrace := func(a config.ArrivalSpec) *config.Scenario {
+		s := scenarioV2()
+		s.Workload[0].Arrival = a
+		return s
+	}
+	path := filepath.Join(dir, "trace.csv")
+	if err := os.WriteFile(path, []byte("timestamp,tenant\n1,a\n2,b\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fromFile := withTrace(config.ArrivalSpec{Type: "replay", ReplayFormat: "csv", ReplayFile: "trace.csv"})
+	before := ConfigHash(fromFile)
+	inline := withTrace(config.ArrivalSpec{Type: "replay", ReplayFormat: "csv", ReplayData: "timestamp,tenant\n1,a\n2,b\n"})
+	if ConfigHash(inline) != before {
+		t.Fatal("expected the same trace to hash the same from replay_file and replay_data")
+	}
+	if err := os.WriteFile(path, []byte("timestamp,tenant\n1,a\n3,b\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if ConfigHash(fromFile) == before {
+		t.Fatal("expected an edited trace file to change the hash")
+	}
+}
+
 func TestConfigHashTopicBehaviorSubscriberConcurrencyChange(t *testing.T) {
 	a, b := scenarioV2(), scenarioV2()
 	topicSvc := func(conc int) config.Service {
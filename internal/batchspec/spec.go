@@ -177,6 +177,7 @@ func batchScalingActionEnumOrder() []simulationv1.BatchScalingAction {
 		simulationv1.BatchScalingAction_QUEUE_SCALE_DOWN_CONCURRENCY,
 		simulationv1.BatchScalingAction_TOPIC_SUBSCRIBER_SCALE_UP_CONCURRENCY,
 		simulationv1.BatchScalingAction_TOPIC_SUBSCRIBER_SCALE_DOWN_CONCURRENCY,
+		simulationv1.BatchScalingAction_HOST_CHANGE_TYPE,
 	}
 }
 
@@ -154,7 +154,7 @@ func CalibrateScenario(base *config.Scenario, obs *ObservedMetrics, opts *Calibr
 				}
 				for ei := range out.Services[si].Endpoints {
 					ep := &out.Services[si].Endpoints[ei]
-					old := ep.MeanCPUMs
+					old := ep.CPUSpec().MeanMs()
 					if !shouldApply(overwrite, fieldEmptyFloat(old), ConfidenceMedium, floor) {
 						report.skipLowConf("%s:%s.mean_cpu_ms (service rollup)", eo.ServiceID, ep.Path)
 						continue
@@ -169,7 +169,8 @@ func CalibrateScenario(base *config.Scenario, obs *ObservedMetrics, opts *Calibr
 			if ep == nil {
 				continue
 			}
-			old := ep.MeanCPUMs
+			// An empirical CPU table has its mean in the table; setting mean_cpu_ms rescales the table.
+			old := ep.CPUSpec().MeanMs()
 			if !shouldApply(overwrite, fieldEmptyFloat(old), ConfidenceMedium, floor) {
 				report.skipLowConf("%s:%s.mean_cpu_ms", eo.ServiceID, eo.EndpointPath)
 				continue
@@ -205,7 +206,8 @@ func CalibrateScenario(base *config.Scenario, obs *ObservedMetrics, opts *Calibr
 			continue
 		}
 		if qw > pl*0.5 && qw > 0 {
-			old := ep.NetLatencyMs.Mean
+			// Like mean_cpu_ms, the mean of an empirical spec rescales its table.
+			old := ep.NetLatencyMs.MeanMs()
 			if !shouldApply(overwrite, fieldEmptyFloat(old), ConfidenceLow, floor) {
 				report.skipLowConf("%s:%s.net_latency_ms.mean", eo.ServiceID, eo.EndpointPath)
 				continue
@@ -320,7 +322,8 @@ func CalibrateScenario(base *config.Scenario, obs *ObservedMetrics, opts *Calibr
 	// --- Cache hit rate ---
 	for _, c := range obs.Caches {
 		svc := findService(out, c.ServiceID)
-		if svc == nil || svc.Behavior == nil || svc.Behavior.Cache == nil {
+		// A cache with a key space derives its hit ratio from keys, capacity and TTL.
+		if svc == nil || svc.Behavior == nil || svc.Behavior.Cache == nil || svc.Behavior.Cache.KeySpace != nil {
 			continue
 		}
 		if !c.HitCount.Present || !c.MissCount.Present {
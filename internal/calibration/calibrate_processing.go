@@ -21,7 +21,7 @@ func findEndpointRequestStats(pred *models.RunMetrics, serviceID, path string) *
 	return nil
 }
 
-// fallbackDenomFromScenarioCPU uses configured mean_cpu_ms as a last-resort denominator when
+// fallbackDenomFromScenarioCPU uses the configured mean CPU time as a last-resort denominator when
 // predicted processing latency is missing. pathPattern "*" selects the max across endpoints.
 func fallbackDenomFromScenarioCPU(out *config.Scenario, serviceID, pathPattern string) float64 {
 	si := findServiceIndex(out, serviceID)
@@ -34,9 +34,7 @@ func fallbackDenomFromScenarioCPU(out *config.Scenario, serviceID, pathPattern s
 		if pathPattern != "*" && pathPattern != "" && ep.Path != pathPattern {
 			continue
 		}
-		if ep.MeanCPUMs > denom {
-			denom = ep.MeanCPUMs
-		}
+		denom = max(denom, ep.CPUSpec().MeanMs())
 	}
 	if denom < 1e-3 {
 		return 1
@@ -117,6 +117,48 @@ func TestCalibrateEndpointProcessingUsesEndpointPredictedBaseline(t *testing.T)
 	}
 }
 
+func TestCalibrateScalesEmpiricalSpecsFromTheirTableMean(t *testing.T) {
+	// Both tables have a mean of 10ms and no explicit mean.
+	table := []config.HistogramBin{{UpToMs: 20, Weight: 1}}
+	base := &config.Scenario{
+		Hosts: []config.Host{{ID: "h1", Cores: 8, MemoryGB: 16}},
+		Services: []config.Service{{
+			ID: "svc", Replicas: 1, Model: "cpu",
+			Endpoints: []config.Endpoint{{
+				Path: "/x", CPUDistribution: config.DistributionEmpirical, CPUHistogram: table,
+				NetLatencyMs: config.LatencySpec{Distribution: config.DistributionEmpirical, Histogram: table},
+			}},
+		}},
+		Workload: []config.WorkloadPattern{
+			{From: "c", To: "svc:/x", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 1}},
+		},
+	}
+	predicted := 10.0
+	pred := &models.RunMetrics{
+		EndpointRequestStats: []models.EndpointRequestStats{{ServiceName: "svc", EndpointPath: "/x", ProcessingLatencyMeanMs: &predicted}},
+	}
+	obs := &ObservedMetrics{
+		Endpoints: []EndpointObservation{{ServiceID: "svc", EndpointPath: "/x", ProcessingLatencyMeanMs: F64(20), QueueWaitMeanMs: F64(40)}},
+	}
+	out, _, err := CalibrateScenario(base, obs, &CalibrateOptions{
+		Overwrite:      OverwriteAlways,
+		PredictedRun:   pred,
+		MinScaleFactor: 0.1,
+		MaxScaleFactor: 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ep := out.Services[0].Endpoints[0]
+	if cpu := ep.CPUSpec().MeanMs(); math.Abs(cpu-20) > 1e-6 {
+		t.Fatalf("expected the CPU table rescaled to a 20ms mean, got %v", cpu)
+	}
+	// 15% of the 40ms queue wait on top of the table mean.
+	if net := ep.NetLatencyMs.MeanMs(); math.Abs(net-16) > 1e-6 {
+		t.Fatalf("expected the net latency table rescaled to a 16ms mean, got %v", net)
+	}
+}
+
 func TestCalibrationRecoversWorkloadScale(t *testing.T) {
 	truth := simpleAPIScenario(8, 3)
 	dur := 800 * time.Millisecond
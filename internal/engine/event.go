@@ -24,7 +24,7 @@ const (
 	// EventTypeDownstreamCall represents a call to a downstream service
 	EventTypeDownstreamCall EventType = "downstream_call"
 
-	// EventTypeScaleUp represents a service scaling up
+	// EventTypeScaleUp represents a service scaling up (autoscaled replicas entering rotation after startup delay)
 	EventTypeScaleUp EventType = "scale_up"
 
 	// EventTypeScaleDown represents a service scaling down
@@ -35,15 +35,58 @@ const (
 	// EventTypeWorkloadGenerate triggers standard-mode incremental workload generation.
 	EventTypeWorkloadGenerate EventType = "workload_generate"
 
+	// EventTypeWorkloadUserReturn returns a closed-workload virtual user whose request finished, to think and issue the next.
+	EventTypeWorkloadUserReturn EventType = "workload_user_return"
+
 	// EventTypeDrainSweep runs periodic replica drain processing independent of request traffic.
 	EventTypeDrainSweep EventType = "drain_sweep"
 
+	// EventTypeAutoscaleSync runs one autoscaler metrics sync / policy evaluation (HPA emulation).
+	EventTypeAutoscaleSync EventType = "autoscale_sync"
+
+	// EventTypeInstanceRestart brings an OOM-killed instance (or one killed by a fault) back into rotation once it is ready again.
+	EventTypeInstanceRestart EventType = "instance_restart"
+
+	// EventTypeInstanceReady brings starting instances (scaled up, replaced or restarted) into rotation once ready.
+	EventTypeInstanceReady EventType = "instance_ready"
+
+	// EventTypeHostFailure takes a scenario host, or a zone of hosts, down (host_failures).
+	EventTypeHostFailure EventType = "host_failure"
+
+	// EventTypeHostRecover brings a failed host back up.
+	EventTypeHostRecover EventType = "host_recover"
+
+	// EventTypeInstanceReschedule places a replacement for an instance lost with its host.
+	EventTypeInstanceReschedule EventType = "instance_reschedule"
+
+	// EventTypeFaultStart injects a chaos fault (scenario faults or the faults API).
+	EventTypeFaultStart EventType = "fault_start"
+
+	// EventTypeFaultEnd lifts an injected fault at the end of its window.
+	EventTypeFaultEnd EventType = "fault_end"
+
+	// EventTypeInstanceDispatch starts the next queued request of an instance once its CPU is free
+	// (services with priority, adaptive_lifo or codel scheduling).
+	EventTypeInstanceDispatch EventType = "instance_dispatch"
+
+	// EventTypeInstanceQueueTimeout fails a request still waiting in an instance queue at its max_queue_wait_ms.
+	EventTypeInstanceQueueTimeout EventType = "instance_queue_timeout"
+
+	// EventTypeCPUShareDone ends the CPU work that finishes next on a processor-sharing instance.
+	EventTypeCPUShareDone EventType = "cpu_share_done"
+
+	// EventTypeCPUShareTimeout fires the local timeout of a request still sharing an instance's CPU.
+	EventTypeCPUShareTimeout EventType = "cpu_share_timeout"
+
 	// EventTypeDownstreamTimeout fires when a downstream call exceeds timeout_ms (DES deadline).
 	EventTypeDownstreamTimeout EventType = "downstream_timeout"
 
 	// EventTypeDownstreamRetry schedules a replacement downstream attempt after simulated backoff (retry policy).
 	EventTypeDownstreamRetry EventType = "downstream_retry"
 
+	// EventTypeDownstreamHedge sends another attempt of a sync call that has not answered yet (hedge policy).
+	EventTypeDownstreamHedge EventType = "downstream_hedge"
+
 	// EventTypeDownstreamCallerOverheadStart allocates caller CPU for downstream serialization / client overhead.
 	EventTypeDownstreamCallerOverheadStart EventType = "downstream_caller_overhead_start"
 
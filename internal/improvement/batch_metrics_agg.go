@@ -23,7 +23,9 @@ func minFloat(a, b float64) float64 {
 // AggregateRunMetrics combines metrics from multiple evaluation runs (same scenario, different seeds).
 // Counts and throughput are averaged across runs. Latency percentiles use the maximum across runs
 // (conservative across seeds; averaging percentiles is not statistically valid). Latency mean uses a
-// request-weighted average of per-run means when successful-request counts are available.
+// request-weighted average of per-run means when successful-request counts are available. OOM kills and
+// restarts use the maximum, so a seed that OOMs keeps the candidate infeasible. Outage availability
+// uses the minimum over runs that saw requests during a host outage.
 func AggregateRunMetrics(runs []*simulationv1.RunMetrics) *simulationv1.RunMetrics {
 	nonNil := 0
 	for _, m := range runs {
@@ -62,6 +64,9 @@ func AggregateRunMetrics(runs []*simulationv1.RunMetrics) *simulationv1.RunMetri
 	var crossZoneReqCount, sameZoneReqCount int64
 	var crossZonePenaltyTotal, sameZonePenaltyTotal, externalPenaltyTotal, topologyPenaltyTotal float64
 	var crossZonePenaltyMeanMax, sameZonePenaltyMeanMax, externalPenaltyMeanMax, topologyPenaltyMeanMax float64
+	var oomKillsMax, restartsMax int64
+	var hostFailures, instancesLost, instancesRescheduled, outageReq, outageFailed int64
+	outageAvailMin, sawOutage := 0.0, false
 	firstTopo := true
 	firstPerc := true
 	for _, m := range runs {
@@ -156,6 +161,23 @@ func AggregateRunMetrics(runs []*simulationv1.RunMetrics) *simulationv1.RunMetri
 		sameZonePenaltyTotal += m.GetSameZoneLatencyPenaltyMsTotal()
 		externalPenaltyTotal += m.GetExternalLatencyMsTotal()
 		topologyPenaltyTotal += m.GetTopologyLatencyPenaltyMsTotal()
+		if v := m.GetOomKillsTotal(); v > oomKillsMax {
+			oomKillsMax = v
+		}
+		if v := m.GetInstanceRestartsTotal(); v > restartsMax {
+			restartsMax = v
+		}
+		hostFailures += m.GetHostFailuresTotal()
+		instancesLost += m.GetInstancesLostTotal()
+		instancesRescheduled += m.GetInstancesRescheduledTotal()
+		outageReq += m.GetOutageRequests()
+		outageFailed += m.GetOutageFailedRequests()
+		if m.GetOutageRequests() > 0 {
+			if !sawOutage || m.GetOutageAvailability() < outageAvailMin {
+				outageAvailMin = m.GetOutageAvailability()
+			}
+			sawOutage = true
+		}
 	}
 	out.TotalRequests = int64(float64(tr) / n)
 	out.SuccessfulRequests = int64(float64(sr) / n)
@@ -216,6 +238,14 @@ func AggregateRunMetrics(runs []*simulationv1.RunMetrics) *simulationv1.RunMetri
 	out.SameZoneLatencyPenaltyMsMean = sameZonePenaltyMeanMax
 	out.ExternalLatencyMsMean = externalPenaltyMeanMax
 	out.TopologyLatencyPenaltyMsMean = topologyPenaltyMeanMax
+	out.OomKillsTotal = oomKillsMax
+	out.InstanceRestartsTotal = restartsMax
+	out.HostFailuresTotal = int64(float64(hostFailures) / n)
+	out.InstancesLostTotal = int64(float64(instancesLost) / n)
+	out.InstancesRescheduledTotal = int64(float64(instancesRescheduled) / n)
+	out.OutageRequests = int64(float64(outageReq) / n)
+	out.OutageFailedRequests = int64(float64(outageFailed) / n)
+	out.OutageAvailability = outageAvailMin
 
 	byName := make(map[string][]*simulationv1.ServiceMetrics)
 	for _, m := range runs {
@@ -2,11 +2,13 @@ package improvement
 
 import (
 	"fmt"
+	"math"
 	"sort"
 	"strings"
 
 	simulationv1 "github.com/GoSim-25-26J-441/simulation-core/gen/go/simulation/v1"
 	"github.com/GoSim-25-26J-441/simulation-core/internal/batchspec"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
 	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
 )
 
@@ -54,9 +56,15 @@ func StaticCapacityOK(s *config.Scenario) bool {
 	return needCPU <= capCPU*1.001 && needMemMB <= capMemMB*1.001
 }
 
+// placementFeasible places every service replica the way the simulator does, so a host
+// shrunk by a type change must still fit the services placed on it.
+func placementFeasible(s *config.Scenario) bool {
+	return resource.NewManager().InitializeFromScenario(s) == nil
+}
+
 // neighborStress is true when the current state looks overloaded vs SLOs or utilization bands.
 func neighborStress(spec *batchspec.BatchSpec, m *simulationv1.RunMetrics) bool {
-	if m == nil {
+	if m == nil || m.GetOomKillsTotal() > 0 {
 		return true
 	}
 	if spec.MaxP95Ms > 0 && m.GetLatencyP95Ms() > spec.MaxP95Ms*1.005 {
@@ -294,10 +302,7 @@ func GenerateBatchNeighbors(spec *batchspec.BatchSpec, baseline, cur *config.Sce
 				if cpu <= 0 {
 					cpu = defaultServiceCPUCores
 				}
-				ns.Services[i].CPUCores = cpu * (1 + spec.ServiceCPURatio)
-				if ns.Services[i].CPUCores > spec.MaxCPUPerInst {
-					ns.Services[i].CPUCores = spec.MaxCPUPerInst
-				}
+				ns.Services[i].ScaleCPU(math.Min(cpu*(1+spec.ServiceCPURatio), spec.MaxCPUPerInst))
 				add(ns)
 			}
 		case simulationv1.BatchScalingAction_SERVICE_SCALE_DOWN_CPU:
@@ -310,10 +315,7 @@ func GenerateBatchNeighbors(spec *batchspec.BatchSpec, baseline, cur *config.Sce
 				if cpu <= 0 {
 					cpu = defaultServiceCPUCores
 				}
-				ns.Services[i].CPUCores = cpu * (1 - spec.ServiceCPURatio)
-				if ns.Services[i].CPUCores < spec.MinCPUPerInst {
-					ns.Services[i].CPUCores = spec.MinCPUPerInst
-				}
+				ns.Services[i].ScaleCPU(math.Max(cpu*(1-spec.ServiceCPURatio), spec.MinCPUPerInst))
 				add(ns)
 			}
 		case simulationv1.BatchScalingAction_SERVICE_SCALE_UP_MEMORY:
@@ -371,6 +373,7 @@ func GenerateBatchNeighbors(spec *batchspec.BatchSpec, baseline, cur *config.Sce
 				ID:       fmt.Sprintf("host-%d", nh),
 				Cores:    h0.Cores,
 				MemoryGB: h0.MemoryGB,
+				Type:     h0.Type,
 			})
 			add(ns)
 		case simulationv1.BatchScalingAction_HOST_SCALE_IN:
@@ -530,12 +533,28 @@ func GenerateBatchNeighbors(spec *batchspec.BatchSpec, baseline, cur *config.Sce
 					add(ns)
 				}
 			}
+		case simulationv1.BatchScalingAction_HOST_CHANGE_TYPE:
+			for i := range cur.Hosts {
+				for _, t := range cur.HostTypes {
+					if t.ID == cur.Hosts[i].Type {
+						continue
+					}
+					ns := cloneScenario(cur)
+					if err := ns.SetHostType(i, t.ID); err != nil || !placementFeasible(ns) {
+						continue
+					}
+					add(ns)
+				}
+			}
 		}
 	}
 
 	// Workload / policy neighbors when not frozen (capacity and bounds still apply).
 	if !spec.FreezeWorkload {
 		for i := range cur.Workload {
+			if !hasTunableRate(cur.Workload[i].Arrival) {
+				continue
+			}
 			for _, mul := range []float64{1.1, 0.9} {
 				ns := cloneScenario(cur)
 				ns.Workload[i].Arrival.RateRPS *= mul
@@ -140,3 +140,63 @@ func TestGenerateBatchNeighborsTopicSubscriberConcurrencyActions(t *testing.T) {
 		t.Fatalf("expected topic subscriber concurrency up/down neighbors, got up=%v down=%v", up, down)
 	}
 }
+
+func TestGenerateBatchNeighborsHostChangeTypeIsPriced(t *testing.T) {
+	base := &config.Scenario{
+		HostTypes: []config.HostType{
+			{ID: "small", Cores: 4, MemoryGB: 16, PricePerHour: 0.1},
+			{ID: "fast", Cores: 8, MemoryGB: 16, SpeedFactor: 1.5, PricePerHour: 0.5},
+		},
+		Hosts: []config.Host{{ID: "h1", Type: "small", Cores: 4, MemoryGB: 16}},
+		Services: []config.Service{
+			{ID: "svc", Replicas: 1, Model: "cpu", CPUCores: 1, MemoryMB: 256, Endpoints: []config.Endpoint{{Path: "/p", MeanCPUMs: 1, CPUSigmaMs: 0, NetLatencyMs: config.LatencySpec{Mean: 1, Sigma: 0}}}},
+		},
+	}
+	spec := batchspec.DefaultBatchSpec(base)
+	spec.AllowedActions = map[simulationv1.BatchScalingAction]struct{}{
+		simulationv1.BatchScalingAction_HOST_CHANGE_TYPE: {},
+	}
+	spec.AllowedActionsOrdered = []simulationv1.BatchScalingAction{simulationv1.BatchScalingAction_HOST_CHANGE_TYPE}
+	neighbors := GenerateBatchNeighbors(spec, base, base, nil)
+	if len(neighbors) != 1 {
+		t.Fatalf("expected one change-type neighbor, got %d", len(neighbors))
+	}
+	h := neighbors[0].Hosts[0]
+	if h.Type != "fast" || h.Cores != 8 {
+		t.Fatalf("expected h1 switched to fast with 8 cores, got %+v", h)
+	}
+	// host_price defaults to 1, and priced hosts are not also costed per core.
+	w := &simulationv1.BatchCostWeights{HostCpu: 1, HostMemoryGb: 1}
+	if got := ComputeInfraCostWeighted(neighbors[0], w) - ComputeInfraCostWeighted(base, w); got < 0.399 || got > 0.401 {
+		t.Fatalf("expected price delta 0.4, got %v", got)
+	}
+	if ComputeChurn(base, neighbors[0]) <= 0 {
+		t.Fatal("expected a host type change to count as churn")
+	}
+}
+
+func TestGenerateBatchNeighborsHostChangeTypeKeepsServicesPlaced(t *testing.T) {
+	base := &config.Scenario{
+		HostTypes: []config.HostType{
+			{ID: "small", Cores: 4, MemoryGB: 16, PricePerHour: 0.1},
+			{ID: "big", Cores: 8, MemoryGB: 16, PricePerHour: 0.5},
+		},
+		Hosts: []config.Host{
+			{ID: "h1", Type: "big", Cores: 8, MemoryGB: 16},
+			{ID: "h2", Type: "big", Cores: 8, MemoryGB: 16},
+		},
+		Services: []config.Service{
+			{ID: "svc", Replicas: 2, Model: "cpu", CPUCores: 5, MemoryMB: 256, Endpoints: []config.Endpoint{{Path: "/p", MeanCPUMs: 1, CPUSigmaMs: 0, NetLatencyMs: config.LatencySpec{Mean: 1, Sigma: 0}}}},
+		},
+	}
+	spec := batchspec.DefaultBatchSpec(base)
+	spec.MinHostCPUCores = 4
+	spec.AllowedActions = map[simulationv1.BatchScalingAction]struct{}{
+		simulationv1.BatchScalingAction_HOST_CHANGE_TYPE: {},
+	}
+	spec.AllowedActionsOrdered = []simulationv1.BatchScalingAction{simulationv1.BatchScalingAction_HOST_CHANGE_TYPE}
+	// 12 cores still cover the 10 requested in aggregate, but a 5-core replica no longer fits a 4-core host.
+	if neighbors := GenerateBatchNeighbors(spec, base, base, nil); len(neighbors) != 0 {
+		t.Fatalf("expected no change-type neighbor that overcommits a host, got %d", len(neighbors))
+	}
+}
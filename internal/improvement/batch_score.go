@@ -11,7 +11,8 @@ import (
 
 const scoreEps = 1e-9
 
-// BatchScore holds feasibility-first ranking components for a candidate.
+// BatchScore holds feasibility-first ranking components for a candidate. OOMViolation counts OOM kills;
+// it has no threshold or penalty weight, so any kill makes a candidate infeasible.
 type BatchScore struct {
 	Feasible                 bool
 	ViolationScore           float64
@@ -32,6 +33,7 @@ type BatchScore struct {
 	LocalityViolation        float64
 	CrossZoneViolation       float64
 	TopologyLatencyViolation float64
+	OOMViolation             float64
 	InfraCost                float64
 	ServiceCPUBal            float64
 	ServiceMemBal            float64
@@ -117,6 +119,8 @@ func balanceTerm(maxU, meanU, low, high float64) float64 {
 }
 
 // ComputeInfraCostWeighted applies BatchCostWeights to the scenario (excluding churn).
+// A host whose type has a price is costed by HostPrice (1 when unset) instead of
+// HostCpu/HostMemoryGb, so a type change is not counted twice.
 func ComputeInfraCostWeighted(s *config.Scenario, w *simulationv1.BatchCostWeights) float64 {
 	if w == nil || s == nil {
 		return EvaluateInfrastructureCost(s)
@@ -141,7 +145,13 @@ func ComputeInfraCostWeighted(s *config.Scenario, w *simulationv1.BatchCostWeigh
 		sumRep += r
 	}
 	var hostCores, hostMem int
-	for _, h := range s.Hosts {
+	var hostPrice float64
+	for i := range s.Hosts {
+		h := &s.Hosts[i]
+		if p := s.HostPricePerHour(h); p > 0 {
+			hostPrice += p
+			continue
+		}
 		hostCores += h.Cores
 		gb := h.MemoryGB
 		if gb < 1 {
@@ -154,10 +164,19 @@ func ComputeInfraCostWeighted(s *config.Scenario, w *simulationv1.BatchCostWeigh
 		w.Replicas*sumRep +
 		w.Hosts*float64(len(s.Hosts)) +
 		w.HostCpu*float64(hostCores) +
-		w.HostMemoryGb*float64(hostMem)
+		w.HostMemoryGb*float64(hostMem) +
+		hostPriceWeight(w)*hostPrice
 }
 
-// ComputeChurn L1 normalized distance from baseline (replicas, CPU, mem per service; host count).
+func hostPriceWeight(w *simulationv1.BatchCostWeights) float64 {
+	if w.HostPrice == nil {
+		return 1
+	}
+	return w.GetHostPrice()
+}
+
+// ComputeChurn L1 normalized distance from baseline (replicas, CPU, mem per service; host count;
+// share of hosts whose host type changed).
 func ComputeChurn(base, cur *config.Scenario) float64 {
 	if base == nil || cur == nil {
 		return 0
@@ -176,6 +195,13 @@ func ComputeChurn(base, cur *config.Scenario) float64 {
 		sum += math.Abs(c.MemoryMB-b.MemoryMB) / math.Max(128, b.MemoryMB)
 	}
 	sum += math.Abs(float64(len(cur.Hosts)-len(base.Hosts))) / math.Max(1, float64(len(base.Hosts)))
+	var retyped int
+	for i := range cur.Hosts {
+		if i < len(base.Hosts) && base.Hosts[i].ID == cur.Hosts[i].ID && base.Hosts[i].Type != cur.Hosts[i].Type {
+			retyped++
+		}
+	}
+	sum += float64(retyped) / math.Max(1, float64(len(base.Hosts)))
 	return sum
 }
 
@@ -261,6 +287,8 @@ func ComputeBatchScore(spec *batchspec.BatchSpec, baseline, scenario *config.Sce
 		if spec.MaxTopologyLatencyPenaltyMeanMs > 0 {
 			out.TopologyLatencyViolation = math.Max(0, m.GetTopologyLatencyPenaltyMsMean()/spec.MaxTopologyLatencyPenaltyMeanMs-1)
 		}
+		// OOM kills are a hard constraint: no threshold, no penalty weight.
+		out.OOMViolation = float64(m.GetOomKillsTotal())
 	}
 
 	out.ViolationScore = pw.P95*out.LatViolation +
@@ -278,7 +306,8 @@ func ComputeBatchScore(spec *batchspec.BatchSpec, baseline, scenario *config.Sce
 		pw.TopicDlq*out.TopicDlqViolation +
 		pw.Locality*out.LocalityViolation +
 		pw.CrossZone*out.CrossZoneViolation +
-		pw.TopologyLatency*out.TopologyLatencyViolation
+		pw.TopologyLatency*out.TopologyLatencyViolation +
+		out.OOMViolation
 
 	maxCPU, meanCPU, maxMem, meanMem, n := serviceUtilStats(m)
 	if n > 0 {
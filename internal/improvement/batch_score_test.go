@@ -199,6 +199,38 @@ func TestComputeBatchScoreTopologyGuardrailViolation(t *testing.T) {
 	}
 }
 
+func TestComputeBatchScoreOOMKillsAreInfeasible(t *testing.T) {
+	base := &config.Scenario{
+		Hosts: []config.Host{{ID: "h1", Cores: 8, MemoryGB: 32}},
+		Services: []config.Service{
+			{ID: "svc1", Replicas: 1, CPUCores: 1, MemoryMB: 512, Model: "cpu"},
+		},
+	}
+	spec, err := batchspec.ParseBatchSpec(&simulationv1.BatchOptimizationConfig{}, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &simulationv1.RunMetrics{
+		LatencyP95Ms:  100,
+		LatencyP99Ms:  200,
+		ThroughputRps: 100,
+		TotalRequests: 1000,
+		OomKillsTotal: 1,
+		ServiceMetrics: []*simulationv1.ServiceMetrics{
+			{ServiceName: "svc1", CpuUtilization: 0.55, MemoryUtilization: 0.5},
+		},
+	}
+	sc := ComputeBatchScore(spec, base, base, m)
+	if sc.Feasible || sc.OOMViolation != 1 {
+		t.Fatalf("expected a single OOM kill to make the candidate infeasible, got %+v", sc)
+	}
+	// One seed that OOMs is enough.
+	agg := AggregateRunMetrics([]*simulationv1.RunMetrics{{}, {}, {OomKillsTotal: 1}})
+	if agg.GetOomKillsTotal() != 1 {
+		t.Fatalf("expected aggregated OOM kills to keep the worst seed, got %d", agg.GetOomKillsTotal())
+	}
+}
+
 func TestCompareBatchScoresPrefersLowerTopologyPenaltyWhenFeasible(t *testing.T) {
 	a := BatchScore{Feasible: true, ViolationScore: 0, EfficiencyScore: 10}
 	b := BatchScore{Feasible: true, ViolationScore: 0, EfficiencyScore: 12}
@@ -125,13 +125,13 @@ func (e *DefaultExplorer) exploreResources(base *config.Scenario) []*config.Scen
 
 		// Increase CPU
 		neighbor := cloneScenario(base)
-		neighbor.Services[i].CPUCores = currentCPU * (1.0 + e.resourceStepSize)
+		neighbor.Services[i].ScaleCPU(currentCPU * (1.0 + e.resourceStepSize))
 		neighbors = append(neighbors, neighbor)
 
 		// Decrease CPU (but keep at least 0.1)
 		if currentCPU > 0.1 {
 			neighbor2 := cloneScenario(base)
-			neighbor2.Services[i].CPUCores = math.Max(0.1, currentCPU*(1.0-e.resourceStepSize))
+			neighbor2.Services[i].ScaleCPU(math.Max(0.1, currentCPU*(1.0-e.resourceStepSize)))
 			neighbors = append(neighbors, neighbor2)
 		}
 
@@ -226,12 +226,21 @@ func (e *DefaultExplorer) explorePolicies(base *config.Scenario) []*config.Scena
 	return neighbors
 }
 
+// hasTunableRate reports whether rate_rps drives the arrival; rate profiles, replayed traces, closed
+// workloads and MMPPs (per-state rates) ignore it.
+func hasTunableRate(a config.ArrivalSpec) bool {
+	return a.RateProfile == nil && a.Type != "replay" && a.Type != "closed" && a.Type != "mmpp"
+}
+
 // exploreWorkload generates neighbors by adjusting workload arrival rates
 func (e *DefaultExplorer) exploreWorkload(base *config.Scenario) []*config.Scenario {
 	neighbors := make([]*config.Scenario, 0)
 
 	for i := range base.Workload {
 		arrival := &base.Workload[i].Arrival
+		if !hasTunableRate(*arrival) {
+			continue
+		}
 		currentRate := arrival.RateRPS
 
 		// Increase arrival rate
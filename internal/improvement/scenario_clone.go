@@ -48,6 +48,53 @@ func clonePlacementPolicy(pp *config.PlacementPolicy) *config.PlacementPolicy {
 	return out
 }
 
+func clonePolicyOverrides(po *config.PolicyOverrides) *config.PolicyOverrides {
+	if po == nil {
+		return nil
+	}
+	out := &config.PolicyOverrides{}
+	if po.Autoscaling != nil {
+		a := *po.Autoscaling
+		a.Enabled = cloneBool(a.Enabled)
+		out.Autoscaling = &a
+	}
+	if po.Retries != nil {
+		r := *po.Retries
+		r.Enabled = cloneBool(r.Enabled)
+		out.Retries = &r
+	}
+	if po.CircuitBreaker != nil {
+		cb := *po.CircuitBreaker
+		cb.Enabled = cloneBool(cb.Enabled)
+		out.CircuitBreaker = &cb
+	}
+	if po.RateLimiting != nil {
+		rl := *po.RateLimiting
+		rl.Enabled = cloneBool(rl.Enabled)
+		out.RateLimiting = &rl
+	}
+	return out
+}
+
+func cloneBool(b *bool) *bool {
+	if b == nil {
+		return nil
+	}
+	v := *b
+	return &v
+}
+
+func cloneStringMap(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
 // cloneScenario returns a deep copy of the scenario so batch/optimizer neighbors
 // preserve v2 metadata, service kind/role/scaling, downstream call semantics, workload
 // source/traffic fields, limits, and policies.
@@ -101,21 +148,36 @@ func cloneScenario(scenario *config.Scenario) *config.Scenario {
 	for i := range scenario.Services {
 		svc := &scenario.Services[i]
 		ns := config.Service{
-			ID:        svc.ID,
-			Kind:      svc.Kind,
-			Role:      svc.Role,
-			Replicas:  svc.Replicas,
-			Model:     svc.Model,
-			CPUCores:  svc.CPUCores,
-			MemoryMB:  svc.MemoryMB,
-			Placement: clonePlacementPolicy(svc.Placement),
-			Routing:   cloneRoutingPolicy(svc.Routing),
-			Endpoints: make([]config.Endpoint, len(svc.Endpoints)),
+			ID:          svc.ID,
+			Kind:        svc.Kind,
+			Role:        svc.Role,
+			Replicas:    svc.Replicas,
+			Model:       svc.Model,
+			CPUCores:    svc.CPUCores,
+			CPURequest:  svc.CPURequest,
+			CPULimit:    svc.CPULimit,
+			MemoryMB:    svc.MemoryMB,
+			QueueLimits: svc.QueueLimits,
+			Placement:   clonePlacementPolicy(svc.Placement),
+			Routing:     cloneRoutingPolicy(svc.Routing),
+			Policies:    clonePolicyOverrides(svc.Policies),
+			Endpoints:   make([]config.Endpoint, len(svc.Endpoints)),
 		}
 		if svc.ExternalNetworkLatencyMs != nil {
 			ls := *svc.ExternalNetworkLatencyMs
 			ns.ExternalNetworkLatencyMs = &ls
 		}
+		if svc.Startup != nil {
+			st := *svc.Startup
+			ns.Startup = &st
+		}
+		if svc.Scheduling != nil {
+			sc := *svc.Scheduling
+			ns.Scheduling = &sc
+		}
+		if len(svc.LoadShedding) > 0 {
+			ns.LoadShedding = append([]config.LoadShedRule(nil), svc.LoadShedding...)
+		}
 		if svc.Scaling != nil {
 			ns.Scaling = &config.ScalingPolicy{
 				Horizontal:     svc.Scaling.Horizontal,
@@ -132,9 +194,15 @@ func cloneScenario(scenario *config.Scenario) *config.Scenario {
 			}
 			if b.Cache != nil {
 				ns.Behavior.Cache = &config.CacheBehavior{
-					HitRate:       b.Cache.HitRate,
-					HitLatencyMs:  b.Cache.HitLatencyMs,
-					MissLatencyMs: b.Cache.MissLatencyMs,
+					HitRate:        b.Cache.HitRate,
+					HitLatencyMs:   b.Cache.HitLatencyMs,
+					MissLatencyMs:  b.Cache.MissLatencyMs,
+					MissCall:       b.Cache.MissCall,
+					CoalesceMisses: b.Cache.CoalesceMisses,
+				}
+				if b.Cache.KeySpace != nil {
+					ks := *b.Cache.KeySpace
+					ns.Behavior.Cache.KeySpace = &ks
 				}
 			}
 			if b.Queue != nil {
@@ -182,6 +250,12 @@ func cloneScenario(scenario *config.Scenario) *config.Scenario {
 				Routing:         cloneRoutingPolicy(ep.Routing),
 				NetLatencyMs:    ep.NetLatencyMs,
 				Downstream:      make([]config.DownstreamCall, len(ep.Downstream)),
+				Policies:        clonePolicyOverrides(ep.Policies),
+				CPUDistribution: ep.CPUDistribution,
+				CPUHistogram:    ep.CPUHistogram,
+				CPUPercentiles:  ep.CPUPercentiles,
+				Execution:       ep.Execution,
+				QueueLimits:     ep.QueueLimits,
 			}
 			for k := range ep.Downstream {
 				ds := &ep.Downstream[k]
@@ -197,11 +271,21 @@ func cloneScenario(scenario *config.Scenario) *config.Scenario {
 					DownstreamFractionCPU: ds.DownstreamFractionCPU,
 					PartitionKey:          ds.PartitionKey,
 					PartitionKeyFrom:      ds.PartitionKeyFrom,
+					Policies:              clonePolicyOverrides(ds.Policies),
+					When:                  append([]config.MetadataCondition(nil), ds.When...),
 				}
 				if ds.Retryable != nil {
 					v := *ds.Retryable
 					dc.Retryable = &v
 				}
+				if ds.Hedge != nil {
+					h := *ds.Hedge
+					if h.CancelLosers != nil {
+						v := *h.CancelLosers
+						h.CancelLosers = &v
+					}
+					dc.Hedge = &h
+				}
 				ne.Downstream[k] = dc
 			}
 			ns.Endpoints[j] = ne
@@ -219,23 +303,43 @@ func cloneScenario(scenario *config.Scenario) *config.Scenario {
 			}
 		}
 		out.Workload[i] = config.WorkloadPattern{
-			From:         wl.From,
-			SourceKind:   wl.SourceKind,
-			TrafficClass: wl.TrafficClass,
-			Metadata:     wlMetadata,
-			To:           wl.To,
-			Arrival:      wl.Arrival,
+			From:          wl.From,
+			SourceKind:    wl.SourceKind,
+			TrafficClass:  wl.TrafficClass,
+			PriorityClass: wl.PriorityClass,
+			Metadata:      wlMetadata,
+			To:            wl.To,
+			Arrival:       wl.Arrival,
+			// Weighted metadata is never tuned; the maps are shared.
+			WeightedMetadata: wl.WeightedMetadata,
+		}
+	}
+
+	if len(scenario.Flows) > 0 {
+		out.Flows = make([]config.Flow, len(scenario.Flows))
+		for i := range scenario.Flows {
+			f := &scenario.Flows[i]
+			nf := *f
+			nf.Metadata = cloneStringMap(f.Metadata)
+			nf.Steps = make([]config.FlowStep, len(f.Steps))
+			for j := range f.Steps {
+				st := f.Steps[j]
+				if st.Probability != nil {
+					p := *st.Probability
+					st.Probability = &p
+				}
+				st.Metadata = cloneStringMap(st.Metadata)
+				nf.Steps[j] = st
+			}
+			out.Flows[i] = nf
 		}
 	}
 
 	if scenario.Policies != nil {
 		out.Policies = &config.Policies{}
 		if scenario.Policies.Autoscaling != nil {
-			out.Policies.Autoscaling = &config.AutoscalingPolicy{
-				Enabled:       scenario.Policies.Autoscaling.Enabled,
-				TargetCPUUtil: scenario.Policies.Autoscaling.TargetCPUUtil,
-				ScaleStep:     scenario.Policies.Autoscaling.ScaleStep,
-			}
+			a := *scenario.Policies.Autoscaling
+			out.Policies.Autoscaling = &a
 		}
 		if scenario.Policies.Retries != nil {
 			out.Policies.Retries = &config.RetryPolicy{
@@ -245,6 +349,36 @@ func cloneScenario(scenario *config.Scenario) *config.Scenario {
 				BaseMs:     scenario.Policies.Retries.BaseMs,
 			}
 		}
+		if scenario.Policies.CircuitBreaker != nil {
+			cb := *scenario.Policies.CircuitBreaker
+			out.Policies.CircuitBreaker = &cb
+		}
+		if scenario.Policies.RateLimiting != nil {
+			rl := *scenario.Policies.RateLimiting
+			out.Policies.RateLimiting = &rl
+		}
+		if scenario.Policies.OOM != nil {
+			oom := *scenario.Policies.OOM
+			out.Policies.OOM = &oom
+		}
+	}
+
+	if len(scenario.HostTypes) > 0 {
+		out.HostTypes = append([]config.HostType(nil), scenario.HostTypes...)
+	}
+	if len(scenario.HostFailures) > 0 {
+		out.HostFailures = append([]config.HostFailure(nil), scenario.HostFailures...)
+	}
+	if len(scenario.PriorityClasses) > 0 {
+		out.PriorityClasses = append([]config.PriorityClass(nil), scenario.PriorityClasses...)
+	}
+
+	if len(scenario.Faults) > 0 {
+		out.Faults = make([]config.Fault, len(scenario.Faults))
+		for i, f := range scenario.Faults {
+			f.Target.Zones = append([]string(nil), f.Target.Zones...)
+			out.Faults[i] = f
+		}
 	}
 
 	return out
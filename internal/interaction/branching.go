@@ -12,6 +12,24 @@ type BranchingStrategy interface {
 	SelectCalls(calls []ResolvedCall, rng *rand.Rand) []ResolvedCall
 }
 
+// MatchingCalls returns the calls whose `when` conditions match request metadata. It returns calls
+// itself when every call matches.
+func MatchingCalls(calls []ResolvedCall, metadata map[string]interface{}) []ResolvedCall {
+	for i := range calls {
+		if calls[i].Call.WhenMatches(metadata) {
+			continue
+		}
+		matched := append(make([]ResolvedCall, 0, len(calls)-1), calls[:i]...)
+		for j := i + 1; j < len(calls); j++ {
+			if calls[j].Call.WhenMatches(metadata) {
+				matched = append(matched, calls[j])
+			}
+		}
+		return matched
+	}
+	return calls
+}
+
 // DefaultBranchingStrategy uses call_count_mean to determine number of calls
 type DefaultBranchingStrategy struct{}
 
@@ -17,8 +17,8 @@
 //	    log.Fatal(err)
 //	}
 //
-//	// Get downstream calls for a completed request
-//	calls, err := manager.GetDownstreamCalls("service-a", "/api/endpoint")
+//	// Get downstream calls for a completed request (calls with `when` conditions match its metadata)
+//	calls, err := manager.GetDownstreamCallsForRequest("service-a", "/api/endpoint", req.Metadata)
 //	if err != nil {
 //	    log.Fatal(err)
 //	}
@@ -47,14 +47,23 @@ func (m *Manager) GetGraph() *Graph {
 	return m.graph
 }
 
-// GetDownstreamCalls returns the downstream calls that should be made for a completed request
+// GetDownstreamCalls returns the downstream calls that should be made for a completed request.
+// Calls with `when` conditions are skipped; use GetDownstreamCallsForRequest to match them.
 func (m *Manager) GetDownstreamCalls(serviceID, endpointPath string) ([]ResolvedCall, error) {
+	return m.GetDownstreamCallsForRequest(serviceID, endpointPath, nil)
+}
+
+// GetDownstreamCallsForRequest returns the downstream calls that should be made for a completed
+// request with the given metadata. Calls whose `when` conditions do not match are dropped before
+// the branching strategy runs.
+func (m *Manager) GetDownstreamCallsForRequest(serviceID, endpointPath string, metadata map[string]interface{}) ([]ResolvedCall, error) {
 	// Resolve all possible downstream calls
 	calls, err := m.graph.ResolveDownstreamCalls(serviceID, endpointPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to resolve downstream calls: %w", err)
 	}
 
+	calls = MatchingCalls(calls, metadata)
 	if len(calls) == 0 {
 		return nil, nil
 	}
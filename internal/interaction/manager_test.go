@@ -91,6 +91,57 @@ func TestManagerGetDownstreamCalls(t *testing.T) {
 	}
 }
 
+func TestManagerGetDownstreamCallsForRequest(t *testing.T) {
+	premium := "premium"
+	scenario := &config.Scenario{
+		Services: []config.Service{
+			{
+				ID: "api",
+				Endpoints: []config.Endpoint{
+					{
+						Path: "/pay",
+						Downstream: []config.DownstreamCall{
+							{To: "ledger:/write"},
+							{To: "fraud:/check", When: []config.MetadataCondition{{Key: "tier", Equals: &premium}}},
+						},
+					},
+				},
+			},
+			{ID: "ledger", Endpoints: []config.Endpoint{{Path: "/write"}}},
+			{ID: "fraud", Endpoints: []config.Endpoint{{Path: "/check"}}},
+		},
+	}
+	manager, err := NewManagerWithSeed(scenario, 1)
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	for _, tc := range []struct {
+		metadata map[string]interface{}
+		want     []string
+	}{
+		{map[string]interface{}{"tier": "premium"}, []string{"ledger", "fraud"}},
+		{map[string]interface{}{"tier": "free"}, []string{"ledger"}},
+		{nil, []string{"ledger"}},
+	} {
+		calls, err := manager.GetDownstreamCallsForRequest("api", "/pay", tc.metadata)
+		if err != nil {
+			t.Fatalf("failed to get downstream calls: %v", err)
+		}
+		if len(calls) != len(tc.want) {
+			t.Fatalf("%v: expected calls to %v, got %+v", tc.metadata, tc.want, calls)
+		}
+		for i, c := range calls {
+			if c.ServiceID != tc.want[i] {
+				t.Fatalf("%v: expected calls to %v, got %+v", tc.metadata, tc.want, calls)
+			}
+		}
+	}
+	if calls, _ := manager.GetDownstreamCalls("api", "/pay"); len(calls) != 1 {
+		t.Fatalf("expected GetDownstreamCalls to skip conditional calls, got %+v", calls)
+	}
+}
+
 func TestManagerCreateDownstreamRequest(t *testing.T) {
 	scenario := &config.Scenario{
 		Services: []config.Service{
@@ -0,0 +1,112 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// CollectorState is a serializable copy of a collector (retained points, reservoirs and streaming
+// aggregates), used to persist terminal runs and restore them after a daemon restart.
+type CollectorState struct {
+	StartTime   time.Time     `json:"start_time"`
+	EndTime     time.Time     `json:"end_time,omitempty"`
+	TotalPoints int           `json:"total_points"`
+	Series      []SeriesState `json:"series"`
+}
+
+// SeriesState is the serializable state of one metric+labels series.
+type SeriesState struct {
+	Name        string             `json:"name"`
+	Labels      map[string]string  `json:"labels,omitempty"`
+	Points      []PointState       `json:"points"`
+	Reservoir   []float64          `json:"reservoir,omitempty"`
+	SeenValues  int64              `json:"seen_values"`
+	Aggregation models.Aggregation `json:"aggregation"`
+}
+
+// PointState is one retained time-series point.
+type PointState struct {
+	Timestamp time.Time `json:"t"`
+	Value     float64   `json:"v"`
+}
+
+// ExportState returns a deep copy of the collector state. Series are ordered by name and label key
+// so the output is stable.
+func (c *Collector) ExportState() *CollectorState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	out := &CollectorState{
+		StartTime:   c.startTime,
+		EndTime:     c.endTime,
+		TotalPoints: c.totalPoints,
+		Series:      make([]SeriesState, 0, c.totalSeries),
+	}
+	names := make([]string, 0, len(c.series))
+	for name := range c.series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		keys := make([]string, 0, len(c.series[name]))
+		for k := range c.series[name] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			s := c.series[name][k]
+			st := SeriesState{
+				Name:        name,
+				Labels:      copyLabels(s.labels),
+				Points:      make([]PointState, len(s.points)),
+				Reservoir:   append([]float64(nil), s.reservoir...),
+				SeenValues:  s.seenValues,
+				Aggregation: s.agg,
+			}
+			for i, p := range s.points {
+				st.Points[i] = PointState{Timestamp: p.Timestamp, Value: p.Value}
+			}
+			out.Series = append(out.Series, st)
+		}
+	}
+	return out
+}
+
+// RestoreCollector builds a collector from an exported state. Point and series caps come from the
+// current environment; restored series keep all exported points.
+func RestoreCollector(state *CollectorState) *Collector {
+	c := NewCollector()
+	if state == nil {
+		return c
+	}
+	c.startTime = state.StartTime
+	c.endTime = state.EndTime
+	c.totalPoints = state.TotalPoints
+	for _, st := range state.Series {
+		key := labelKey(st.Labels)
+		if c.series[st.Name] == nil {
+			c.series[st.Name] = make(map[string]*metricSeries)
+		}
+		s := newMetricSeries(st.Labels, c.maxSeriesPoints, c.maxReservoir)
+		s.points = make([]*models.MetricPoint, len(st.Points))
+		for i, p := range st.Points {
+			s.points[i] = &models.MetricPoint{
+				Timestamp: p.Timestamp,
+				Name:      st.Name,
+				Value:     p.Value,
+				Labels:    copyLabels(st.Labels),
+			}
+		}
+		s.reservoir = append([]float64(nil), st.Reservoir...)
+		s.seenValues = st.SeenValues
+		s.agg = st.Aggregation
+		s.dirtyPct = true
+		if _, exists := c.series[st.Name][key]; !exists {
+			c.totalSeries++
+		}
+		c.series[st.Name][key] = s
+	}
+	return c
+}
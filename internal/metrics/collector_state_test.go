@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCollectorExportRestoreRoundTrip(t *testing.T) {
+	c := NewCollector()
+	c.Start()
+	base := time.Unix(1_700_000_000, 0).UTC()
+	labels := map[string]string{"service": "api"}
+	for i := 0; i < 10; i++ {
+		c.Record("request_latency_ms", float64(i+1), base.Add(time.Duration(i)*time.Second), labels)
+	}
+	c.Record("request_count", 1, base, nil)
+	c.Stop()
+
+	raw, err := json.Marshal(c.ExportState())
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var state CollectorState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	restored := RestoreCollector(&state)
+
+	if got := restored.GetTimeSeries("request_latency_ms", labels); len(got) != 10 || got[9].Value != 10 || !got[9].Timestamp.Equal(base.Add(9*time.Second)) {
+		t.Fatalf("unexpected restored series: %v", got)
+	}
+	want := c.GetAggregation("request_latency_ms", labels)
+	got := restored.GetAggregation("request_latency_ms", labels)
+	if got == nil || got.Count != want.Count || got.Sum != want.Sum || got.P95 != want.P95 {
+		t.Fatalf("expected aggregation %+v, got %+v", want, got)
+	}
+	if restored.Snapshot() != c.Snapshot() {
+		t.Fatalf("expected snapshot %+v, got %+v", c.Snapshot(), restored.Snapshot())
+	}
+	if v, ok := restored.GetLastValue("request_count", nil); !ok || v != 1 {
+		t.Fatalf("expected unlabeled series restored, got %v (ok=%v)", v, ok)
+	}
+
+	// Restored collectors keep accepting points.
+	restored.Record("request_latency_ms", 11, base.Add(10*time.Second), labels)
+	if agg := restored.GetAggregation("request_latency_ms", labels); agg.Count != 11 {
+		t.Fatalf("expected 11 samples after append, got %d", agg.Count)
+	}
+}
@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// CFS throttling metrics (services with cpu_limit; labels: service, instance).
+const (
+	// MetricCPUThrottledMs records the time CPU work waited with its instance's quota used up (ms per reservation).
+	MetricCPUThrottledMs = "cpu_throttled_ms"
+	// MetricCPUThrottledPeriodCount counts CFS periods in which an instance was throttled.
+	MetricCPUThrottledPeriodCount = "cpu_throttled_period_count"
+)
+
+// RecordCPUThrottle records the throttling of one CPU reservation on an instance.
+func RecordCPUThrottle(collector *Collector, throttledMs float64, periods int, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricCPUThrottledMs, throttledMs, timestamp, labels)
+	if periods > 0 {
+		collector.Record(MetricCPUThrottledPeriodCount, float64(periods), timestamp, labels)
+	}
+}
+
+// AttachCPUThrottleStats fills the throttling rollups of rm, in total and per instance, from the
+// throttling series.
+func AttachCPUThrottleStats(collector *Collector, rm *models.RunMetrics) {
+	if collector == nil || rm == nil {
+		return
+	}
+	type key struct{ service, instance string }
+	rows := map[key]*models.InstanceCPUThrottleStats{}
+	row := func(labels map[string]string) *models.InstanceCPUThrottleStats {
+		k := key{labels["service"], labels["instance"]}
+		if rows[k] == nil {
+			rows[k] = &models.InstanceCPUThrottleStats{ServiceName: k.service, InstanceID: k.instance}
+		}
+		return rows[k]
+	}
+	for _, labels := range collector.GetLabelsForMetric(MetricCPUThrottledMs) {
+		if agg := collector.GetOrComputeAggregation(MetricCPUThrottledMs, labels); agg != nil {
+			row(labels).ThrottledMs += agg.Sum
+		}
+	}
+	for _, labels := range collector.GetLabelsForMetric(MetricCPUThrottledPeriodCount) {
+		if agg := collector.GetOrComputeAggregation(MetricCPUThrottledPeriodCount, labels); agg != nil {
+			row(labels).ThrottledPeriods += int64(agg.Sum)
+		}
+	}
+	for _, r := range rows {
+		if r.ThrottledMs <= 0 && r.ThrottledPeriods == 0 {
+			continue
+		}
+		rm.CPUThrottledMsTotal += r.ThrottledMs
+		rm.CPUThrottledPeriodsTotal += r.ThrottledPeriods
+		rm.InstanceCPUThrottleStats = append(rm.InstanceCPUThrottleStats, *r)
+	}
+	sort.Slice(rm.InstanceCPUThrottleStats, func(i, j int) bool {
+		a, b := rm.InstanceCPUThrottleStats[i], rm.InstanceCPUThrottleStats[j]
+		if a.ServiceName != b.ServiceName {
+			return a.ServiceName < b.ServiceName
+		}
+		return a.InstanceID < b.InstanceID
+	})
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import "time"
+
+// Chaos fault metrics (scenario faults and the faults API).
+const (
+	// MetricFaultActive is 1 while a fault is injected and 0 once it is lifted (labels: fault, type,
+	// target). Its points mark the fault windows in the time series.
+	MetricFaultActive = "fault_active"
+	// MetricFaultKillCount counts instances killed by kill_instances faults (labels: service, instance, fault).
+	MetricFaultKillCount = "fault_kill_count"
+)
+
+// CreateFaultLabels creates the labels of a fault window.
+func CreateFaultLabels(faultID, faultType, target string) map[string]string {
+	return map[string]string{
+		"fault":  faultID,
+		"type":   faultType,
+		"target": target,
+	}
+}
+
+// RecordFaultActive records a fault injected (active) or lifted.
+func RecordFaultActive(collector *Collector, active bool, timestamp time.Time, labels map[string]string) {
+	v := 0.0
+	if active {
+		v = 1.0
+	}
+	collector.Record(MetricFaultActive, v, timestamp, labels)
+}
+
+// RecordFaultKill records an instance killed by a fault.
+func RecordFaultKill(collector *Collector, serviceName, instanceID, faultID string, timestamp time.Time) {
+	collector.Record(MetricFaultKillCount, 1.0, timestamp, map[string]string{
+		"service":  serviceName,
+		"instance": instanceID,
+		"fault":    faultID,
+	})
+}
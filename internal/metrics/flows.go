@@ -0,0 +1,171 @@
+package metrics
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// Flow session metrics (labels: flow, step, step_index; session series add outcome).
+const (
+	// MetricFlowSessionStartCount counts started sessions (label flow only).
+	MetricFlowSessionStartCount = "flow_session_start_count"
+	// MetricFlowStepCount counts finished step requests of flow sessions.
+	MetricFlowStepCount = "flow_step_count"
+	// MetricFlowSessionCount counts finished sessions by outcome, labelled with the step they ended at.
+	MetricFlowSessionCount = "flow_session_count"
+	// MetricFlowSessionDuration records session duration (ms) from the first arrival to the end of the last step.
+	MetricFlowSessionDuration = "flow_session_duration_ms"
+
+	LabelFlow      = "flow"
+	LabelFlowStep  = "step"
+	LabelStepIndex = "step_index"
+	LabelOutcome   = "outcome"
+
+	FlowOutcomeCompleted = "completed"
+	FlowOutcomeAbandoned = "abandoned"
+	FlowOutcomeFailed    = "failed"
+)
+
+// CreateFlowStepLabels creates labels for one step of a flow.
+func CreateFlowStepLabels(flowID, step string, index int) map[string]string {
+	return map[string]string{
+		LabelFlow:      flowID,
+		LabelFlowStep:  step,
+		LabelStepIndex: strconv.Itoa(index),
+	}
+}
+
+// RecordFlowSessionStart records a session started by the arrival of its first step.
+func RecordFlowSessionStart(collector *Collector, flowID string, timestamp time.Time) {
+	collector.Record(MetricFlowSessionStartCount, 1.0, timestamp, map[string]string{LabelFlow: flowID})
+}
+
+// RecordFlowStepCount records a finished step request.
+func RecordFlowStepCount(collector *Collector, count float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricFlowStepCount, count, timestamp, labels)
+}
+
+// RecordFlowSessionEnd records a finished session: its outcome at the step labels and its duration per flow and outcome.
+func RecordFlowSessionEnd(collector *Collector, outcome string, durationMs float64, timestamp time.Time, stepLabels map[string]string) {
+	lbl := make(map[string]string, len(stepLabels)+1)
+	for k, v := range stepLabels {
+		lbl[k] = v
+	}
+	lbl[LabelOutcome] = outcome
+	collector.Record(MetricFlowSessionCount, 1.0, timestamp, lbl)
+	collector.Record(MetricFlowSessionDuration, durationMs, timestamp, map[string]string{
+		LabelFlow:    stepLabels[LabelFlow],
+		LabelOutcome: outcome,
+	})
+}
+
+// AttachFlowStats fills rm.FlowStats from the flow session series, with steps in flow order.
+func AttachFlowStats(collector *Collector, rm *models.RunMetrics) {
+	if collector == nil || rm == nil {
+		return
+	}
+	type stepKey struct {
+		flow  string
+		index int
+	}
+	flows := map[string]*models.FlowStats{}
+	started := map[string]int64{}
+	steps := map[stepKey]*models.FlowStepStats{}
+	stepFor := func(labels map[string]string) *models.FlowStepStats {
+		flow := labels[LabelFlow]
+		index, err := strconv.Atoi(labels[LabelStepIndex])
+		if flow == "" || err != nil {
+			return nil
+		}
+		if flows[flow] == nil {
+			flows[flow] = &models.FlowStats{FlowID: flow}
+		}
+		k := stepKey{flow, index}
+		if steps[k] == nil {
+			steps[k] = &models.FlowStepStats{Step: labels[LabelFlowStep]}
+		}
+		return steps[k]
+	}
+
+	for _, labels := range collector.GetLabelsForMetric(MetricFlowStepCount) {
+		st := stepFor(labels)
+		if agg := collector.GetOrComputeAggregation(MetricFlowStepCount, labels); st != nil && agg != nil {
+			st.Requests += int64(agg.Sum)
+		}
+	}
+	for _, labels := range collector.GetLabelsForMetric(MetricFlowSessionCount) {
+		st := stepFor(labels)
+		agg := collector.GetOrComputeAggregation(MetricFlowSessionCount, labels)
+		if st == nil || agg == nil {
+			continue
+		}
+		n := int64(agg.Sum)
+		fs := flows[labels[LabelFlow]]
+		fs.Sessions += n
+		switch labels[LabelOutcome] {
+		case FlowOutcomeCompleted:
+			fs.CompletedSessions += n
+		case FlowOutcomeAbandoned:
+			fs.AbandonedSessions += n
+			st.Abandoned += n
+		case FlowOutcomeFailed:
+			fs.FailedSessions += n
+			st.Failed += n
+		}
+	}
+	for _, labels := range collector.GetLabelsForMetric(MetricFlowSessionStartCount) {
+		flow := labels[LabelFlow]
+		agg := collector.GetOrComputeAggregation(MetricFlowSessionStartCount, labels)
+		if flow == "" || agg == nil {
+			continue
+		}
+		if flows[flow] == nil {
+			flows[flow] = &models.FlowStats{FlowID: flow}
+		}
+		started[flow] += int64(agg.Sum)
+	}
+	if len(flows) == 0 {
+		return
+	}
+
+	keys := make([]stepKey, 0, len(steps))
+	for k := range steps {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].flow != keys[j].flow {
+			return keys[i].flow < keys[j].flow
+		}
+		return keys[i].index < keys[j].index
+	})
+	for _, k := range keys {
+		st := steps[k]
+		if st.Requests > 0 {
+			st.AbandonmentRate = float64(st.Abandoned) / float64(st.Requests)
+		}
+		flows[k.flow].Steps = append(flows[k.flow].Steps, *st)
+	}
+
+	out := make([]models.FlowStats, 0, len(flows))
+	for id, fs := range flows {
+		// Sessions still running at the end count as in progress, so a short run does not report
+		// only the sessions quick enough to finish.
+		if started[id] > fs.Sessions {
+			fs.InProgressSessions = started[id] - fs.Sessions
+			fs.Sessions = started[id]
+		}
+		if fs.Sessions > 0 {
+			fs.CompletionRate = float64(fs.CompletedSessions) / float64(fs.Sessions)
+		}
+		dur := collector.GetOrComputeAggregation(MetricFlowSessionDuration, map[string]string{LabelFlow: id, LabelOutcome: FlowOutcomeCompleted})
+		if dur != nil && dur.Count > 0 {
+			fs.SessionDurationP50Ms, fs.SessionDurationP95Ms, fs.SessionDurationP99Ms, fs.SessionDurationMeanMs = dur.P50, dur.P95, dur.P99, dur.Mean
+		}
+		out = append(out, *fs)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].FlowID < out[j].FlowID })
+	rm.FlowStats = out
+}
@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+func TestAttachFlowStats(t *testing.T) {
+	c := NewCollector()
+	c.Start()
+	now := time.Now()
+	browse := CreateFlowStepLabels("shop", "browse", 0)
+	buy := CreateFlowStepLabels("shop", "buy", 1)
+	for i := 0; i < 4; i++ {
+		RecordFlowStepCount(c, 1, now, browse)
+	}
+	RecordFlowSessionEnd(c, FlowOutcomeAbandoned, 10, now, browse)
+	for i := 0; i < 3; i++ {
+		RecordFlowStepCount(c, 1, now, buy)
+	}
+	RecordFlowSessionEnd(c, FlowOutcomeCompleted, 100, now, buy)
+	RecordFlowSessionEnd(c, FlowOutcomeCompleted, 200, now, buy)
+	RecordFlowSessionEnd(c, FlowOutcomeFailed, 50, now, buy)
+	for i := 0; i < 6; i++ {
+		RecordFlowSessionStart(c, "shop", now)
+	}
+
+	rm := &models.RunMetrics{}
+	AttachFlowStats(c, rm)
+	if len(rm.FlowStats) != 1 {
+		t.Fatalf("expected one flow, got %+v", rm.FlowStats)
+	}
+	fs := rm.FlowStats[0]
+	// Two of the six started sessions had not finished.
+	if fs.FlowID != "shop" || fs.Sessions != 6 || fs.CompletedSessions != 2 || fs.AbandonedSessions != 1 || fs.FailedSessions != 1 || fs.InProgressSessions != 2 {
+		t.Fatalf("unexpected session counts %+v", fs)
+	}
+	if fs.CompletionRate != 2.0/6 || fs.SessionDurationMeanMs != 150 {
+		t.Fatalf("expected completion rate 1/3 and mean duration of completed sessions 150ms, got %+v", fs)
+	}
+	want := []models.FlowStepStats{
+		{Step: "browse", Requests: 4, Abandoned: 1, AbandonmentRate: 0.25},
+		{Step: "buy", Requests: 3, Failed: 1},
+	}
+	if len(fs.Steps) != len(want) {
+		t.Fatalf("expected %d steps, got %+v", len(want), fs.Steps)
+	}
+	for i := range want {
+		if fs.Steps[i] != want[i] {
+			t.Fatalf("step %d: got %+v, want %+v", i, fs.Steps[i], want[i])
+		}
+	}
+
+	empty := &models.RunMetrics{}
+	AttachFlowStats(NewCollector(), empty)
+	if empty.FlowStats != nil {
+		t.Fatalf("expected no flow stats without flow series, got %+v", empty.FlowStats)
+	}
+}
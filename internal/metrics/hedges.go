@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// Hedged request metrics (labels: service, endpoint of the called endpoint).
+const (
+	// MetricHedgedCallCount counts sync calls made on edges with a hedge policy.
+	MetricHedgedCallCount = "hedged_call_count"
+	// MetricHedgeRequestCount counts hedge attempts sent.
+	MetricHedgeRequestCount = "hedge_request_count"
+	// MetricHedgeWinCount counts calls answered by a hedge attempt rather than the first attempt.
+	MetricHedgeWinCount = "hedge_win_count"
+	// MetricHedgeCancelledCount counts losing attempts dropped before they started.
+	MetricHedgeCancelledCount = "hedge_cancelled_count"
+	// MetricHedgeWastedCPU records local CPU time (ms) spent by losing attempts.
+	MetricHedgeWastedCPU = "hedge_wasted_cpu_ms"
+	// MetricHedgeLatencyGain records, for a call won by a hedge, how much later (ms) the first attempt
+	// answered. Only first attempts that still complete in full report it.
+	MetricHedgeLatencyGain = "hedge_latency_gain_ms"
+)
+
+// RecordHedgedCall records a sync call made on an edge with a hedge policy.
+func RecordHedgedCall(collector *Collector, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricHedgedCallCount, 1.0, timestamp, labels)
+}
+
+// RecordHedgeRequest records a hedge attempt sent.
+func RecordHedgeRequest(collector *Collector, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricHedgeRequestCount, 1.0, timestamp, labels)
+}
+
+// RecordHedgeWin records a call answered by a hedge attempt.
+func RecordHedgeWin(collector *Collector, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricHedgeWinCount, 1.0, timestamp, labels)
+}
+
+// RecordHedgeCancelled records a losing attempt dropped before it started.
+func RecordHedgeCancelled(collector *Collector, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricHedgeCancelledCount, 1.0, timestamp, labels)
+}
+
+// RecordHedgeWastedCPU records local CPU time spent by a losing attempt.
+func RecordHedgeWastedCPU(collector *Collector, cpuMs float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricHedgeWastedCPU, cpuMs, timestamp, labels)
+}
+
+// RecordHedgeLatencyGain records how much later the first attempt of a call won by a hedge answered.
+func RecordHedgeLatencyGain(collector *Collector, gainMs float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricHedgeLatencyGain, gainMs, timestamp, labels)
+}
+
+// AttachHedgeStats fills the hedging rollups of rm from the hedge series.
+func AttachHedgeStats(collector *Collector, rm *models.RunMetrics) {
+	if collector == nil || rm == nil {
+		return
+	}
+	rm.HedgedCallsTotal = int64(collector.SumMetric(MetricHedgedCallCount))
+	rm.HedgeRequestsTotal = int64(collector.SumMetric(MetricHedgeRequestCount))
+	rm.HedgeWinsTotal = int64(collector.SumMetric(MetricHedgeWinCount))
+	rm.HedgeCancelledTotal = int64(collector.SumMetric(MetricHedgeCancelledCount))
+	rm.HedgeWastedCPUMsTotal = collector.SumMetric(MetricHedgeWastedCPU)
+	if rm.HedgedCallsTotal > 0 {
+		rm.HedgeRate = float64(rm.HedgeRequestsTotal) / float64(rm.HedgedCallsTotal)
+	}
+	if gain := collector.GetMetricAggregation(MetricHedgeLatencyGain); gain != nil && gain.Count > 0 {
+		rm.HedgeLatencyGainMsMean = gain.Mean
+		rm.HedgeLatencyGainMsP95 = gain.P95
+	}
+}
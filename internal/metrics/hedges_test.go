@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+func TestAttachHedgeStats(t *testing.T) {
+	c := NewCollector()
+	c.Start()
+	now := time.Now()
+	labels := CreateEndpointLabels("store", "/get")
+	for i := 0; i < 4; i++ {
+		RecordHedgedCall(c, now, labels)
+	}
+	RecordHedgeRequest(c, now, labels)
+	RecordHedgeWin(c, now, labels)
+	RecordHedgeCancelled(c, now, labels)
+	RecordHedgeWastedCPU(c, 2.5, now, labels)
+	RecordHedgeLatencyGain(c, 40, now, labels)
+
+	rm := &models.RunMetrics{}
+	AttachHedgeStats(c, rm)
+	if rm.HedgedCallsTotal != 4 || rm.HedgeRequestsTotal != 1 || rm.HedgeWinsTotal != 1 || rm.HedgeCancelledTotal != 1 {
+		t.Fatalf("unexpected hedge totals %+v", rm)
+	}
+	if rm.HedgeRate != 0.25 || rm.HedgeWastedCPUMsTotal != 2.5 || rm.HedgeLatencyGainMsMean != 40 {
+		t.Fatalf("unexpected hedge rollups %+v", rm)
+	}
+
+	empty := &models.RunMetrics{}
+	AttachHedgeStats(NewCollector(), empty)
+	if empty.HedgedCallsTotal != 0 || empty.HedgeRate != 0 {
+		t.Fatalf("expected no hedge stats without hedge series, got %+v", empty)
+	}
+}
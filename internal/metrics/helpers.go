@@ -42,6 +42,19 @@ const (
 	MetricActiveConnections     = "active_connections"
 	MetricCacheHitCount         = "cache_hit_count"
 	MetricCacheMissCount        = "cache_miss_count"
+	// Cache key-space metrics (behavior.cache.key_space; labels service, endpoint of the cache).
+	// MetricCacheLoadCount counts misses that load the value themselves (miss_call, then write-back).
+	MetricCacheLoadCount = "cache_load_count"
+	// MetricCacheStampedeCount counts misses on a key whose load was already in flight.
+	MetricCacheStampedeCount = "cache_stampede_count"
+	// MetricCacheCoalescedCount counts misses that waited for the load in flight instead of loading again.
+	MetricCacheCoalescedCount = "cache_coalesced_count"
+	// MetricCacheExpiredCount counts lookups that found their entry past its TTL.
+	MetricCacheExpiredCount = "cache_expired_count"
+	// MetricCacheEvictionCount counts entries evicted to make room for a write-back.
+	MetricCacheEvictionCount = "cache_eviction_count"
+	// MetricCacheEntries is the number of entries held after each write-back (gauge).
+	MetricCacheEntries = "cache_entries"
 	// MetricDownstreamCallerCPU records caller-side CPU work for downstream serialization / client overhead (ms per edge attempt).
 	MetricDownstreamCallerCPU = "downstream_caller_cpu_ms"
 
@@ -66,6 +79,14 @@ const (
 	MetricTopicMessageAgeMs     = "topic_message_age_ms"
 	MetricTopicPublishLatencyMs = "topic_publish_latency_ms"
 	MetricTopicConsumerLag      = "topic_consumer_lag"
+
+	// MetricAutoscalingDesiredReplicas is the per-service replica target chosen at each autoscaler sync.
+	MetricAutoscalingDesiredReplicas = "autoscaling_desired_replicas"
+
+	// Resilience policy metrics (labels: service, endpoint; transitions add from/to states).
+	MetricCircuitBreakerStateTransition = "circuit_breaker_state_transition_count"
+	MetricCircuitBreakerOpen            = "circuit_breaker_open"
+	MetricRateLimitRejection            = "rate_limit_rejection_count"
 )
 
 // RecordLatency records end-to-end latency for a completed request (per-hop total duration when the request node finishes).
@@ -193,6 +214,36 @@ func RecordCacheMissCount(collector *Collector, count float64, timestamp time.Ti
 	collector.Record(MetricCacheMissCount, count, timestamp, labels)
 }
 
+// RecordCacheLoadCount records a miss that loads the value itself.
+func RecordCacheLoadCount(collector *Collector, count float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricCacheLoadCount, count, timestamp, labels)
+}
+
+// RecordCacheStampedeCount records a miss on a key whose load was already in flight.
+func RecordCacheStampedeCount(collector *Collector, count float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricCacheStampedeCount, count, timestamp, labels)
+}
+
+// RecordCacheCoalescedCount records a miss that waited for the load in flight.
+func RecordCacheCoalescedCount(collector *Collector, count float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricCacheCoalescedCount, count, timestamp, labels)
+}
+
+// RecordCacheExpiredCount records a lookup that found its entry expired.
+func RecordCacheExpiredCount(collector *Collector, count float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricCacheExpiredCount, count, timestamp, labels)
+}
+
+// RecordCacheEvictionCount records entries evicted by a write-back.
+func RecordCacheEvictionCount(collector *Collector, count float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricCacheEvictionCount, count, timestamp, labels)
+}
+
+// RecordCacheEntries records the number of entries a cache holds.
+func RecordCacheEntries(collector *Collector, entries float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricCacheEntries, entries, timestamp, labels)
+}
+
 // RecordDownstreamCallerCPU records caller-side CPU time charged for a downstream edge attempt.
 func RecordDownstreamCallerCPU(collector *Collector, cpuMs float64, timestamp time.Time, labels map[string]string) {
 	collector.Record(MetricDownstreamCallerCPU, cpuMs, timestamp, labels)
@@ -284,6 +335,26 @@ func RecordIngressLogicalFailure(collector *Collector, count float64, timestamp
 	collector.Record(MetricIngressLogicalFailure, count, timestamp, labels)
 }
 
+// RecordAutoscalingDesiredReplicas records the autoscaler's stabilized replica target for a service (gauge).
+func RecordAutoscalingDesiredReplicas(collector *Collector, replicas float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricAutoscalingDesiredReplicas, replicas, timestamp, labels)
+}
+
+// RecordCircuitBreakerStateTransition records one circuit state change (counter; labels include from/to).
+func RecordCircuitBreakerStateTransition(collector *Collector, count float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricCircuitBreakerStateTransition, count, timestamp, labels)
+}
+
+// RecordCircuitBreakerOpen records whether a service/endpoint circuit is open (gauge: 1 open, 0.5 half-open, 0 closed).
+func RecordCircuitBreakerOpen(collector *Collector, value float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricCircuitBreakerOpen, value, timestamp, labels)
+}
+
+// RecordRateLimitRejection records requests rejected by the rate limiting policy (counter).
+func RecordRateLimitRejection(collector *Collector, count float64, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricRateLimitRejection, count, timestamp, labels)
+}
+
 // CreateServiceLabels creates a labels map for a service
 func CreateServiceLabels(serviceName string) map[string]string {
 	return map[string]string{
@@ -726,6 +797,12 @@ func ConvertToRunMetrics(collector *Collector, serviceLabels []map[string]string
 	}
 	AttachEndpointRequestStats(collector, rm)
 	AttachInstanceRouteStats(collector, rm)
+	AttachFlowStats(collector, rm)
+	AttachHedgeStats(collector, rm)
+	AttachOOMStats(collector, rm)
+	AttachHostFailureStats(collector, rm)
+	AttachCPUThrottleStats(collector, rm)
+	AttachPriorityClassStats(collector, rm)
 	return rm
 }
 
@@ -0,0 +1,94 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// Host failure injection metrics (scenario host_failures).
+const (
+	// MetricHostFailureCount counts failed hosts (labels: host, zone).
+	MetricHostFailureCount = "host_failure_count"
+	// MetricHostRecoveryCount counts hosts back up after a failure (labels: host, zone).
+	MetricHostRecoveryCount = "host_recovery_count"
+	// MetricHostsDown is the number of hosts down (gauge, recorded on every failure and recovery).
+	MetricHostsDown = "hosts_down"
+	// MetricInstanceLostCount counts instances lost with their host (labels: service, instance, host).
+	MetricInstanceLostCount = "instance_lost_count"
+	// MetricInstanceRescheduleCount counts replacements placed for lost instances (labels: service,
+	// instance, host of the replacement).
+	MetricInstanceRescheduleCount = "instance_reschedule_count"
+	// MetricOutageRequestCount counts ingress requests arriving while a host is down (labels: service, endpoint).
+	MetricOutageRequestCount = "outage_request_count"
+	// MetricOutageFailureCount counts user-visible failures of those requests (labels: service, endpoint).
+	MetricOutageFailureCount = "outage_failure_count"
+)
+
+// CreateHostZoneLabels creates the labels of a host failure or recovery.
+func CreateHostZoneLabels(hostID, zone string) map[string]string {
+	return map[string]string{
+		"host": hostID,
+		"zone": zone,
+	}
+}
+
+// CreateInstanceHostLabels creates the labels of an instance lost or placed on a host.
+func CreateInstanceHostLabels(serviceName, instanceID, hostID string) map[string]string {
+	return map[string]string{
+		"service":  serviceName,
+		"instance": instanceID,
+		"host":     hostID,
+	}
+}
+
+// RecordHostFailure records a failed host.
+func RecordHostFailure(collector *Collector, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricHostFailureCount, 1.0, timestamp, labels)
+}
+
+// RecordHostRecovery records a host back up.
+func RecordHostRecovery(collector *Collector, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricHostRecoveryCount, 1.0, timestamp, labels)
+}
+
+// RecordHostsDown records the number of hosts down.
+func RecordHostsDown(collector *Collector, down float64, timestamp time.Time) {
+	collector.Record(MetricHostsDown, down, timestamp, map[string]string{})
+}
+
+// RecordInstanceLost records an instance lost with its host.
+func RecordInstanceLost(collector *Collector, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricInstanceLostCount, 1.0, timestamp, labels)
+}
+
+// RecordInstanceReschedule records a replacement placed for a lost instance.
+func RecordInstanceReschedule(collector *Collector, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricInstanceRescheduleCount, 1.0, timestamp, labels)
+}
+
+// RecordOutageRequest records an ingress request arriving while a host is down.
+func RecordOutageRequest(collector *Collector, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricOutageRequestCount, 1.0, timestamp, labels)
+}
+
+// RecordOutageFailure records a user-visible failure of a request that arrived while a host was down.
+func RecordOutageFailure(collector *Collector, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricOutageFailureCount, 1.0, timestamp, labels)
+}
+
+// AttachHostFailureStats fills the host failure rollups of rm, including the availability seen by
+// requests that arrived during an outage.
+func AttachHostFailureStats(collector *Collector, rm *models.RunMetrics) {
+	if collector == nil || rm == nil {
+		return
+	}
+	rm.HostFailuresTotal = int64(collector.SumMetric(MetricHostFailureCount))
+	rm.InstancesLostTotal = int64(collector.SumMetric(MetricInstanceLostCount))
+	rm.InstancesRescheduledTotal = int64(collector.SumMetric(MetricInstanceRescheduleCount))
+	rm.OutageRequests = int64(collector.SumMetric(MetricOutageRequestCount))
+	rm.OutageFailedRequests = int64(collector.SumMetric(MetricOutageFailureCount))
+	if rm.OutageRequests > 0 {
+		rm.OutageAvailability = 1 - float64(rm.OutageFailedRequests)/float64(rm.OutageRequests)
+	}
+}
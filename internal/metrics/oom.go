@@ -0,0 +1,52 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// Memory limit enforcement metrics (policies.oom).
+const (
+	// MetricOOMKillCount counts OOM-killed instances (labels: service, instance, host, scope).
+	MetricOOMKillCount = "oom_kill_count"
+	// MetricInstanceRestartCount counts instances back in rotation after an OOM kill or a kill_instances
+	// fault (labels: service, instance).
+	MetricInstanceRestartCount = "instance_restart_count"
+
+	// LabelOOMScope tells which limit an OOM kill enforced.
+	LabelOOMScope = "scope"
+	// OOMScopeInstance: the instance exceeded its memory_mb.
+	OOMScopeInstance = "instance"
+	// OOMScopeHost: the host ran out of memory and the kill freed the largest instance on it.
+	OOMScopeHost = "host"
+)
+
+// CreateOOMKillLabels creates the labels of an OOM kill.
+func CreateOOMKillLabels(serviceName, instanceID, hostID, scope string) map[string]string {
+	return map[string]string{
+		"service":     serviceName,
+		"instance":    instanceID,
+		"host":        hostID,
+		LabelOOMScope: scope,
+	}
+}
+
+// RecordOOMKill records an OOM-killed instance.
+func RecordOOMKill(collector *Collector, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricOOMKillCount, 1.0, timestamp, labels)
+}
+
+// RecordInstanceRestart records an instance back in rotation after a kill.
+func RecordInstanceRestart(collector *Collector, timestamp time.Time, labels map[string]string) {
+	collector.Record(MetricInstanceRestartCount, 1.0, timestamp, labels)
+}
+
+// AttachOOMStats fills the OOM rollups of rm from the OOM series.
+func AttachOOMStats(collector *Collector, rm *models.RunMetrics) {
+	if collector == nil || rm == nil {
+		return
+	}
+	rm.OOMKillsTotal = int64(collector.SumMetric(MetricOOMKillCount))
+	rm.InstanceRestartsTotal = int64(collector.SumMetric(MetricInstanceRestartCount))
+}
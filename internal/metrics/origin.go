@@ -12,6 +12,9 @@ const (
 	LabelReason       = "reason"
 	LabelIsRetry      = "is_retry"
 	LabelRetryAttempt = "attempt"
+
+	// LabelPriorityClass is the scenario priority class of workload requests and their downstream calls.
+	LabelPriorityClass = "priority_class"
 )
 
 // Standard error reason values for request_error_count labels.
@@ -29,6 +32,15 @@ const (
 	ReasonLocalFailure         = "local_failure"
 	ReasonDBConnectionTimeout  = "db_connection_timeout"
 	ReasonDBConnectionRejected = "db_connection_rejected"
+	ReasonOOMKilled            = "oom_killed"
+	ReasonHostFailure          = "host_failure"
+	ReasonFaultInjected        = "fault_injected"
+	ReasonNetworkPartition     = "network_partition"
+	ReasonInstanceKilled       = "instance_killed"
+	ReasonCoDelDropped         = "codel_dropped"
+	ReasonQueueFull            = "queue_full"
+	ReasonQueueTimeout         = "queue_timeout"
+	ReasonLoadShed             = "load_shed"
 )
 
 // EndpointLabelsWithOrigin adds an origin label to endpoint-scoped metrics.
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"sort"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// AttachPriorityClassStats fills rm.PriorityClassStats from the request series labelled with a
+// priority class: ingress request_count, ingress_logical_failure_count, load_shed errors at any
+// hop and root_request_latency_ms.
+func AttachPriorityClassStats(collector *Collector, rm *models.RunMetrics) {
+	if collector == nil || rm == nil {
+		return
+	}
+	rows := map[string]*models.PriorityClassStats{}
+	row := func(labels map[string]string) *models.PriorityClassStats {
+		pc := labels[LabelPriorityClass]
+		if pc == "" {
+			return nil
+		}
+		if rows[pc] == nil {
+			rows[pc] = &models.PriorityClassStats{PriorityClass: pc}
+		}
+		return rows[pc]
+	}
+	for _, labels := range collector.GetLabelsForMetric(MetricRequestCount) {
+		if labels[LabelOrigin] != OriginIngress {
+			continue
+		}
+		if r, agg := row(labels), collector.GetOrComputeAggregation(MetricRequestCount, labels); r != nil && agg != nil {
+			r.Requests += int64(agg.Sum)
+		}
+	}
+	for _, labels := range collector.GetLabelsForMetric(MetricIngressLogicalFailure) {
+		if r, agg := row(labels), collector.GetOrComputeAggregation(MetricIngressLogicalFailure, labels); r != nil && agg != nil {
+			r.FailedRequests += int64(agg.Sum)
+		}
+	}
+	for _, labels := range collector.GetLabelsForMetric(MetricRequestErrorCount) {
+		if labels[LabelReason] != ReasonLoadShed {
+			continue
+		}
+		if r, agg := row(labels), collector.GetOrComputeAggregation(MetricRequestErrorCount, labels); r != nil && agg != nil {
+			r.ShedAttempts += int64(agg.Sum)
+		}
+	}
+	for _, r := range rows {
+		if r.Requests > 0 {
+			r.ErrorRate = float64(r.FailedRequests) / float64(r.Requests)
+		}
+		if agg := collector.GetOrComputeAggregationForLabelSubset(MetricRootRequestLatency, map[string]string{LabelPriorityClass: r.PriorityClass}); agg != nil && agg.Count > 0 {
+			r.LatencyP50Ms, r.LatencyP95Ms, r.LatencyP99Ms, r.LatencyMeanMs = agg.P50, agg.P95, agg.P99, agg.Mean
+		}
+		rm.PriorityClassStats = append(rm.PriorityClassStats, *r)
+	}
+	sort.Slice(rm.PriorityClassStats, func(i, j int) bool {
+		return rm.PriorityClassStats[i].PriorityClass < rm.PriorityClassStats[j].PriorityClass
+	})
+}
@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+// SummarizeValues returns the mean, sample standard deviation and 95% Student t confidence interval of values.
+func SummarizeValues(values []float64) models.SummaryStat {
+	st := models.SummaryStat{N: len(values)}
+	if len(values) == 0 {
+		return st
+	}
+	st.Mean = utils.Mean(values)
+	st.CI95Low, st.CI95High = st.Mean, st.Mean
+	if len(values) < 2 {
+		return st
+	}
+	st.StdDev = utils.SampleStdDev(values)
+	st.CI95HalfWidth = utils.StudentTCritical95(len(values)-1) * st.StdDev / math.Sqrt(float64(len(values)))
+	st.CI95Low = st.Mean - st.CI95HalfWidth
+	st.CI95High = st.Mean + st.CI95HalfWidth
+	return st
+}
+
+// RelativeHalfWidth is the CI half-width as a fraction of |mean| (0 when there is no spread, +Inf for a noisy zero mean).
+func RelativeHalfWidth(st models.SummaryStat) float64 {
+	if st.CI95HalfWidth == 0 {
+		return 0
+	}
+	if st.Mean == 0 {
+		return math.Inf(1)
+	}
+	return st.CI95HalfWidth / math.Abs(st.Mean)
+}
+
+// scopeSamples collects per-replication values of the headline metrics of one scope.
+type scopeSamples struct {
+	p50, p95, p99, mean, throughput, errRate []float64
+}
+
+func (s *scopeSamples) add(p50, p95, p99, mean, throughput, errRate float64) {
+	s.addLatency(&p50, &p95, &p99, &mean)
+	s.throughput = append(s.throughput, throughput)
+	s.errRate = append(s.errRate, errRate)
+}
+
+// addLatency appends the latencies that are present (endpoint latencies are optional).
+func (s *scopeSamples) addLatency(p50, p95, p99, mean *float64) {
+	for _, v := range []struct {
+		dst *[]float64
+		v   *float64
+	}{{&s.p50, p50}, {&s.p95, p95}, {&s.p99, p99}, {&s.mean, mean}} {
+		if v.v != nil {
+			*v.dst = append(*v.dst, *v.v)
+		}
+	}
+}
+
+func (s *scopeSamples) summarize() models.ReplicationMetrics {
+	return models.ReplicationMetrics{
+		LatencyP50:    SummarizeValues(s.p50),
+		LatencyP95:    SummarizeValues(s.p95),
+		LatencyP99:    SummarizeValues(s.p99),
+		LatencyMean:   SummarizeValues(s.mean),
+		ThroughputRPS: SummarizeValues(s.throughput),
+		ErrorRate:     SummarizeValues(s.errRate),
+	}
+}
+
+func ratio(num, den int64) float64 {
+	if den <= 0 {
+		return 0
+	}
+	return float64(num) / float64(den)
+}
+
+// SummarizeReplications aggregates the RunMetrics of independent replications (runs[i] produced with seeds[i])
+// into per-run, per-service and per-endpoint statistics. simDuration converts service and endpoint request
+// counts to throughput. Services, endpoints and endpoint latencies absent from a replication do not contribute a sample.
+func SummarizeReplications(runs []*models.RunMetrics, seeds []int64, simDuration time.Duration) *models.ReplicationSummary {
+	out := &models.ReplicationSummary{
+		Replications: len(runs),
+		Seeds:        append([]int64(nil), seeds...),
+	}
+	sec := simDuration.Seconds()
+	perSec := func(n int64) float64 {
+		if sec <= 0 {
+			return 0
+		}
+		return float64(n) / sec
+	}
+
+	var run scopeSamples
+	services := make(map[string]*scopeSamples)
+	type endpointKey struct{ service, path string }
+	endpoints := make(map[endpointKey]*scopeSamples)
+	for _, rm := range runs {
+		if rm == nil {
+			continue
+		}
+		run.add(rm.LatencyP50, rm.LatencyP95, rm.LatencyP99, rm.LatencyMean, rm.ThroughputRPS, ratio(rm.FailedRequests, rm.TotalRequests))
+		for name, sm := range rm.ServiceMetrics {
+			if sm == nil {
+				continue
+			}
+			s := services[name]
+			if s == nil {
+				s = &scopeSamples{}
+				services[name] = s
+			}
+			s.add(sm.LatencyP50, sm.LatencyP95, sm.LatencyP99, sm.LatencyMean, perSec(sm.RequestCount), ratio(sm.ErrorCount, sm.RequestCount))
+		}
+		for i := range rm.EndpointRequestStats {
+			ep := &rm.EndpointRequestStats[i]
+			k := endpointKey{ep.ServiceName, ep.EndpointPath}
+			s := endpoints[k]
+			if s == nil {
+				s = &scopeSamples{}
+				endpoints[k] = s
+			}
+			s.addLatency(ep.LatencyP50Ms, ep.LatencyP95Ms, ep.LatencyP99Ms, ep.LatencyMeanMs)
+			s.throughput = append(s.throughput, perSec(ep.RequestCount))
+			s.errRate = append(s.errRate, ratio(ep.ErrorCount, ep.RequestCount))
+		}
+	}
+
+	out.Run = run.summarize()
+	if len(services) > 0 {
+		out.Services = make(map[string]*models.ReplicationMetrics, len(services))
+		for name, s := range services {
+			m := s.summarize()
+			out.Services[name] = &m
+		}
+	}
+	for k, s := range endpoints {
+		out.Endpoints = append(out.Endpoints, models.EndpointReplicationMetrics{
+			ServiceName:        k.service,
+			EndpointPath:       k.path,
+			ReplicationMetrics: s.summarize(),
+		})
+	}
+	sort.Slice(out.Endpoints, func(i, j int) bool {
+		a, b := out.Endpoints[i], out.Endpoints[j]
+		if a.ServiceName != b.ServiceName {
+			return a.ServiceName < b.ServiceName
+		}
+		return a.EndpointPath < b.EndpointPath
+	})
+
+	// Latencies and throughput are non-negative, so a zero mean has no spread and the ratio stays finite.
+	for _, st := range []models.SummaryStat{out.Run.LatencyP50, out.Run.LatencyP95, out.Run.LatencyP99, out.Run.LatencyMean, out.Run.ThroughputRPS} {
+		out.MaxRelativeHalfWidth = math.Max(out.MaxRelativeHalfWidth, RelativeHalfWidth(st))
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+func TestSummarizeValues(t *testing.T) {
+	st := SummarizeValues([]float64{10, 12, 14, 16, 18})
+	// mean 14, sample stddev sqrt(10), t(4) = 2.776
+	wantHalf := 2.776 * math.Sqrt(10) / math.Sqrt(5)
+	if st.N != 5 || st.Mean != 14 || math.Abs(st.StdDev-math.Sqrt(10)) > 1e-9 || math.Abs(st.CI95HalfWidth-wantHalf) > 1e-9 {
+		t.Fatalf("unexpected summary %+v", st)
+	}
+	if math.Abs(st.CI95Low-(14-wantHalf)) > 1e-9 || math.Abs(st.CI95High-(14+wantHalf)) > 1e-9 {
+		t.Fatalf("unexpected interval [%f, %f]", st.CI95Low, st.CI95High)
+	}
+	if math.Abs(RelativeHalfWidth(st)-wantHalf/14) > 1e-9 {
+		t.Fatalf("unexpected relative half-width %f", RelativeHalfWidth(st))
+	}
+
+	single := SummarizeValues([]float64{7})
+	if single.N != 1 || single.Mean != 7 || single.CI95HalfWidth != 0 || single.CI95Low != 7 || single.CI95High != 7 {
+		t.Fatalf("unexpected single-sample summary %+v", single)
+	}
+}
+
+func TestSummarizeReplications(t *testing.T) {
+	ptr := func(v float64) *float64 { return &v }
+	runs := []*models.RunMetrics{
+		{
+			TotalRequests: 100, FailedRequests: 10, LatencyP95: 20, ThroughputRPS: 10,
+			ServiceMetrics: map[string]*models.ServiceMetrics{"api": {RequestCount: 100, ErrorCount: 10, LatencyP95: 20}},
+			EndpointRequestStats: []models.EndpointRequestStats{
+				{ServiceName: "api", EndpointPath: "/b", RequestCount: 60, LatencyP95Ms: ptr(25)},
+				{ServiceName: "api", EndpointPath: "/a", RequestCount: 40, ErrorCount: 10},
+			},
+		},
+		{
+			TotalRequests: 120, FailedRequests: 0, LatencyP95: 30, ThroughputRPS: 12,
+			ServiceMetrics: map[string]*models.ServiceMetrics{"api": {RequestCount: 120, LatencyP95: 30}},
+			EndpointRequestStats: []models.EndpointRequestStats{
+				{ServiceName: "api", EndpointPath: "/b", RequestCount: 80, LatencyP95Ms: ptr(35)},
+			},
+		},
+	}
+	s := SummarizeReplications(runs, []int64{1, 2}, 10*time.Second)
+
+	if s.Replications != 2 || len(s.Seeds) != 2 {
+		t.Fatalf("unexpected replication count %d seeds %v", s.Replications, s.Seeds)
+	}
+	if s.Run.LatencyP95.Mean != 25 || s.Run.ThroughputRPS.Mean != 11 || s.Run.ErrorRate.Mean != 0.05 {
+		t.Fatalf("unexpected run stats %+v", s.Run)
+	}
+	api := s.Services["api"]
+	if api == nil || api.ThroughputRPS.Mean != 11 || api.LatencyP95.N != 2 {
+		t.Fatalf("unexpected service stats %+v", api)
+	}
+	if len(s.Endpoints) != 2 || s.Endpoints[0].EndpointPath != "/a" || s.Endpoints[1].EndpointPath != "/b" {
+		t.Fatalf("expected endpoints sorted by path, got %+v", s.Endpoints)
+	}
+	if a := s.Endpoints[0]; a.ThroughputRPS.N != 1 || a.LatencyP95.N != 0 || a.ErrorRate.Mean != 0.25 {
+		t.Fatalf("expected /a to have one sample and no latency, got %+v", a)
+	}
+	if b := s.Endpoints[1]; b.LatencyP95.N != 2 || b.LatencyP95.Mean != 30 || b.ThroughputRPS.Mean != 7 {
+		t.Fatalf("unexpected /b stats %+v", b)
+	}
+	if want := RelativeHalfWidth(s.Run.LatencyP95); s.MaxRelativeHalfWidth < want || want == 0 {
+		t.Fatalf("expected max relative half-width >= p95's %f, got %f", want, s.MaxRelativeHalfWidth)
+	}
+}
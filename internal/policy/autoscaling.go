@@ -28,12 +28,13 @@ func NewAutoscalingPolicyFromConfig(cfg *config.AutoscalingPolicy) AutoscalingPo
 		targetCPU = 0.7 // Default to 70% if invalid
 	}
 
+	minReplicas, maxReplicas := cfg.EffectiveReplicaBounds()
 	return &autoscalingPolicy{
 		enabled:       cfg.Enabled,
 		targetCPUUtil: targetCPU,
 		scaleStep:     cfg.ScaleStep,
-		minReplicas:   1,   // Default minimum
-		maxReplicas:   100, // Default maximum
+		minReplicas:   minReplicas,
+		maxReplicas:   maxReplicas,
 	}
 }
 
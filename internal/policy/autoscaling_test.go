@@ -24,6 +24,18 @@ func TestNewAutoscalingPolicyFromConfig(t *testing.T) {
 	}
 }
 
+func TestNewAutoscalingPolicyFromConfigReplicaBounds(t *testing.T) {
+	p := NewAutoscalingPolicyFromConfig(&config.AutoscalingPolicy{
+		Enabled: true, TargetCPUUtil: 0.5, ScaleStep: 5, MinReplicas: 2, MaxReplicas: 4,
+	})
+	if got := p.GetTargetReplicas("svc", 3, 0.95); got != 4 {
+		t.Fatalf("expected scale-up clamped to max_replicas=4, got %d", got)
+	}
+	if got := p.GetTargetReplicas("svc", 3, 0.05); got != 2 {
+		t.Fatalf("expected scale-down clamped to min_replicas=2, got %d", got)
+	}
+}
+
 func TestAutoscalingPolicyShouldScaleUp(t *testing.T) {
 	policy := NewAutoscalingPolicy(true, 0.7, 1, 1, 10)
 
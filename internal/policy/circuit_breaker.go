@@ -3,6 +3,8 @@ package policy
 import (
 	"sync"
 	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
 )
 
 // circuitBreakerPolicy implements CircuitBreakerPolicy
@@ -17,6 +19,8 @@ type circuitBreakerPolicy struct {
 	// circuits tracks circuit state per service/endpoint
 	circuits map[string]*circuitState
 	mu       sync.RWMutex
+	// observer (optional) is notified of every state transition
+	observer CircuitStateObserver
 }
 
 // circuitState tracks the state of a circuit breaker for a service/endpoint
@@ -40,6 +44,31 @@ func NewCircuitBreakerPolicy(enabled bool, failureThreshold, successThreshold in
 	}
 }
 
+// NewCircuitBreakerPolicyFromConfig creates a circuit breaker policy from config (defaults applied).
+func NewCircuitBreakerPolicyFromConfig(cfg *config.CircuitBreakerPolicy) CircuitBreakerPolicy {
+	return NewCircuitBreakerPolicy(cfg.Enabled, cfg.EffectiveFailureThreshold(), cfg.EffectiveSuccessThreshold(), cfg.EffectiveTimeout())
+}
+
+// SetStateObserver registers fn to be called on every circuit state transition.
+func (p *circuitBreakerPolicy) SetStateObserver(fn CircuitStateObserver) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.observer = fn
+}
+
+// transition moves circuit to state and notifies the observer. Caller holds circuit.mu.
+func (p *circuitBreakerPolicy) transition(circuit *circuitState, serviceID, endpointPath string, to CircuitState, currentTime time.Time) {
+	from := circuit.state
+	circuit.state = to
+	circuit.lastStateChange = currentTime
+	p.mu.RLock()
+	observer := p.observer
+	p.mu.RUnlock()
+	if observer != nil && from != to {
+		observer(serviceID, endpointPath, from, to, currentTime)
+	}
+}
+
 func (p *circuitBreakerPolicy) Enabled() bool {
 	return p.enabled
 }
@@ -76,9 +105,8 @@ func (p *circuitBreakerPolicy) AllowRequest(serviceID, endpointPath string, curr
 	// Check if we should transition from open to half-open
 	if circuit.state == CircuitStateOpen {
 		if currentTime.Sub(circuit.lastStateChange) >= p.timeout {
-			circuit.state = CircuitStateHalfOpen
 			circuit.successCount = 0
-			circuit.lastStateChange = currentTime
+			p.transition(circuit, serviceID, endpointPath, CircuitStateHalfOpen, currentTime)
 		} else {
 			return false // Circuit is open, reject request
 		}
@@ -107,9 +135,8 @@ func (p *circuitBreakerPolicy) RecordSuccess(serviceID, endpointPath string, cur
 	if circuit.state == CircuitStateHalfOpen {
 		circuit.successCount++
 		if circuit.successCount >= p.successThreshold {
-			circuit.state = CircuitStateClosed
 			circuit.failureCount = 0
-			circuit.lastStateChange = currentTime
+			p.transition(circuit, serviceID, endpointPath, CircuitStateClosed, currentTime)
 		}
 	} else if circuit.state == CircuitStateClosed {
 		// Reset failure count on success
@@ -147,14 +174,12 @@ func (p *circuitBreakerPolicy) RecordFailure(serviceID, endpointPath string, cur
 
 	if circuit.state == CircuitStateHalfOpen {
 		// Any failure in half-open state immediately opens the circuit
-		circuit.state = CircuitStateOpen
 		circuit.successCount = 0
-		circuit.lastStateChange = currentTime
+		p.transition(circuit, serviceID, endpointPath, CircuitStateOpen, currentTime)
 	} else if circuit.state == CircuitStateClosed {
 		// Check if we've exceeded the failure threshold
 		if circuit.failureCount >= p.failureThreshold {
-			circuit.state = CircuitStateOpen
-			circuit.lastStateChange = currentTime
+			p.transition(circuit, serviceID, endpointPath, CircuitStateOpen, currentTime)
 		}
 	}
 }
@@ -179,9 +204,8 @@ func (p *circuitBreakerPolicy) CheckAndGetState(serviceID, endpointPath string,
 	// Check if we should transition from open to half-open
 	if circuit.state == CircuitStateOpen {
 		if currentTime.Sub(circuit.lastStateChange) >= p.timeout {
-			circuit.state = CircuitStateHalfOpen
 			circuit.successCount = 0
-			circuit.lastStateChange = currentTime
+			p.transition(circuit, serviceID, endpointPath, CircuitStateHalfOpen, currentTime)
 		}
 	}
 
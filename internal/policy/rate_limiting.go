@@ -3,6 +3,8 @@ package policy
 import (
 	"sync"
 	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
 )
 
 // rateLimitingPolicy implements RateLimitingPolicy using token bucket algorithm
@@ -33,6 +35,11 @@ func NewRateLimitingPolicy(enabled bool, rateLimitPerSecond int) RateLimitingPol
 	}
 }
 
+// NewRateLimitingPolicyFromConfig creates a rate limiting policy from config
+func NewRateLimitingPolicyFromConfig(cfg *config.RateLimitingPolicy) RateLimitingPolicy {
+	return NewRateLimitingPolicy(cfg.Enabled, cfg.RateLimitPerSecond)
+}
+
 func (p *rateLimitingPolicy) Enabled() bool {
 	return p.enabled
 }
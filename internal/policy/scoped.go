@@ -0,0 +1,219 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+// policyScope indexes scenario services and endpoints so per-hop policies can be resolved without scans.
+// All methods are nil-safe (a Manager built without a scenario has no scope).
+type policyScope struct {
+	services  map[string]*config.Service
+	endpoints map[string]*config.Endpoint // key: "svc:ep"
+}
+
+func newPolicyScope(scenario *config.Scenario) *policyScope {
+	s := &policyScope{
+		services:  make(map[string]*config.Service),
+		endpoints: make(map[string]*config.Endpoint),
+	}
+	for i := range scenario.Services {
+		svc := &scenario.Services[i]
+		s.services[svc.ID] = svc
+		for j := range svc.Endpoints {
+			s.endpoints[svc.ID+":"+svc.Endpoints[j].Path] = &svc.Endpoints[j]
+		}
+	}
+	return s
+}
+
+func (s *policyScope) lookup(serviceID, endpointPath string) (*config.Service, *config.Endpoint) {
+	if s == nil {
+		return nil, nil
+	}
+	return s.services[serviceID], s.endpoints[serviceID+":"+endpointPath]
+}
+
+// hasRetryOverride reports whether any level below the scenario sets retries for this call.
+func (s *policyScope) hasRetryOverride(serviceID, endpointPath string, call *config.DownstreamCall) bool {
+	if call != nil && call.Policies != nil && call.Policies.Retries != nil {
+		return true
+	}
+	svc, ep := s.lookup(serviceID, endpointPath)
+	if svc != nil && svc.Policies != nil && svc.Policies.Retries != nil {
+		return true
+	}
+	return ep != nil && ep.Policies != nil && ep.Policies.Retries != nil
+}
+
+// autoscaledServices returns the IDs of services with an autoscaling override.
+func (s *policyScope) autoscaledServices() map[string]struct{} {
+	out := make(map[string]struct{})
+	if s == nil {
+		return out
+	}
+	for id, svc := range s.services {
+		if svc.Policies != nil && svc.Policies.Autoscaling != nil {
+			out[id] = struct{}{}
+		}
+	}
+	return out
+}
+
+// scopedCircuitBreaker routes each service/endpoint to a breaker built from its effective
+// configuration (endpoint override, then service override, then scenario policies).
+// Circuits are keyed per service/endpoint, so one breaker per endpoint keeps state isolated.
+type scopedCircuitBreaker struct {
+	// fallback handles endpoints not declared in the scenario (may be nil)
+	fallback   CircuitBreakerPolicy
+	byEndpoint map[string]CircuitBreakerPolicy
+}
+
+func newScopedCircuitBreaker(scenario *config.Scenario) *scopedCircuitBreaker {
+	var global *config.CircuitBreakerPolicy
+	if scenario.Policies != nil {
+		global = scenario.Policies.CircuitBreaker
+	}
+	s := &scopedCircuitBreaker{byEndpoint: make(map[string]CircuitBreakerPolicy)}
+	if global != nil {
+		s.fallback = NewCircuitBreakerPolicyFromConfig(global)
+	}
+	for i := range scenario.Services {
+		svc := &scenario.Services[i]
+		for j := range svc.Endpoints {
+			ep := &svc.Endpoints[j]
+			if cfg := config.EffectiveCircuitBreaker(global, svc, ep); cfg != nil {
+				s.byEndpoint[svc.ID+":"+ep.Path] = NewCircuitBreakerPolicyFromConfig(cfg)
+			}
+		}
+	}
+	return s
+}
+
+func (s *scopedCircuitBreaker) resolve(serviceID, endpointPath string) CircuitBreakerPolicy {
+	if cb, ok := s.byEndpoint[serviceID+":"+endpointPath]; ok {
+		return cb
+	}
+	return s.fallback
+}
+
+func (s *scopedCircuitBreaker) Enabled() bool {
+	if s.fallback != nil && s.fallback.Enabled() {
+		return true
+	}
+	for _, cb := range s.byEndpoint {
+		if cb.Enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *scopedCircuitBreaker) Name() string {
+	return "circuit_breaker"
+}
+
+func (s *scopedCircuitBreaker) AllowRequest(serviceID, endpointPath string, currentTime time.Time) bool {
+	if cb := s.resolve(serviceID, endpointPath); cb != nil {
+		return cb.AllowRequest(serviceID, endpointPath, currentTime)
+	}
+	return true
+}
+
+func (s *scopedCircuitBreaker) RecordSuccess(serviceID, endpointPath string, currentTime time.Time) {
+	if cb := s.resolve(serviceID, endpointPath); cb != nil {
+		cb.RecordSuccess(serviceID, endpointPath, currentTime)
+	}
+}
+
+func (s *scopedCircuitBreaker) RecordFailure(serviceID, endpointPath string, currentTime time.Time) {
+	if cb := s.resolve(serviceID, endpointPath); cb != nil {
+		cb.RecordFailure(serviceID, endpointPath, currentTime)
+	}
+}
+
+func (s *scopedCircuitBreaker) CheckAndGetState(serviceID, endpointPath string, currentTime time.Time) CircuitState {
+	if cb := s.resolve(serviceID, endpointPath); cb != nil {
+		return cb.CheckAndGetState(serviceID, endpointPath, currentTime)
+	}
+	return CircuitStateClosed
+}
+
+// SetStateObserver registers fn on every underlying breaker.
+func (s *scopedCircuitBreaker) SetStateObserver(fn CircuitStateObserver) {
+	if obs, ok := s.fallback.(circuitStateObservable); ok {
+		obs.SetStateObserver(fn)
+	}
+	for _, cb := range s.byEndpoint {
+		if obs, ok := cb.(circuitStateObservable); ok {
+			obs.SetStateObserver(fn)
+		}
+	}
+}
+
+// scopedRateLimiting routes each service/endpoint to a token bucket limiter built from its
+// effective configuration (endpoint override, then service override, then scenario policies).
+type scopedRateLimiting struct {
+	// fallback handles endpoints not declared in the scenario (may be nil)
+	fallback   RateLimitingPolicy
+	byEndpoint map[string]RateLimitingPolicy
+}
+
+func newScopedRateLimiting(scenario *config.Scenario) *scopedRateLimiting {
+	var global *config.RateLimitingPolicy
+	if scenario.Policies != nil {
+		global = scenario.Policies.RateLimiting
+	}
+	s := &scopedRateLimiting{byEndpoint: make(map[string]RateLimitingPolicy)}
+	if global != nil {
+		s.fallback = NewRateLimitingPolicyFromConfig(global)
+	}
+	for i := range scenario.Services {
+		svc := &scenario.Services[i]
+		for j := range svc.Endpoints {
+			ep := &svc.Endpoints[j]
+			if cfg := config.EffectiveRateLimiting(global, svc, ep); cfg != nil {
+				s.byEndpoint[svc.ID+":"+ep.Path] = NewRateLimitingPolicyFromConfig(cfg)
+			}
+		}
+	}
+	return s
+}
+
+func (s *scopedRateLimiting) resolve(serviceID, endpointPath string) RateLimitingPolicy {
+	if rl, ok := s.byEndpoint[serviceID+":"+endpointPath]; ok {
+		return rl
+	}
+	return s.fallback
+}
+
+func (s *scopedRateLimiting) Enabled() bool {
+	if s.fallback != nil && s.fallback.Enabled() {
+		return true
+	}
+	for _, rl := range s.byEndpoint {
+		if rl.Enabled() {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *scopedRateLimiting) Name() string {
+	return "rate_limiting"
+}
+
+func (s *scopedRateLimiting) AllowRequest(serviceID, endpointPath string, requestTime time.Time) bool {
+	if rl := s.resolve(serviceID, endpointPath); rl != nil {
+		return rl.AllowRequest(serviceID, endpointPath, requestTime)
+	}
+	return true
+}
+
+func (s *scopedRateLimiting) GetRemainingQuota(serviceID, endpointPath string, currentTime time.Time) int {
+	if rl := s.resolve(serviceID, endpointPath); rl != nil {
+		return rl.GetRemainingQuota(serviceID, endpointPath, currentTime)
+	}
+	return -1 // Unlimited
+}
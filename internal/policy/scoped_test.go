@@ -0,0 +1,190 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+func scopedTestScenario() *config.Scenario {
+	return &config.Scenario{
+		Services: []config.Service{
+			{
+				ID: "payments",
+				Policies: &config.PolicyOverrides{
+					CircuitBreaker: &config.CircuitBreakerOverride{Enabled: config.BoolPtr(true), FailureThreshold: 1},
+				},
+				Endpoints: []config.Endpoint{
+					{Path: "/charge"},
+					{
+						Path: "/refund",
+						Policies: &config.PolicyOverrides{
+							CircuitBreaker: &config.CircuitBreakerOverride{Enabled: config.BoolPtr(false)},
+							RateLimiting:   &config.RateLimitingOverride{Enabled: config.BoolPtr(true), RateLimitPerSecond: 1},
+						},
+					},
+				},
+			},
+			{ID: "analytics", Endpoints: []config.Endpoint{{Path: "/track"}}},
+		},
+		Policies: &config.Policies{
+			CircuitBreaker: &config.CircuitBreakerPolicy{Enabled: true, FailureThreshold: 3, TimeoutMs: 1000},
+		},
+	}
+}
+
+func TestApplyScenarioOverridesCircuitBreaker(t *testing.T) {
+	pm := NewPolicyManager(nil)
+	pm.ApplyScenarioOverrides(scopedTestScenario())
+	cb := pm.GetCircuitBreaker()
+	if cb == nil || !cb.Enabled() {
+		t.Fatal("expected scoped circuit breaker")
+	}
+	now := time.Now()
+
+	// Service override: one failure opens /charge.
+	cb.RecordFailure("payments", "/charge", now)
+	if cb.AllowRequest("payments", "/charge", now) {
+		t.Fatal("expected /charge circuit open after 1 failure (service override)")
+	}
+	// Endpoint override disables the breaker for /refund.
+	for i := 0; i < 5; i++ {
+		cb.RecordFailure("payments", "/refund", now)
+	}
+	if !cb.AllowRequest("payments", "/refund", now) {
+		t.Fatal("expected /refund breaker disabled by endpoint override")
+	}
+	// Global block applies to services without overrides.
+	cb.RecordFailure("analytics", "/track", now)
+	cb.RecordFailure("analytics", "/track", now)
+	if !cb.AllowRequest("analytics", "/track", now) {
+		t.Fatal("expected /track closed below global threshold")
+	}
+	cb.RecordFailure("analytics", "/track", now)
+	if cb.CheckAndGetState("analytics", "/track", now) != CircuitStateOpen {
+		t.Fatal("expected /track open at global threshold")
+	}
+	// Unknown endpoints fall back to the global block.
+	if cb.CheckAndGetState("other", "/", now) != CircuitStateClosed {
+		t.Fatal("expected unknown endpoint closed")
+	}
+}
+
+func TestApplyScenarioOverridesRateLimiting(t *testing.T) {
+	pm := NewPolicyManager(nil)
+	pm.ApplyScenarioOverrides(scopedTestScenario())
+	rl := pm.GetRateLimiting()
+	if rl == nil || !rl.Enabled() {
+		t.Fatal("expected scoped rate limiting from endpoint override")
+	}
+	now := time.Now()
+	if !rl.AllowRequest("payments", "/refund", now) || rl.AllowRequest("payments", "/refund", now) {
+		t.Fatal("expected /refund limited to 1 request per second")
+	}
+	for i := 0; i < 10; i++ {
+		if !rl.AllowRequest("payments", "/charge", now) {
+			t.Fatal("expected /charge unlimited")
+		}
+	}
+	if q := rl.GetRemainingQuota("analytics", "/track", now); q != -1 {
+		t.Fatalf("expected unlimited quota without config, got %d", q)
+	}
+}
+
+func TestApplyScenarioOverridesKeepsProgrammaticPolicies(t *testing.T) {
+	pm := NewPolicyManager(nil)
+	programmatic := NewCircuitBreakerPolicy(true, 1, 1, time.Second)
+	pm.SetCircuitBreaker(programmatic)
+	pm.ApplyScenarioOverrides(&config.Scenario{})
+	if pm.GetCircuitBreaker() != programmatic {
+		t.Fatal("expected programmatic circuit breaker to be kept when scenario has none")
+	}
+	if pm.GetRateLimiting() != nil {
+		t.Fatal("expected no rate limiting")
+	}
+}
+
+func TestCircuitStateObserver(t *testing.T) {
+	pm := NewPolicyManager(&config.Policies{
+		CircuitBreaker: &config.CircuitBreakerPolicy{Enabled: true, FailureThreshold: 1, SuccessThreshold: 1, TimeoutMs: 100},
+	})
+	var transitions []CircuitState
+	pm.SetCircuitStateObserver(func(serviceID, endpointPath string, from, to CircuitState, at time.Time) {
+		if serviceID != "svc" || endpointPath != "/ep" {
+			t.Fatalf("unexpected key %s:%s", serviceID, endpointPath)
+		}
+		transitions = append(transitions, to)
+	})
+	cb := pm.GetCircuitBreaker()
+	now := time.Now()
+	cb.RecordFailure("svc", "/ep", now)
+	cb.AllowRequest("svc", "/ep", now.Add(200*time.Millisecond))
+	cb.RecordSuccess("svc", "/ep", now.Add(300*time.Millisecond))
+
+	want := []CircuitState{CircuitStateOpen, CircuitStateHalfOpen, CircuitStateClosed}
+	if len(transitions) != len(want) {
+		t.Fatalf("expected transitions %v, got %v", want, transitions)
+	}
+	for i := range want {
+		if transitions[i] != want[i] {
+			t.Fatalf("expected transitions %v, got %v", want, transitions)
+		}
+	}
+}
+
+func TestGetRetryForMostSpecificFirst(t *testing.T) {
+	sc := scopedTestScenario()
+	sc.Policies.Retries = &config.RetryPolicy{Enabled: true, MaxRetries: 2, Backoff: "constant", BaseMs: 10}
+	sc.Services[0].Endpoints[1].Policies.Retries = &config.RetryOverride{Enabled: config.BoolPtr(false)}
+	call := &config.DownstreamCall{
+		To:       "payments:/charge",
+		Policies: &config.PolicyOverrides{Retries: &config.RetryOverride{Enabled: config.BoolPtr(true), MaxRetries: 4}},
+	}
+	pm := NewPolicyManager(sc.Policies)
+	pm.ApplyScenarioOverrides(sc)
+
+	if rp := pm.GetRetryFor("payments", "/charge", nil); rp != pm.GetRetry() {
+		t.Fatal("expected scenario retry policy without overrides")
+	}
+	if rp := pm.GetRetryFor("payments", "/charge", call); rp == nil || !rp.Enabled() || rp.GetMaxRetries() != 4 || rp.GetBackoffDuration(1) != 10*time.Millisecond {
+		t.Fatalf("expected call override with inherited backoff, got %+v", rp)
+	}
+	if rp := pm.GetRetryFor("payments", "/refund", nil); rp == nil || rp.Enabled() {
+		t.Fatalf("expected endpoint override to disable retries, got %+v", rp)
+	}
+}
+
+func TestGetAutoscalingForServiceOverride(t *testing.T) {
+	sc := scopedTestScenario()
+	sc.Policies.Autoscaling = &config.AutoscalingPolicy{Enabled: false, TargetCPUUtil: 0.5, ScaleStep: 1, SyncPeriodMs: 2000}
+	sc.Services[0].Policies.Autoscaling = &config.AutoscalingOverride{Enabled: config.BoolPtr(true), MaxReplicas: 4}
+	pm := NewPolicyManager(sc.Policies)
+	pm.ApplyScenarioOverrides(sc)
+
+	if pm.GetAutoscaling() != nil || pm.GetAutoscalingConfig() != nil {
+		t.Fatal("expected scenario-wide autoscaling disabled")
+	}
+	if !pm.HasAutoscaling() {
+		t.Fatal("expected autoscaling enabled through service override")
+	}
+	cfg := pm.GetAutoscalingConfigFor("payments")
+	if cfg == nil || cfg.TargetCPUUtil != 0.5 || cfg.MaxReplicas != 4 {
+		t.Fatalf("unexpected effective autoscaling for payments: %+v", cfg)
+	}
+	if pol := pm.GetAutoscalingFor("payments"); pol == nil || pol.GetTargetReplicas("payments", 4, 1.0) != 4 {
+		t.Fatal("expected payments autoscaling capped at override max_replicas")
+	}
+	if pm.GetAutoscalingFor("analytics") != nil || pm.GetAutoscalingConfigFor("analytics") != nil {
+		t.Fatal("expected analytics to follow disabled scenario autoscaling")
+	}
+	if pm.AutoscalingSyncPeriod() != 2*time.Second {
+		t.Fatalf("expected sync period from scenario policies, got %v", pm.AutoscalingSyncPeriod())
+	}
+
+	// Disabling at runtime keeps the source config so overrides still inherit from it.
+	pm.UpdateAutoscaling(&config.AutoscalingPolicy{Enabled: false, TargetCPUUtil: 0.9, ScaleStep: 2})
+	if cfg := pm.GetAutoscalingConfigFor("payments"); cfg == nil || cfg.TargetCPUUtil != 0.9 {
+		t.Fatalf("expected override to inherit updated target, got %+v", cfg)
+	}
+}
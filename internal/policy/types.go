@@ -58,6 +58,14 @@ type CircuitBreakerPolicy interface {
 	CheckAndGetState(serviceID, endpointPath string, currentTime time.Time) CircuitState
 }
 
+// CircuitStateObserver is notified when a service/endpoint circuit changes state.
+type CircuitStateObserver func(serviceID, endpointPath string, from, to CircuitState, at time.Time)
+
+// circuitStateObservable is implemented by circuit breakers that report state transitions.
+type circuitStateObservable interface {
+	SetStateObserver(fn CircuitStateObserver)
+}
+
 // CircuitState represents the state of a circuit breaker
 type CircuitState string
 
@@ -69,10 +77,19 @@ const (
 
 // Manager manages all active policies
 type Manager struct {
-	autoscaling    AutoscalingPolicy
+	autoscaling AutoscalingPolicy
+	// autoscalingCfg keeps the source config for HPA timing (sync period, stabilization, startup delay).
+	// It is kept while autoscaling is disabled so per-service overrides can still inherit from it.
+	autoscalingCfg *config.AutoscalingPolicy
 	rateLimiting   RateLimitingPolicy
 	retry          RetryPolicy
 	circuitBreaker CircuitBreakerPolicy
+	// Scenario-wide source blocks, used to resolve per-service/endpoint/call overrides.
+	retryCfg          *config.RetryPolicy
+	circuitBreakerCfg *config.CircuitBreakerPolicy
+	rateLimitingCfg   *config.RateLimitingPolicy
+	// scope indexes scenario services/endpoints carrying policies overrides (nil without a scenario).
+	scope *policyScope
 }
 
 // NewPolicyManager creates a new policy manager from configuration
@@ -80,14 +97,22 @@ func NewPolicyManager(policies *config.Policies) *Manager {
 	pm := &Manager{}
 
 	if policies != nil {
+		pm.autoscalingCfg = cloneAutoscalingConfig(policies.Autoscaling)
 		if policies.Autoscaling != nil && policies.Autoscaling.Enabled {
 			pm.autoscaling = NewAutoscalingPolicyFromConfig(policies.Autoscaling)
 		}
+		pm.retryCfg = policies.Retries
+		pm.circuitBreakerCfg = policies.CircuitBreaker
+		pm.rateLimitingCfg = policies.RateLimiting
 		if policies.Retries != nil && policies.Retries.Enabled {
 			pm.retry = NewRetryPolicyFromConfig(policies.Retries)
 		}
-		// Rate limiting and circuit breaker policies are initialized programmatically
-		// until configuration types are extended to support them.
+		if policies.RateLimiting != nil && policies.RateLimiting.Enabled {
+			pm.rateLimiting = NewRateLimitingPolicyFromConfig(policies.RateLimiting)
+		}
+		if policies.CircuitBreaker != nil && policies.CircuitBreaker.Enabled {
+			pm.circuitBreaker = NewCircuitBreakerPolicyFromConfig(policies.CircuitBreaker)
+		}
 	}
 
 	return pm
@@ -98,6 +123,14 @@ func (pm *Manager) GetAutoscaling() AutoscalingPolicy {
 	return pm.autoscaling
 }
 
+// GetAutoscalingConfig returns a copy of the autoscaling configuration, or nil when autoscaling is disabled.
+func (pm *Manager) GetAutoscalingConfig() *config.AutoscalingPolicy {
+	if pm.autoscaling == nil {
+		return nil
+	}
+	return cloneAutoscalingConfig(pm.autoscalingCfg)
+}
+
 // GetRateLimiting returns the rate limiting policy if enabled
 func (pm *Manager) GetRateLimiting() RateLimitingPolicy {
 	return pm.rateLimiting
@@ -118,12 +151,131 @@ func (pm *Manager) SetCircuitBreaker(cb CircuitBreakerPolicy) {
 	pm.circuitBreaker = cb
 }
 
+// SetCircuitStateObserver registers fn for state transitions of the current circuit breaker.
+// It is a no-op when no circuit breaker is set or it does not report transitions.
+func (pm *Manager) SetCircuitStateObserver(fn CircuitStateObserver) {
+	if obs, ok := pm.circuitBreaker.(circuitStateObservable); ok {
+		obs.SetStateObserver(fn)
+	}
+}
+
+// ApplyScenarioOverrides registers the scenario's per-service, per-endpoint and per-call policies blocks
+// and rebuilds the circuit breaker and rate limiting policies from them, resolved most-specific-first.
+// Circuit breaker / rate limiting the scenario does not configure at any level are left as they are
+// (e.g. attached programmatically).
+func (pm *Manager) ApplyScenarioOverrides(scenario *config.Scenario) {
+	if scenario == nil {
+		return
+	}
+	pm.scope = newPolicyScope(scenario)
+	if scenario.Policies != nil {
+		if scenario.Policies.CircuitBreaker != nil {
+			pm.circuitBreakerCfg = scenario.Policies.CircuitBreaker
+		}
+		if scenario.Policies.RateLimiting != nil {
+			pm.rateLimitingCfg = scenario.Policies.RateLimiting
+		}
+	}
+	if config.HasCircuitBreakerConfig(scenario) {
+		pm.circuitBreaker = newScopedCircuitBreaker(scenario)
+	}
+	if config.HasRateLimitingConfig(scenario) {
+		pm.rateLimiting = newScopedRateLimiting(scenario)
+	}
+}
+
+// GetRetryFor returns the retry policy for a call to serviceID/endpointPath, resolved most-specific-first
+// (call, callee endpoint, callee service, scenario). call may be nil. Returns nil when no level configures retries.
+func (pm *Manager) GetRetryFor(serviceID, endpointPath string, call *config.DownstreamCall) RetryPolicy {
+	if !pm.scope.hasRetryOverride(serviceID, endpointPath, call) {
+		return pm.retry
+	}
+	cfg := pm.EffectiveRetryConfig(serviceID, endpointPath, call)
+	if cfg == nil {
+		return nil
+	}
+	return NewRetryPolicyFromConfig(cfg)
+}
+
+// EffectiveRetryConfig returns the resolved retries block for a call (nil when none is configured).
+func (pm *Manager) EffectiveRetryConfig(serviceID, endpointPath string, call *config.DownstreamCall) *config.RetryPolicy {
+	svc, ep := pm.scope.lookup(serviceID, endpointPath)
+	return config.EffectiveRetries(pm.retryCfg, svc, ep, call)
+}
+
+// EffectiveCircuitBreakerConfig returns the resolved circuit_breaker block for an endpoint (nil when none is configured).
+func (pm *Manager) EffectiveCircuitBreakerConfig(serviceID, endpointPath string) *config.CircuitBreakerPolicy {
+	svc, ep := pm.scope.lookup(serviceID, endpointPath)
+	return config.EffectiveCircuitBreaker(pm.circuitBreakerCfg, svc, ep)
+}
+
+// EffectiveRateLimitingConfig returns the resolved rate_limiting block for an endpoint (nil when none is configured).
+func (pm *Manager) EffectiveRateLimitingConfig(serviceID, endpointPath string) *config.RateLimitingPolicy {
+	svc, ep := pm.scope.lookup(serviceID, endpointPath)
+	return config.EffectiveRateLimiting(pm.rateLimitingCfg, svc, ep)
+}
+
+// GetAutoscalingConfigFor returns the autoscaling configuration for serviceID (service override, then
+// scenario), or nil when autoscaling is disabled for that service.
+func (pm *Manager) GetAutoscalingConfigFor(serviceID string) *config.AutoscalingPolicy {
+	svc, _ := pm.scope.lookup(serviceID, "")
+	if svc == nil || svc.Policies == nil || svc.Policies.Autoscaling == nil {
+		return pm.GetAutoscalingConfig()
+	}
+	cfg := config.EffectiveAutoscaling(pm.autoscalingCfg, svc)
+	if !cfg.Enabled {
+		return nil
+	}
+	return cfg
+}
+
+// GetAutoscalingFor returns the autoscaling policy for serviceID, or nil when disabled for that service.
+func (pm *Manager) GetAutoscalingFor(serviceID string) AutoscalingPolicy {
+	svc, _ := pm.scope.lookup(serviceID, "")
+	if svc == nil || svc.Policies == nil || svc.Policies.Autoscaling == nil {
+		return pm.autoscaling
+	}
+	cfg := pm.GetAutoscalingConfigFor(serviceID)
+	if cfg == nil {
+		return nil
+	}
+	return NewAutoscalingPolicyFromConfig(cfg)
+}
+
+// AutoscalingSyncPeriod returns the controller-wide autoscaler sync period from scenario policies
+// (also used when only per-service overrides enable autoscaling).
+func (pm *Manager) AutoscalingSyncPeriod() time.Duration {
+	return pm.autoscalingCfg.EffectiveSyncPeriod()
+}
+
+// HasAutoscaling reports whether autoscaling is enabled globally or for any service override.
+func (pm *Manager) HasAutoscaling() bool {
+	if pm.autoscaling != nil {
+		return true
+	}
+	for id := range pm.scope.autoscaledServices() {
+		if pm.GetAutoscalingConfigFor(id) != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // UpdateAutoscaling replaces the autoscaling policy with one built from cfg (for dynamic config).
-// If cfg is nil or cfg.Enabled is false, autoscaling is cleared.
+// If cfg is nil or cfg.Enabled is false, autoscaling is cleared (per-service overrides still apply).
 func (pm *Manager) UpdateAutoscaling(cfg *config.AutoscalingPolicy) {
+	pm.autoscalingCfg = cloneAutoscalingConfig(cfg)
 	if cfg == nil || !cfg.Enabled {
 		pm.autoscaling = nil
 		return
 	}
 	pm.autoscaling = NewAutoscalingPolicyFromConfig(cfg)
 }
+
+func cloneAutoscalingConfig(cfg *config.AutoscalingPolicy) *config.AutoscalingPolicy {
+	if cfg == nil {
+		return nil
+	}
+	out := *cfg
+	return &out
+}
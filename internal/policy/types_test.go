@@ -103,3 +103,24 @@ func TestNewPolicyManagerWithDisabledPolicies(t *testing.T) {
 		t.Fatalf("expected no retry policy when disabled")
 	}
 }
+
+func TestPolicyManagerAutoscalingConfig(t *testing.T) {
+	pm := NewPolicyManager(nil)
+	if pm.GetAutoscalingConfig() != nil {
+		t.Fatalf("expected nil autoscaling config without policies")
+	}
+	cfg := &config.AutoscalingPolicy{Enabled: true, TargetCPUUtil: 0.6, ScaleStep: 1, SyncPeriodMs: 2000, StartupDelayMs: 500}
+	pm.UpdateAutoscaling(cfg)
+	got := pm.GetAutoscalingConfig()
+	if got == nil || got.SyncPeriodMs != 2000 || got.StartupDelayMs != 500 {
+		t.Fatalf("unexpected autoscaling config: %+v", got)
+	}
+	got.SyncPeriodMs = 1
+	if pm.GetAutoscalingConfig().SyncPeriodMs != 2000 {
+		t.Fatalf("expected GetAutoscalingConfig to return a copy")
+	}
+	pm.UpdateAutoscaling(&config.AutoscalingPolicy{Enabled: false})
+	if pm.GetAutoscalingConfig() != nil {
+		t.Fatalf("expected nil autoscaling config after disabling")
+	}
+}
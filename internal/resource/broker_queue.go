@@ -41,8 +41,10 @@ type BrokerQueueShard struct {
 	ConsumerGroup  string
 	SubscriberName string
 
-	inFlight        int
-	messages        []*QueuedMessage
+	inFlight int
+	messages []*QueuedMessage
+	// held counts capacity slots taken by queue_fill faults; they are never dispatched.
+	held            int
 	dropCount       int64
 	redeliveryCount int64
 	dlqCount        int64
@@ -130,7 +132,18 @@ func (s *BrokerQueueShard) capacityReachedLocked() bool {
 	if s.Capacity <= 0 {
 		return false
 	}
-	return len(s.messages) >= s.Capacity
+	return len(s.messages)+s.held >= s.Capacity
+}
+
+// Hold takes n capacity slots, as if messages no consumer reads filled the queue; a negative n gives
+// slots back. Held slots count against Capacity but are not part of Depth.
+func (s *BrokerQueueShard) Hold(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.held += n
+	if s.held < 0 {
+		s.held = 0
+	}
 }
 
 // Depth returns current backlog length.
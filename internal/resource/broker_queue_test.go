@@ -73,6 +73,27 @@ func TestBrokerQueueEnqueueDropPolicies(t *testing.T) {
 	})
 }
 
+func TestBrokerQueueHeldSlotsCountAgainstCapacity(t *testing.T) {
+	now := time.Now()
+	s := newBrokerQueues().GetOrCreateShard("mq", "/held", &config.QueueBehavior{
+		Capacity: 2, DropPolicy: "reject", ConsumerTarget: "svc:/p", ConsumerConcurrency: 1,
+	})
+	s.Hold(1)
+	if res := s.Enqueue(&QueuedMessage{ID: "m1", EnqueueTime: now}); !res.Accepted {
+		t.Fatalf("expected a free slot, got %+v", res)
+	}
+	if res := s.Enqueue(&QueuedMessage{ID: "m2", EnqueueTime: now}); res.Accepted || res.DropReason != "reject" {
+		t.Fatalf("expected held slot to fill the queue, got %+v", res)
+	}
+	if d := s.Depth(); d != 1 {
+		t.Fatalf("held slots should not count in depth, got %d", d)
+	}
+	s.Hold(-1)
+	if res := s.Enqueue(&QueuedMessage{ID: "m3", EnqueueTime: now}); !res.Accepted {
+		t.Fatalf("expected released slot to take a message, got %+v", res)
+	}
+}
+
 func TestTopicSubscriberPartitionShardsAreIndependent(t *testing.T) {
 	bq := newBrokerQueues()
 	eff := &config.TopicBehavior{Capacity: 10}
@@ -14,6 +14,11 @@ type Host struct {
 	memoryGB int
 	zone     string
 	labels   map[string]string
+	// hostType is the host_types entry of the host ("" without one); speedFactor its per-core speed.
+	hostType    string
+	speedFactor float64
+	// down is set while the host has failed: it runs no instances and takes no placements.
+	down bool
 
 	// Current utilization (aggregated from all service instances)
 	cpuUtilization    float64 // 0.0 to 1.0
@@ -29,6 +34,7 @@ func NewHost(id string, cpuCores, memoryGB int) *Host {
 		id:               id,
 		cpuCores:         cpuCores,
 		memoryGB:         memoryGB,
+		speedFactor:      1,
 		labels:           make(map[string]string),
 		serviceInstances: make([]string, 0),
 	}
@@ -69,6 +75,31 @@ func (h *Host) SetZone(zone string) {
 	h.zone = zone
 }
 
+// HostType returns the host type ID ("" for a host without a type).
+func (h *Host) HostType() string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.hostType
+}
+
+// SpeedFactor returns the per-core speed of the host relative to a baseline core.
+func (h *Host) SpeedFactor() float64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.speedFactor
+}
+
+// SetHostType sets the host type and its per-core speed; a non-positive speed means 1.
+func (h *Host) SetHostType(hostType string, speedFactor float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if speedFactor <= 0 {
+		speedFactor = 1
+	}
+	h.hostType = hostType
+	h.speedFactor = speedFactor
+}
+
 // Labels returns a copy of host labels.
 func (h *Host) Labels() map[string]string {
 	h.mu.RLock()
@@ -95,6 +126,20 @@ func (h *Host) SetLabels(labels map[string]string) {
 	h.labels = next
 }
 
+// IsDown reports whether the host has failed and not recovered.
+func (h *Host) IsDown() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.down
+}
+
+// SetDown marks the host failed (true) or recovered (false).
+func (h *Host) SetDown(down bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.down = down
+}
+
 // SetCPUCores updates the host's CPU core capacity. Values less than 1 are clamped to 1.
 func (h *Host) SetCPUCores(cores int) {
 	h.mu.Lock()
@@ -2,6 +2,7 @@ package resource
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 	"sort"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
 )
 
 const (
@@ -37,14 +39,30 @@ type Manager struct {
 	serviceRouting        map[string]*config.RoutingPolicy // service id -> routing policy
 	endpointRouting       map[string]*config.RoutingPolicy // service:endpoint -> routing policy
 	routingRand           *rand.Rand
+	serviceStartup        map[string]*config.ServiceStartup  // service id -> startup of scaled-up replicas
+	servicePlacement      map[string]*config.PlacementPolicy // service id -> placement of new replicas
+	desiredReplicas       map[string]int                     // service id -> replicas last set by the scenario or a scale
+	startupRand           *utils.RandSource
 	nextInstanceID        int // global counter for new instance IDs when scaling up
 	// drainTimeout is the simulated-time budget for scale-down drains when callers
 	// use ScaleService without explicit options (set per run from optimization config).
 	drainTimeout time.Duration
 	// lastSimTime tracks the latest simulation time seen from the workload (for sweeps).
 	lastSimTime time.Time
+	// oomKill admits memory past host capacity; the caller OOM-kills over-limit instances (policies.oom).
+	oomKill bool
+	// noMemoryLimit holds services without memory_mb: their instances are only OOM-killed under host pressure.
+	noMemoryLimit map[string]bool
+	// cpuLimit holds the cpu_limit of services with one: their instances burst and are CFS-throttled.
+	cpuLimit map[string]float64
+	// scheduling holds the scheduling of services that set one, for their instance queues.
+	scheduling map[string]*config.Scheduling
 	// brokerQueues holds FIFO broker state for kind:queue services (per broker + topic).
 	brokerQueues *BrokerQueues
+	// hostTypes is the scenario host_types catalog, for hosts added by scale-out.
+	hostTypes map[string]config.HostType
+	// readinessHook is told the ready time of each instance that starts, to schedule its activation.
+	readinessHook func(readyAt time.Time)
 }
 
 // NewManager creates a new resource manager
@@ -59,7 +77,15 @@ func NewManager() *Manager {
 		serviceRouting:        make(map[string]*config.RoutingPolicy),
 		endpointRouting:       make(map[string]*config.RoutingPolicy),
 		routingRand:           rand.New(rand.NewSource(time.Now().UnixNano())),
+		serviceStartup:        make(map[string]*config.ServiceStartup),
+		servicePlacement:      make(map[string]*config.PlacementPolicy),
+		desiredReplicas:       make(map[string]int),
+		startupRand:           utils.NewRandSource(time.Now().UnixNano()),
+		noMemoryLimit:         make(map[string]bool),
+		cpuLimit:              make(map[string]float64),
+		scheduling:            make(map[string]*config.Scheduling),
 		brokerQueues:          newBrokerQueues(),
+		hostTypes:             make(map[string]config.HostType),
 	}
 }
 
@@ -74,9 +100,44 @@ func hostMemoryCapacityMB(h *Host) float64 {
 	return float64(gb) * 1024.0
 }
 
+// placementAllowsHostLocked reports whether the placement policy lets an instance run on hostID:
+// the host is in a required zone and runs no anti-affinity service. Caller must hold m.mu.
+func (m *Manager) placementAllowsHostLocked(p *config.PlacementPolicy, hostID string) bool {
+	if p == nil {
+		return true
+	}
+	h := m.hosts[hostID]
+	if len(p.RequiredZones) > 0 {
+		zoneOK := false
+		for _, z := range p.RequiredZones {
+			if strings.EqualFold(strings.TrimSpace(h.Zone()), strings.TrimSpace(z)) {
+				zoneOK = true
+				break
+			}
+		}
+		if !zoneOK {
+			return false
+		}
+	}
+	for _, iid := range m.hostToInstances[hostID] {
+		inst, ok := m.instances[iid]
+		if !ok || inst == nil {
+			continue
+		}
+		for _, blockedSvc := range p.AntiAffinityServices {
+			if strings.EqualFold(strings.TrimSpace(blockedSvc), strings.TrimSpace(inst.ServiceName())) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // pickHostForNewInstanceLocked returns a host ID that can fit another instance with the
 // given reservation, or an error if none qualify. Hosts are tried in round-robin order
-// (by existing instance count for this service) among those with capacity. Caller must hold m.mu (write lock).
+// (by existing instance count for this service) among those that are up, satisfy the service
+// placement and have capacity; with spread_across_zones the zone running the fewest instances
+// of the service wins. Caller must hold m.mu (write lock).
 func (m *Manager) pickHostForNewInstanceLocked(serviceID string, cpuCores, memoryMB float64) (string, error) {
 	hostIDs := make([]string, 0, len(m.hosts))
 	for id := range m.hosts {
@@ -87,15 +148,25 @@ func (m *Manager) pickHostForNewInstanceLocked(serviceID string, cpuCores, memor
 		return "", fmt.Errorf("no hosts available")
 	}
 	var existing int
+	perZone := make(map[string]int)
 	for _, inst := range m.instances {
 		if inst != nil && inst.ServiceName() == serviceID {
 			existing++
+			if h, ok := m.hosts[inst.HostID()]; ok {
+				perZone[h.Zone()]++
+			}
 		}
 	}
+	placement := m.servicePlacement[serviceID]
+	spread := placement != nil && placement.SpreadAcrossZones
+	picked := ""
 	start := existing % len(hostIDs)
 	for j := 0; j < len(hostIDs); j++ {
 		hid := hostIDs[(start+j)%len(hostIDs)]
 		h := m.hosts[hid]
+		if h.IsDown() || !m.placementAllowsHostLocked(placement, hid) {
+			continue
+		}
 		var cpuSum, memSum float64
 		for _, iid := range m.hostToInstances[hid] {
 			inst, ok := m.instances[iid]
@@ -107,9 +178,17 @@ func (m *Manager) pickHostForNewInstanceLocked(serviceID string, cpuCores, memor
 		}
 		capMem := hostMemoryCapacityMB(h)
 		if cpuSum+cpuCores <= float64(h.CPUCores())+1e-9 && memSum+memoryMB <= capMem+1e-6 {
-			return hid, nil
+			if !spread {
+				return hid, nil
+			}
+			if picked == "" || perZone[h.Zone()] < perZone[m.hosts[picked].Zone()] {
+				picked = hid
+			}
 		}
 	}
+	if picked != "" {
+		return picked, nil
+	}
 	return "", fmt.Errorf("no host has capacity for new instance (need %.2f CPU cores, %.2f MB memory)", cpuCores, memoryMB)
 }
 
@@ -118,8 +197,14 @@ func (m *Manager) InitializeFromScenario(scenario *config.Scenario) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.oomKill = scenario.Policies != nil && scenario.Policies.OOM.IsEnabled()
+
 	// Initialize hosts
-	for _, hostConfig := range scenario.Hosts {
+	for _, t := range scenario.HostTypes {
+		m.hostTypes[t.ID] = t
+	}
+	for i := range scenario.Hosts {
+		hostConfig := &scenario.Hosts[i]
 		memoryGB := hostConfig.MemoryGB
 		if memoryGB <= 0 {
 			memoryGB = 16
@@ -127,6 +212,7 @@ func (m *Manager) InitializeFromScenario(scenario *config.Scenario) error {
 		host := NewHost(hostConfig.ID, hostConfig.Cores, memoryGB)
 		host.SetZone(hostConfig.Zone)
 		host.SetLabels(hostConfig.Labels)
+		host.SetHostType(hostConfig.Type, scenario.HostSpeedFactor(hostConfig))
 		m.hosts[hostConfig.ID] = host
 	}
 
@@ -156,6 +242,13 @@ func (m *Manager) InitializeFromScenario(scenario *config.Scenario) error {
 		if serviceConfig.Routing != nil {
 			m.serviceRouting[serviceConfig.ID] = serviceConfig.Routing
 		}
+		if serviceConfig.Startup != nil {
+			m.serviceStartup[serviceConfig.ID] = serviceConfig.Startup
+		}
+		if serviceConfig.Placement != nil {
+			m.servicePlacement[serviceConfig.ID] = serviceConfig.Placement
+		}
+		m.desiredReplicas[serviceConfig.ID] = serviceConfig.Replicas
 		for j := range serviceConfig.Endpoints {
 			ep := &serviceConfig.Endpoints[j]
 			if ep.Routing != nil {
@@ -169,6 +262,13 @@ func (m *Manager) InitializeFromScenario(scenario *config.Scenario) error {
 		memoryMB := serviceConfig.MemoryMB
 		if memoryMB == 0 {
 			memoryMB = DefaultInstanceMemoryMB
+			m.noMemoryLimit[serviceConfig.ID] = true
+		}
+		if serviceConfig.CPULimit > 0 {
+			m.cpuLimit[serviceConfig.ID] = serviceConfig.CPULimit
+		}
+		if sc := scenario.ServiceScheduling(serviceConfig); sc != nil {
+			m.scheduling[serviceConfig.ID] = sc
 		}
 
 		for replica := 0; replica < serviceConfig.Replicas; replica++ {
@@ -177,38 +277,8 @@ func (m *Manager) InitializeFromScenario(scenario *config.Scenario) error {
 			for j := 0; j < len(hostIDs); j++ {
 				hostID := hostIDs[(start+j)%len(hostIDs)]
 				h := m.hosts[hostID]
-				if serviceConfig.Placement != nil && len(serviceConfig.Placement.RequiredZones) > 0 {
-					zoneOK := false
-					for _, z := range serviceConfig.Placement.RequiredZones {
-						if strings.EqualFold(strings.TrimSpace(h.Zone()), strings.TrimSpace(z)) {
-							zoneOK = true
-							break
-						}
-					}
-					if !zoneOK {
-						continue
-					}
-				}
-				if serviceConfig.Placement != nil && len(serviceConfig.Placement.AntiAffinityServices) > 0 {
-					conflict := false
-					for _, iid := range m.hostToInstances[hostID] {
-						inst, ok := m.instances[iid]
-						if !ok || inst == nil {
-							continue
-						}
-						for _, blockedSvc := range serviceConfig.Placement.AntiAffinityServices {
-							if strings.EqualFold(strings.TrimSpace(blockedSvc), strings.TrimSpace(inst.ServiceName())) {
-								conflict = true
-								break
-							}
-						}
-						if conflict {
-							break
-						}
-					}
-					if conflict {
-						continue
-					}
+				if !m.placementAllowsHostLocked(serviceConfig.Placement, hostID) {
+					continue
 				}
 				l := hostLoad[hostID]
 				capMem := hostMemoryCapacityMB(h)
@@ -398,14 +468,16 @@ func (m *Manager) ScaleServiceWithOptions(serviceID string, newReplicas int, opt
 	if len(allInst) == 0 {
 		return fmt.Errorf("service not found: %s", serviceID)
 	}
+	m.desiredReplicas[serviceID] = newReplicas
 
 	sort.Slice(allInst, func(i, j int) bool {
 		return allInst[i].ID() < allInst[j].ID()
 	})
 
+	// Starting instances count as replicas: they only lack readiness.
 	var activeInst []*ServiceInstance
 	for _, inst := range allInst {
-		if inst.Lifecycle() == InstanceActive {
+		if lc := inst.Lifecycle(); lc == InstanceActive || lc == InstanceStarting {
 			activeInst = append(activeInst, inst)
 		}
 	}
@@ -428,13 +500,7 @@ func (m *Manager) ScaleServiceWithOptions(serviceID string, newReplicas int, opt
 			if err != nil {
 				return err
 			}
-			instanceIDStr := fmt.Sprintf("%s-instance-%d", serviceID, m.nextInstanceID)
-			m.nextInstanceID++
-
-			instance := NewServiceInstance(instanceIDStr, serviceID, hostID, cpuCores, memoryMB)
-			m.instances[instanceIDStr] = instance
-			m.hosts[hostID].AddService(instanceIDStr)
-			m.hostToInstances[hostID] = append(m.hostToInstances[hostID], instanceIDStr)
+			m.addInstanceLocked(serviceID, hostID, cpuCores, memoryMB, simTime)
 		}
 		m.rebuildSortedInstanceCache()
 	} else if newReplicas < activeN {
@@ -450,6 +516,26 @@ func (m *Manager) ScaleServiceWithOptions(serviceID string, newReplicas int, opt
 	return nil
 }
 
+// addInstanceLocked adds a replica of serviceID on hostID at simTime. It starts per the service
+// startup, if any. The caller rebuilds the sorted instance cache. Assumes lock is held.
+func (m *Manager) addInstanceLocked(serviceID, hostID string, cpuCores, memoryMB float64, simTime time.Time) *ServiceInstance {
+	instanceIDStr := fmt.Sprintf("%s-instance-%d", serviceID, m.nextInstanceID)
+	m.nextInstanceID++
+
+	instance := NewServiceInstance(instanceIDStr, serviceID, hostID, cpuCores, memoryMB)
+	if startup := m.serviceStartup[serviceID]; startup != nil {
+		delayMs := startup.DelayMs.Sample(m.startupRand) + startup.ReadinessDelayMs
+		instance.SetStarting(simTime.Add(time.Duration(delayMs*float64(time.Millisecond))), startup)
+		if !instance.markReadyAt(simTime) {
+			m.notifyStartingLocked(instance.ReadyAt())
+		}
+	}
+	m.instances[instanceIDStr] = instance
+	m.hosts[hostID].AddService(instanceIDStr)
+	m.hostToInstances[hostID] = append(m.hostToInstances[hostID], instanceIDStr)
+	return instance
+}
+
 // ProcessDrainingInstances removes draining instances that are idle or past their
 // simulated drain deadline. For hard timeouts with queued work, it returns the
 // request IDs that were waiting in those instance queues so callers can fail them.
@@ -500,6 +586,40 @@ func (m *Manager) NoteSimTime(t time.Time) {
 	}
 }
 
+// SetReadinessHook sets fn to be called, with the manager lock held, with the ready time of every
+// instance that starts (scale-up, replacement or restart). The caller schedules
+// ActivateReadyInstances for that time; fn must not call back into the manager.
+func (m *Manager) SetReadinessHook(fn func(readyAt time.Time)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.readinessHook = fn
+}
+
+// notifyStartingLocked passes readyAt to the readiness hook, if any. Assumes lock is held.
+func (m *Manager) notifyStartingLocked(readyAt time.Time) {
+	if m.readinessHook != nil {
+		m.readinessHook(readyAt)
+	}
+}
+
+// ActivateReadyInstances brings starting instances that are ready by simTime into rotation.
+func (m *Manager) ActivateReadyInstances(simTime time.Time) {
+	if simTime.IsZero() {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	activated := false
+	for _, inst := range m.instances {
+		if inst.markReadyAt(simTime) {
+			activated = true
+		}
+	}
+	if activated {
+		m.rebuildSortedInstanceCache()
+	}
+}
+
 // LastSimTime returns the last observed simulation time, or zero if unknown.
 func (m *Manager) LastSimTime() time.Time {
 	m.mu.RLock()
@@ -556,6 +676,162 @@ func (m *Manager) ActiveReplicas(serviceID string) int {
 	return len(instances)
 }
 
+// OOMVictim returns the instance to OOM-kill after memory was allocated on instanceID: the instance
+// itself when its in-flight memory exceeds its limit, else the instance using the most memory on its
+// host when the host is over capacity (hostLevel). It returns "" while memory is within limits.
+// Instances of a service without memory_mb have no limit of their own.
+func (m *Manager) OOMVictim(instanceID string) (victimID string, hostLevel bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	inst, ok := m.instances[instanceID]
+	if !ok {
+		return "", false
+	}
+	if limit := inst.MemoryMB(); limit > 0 && !m.noMemoryLimit[inst.ServiceName()] && inst.ActiveMemoryMB() > limit {
+		return instanceID, false
+	}
+	host, ok := m.hosts[inst.HostID()]
+	if !ok || host.MemoryGB() <= 0 {
+		return "", false
+	}
+	var used float64
+	var victim *ServiceInstance
+	for _, other := range m.collectInstancesForHost(host.ID()) {
+		mem := other.ActiveMemoryMB()
+		used += mem
+		if victim == nil || mem > victim.ActiveMemoryMB() || (mem == victim.ActiveMemoryMB() && other.ID() < victim.ID()) {
+			victim = other
+		}
+	}
+	if victim == nil || used <= hostMemoryCapacityMB(host) {
+		return "", false
+	}
+	return victim.ID(), true
+}
+
+// KillInstance OOM-kills an instance at simTime: its in-flight and queued work is dropped (the queued
+// request IDs are returned) and it restarts at restartAt, ready after the service startup delay if
+// one is configured. A draining instance is removed instead; readyAt is zero then.
+func (m *Manager) KillInstance(instanceID string, simTime, restartAt time.Time) (dropped []string, readyAt time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	inst, ok := m.instances[instanceID]
+	if !ok {
+		return nil, time.Time{}
+	}
+	if inst.Lifecycle() == InstanceDraining {
+		dropped = m.removeInstanceLocked(instanceID, simTime, true)
+		m.rebuildSortedInstanceCache()
+		return dropped, time.Time{}
+	}
+	dropped = inst.EvictResourceState(simTime)
+	if host, ok := m.hosts[inst.HostID()]; ok {
+		instances := m.collectInstancesForHost(host.ID())
+		m.updateHostCPUUtilizationWithData(host, instances, simTime)
+		m.updateHostMemoryUtilizationWithData(host, instances)
+	}
+	readyAt = restartAt
+	startup := m.serviceStartup[inst.ServiceName()]
+	if startup != nil {
+		delayMs := startup.DelayMs.Sample(m.startupRand) + startup.ReadinessDelayMs
+		readyAt = readyAt.Add(time.Duration(delayMs * float64(time.Millisecond)))
+	}
+	inst.SetStarting(readyAt, startup)
+	m.notifyStartingLocked(readyAt)
+	m.rebuildSortedInstanceCache()
+	return dropped, readyAt
+}
+
+// FailHost takes a host down at simTime: its instances are removed with their in-flight and queued
+// work (the queued request IDs are returned) and nothing is placed on it until RecoverHost. It
+// returns the removed instances, by ID, and nothing when the host is missing or already down.
+func (m *Manager) FailHost(hostID string, simTime time.Time) (lost []*ServiceInstance, dropped []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	host, ok := m.hosts[hostID]
+	if !ok || host.IsDown() {
+		return nil, nil
+	}
+	host.SetDown(true)
+	ids := append([]string(nil), m.hostToInstances[hostID]...)
+	sort.Strings(ids)
+	for _, id := range ids {
+		if inst, ok := m.instances[id]; ok {
+			lost = append(lost, inst)
+			dropped = append(dropped, m.removeInstanceLocked(id, simTime, true)...)
+		}
+	}
+	if len(lost) > 0 {
+		m.rebuildSortedInstanceCache()
+	}
+	return lost, dropped
+}
+
+// RecoverHost brings a failed host back up, empty and available for placement. It reports false
+// when the host is missing or not down.
+func (m *Manager) RecoverHost(hostID string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	host, ok := m.hosts[hostID]
+	if !ok || !host.IsDown() {
+		return false
+	}
+	host.SetDown(false)
+	return true
+}
+
+// IsHostDown reports whether a host has failed and not recovered.
+func (m *Manager) IsHostDown(hostID string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	host, ok := m.hosts[hostID]
+	return ok && host.IsDown()
+}
+
+// PlaceReplacement schedules a replica of serviceID in place of one lost with its host: it is sized
+// like the service's current instances (cpuCores and memoryMB when none are left), placed on a host
+// that is up, satisfies the service placement and fits it, and starts like a scaled-up replica.
+// It places nothing, and returns a nil instance, when the service already has its desired replicas
+// (a scale-down or scale-up since the loss).
+func (m *Manager) PlaceReplacement(serviceID string, cpuCores, memoryMB float64, simTime time.Time) (*ServiceInstance, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.provisionedReplicasLocked(serviceID) >= m.desiredReplicas[serviceID] {
+		return nil, nil
+	}
+	if existing := m.getInstancesForServiceLocked(serviceID); len(existing) > 0 {
+		cpuCores, memoryMB = existing[0].CPUCores(), existing[0].MemoryMB()
+	}
+	hostID, err := m.pickHostForNewInstanceLocked(serviceID, cpuCores, memoryMB)
+	if err != nil {
+		return nil, err
+	}
+	inst := m.addInstanceLocked(serviceID, hostID, cpuCores, memoryMB, simTime)
+	m.rebuildSortedInstanceCache()
+	return inst, nil
+}
+
+// ProvisionedReplicas returns the number of routable plus starting instances for a service.
+func (m *Manager) ProvisionedReplicas(serviceID string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.provisionedReplicasLocked(serviceID)
+}
+
+// provisionedReplicasLocked is ProvisionedReplicas. Assumes lock is held.
+func (m *Manager) provisionedReplicasLocked(serviceID string) int {
+	n := 0
+	for _, inst := range m.instances {
+		if inst.ServiceName() != serviceID {
+			continue
+		}
+		if lc := inst.Lifecycle(); lc == InstanceActive || lc == InstanceStarting {
+			n++
+		}
+	}
+	return n
+}
+
 // HostCount returns the number of hosts currently managed.
 func (m *Manager) HostCount() int {
 	m.mu.RLock()
@@ -623,10 +899,11 @@ func (m *Manager) HostIDs() []string {
 	return ids
 }
 
-// ScaleOutHosts increases the number of hosts up to targetCount by adding new
-// hosts with the same capacity as an existing host. If targetCount is less
-// than or equal to the current host count, this is a no-op.
-func (m *Manager) ScaleOutHosts(targetCount int) error {
+// ScaleOutHosts increases the number of hosts up to targetCount by adding new hosts. With an empty
+// hostType they copy the capacity and host type of an existing host; otherwise they are hosts of that
+// host_types entry, with its capacity and speed. If targetCount is less than or equal to the current
+// host count, this is a no-op.
+func (m *Manager) ScaleOutHosts(targetCount int, hostType string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -634,6 +911,18 @@ func (m *Manager) ScaleOutHosts(targetCount int) error {
 	if targetCount <= current {
 		return nil
 	}
+	if hostType != "" {
+		t, ok := m.hostTypes[hostType]
+		if !ok {
+			return fmt.Errorf("cannot scale out hosts: host type %s does not exist", hostType)
+		}
+		memoryGB := t.MemoryGB
+		if memoryGB <= 0 {
+			memoryGB = 16
+		}
+		m.addHostsLocked(targetCount, t.Cores, memoryGB, t.ID, t.EffectiveSpeedFactor())
+		return nil
+	}
 	if current == 0 {
 		return fmt.Errorf("cannot scale out hosts: no existing hosts to copy capacity from")
 	}
@@ -647,19 +936,23 @@ func (m *Manager) ScaleOutHosts(targetCount int) error {
 	if template == nil {
 		return fmt.Errorf("cannot scale out hosts: template host not found")
 	}
+	m.addHostsLocked(targetCount, template.CPUCores(), template.MemoryGB(), template.HostType(), template.SpeedFactor())
+	return nil
+}
 
-	nextIndex := current + 1
+// addHostsLocked adds host-auto-N hosts of the given capacity and type until there are targetCount.
+func (m *Manager) addHostsLocked(targetCount, cpuCores, memoryGB int, hostType string, speedFactor float64) {
+	nextIndex := len(m.hosts) + 1
 	for len(m.hosts) < targetCount {
 		id := fmt.Sprintf("host-auto-%d", nextIndex)
 		nextIndex++
 		if _, exists := m.hosts[id]; exists {
 			continue
 		}
-		host := NewHost(id, template.CPUCores(), template.MemoryGB())
+		host := NewHost(id, cpuCores, memoryGB)
+		host.SetHostType(hostType, speedFactor)
 		m.hosts[id] = host
 	}
-
-	return nil
 }
 
 // IncreaseHostCapacity increases CPU cores and/or memory (GB) for all hosts.
@@ -891,6 +1184,9 @@ func (m *Manager) UpdateServiceResourcesWithHeadroom(serviceID string, cpuCores,
 			inst.SetMemoryMB(memoryMB)
 		}
 	}
+	if memoryMB > 0 {
+		delete(m.noMemoryLimit, serviceID)
+	}
 
 	// Host utilization will be recomputed on next allocation/release; no need
 	// to force an update here for correctness of the simulator.
@@ -914,15 +1210,48 @@ func (m *Manager) ListServiceIDs() []string {
 }
 
 // ReserveCPUWork reserves the next FIFO CPU interval on the instance (see ServiceInstance.ReserveCPUWork).
-func (m *Manager) ReserveCPUWork(instanceID string, arrivalTime time.Time, cpuDemandMs float64) (cpuStart, cpuEnd time.Time, err error) {
+// cpuDemandMs is in baseline-core milliseconds: it is divided by the speed factor of the instance's host.
+// Instances of a service with a cpu_limit burst onto the host cores idle at arrivalTime and are
+// throttled past their quota (see ServiceInstance.ReserveCPUWorkLimited).
+func (m *Manager) ReserveCPUWork(instanceID string, arrivalTime time.Time, cpuDemandMs float64) (cpuStart, cpuEnd time.Time, throttle CPUThrottle, err error) {
 	m.mu.Lock()
 	instance, ok := m.instances[instanceID]
+	var host *Host
+	var limit, burst float64
+	if ok {
+		host = m.hosts[instance.HostID()]
+		limit = m.cpuLimit[instance.ServiceName()]
+		if limit > 0 && host != nil {
+			burst = m.idleHostCoresLocked(host, instance, arrivalTime)
+		}
+	}
 	m.mu.Unlock()
 	if !ok {
-		return time.Time{}, time.Time{}, fmt.Errorf("instance not found: %s", instanceID)
+		return time.Time{}, time.Time{}, CPUThrottle{}, fmt.Errorf("instance not found: %s", instanceID)
+	}
+	if host != nil {
+		cpuDemandMs /= host.SpeedFactor()
+	}
+	if limit > 0 {
+		cpuStart, cpuEnd, throttle = instance.ReserveCPUWorkLimited(arrivalTime, cpuDemandMs, limit, burst)
+		return cpuStart, cpuEnd, throttle, nil
 	}
 	cpuStart, cpuEnd = instance.ReserveCPUWork(arrivalTime, cpuDemandMs)
-	return cpuStart, cpuEnd, nil
+	return cpuStart, cpuEnd, CPUThrottle{}, nil
+}
+
+// idleHostCoresLocked returns the cores of host that neither inst nor another instance busy at at
+// has reserved. Caller must hold m.mu.
+func (m *Manager) idleHostCoresLocked(host *Host, inst *ServiceInstance, at time.Time) float64 {
+	idle := float64(host.CPUCores()) - inst.CPUCores()
+	for _, id := range m.hostToInstances[host.ID()] {
+		other, ok := m.instances[id]
+		if !ok || other == inst || !other.busyAt(at) {
+			continue
+		}
+		idle -= other.CPUCores()
+	}
+	return math.Max(0, idle)
 }
 
 // ReserveDBWork reserves a datastore connection slot for IO duration after CPU completes.
@@ -1039,8 +1368,9 @@ func (m *Manager) AllocateMemory(instanceID string, memoryMB float64) error {
 	// lock for the entire allocation operation to avoid lock hierarchy issues. This means another
 	// goroutine could allocate memory between our check and allocation, potentially causing
 	// over-allocation. This is an acceptable trade-off for better concurrency.
+	// With OOM kills enabled the host admits the allocation; the caller kills an instance instead.
 	hostMemoryGB := host.MemoryGB()
-	if hostMemoryGB > 0 {
+	if hostMemoryGB > 0 && !m.oomKill {
 		hostMemUtil := host.MemoryUtilization()
 		if hostMemUtil+(memoryMB/1024.0)/float64(hostMemoryGB) > 1.0 {
 			m.mu.Unlock()
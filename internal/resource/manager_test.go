@@ -1110,10 +1110,10 @@ func TestManagerWorkReservationHelpersAndHostMemoryUtilization(t *testing.T) {
 	inst := instances[0]
 	now := time.Unix(1000, 0)
 
-	if _, _, err := m.ReserveCPUWork("missing", now, 20); err == nil {
+	if _, _, _, err := m.ReserveCPUWork("missing", now, 20); err == nil {
 		t.Fatal("expected ReserveCPUWork to fail for unknown instance")
 	}
-	cpuStart, cpuEnd, err := m.ReserveCPUWork(inst.ID(), now, 20)
+	cpuStart, cpuEnd, _, err := m.ReserveCPUWork(inst.ID(), now, 20)
 	if err != nil {
 		t.Fatalf("ReserveCPUWork error: %v", err)
 	}
@@ -1220,6 +1220,71 @@ func TestDrainingInstanceNotSelectedForTraffic(t *testing.T) {
 	}
 }
 
+func TestStartingInstanceRoutableOnceReady(t *testing.T) {
+	m := NewManager()
+	scenario := &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 8}},
+		Services: []config.Service{{ID: "svc1", Replicas: 1, Model: "cpu", Startup: &config.ServiceStartup{
+			DelayMs:             config.LatencySpec{Mean: 1000, Distribution: config.DistributionFixed},
+			ReadinessDelayMs:    500,
+			WarmupMs:            2000,
+			WarmupCPUMultiplier: 3,
+		}}},
+	}
+	if err := m.InitializeFromScenario(scenario); err != nil {
+		t.Fatalf("InitializeFromScenario: %v", err)
+	}
+	var notified []time.Time
+	m.SetReadinessHook(func(readyAt time.Time) { notified = append(notified, readyAt) })
+	t0 := time.Unix(100, 0)
+	if err := m.ScaleServiceWithOptions("svc1", 2, ScaleServiceOptions{SimTime: t0}); err != nil {
+		t.Fatalf("ScaleServiceWithOptions: %v", err)
+	}
+	if len(notified) != 1 || !notified[0].Equal(t0.Add(1500*time.Millisecond)) {
+		t.Fatalf("expected the readiness hook to get the ready time, got %v", notified)
+	}
+	if a, p := m.ActiveReplicas("svc1"), m.ProvisionedReplicas("svc1"); a != 1 || p != 2 {
+		t.Fatalf("expected 1 active of 2 provisioned replicas while starting, got %d of %d", a, p)
+	}
+	var starting *ServiceInstance
+	for _, inst := range m.GetInstancesForService("svc1") {
+		if inst.Lifecycle() == InstanceStarting {
+			starting = inst
+		}
+	}
+	if starting == nil || !starting.ReadyAt().Equal(t0.Add(1500*time.Millisecond)) {
+		t.Fatalf("expected a starting instance ready after 1.5s, got %+v", starting)
+	}
+	// Scaling to the provisioned size does not add another replica.
+	if err := m.ScaleServiceWithOptions("svc1", 2, ScaleServiceOptions{SimTime: t0}); err != nil || len(m.GetInstancesForService("svc1")) != 2 {
+		t.Fatalf("expected scaling to 2 to be a no-op while starting, err=%v", err)
+	}
+	for i := 0; i < 4; i++ {
+		if inst, _ := m.SelectInstanceForService("svc1"); inst == starting {
+			t.Fatal("starting instance must not receive traffic")
+		}
+	}
+
+	m.NoteSimTime(t0.Add(2 * time.Second))
+	m.ActivateReadyInstances(t0.Add(1499 * time.Millisecond))
+	if m.ActiveReplicas("svc1") != 1 {
+		t.Fatal("expected the instance to stay starting before its readiness time")
+	}
+	ready := t0.Add(1500 * time.Millisecond)
+	m.ActivateReadyInstances(ready)
+	if m.ActiveReplicas("svc1") != 2 || starting.Lifecycle() != InstanceActive {
+		t.Fatalf("expected the instance to enter rotation when ready, got %d active", m.ActiveReplicas("svc1"))
+	}
+	for _, tc := range []struct {
+		at   time.Duration
+		want float64
+	}{{0, 3}, {time.Second, 2}, {2 * time.Second, 1}} {
+		if got := starting.CPUCostMultiplierAt(ready.Add(tc.at)); got != tc.want {
+			t.Fatalf("warm-up multiplier %v after ready: expected %v, got %v", tc.at, tc.want, got)
+		}
+	}
+}
+
 func TestManagerHostScalingHelpers(t *testing.T) {
 	m := NewManager()
 	scenario := &config.Scenario{
@@ -1240,7 +1305,7 @@ func TestManagerHostScalingHelpers(t *testing.T) {
 	}
 
 	// Scale out hosts to 3 total.
-	if err := m.ScaleOutHosts(3); err != nil {
+	if err := m.ScaleOutHosts(3, ""); err != nil {
 		t.Fatalf("ScaleOutHosts error: %v", err)
 	}
 	if got := m.HostCount(); got != 3 {
@@ -1265,7 +1330,7 @@ func TestManagerScaleInHosts(t *testing.T) {
 	if err := m.InitializeFromScenario(scenario); err != nil {
 		t.Fatalf("InitializeFromScenario: %v", err)
 	}
-	if err := m.ScaleOutHosts(3); err != nil {
+	if err := m.ScaleOutHosts(3, ""); err != nil {
 		t.Fatalf("ScaleOutHosts: %v", err)
 	}
 	if m.HostCount() != 3 {
@@ -2123,3 +2188,234 @@ func TestSelectInstanceForRequest_LocalityPreferenceEndpointOverride(t *testing.
 		t.Fatalf("expected endpoint locality override to zone-b, got zone=%s host=%s", host.Zone(), host.ID())
 	}
 }
+
+func TestOOMVictimAndKillInstance(t *testing.T) {
+	m := NewManager()
+	scenario := &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 8, MemoryGB: 1}},
+		Services: []config.Service{
+			{ID: "limited", Replicas: 1, Model: "cpu", MemoryMB: 256},
+			{ID: "unlimited", Replicas: 1, Model: "cpu"},
+		},
+		Policies: &config.Policies{OOM: &config.OOMPolicy{Enabled: true}},
+	}
+	if err := m.InitializeFromScenario(scenario); err != nil {
+		t.Fatalf("InitializeFromScenario: %v", err)
+	}
+	limited := m.GetInstancesForService("limited")[0]
+	unlimited := m.GetInstancesForService("unlimited")[0]
+
+	if err := m.AllocateMemory(limited.ID(), 300); err != nil {
+		t.Fatalf("AllocateMemory: %v", err)
+	}
+	if id, hostLevel := m.OOMVictim(limited.ID()); id != limited.ID() || hostLevel {
+		t.Fatalf("expected the instance over its memory_mb to be the victim, got %q (host=%v)", id, hostLevel)
+	}
+	t0 := time.Unix(100, 0)
+	dropped, readyAt := m.KillInstance(limited.ID(), t0, t0.Add(time.Second))
+	if len(dropped) != 0 || !readyAt.Equal(t0.Add(time.Second)) {
+		t.Fatalf("expected a restart after 1s, got dropped=%v readyAt=%v", dropped, readyAt)
+	}
+	if limited.Lifecycle() != InstanceStarting || limited.ActiveMemoryMB() != 0 || m.ActiveReplicas("limited") != 0 {
+		t.Fatalf("expected the killed instance to be starting with no memory, got %v / %v MB", limited.Lifecycle(), limited.ActiveMemoryMB())
+	}
+	m.ActivateReadyInstances(t0.Add(time.Second))
+	if limited.Lifecycle() != InstanceActive {
+		t.Fatal("expected the killed instance back in rotation after its restart delay")
+	}
+
+	// Without memory_mb only the host limits the instance; the host admits memory past its capacity.
+	if err := m.AllocateMemory(unlimited.ID(), 900); err != nil {
+		t.Fatalf("AllocateMemory: %v", err)
+	}
+	if id, _ := m.OOMVictim(unlimited.ID()); id != "" {
+		t.Fatalf("expected no victim within host memory, got %q", id)
+	}
+	if err := m.AllocateMemory(limited.ID(), 200); err != nil {
+		t.Fatalf("expected the host to admit memory past capacity with OOM kills enabled, got %v", err)
+	}
+	if id, hostLevel := m.OOMVictim(limited.ID()); id != unlimited.ID() || !hostLevel {
+		t.Fatalf("expected the largest instance on the host to be the victim, got %q (host=%v)", id, hostLevel)
+	}
+}
+
+func TestFailHostAndPlaceReplacement(t *testing.T) {
+	m := NewManager()
+	scenario := &config.Scenario{
+		Hosts: []config.Host{
+			{ID: "a-1", Cores: 2, Zone: "zone-a"},
+			{ID: "a-2", Cores: 2, Zone: "zone-a"},
+			{ID: "b-1", Cores: 2, Zone: "zone-b"},
+		},
+		Services: []config.Service{
+			{ID: "web", Replicas: 2, Model: "cpu", Placement: &config.PlacementPolicy{SpreadAcrossZones: true}},
+			{ID: "db", Replicas: 1, Model: "cpu", Placement: &config.PlacementPolicy{RequiredZones: []string{"zone-a"}}},
+		},
+	}
+	if err := m.InitializeFromScenario(scenario); err != nil {
+		t.Fatalf("InitializeFromScenario: %v", err)
+	}
+	hostOf := func(serviceID string) map[string]int {
+		out := make(map[string]int)
+		for _, inst := range m.GetInstancesForService(serviceID) {
+			out[inst.HostID()]++
+		}
+		return out
+	}
+	if got := hostOf("web"); got["a-1"] != 1 || got["a-2"] != 1 {
+		t.Fatalf("unexpected initial web placement %v", got)
+	}
+	if err := m.EnqueueRequest("web-instance-0", "queued"); err != nil {
+		t.Fatalf("EnqueueRequest: %v", err)
+	}
+
+	t0 := time.Unix(100, 0)
+	lost, dropped := m.FailHost("a-1", t0)
+	if len(lost) != 2 || lost[0].ID() != "db-instance-2" || lost[1].ID() != "web-instance-0" {
+		t.Fatalf("expected db and web lost with a-1, got %v", lost)
+	}
+	if len(dropped) != 1 || dropped[0] != "queued" {
+		t.Fatalf("expected the queued request dropped, got %v", dropped)
+	}
+	if !m.IsHostDown("a-1") || m.ActiveReplicas("web") != 1 || m.ActiveReplicas("db") != 0 {
+		t.Fatalf("expected a-1 down with its replicas gone")
+	}
+	if again, _ := m.FailHost("a-1", t0); again != nil {
+		t.Fatal("expected no instances lost failing a host that is already down")
+	}
+
+	// Spread across zones: zone-a already runs web, so the replacement goes to zone-b.
+	web, err := m.PlaceReplacement("web", 1, 512, t0)
+	if err != nil || web.HostID() != "b-1" {
+		t.Fatalf("expected the web replacement on b-1, got %v / %v", web, err)
+	}
+	// Required zone: the only zone-a host left is a-2.
+	db, err := m.PlaceReplacement("db", 1, 512, t0)
+	if err != nil || db.HostID() != "a-2" {
+		t.Fatalf("expected the db replacement on a-2, got %v / %v", db, err)
+	}
+
+	m.FailHost("a-2", t0)
+	if _, err := m.PlaceReplacement("db", 1, 512, t0); err == nil {
+		t.Fatal("expected no host for db while zone-a is down")
+	}
+	if !m.RecoverHost("a-1") || m.RecoverHost("a-1") {
+		t.Fatal("expected a-1 to recover once")
+	}
+	db, err = m.PlaceReplacement("db", 1, 512, t0)
+	if err != nil || db.HostID() != "a-1" {
+		t.Fatalf("expected the db replacement on recovered a-1, got %v / %v", db, err)
+	}
+	if m.ActiveReplicas("db") != 1 || m.ActiveReplicas("web") != 1 {
+		t.Fatalf("expected db back and one web replica on b-1, got db=%d web=%d", m.ActiveReplicas("db"), m.ActiveReplicas("web"))
+	}
+	// db is back at its replica count, and web was scaled down to the replica it has left.
+	if extra, err := m.PlaceReplacement("db", 1, 512, t0); extra != nil || err != nil {
+		t.Fatalf("expected no replacement past the db replica count, got %v / %v", extra, err)
+	}
+	if err := m.ScaleServiceWithOptions("web", 1, ScaleServiceOptions{SimTime: t0}); err != nil {
+		t.Fatalf("ScaleServiceWithOptions: %v", err)
+	}
+	if extra, err := m.PlaceReplacement("web", 1, 512, t0); extra != nil || err != nil {
+		t.Fatalf("expected no replacement after web was scaled down, got %v / %v", extra, err)
+	}
+}
+
+func TestManagerHostTypeSpeedFactorAndScaleOutHosts(t *testing.T) {
+	m := NewManager()
+	scenario := &config.Scenario{
+		HostTypes: []config.HostType{
+			{ID: "fast", Cores: 4, MemoryGB: 8, SpeedFactor: 2},
+			{ID: "big", Cores: 16, MemoryGB: 64},
+		},
+		Hosts:    []config.Host{{ID: "host-1", Type: "fast", Cores: 4, MemoryGB: 8}},
+		Services: []config.Service{{ID: "svc1", Replicas: 1, Model: "cpu"}},
+	}
+	if err := m.InitializeFromScenario(scenario); err != nil {
+		t.Fatalf("InitializeFromScenario: %v", err)
+	}
+	host, ok := m.GetHost("host-1")
+	if !ok || host.HostType() != "fast" || host.SpeedFactor() != 2 {
+		t.Fatalf("expected fast host with speed 2, got %+v", host)
+	}
+	inst := m.GetInstancesForService("svc1")[0]
+	now := time.Unix(1000, 0)
+	cpuStart, cpuEnd, _, err := m.ReserveCPUWork(inst.ID(), now, 20)
+	if err != nil {
+		t.Fatalf("ReserveCPUWork: %v", err)
+	}
+	if got := cpuEnd.Sub(cpuStart); got != 10*time.Millisecond {
+		t.Fatalf("expected 20ms of work to take 10ms on a 2x host, got %v", got)
+	}
+
+	if err := m.ScaleOutHosts(2, ""); err != nil {
+		t.Fatalf("ScaleOutHosts: %v", err)
+	}
+	added, ok := m.GetHost("host-auto-2")
+	if !ok || added.HostType() != "fast" || added.CPUCores() != 4 || added.MemoryGB() != 8 || added.SpeedFactor() != 2 {
+		t.Fatalf("expected the scale-out host to copy the fast host, got %+v", added)
+	}
+
+	if err := m.ScaleOutHosts(3, "gpu"); err == nil {
+		t.Fatal("expected error for unknown host type")
+	}
+	if err := m.ScaleOutHosts(3, "big"); err != nil {
+		t.Fatalf("ScaleOutHosts of type big: %v", err)
+	}
+	typed, ok := m.GetHost("host-auto-3")
+	if !ok || typed.HostType() != "big" || typed.CPUCores() != 16 || typed.MemoryGB() != 64 || typed.SpeedFactor() != 1 {
+		t.Fatalf("unexpected typed scale-out host: %+v", typed)
+	}
+	if got := m.HostCount(); got != 3 {
+		t.Fatalf("expected 3 hosts, got %d", got)
+	}
+}
+
+func TestManagerCPULimitBurstAndThrottle(t *testing.T) {
+	m := NewManager()
+	scenario := &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 4, MemoryGB: 16}},
+		Services: []config.Service{
+			{ID: "api", Replicas: 1, Model: "cpu", CPUCores: 0.5, CPULimit: 1},
+			{ID: "batch", Replicas: 1, Model: "cpu", CPUCores: 3},
+		},
+	}
+	if err := m.InitializeFromScenario(scenario); err != nil {
+		t.Fatalf("InitializeFromScenario: %v", err)
+	}
+	api := m.GetInstancesForService("api")[0]
+	batch := m.GetInstancesForService("batch")[0]
+	t0 := time.Unix(1000, 0) // on a CFS period boundary
+
+	// Idle host: api runs on 4 cores, uses its 100ms quota in 25ms, waits 75ms and finishes the
+	// last 50ms in 12.5ms.
+	start, end, throttle, err := m.ReserveCPUWork(api.ID(), t0, 150)
+	if err != nil {
+		t.Fatalf("ReserveCPUWork: %v", err)
+	}
+	if !start.Equal(t0) || end.Sub(t0) != 112500*time.Microsecond {
+		t.Fatalf("expected [0, 112.5ms], got [%v, %v]", start.Sub(t0), end.Sub(t0))
+	}
+	if throttle.ThrottledMs != 75 || throttle.Periods != 1 {
+		t.Fatalf("expected 75ms over one period, got %+v", throttle)
+	}
+
+	// With batch busy, only its request-free half core is left to burst onto: api runs on one core
+	// and stays within its quota.
+	t1 := t0.Add(time.Second)
+	if _, _, _, err := m.ReserveCPUWork(batch.ID(), t1, 3000); err != nil {
+		t.Fatalf("ReserveCPUWork batch: %v", err)
+	}
+	_, end, throttle, err = m.ReserveCPUWork(api.ID(), t1, 50)
+	if err != nil {
+		t.Fatalf("ReserveCPUWork: %v", err)
+	}
+	if end.Sub(t1) != 50*time.Millisecond || throttle != (CPUThrottle{}) {
+		t.Fatalf("expected 50ms unthrottled, got %v and %+v", end.Sub(t1), throttle)
+	}
+
+	// Services without a limit keep the fixed-rate model.
+	if _, _, throttle, _ := m.ReserveCPUWork(batch.ID(), t1, 30); throttle != (CPUThrottle{}) {
+		t.Fatalf("unlimited service was throttled: %+v", throttle)
+	}
+}
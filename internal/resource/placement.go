@@ -13,7 +13,7 @@ type InstancePlacement struct {
 	HostID            string
 	HostZone          string
 	HostLabels        map[string]string
-	Lifecycle         string // ACTIVE or DRAINING
+	Lifecycle         string // ACTIVE, DRAINING or STARTING
 	CPUCores          float64
 	MemoryMB          float64
 	CPUUtilization    float64
@@ -58,8 +58,11 @@ func (m *Manager) GetInstancePlacements(simTime time.Time) []InstancePlacement {
 	for _, r := range rows {
 		inst := r.inst
 		lc := "ACTIVE"
-		if inst.Lifecycle() == InstanceDraining {
+		switch inst.Lifecycle() {
+		case InstanceDraining:
 			lc = "DRAINING"
+		case InstanceStarting:
+			lc = "STARTING"
 		}
 		out = append(out, InstancePlacement{
 			InstanceID: r.id,
@@ -98,7 +98,7 @@ func TestScaleOutAfterHostScaleOut(t *testing.T) {
 	if err := m.ScaleService("svc1", 2); err == nil {
 		t.Fatal("expected scale-out to fail before adding host")
 	}
-	if err := m.ScaleOutHosts(2); err != nil {
+	if err := m.ScaleOutHosts(2, ""); err != nil {
 		t.Fatalf("ScaleOutHosts: %v", err)
 	}
 	if err := m.ScaleService("svc1", 2); err != nil {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
 	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
 )
 
 const (
@@ -22,11 +23,13 @@ const (
 	weightedWheelScale      = 1000
 )
 
-// SetRoutingSeed configures deterministic random/weighted routing for reproducible simulations.
+// SetRoutingSeed configures deterministic random/weighted routing and instance startup delays
+// for reproducible simulations.
 func (m *Manager) SetRoutingSeed(seed int64) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	m.routingRand = rand.New(rand.NewSource(seed))
+	m.startupRand = utils.NewRandSource(seed + 1)
 }
 
 func normalizeRoutingStrategy(s string) string {
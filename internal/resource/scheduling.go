@@ -0,0 +1,262 @@
+package resource
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+// QueuedRequest is a request waiting in an instance queue.
+type QueuedRequest struct {
+	ID string
+	// EnqueuedAt is the simulation time the request joined the queue.
+	EnqueuedAt time.Time
+	// Priority orders the queue of priority-scheduled instances (higher first).
+	Priority float64
+}
+
+// EnqueueRequestAt adds a request to the queue at simulation time req.EnqueuedAt.
+func (s *ServiceInstance) EnqueueRequestAt(req QueuedRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requestQueue) == 0 {
+		s.queueEmptyAt = req.EnqueuedAt
+	}
+	s.requestQueue = append(s.requestQueue, req)
+}
+
+// DequeueScheduledRequest removes the request the scheduling discipline serves next at at:
+//   - fcfs and processor_sharing: the oldest request;
+//   - priority: the highest priority, oldest first among equals;
+//   - adaptive_lifo: the oldest, or the newest while the instance is overloaded;
+//   - codel: the oldest, after dropping the requests that waited longer than the target delay
+//     while the instance is overloaded.
+//
+// The instance is overloaded when its queue has not been empty for the discipline's interval.
+// dropped lists the requests codel dropped, which the caller fails; ok is false when no request is left.
+func (s *ServiceInstance) DequeueScheduledRequest(at time.Time, sc *config.Scheduling) (requestID string, dropped []string, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requestQueue) == 0 {
+		return "", nil, false
+	}
+	overloaded := at.Sub(s.queueEmptyAt) >= msDuration(sc.EffectiveIntervalMs())
+	next := 0
+	switch sc.EffectiveDiscipline() {
+	case config.SchedulingPriority:
+		for i, q := range s.requestQueue {
+			if q.Priority > s.requestQueue[next].Priority {
+				next = i
+			}
+		}
+	case config.SchedulingAdaptiveLIFO:
+		if overloaded {
+			next = len(s.requestQueue) - 1
+		}
+	case config.SchedulingCoDel:
+		if overloaded {
+			target := msDuration(sc.EffectiveTargetDelayMs())
+			for len(s.requestQueue) > 0 && at.Sub(s.requestQueue[0].EnqueuedAt) > target {
+				dropped = append(dropped, s.removeQueuedLocked(0))
+			}
+		}
+		if len(s.requestQueue) == 0 {
+			return "", dropped, false
+		}
+	}
+	return s.removeQueuedLocked(next), dropped, true
+}
+
+// RemoveQueuedRequest removes requestID from the queue, reporting whether it was waiting there.
+func (s *ServiceInstance) RemoveQueuedRequest(requestID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, q := range s.requestQueue {
+		if q.ID == requestID {
+			s.removeQueuedLocked(i)
+			return true
+		}
+	}
+	return false
+}
+
+// StartSharedCPUWork admits cpuDemandMs of CPU work for requestID on a processor-sharing instance
+// at at. The admitted requests run at once, each at cpuCores/n. It returns when the next of them
+// finishes (absent other arrivals) and the version FinishSharedCPUWork expects at that time.
+func (s *ServiceInstance) StartSharedCPUWork(requestID string, at time.Time, cpuDemandMs float64) (nextDone time.Time, version int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advanceSharedLocked(at)
+	if s.shared == nil {
+		s.shared = make(map[string]float64)
+	}
+	s.shared[requestID] = math.Max(0, cpuDemandMs)
+	nextDone = s.rescheduleSharedLocked()
+	return nextDone, s.sharedVersion
+}
+
+// FinishSharedCPUWork removes the requests whose shared CPU work is done at at. ok is false when
+// version is stale (the set of requests changed after the event was scheduled). nextDone is zero
+// when no request is left running.
+func (s *ServiceInstance) FinishSharedCPUWork(at time.Time, version int) (done []string, nextDone time.Time, nextVersion int, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if version != s.sharedVersion {
+		return nil, time.Time{}, 0, false
+	}
+	s.advanceSharedLocked(at)
+	for id, remaining := range s.shared {
+		if remaining <= 1e-6 {
+			done = append(done, id)
+		}
+	}
+	sort.Strings(done)
+	for _, id := range done {
+		delete(s.shared, id)
+	}
+	nextDone = s.rescheduleSharedLocked()
+	return done, nextDone, s.sharedVersion, true
+}
+
+// SharingCPU reports whether requestID still has shared CPU work running on the instance.
+func (s *ServiceInstance) SharingCPU(requestID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.shared[requestID]
+	return ok
+}
+
+// advanceSharedLocked charges the CPU served since sharedAt to the running requests. Caller must hold s.mu.
+func (s *ServiceInstance) advanceSharedLocked(at time.Time) {
+	if n := len(s.shared); n > 0 && at.After(s.sharedAt) {
+		perRequestMs := float64(at.Sub(s.sharedAt).Nanoseconds()) / 1e6 * s.sharedRateLocked() / float64(n)
+		for id := range s.shared {
+			s.shared[id] -= perRequestMs
+		}
+	}
+	s.sharedAt = at
+}
+
+// rescheduleSharedLocked bumps the version and returns when the next running request finishes. It
+// keeps cpuNextFree at the end of the remaining work, so routing sees the backlog. Caller must hold s.mu.
+func (s *ServiceInstance) rescheduleSharedLocked() time.Time {
+	s.sharedVersion++
+	if len(s.shared) == 0 {
+		s.cpuNextFree = s.sharedAt
+		return time.Time{}
+	}
+	rate := s.sharedRateLocked()
+	minMs, totalMs := math.Inf(1), 0.0
+	for _, remaining := range s.shared {
+		r := math.Max(0, remaining)
+		minMs = math.Min(minMs, r)
+		totalMs += r
+	}
+	s.cpuNextFree = s.sharedAt.Add(ceilMsDuration(totalMs / rate))
+	return s.sharedAt.Add(ceilMsDuration(minMs * float64(len(s.shared)) / rate))
+}
+
+func (s *ServiceInstance) sharedRateLocked() float64 {
+	return math.Max(s.cpuCores, 1e-9)
+}
+
+func msDuration(ms float64) time.Duration {
+	return time.Duration(math.Round(ms * float64(time.Millisecond)))
+}
+
+// ceilMsDuration rounds up, so shared work is complete by the returned time.
+func ceilMsDuration(ms float64) time.Duration {
+	return time.Duration(math.Ceil(ms * float64(time.Millisecond)))
+}
+
+// EnqueueScheduledRequest adds a request to the instance queue, for its service's scheduling
+// discipline to pick (see ServiceInstance.DequeueScheduledRequest).
+func (m *Manager) EnqueueScheduledRequest(instanceID string, req QueuedRequest) error {
+	m.mu.RLock()
+	instance, ok := m.instances[instanceID]
+	m.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("instance not found: %s", instanceID)
+	}
+	instance.EnqueueRequestAt(req)
+	return nil
+}
+
+// DequeueScheduledRequest removes the request the instance's scheduling discipline serves next at at.
+func (m *Manager) DequeueScheduledRequest(instanceID string, at time.Time) (requestID string, dropped []string, ok bool) {
+	m.mu.RLock()
+	instance, found := m.instances[instanceID]
+	var sc *config.Scheduling
+	if found {
+		sc = m.scheduling[instance.ServiceName()]
+	}
+	m.mu.RUnlock()
+	if !found {
+		return "", nil, false
+	}
+	return instance.DequeueScheduledRequest(at, sc)
+}
+
+// RemoveQueuedRequest removes requestID from the instance queue, reporting whether it was waiting there.
+func (m *Manager) RemoveQueuedRequest(instanceID, requestID string) bool {
+	m.mu.RLock()
+	instance, ok := m.instances[instanceID]
+	m.mu.RUnlock()
+	return ok && instance.RemoveQueuedRequest(requestID)
+}
+
+// CPUBusyUntil returns the end of the CPU work reserved on the instance (zero when none was).
+func (m *Manager) CPUBusyUntil(instanceID string) (time.Time, bool) {
+	m.mu.RLock()
+	instance, ok := m.instances[instanceID]
+	m.mu.RUnlock()
+	if !ok {
+		return time.Time{}, false
+	}
+	instance.mu.RLock()
+	defer instance.mu.RUnlock()
+	return instance.cpuNextFree, true
+}
+
+// StartSharedCPUWork admits CPU work on a processor-sharing instance (see
+// ServiceInstance.StartSharedCPUWork). cpuDemandMs is in baseline-core milliseconds.
+func (m *Manager) StartSharedCPUWork(instanceID, requestID string, at time.Time, cpuDemandMs float64) (nextDone time.Time, version int, err error) {
+	m.mu.RLock()
+	instance, ok := m.instances[instanceID]
+	var host *Host
+	if ok {
+		host = m.hosts[instance.HostID()]
+	}
+	m.mu.RUnlock()
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("instance not found: %s", instanceID)
+	}
+	if host != nil {
+		cpuDemandMs /= host.SpeedFactor()
+	}
+	nextDone, version = instance.StartSharedCPUWork(requestID, at, cpuDemandMs)
+	return nextDone, version, nil
+}
+
+// FinishSharedCPUWork removes the requests whose shared CPU work is done (see
+// ServiceInstance.FinishSharedCPUWork). ok is false for a removed instance or a stale version.
+func (m *Manager) FinishSharedCPUWork(instanceID string, at time.Time, version int) (done []string, nextDone time.Time, nextVersion int, ok bool) {
+	m.mu.RLock()
+	instance, found := m.instances[instanceID]
+	m.mu.RUnlock()
+	if !found {
+		return nil, time.Time{}, 0, false
+	}
+	return instance.FinishSharedCPUWork(at, version)
+}
+
+// SharingCPU reports whether requestID still has shared CPU work running on the instance.
+func (m *Manager) SharingCPU(instanceID, requestID string) bool {
+	m.mu.RLock()
+	instance, ok := m.instances[instanceID]
+	m.mu.RUnlock()
+	return ok && instance.SharingCPU(requestID)
+}
@@ -0,0 +1,106 @@
+package resource
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+func TestDequeueScheduledRequestDisciplines(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	ms := func(n int) time.Time { return t0.Add(time.Duration(n) * time.Millisecond) }
+	drain := func(inst *ServiceInstance, at time.Time, sc *config.Scheduling) (order, dropped []string) {
+		for {
+			id, d, ok := inst.DequeueScheduledRequest(at, sc)
+			dropped = append(dropped, d...)
+			if !ok {
+				return order, dropped
+			}
+			order = append(order, id)
+		}
+	}
+
+	inst := NewServiceInstance("i", "svc", "h", 1, 512)
+	inst.EnqueueRequestAt(QueuedRequest{ID: "a", EnqueuedAt: ms(0), Priority: 1})
+	inst.EnqueueRequestAt(QueuedRequest{ID: "b", EnqueuedAt: ms(1), Priority: 5})
+	inst.EnqueueRequestAt(QueuedRequest{ID: "c", EnqueuedAt: ms(2), Priority: 5})
+	if order, _ := drain(inst, ms(3), &config.Scheduling{Discipline: config.SchedulingPriority}); !reflect.DeepEqual(order, []string{"b", "c", "a"}) {
+		t.Fatalf("priority: expected [b c a], got %v", order)
+	}
+
+	lifo := &config.Scheduling{Discipline: config.SchedulingAdaptiveLIFO, IntervalMs: 100}
+	for i, id := range []string{"a", "b", "c"} {
+		inst.EnqueueRequestAt(QueuedRequest{ID: id, EnqueuedAt: ms(i * 10)})
+	}
+	if id, _, _ := inst.DequeueScheduledRequest(ms(50), lifo); id != "a" {
+		t.Fatalf("adaptive_lifo below the interval should serve the oldest, got %s", id)
+	}
+	if order, _ := drain(inst, ms(150), lifo); !reflect.DeepEqual(order, []string{"c", "b"}) {
+		t.Fatalf("adaptive_lifo when overloaded should serve newest first, got %v", order)
+	}
+
+	codel := &config.Scheduling{Discipline: config.SchedulingCoDel}
+	inst.EnqueueRequestAt(QueuedRequest{ID: "a", EnqueuedAt: ms(0)})
+	inst.EnqueueRequestAt(QueuedRequest{ID: "b", EnqueuedAt: ms(120)})
+	inst.EnqueueRequestAt(QueuedRequest{ID: "c", EnqueuedAt: ms(148)})
+	order, dropped := drain(inst, ms(150), codel)
+	if !reflect.DeepEqual(dropped, []string{"a", "b"}) || !reflect.DeepEqual(order, []string{"c"}) {
+		t.Fatalf("codel: expected a and b dropped and c served, got dropped %v served %v", dropped, order)
+	}
+	inst.EnqueueRequestAt(QueuedRequest{ID: "d", EnqueuedAt: ms(200)})
+	if order, dropped := drain(inst, ms(290), codel); len(dropped) != 0 || !reflect.DeepEqual(order, []string{"d"}) {
+		t.Fatalf("codel below the interval should not drop, got dropped %v served %v", dropped, order)
+	}
+}
+
+func TestSharedCPUWork(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	ms := func(n int) time.Time { return t0.Add(time.Duration(n) * time.Millisecond) }
+	inst := NewServiceInstance("i", "svc", "h", 2, 512)
+
+	next, v1 := inst.StartSharedCPUWork("a", ms(0), 100)
+	if !next.Equal(ms(50)) {
+		t.Fatalf("a alone on 2 cores should finish at 50ms, got %v", next.Sub(t0))
+	}
+	// From 10ms a (80ms left) and b share the cores at one core each.
+	next, v2 := inst.StartSharedCPUWork("b", ms(10), 100)
+	if !next.Equal(ms(90)) {
+		t.Fatalf("expected a to finish at 90ms, got %v", next.Sub(t0))
+	}
+	if !inst.busyAt(ms(99)) || inst.busyAt(ms(100)) {
+		t.Fatalf("the instance should be busy until its remaining work is done at 100ms")
+	}
+	if _, _, _, ok := inst.FinishSharedCPUWork(ms(50), v1); ok {
+		t.Fatalf("a completion scheduled before b arrived should be stale")
+	}
+	done, next, v3, ok := inst.FinishSharedCPUWork(ms(90), v2)
+	if !ok || !reflect.DeepEqual(done, []string{"a"}) || !next.Equal(ms(100)) {
+		t.Fatalf("expected a done and b finishing at 100ms, got %v %v %v", ok, done, next.Sub(t0))
+	}
+	if !inst.SharingCPU("b") || inst.SharingCPU("a") {
+		t.Fatalf("only b should still share the CPU")
+	}
+	done, next, _, ok = inst.FinishSharedCPUWork(ms(100), v3)
+	if !ok || !reflect.DeepEqual(done, []string{"b"}) || !next.IsZero() {
+		t.Fatalf("expected b done and nothing left, got %v %v %v", ok, done, next)
+	}
+}
+
+func TestRemoveQueuedRequest(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	inst := NewServiceInstance("i", "svc", "h", 1, 512)
+	for _, id := range []string{"a", "b", "c"} {
+		inst.EnqueueRequestAt(QueuedRequest{ID: id, EnqueuedAt: t0})
+	}
+	if !inst.RemoveQueuedRequest("b") || inst.RemoveQueuedRequest("b") {
+		t.Fatalf("b should be removed once")
+	}
+	if id, ok := inst.DequeueRequest(); !ok || id != "a" {
+		t.Fatalf("expected a next, got %q", id)
+	}
+	if id, ok := inst.DequeueRequest(); !ok || id != "c" {
+		t.Fatalf("expected c next, got %q", id)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"math"
 	"sync"
 	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
 )
 
 // InstanceLifecycle describes whether an instance accepts new work.
@@ -15,6 +17,9 @@ const (
 	// InstanceDraining means no new traffic is routed here; the instance is
 	// removed once idle (or after a simulated-time drain deadline).
 	InstanceDraining
+	// InstanceStarting means the instance was added by scaling and is not routable until
+	// its readiness time.
+	InstanceStarting
 )
 
 // ServiceInstance represents a service instance with resource tracking
@@ -29,6 +34,10 @@ type ServiceInstance struct {
 	// drainDeadline is simulated-time after which the manager may force-remove
 	// this instance even if still busy. Zero means not draining.
 	drainDeadline time.Time
+	// readyAt is when a starting instance becomes routable; zero for instances ready from the start.
+	readyAt time.Time
+	// startup holds the warm-up of instances added by scaling (nil: no warm-up).
+	startup *config.ServiceStartup
 
 	// Resource allocation
 	cpuCores float64 // Allocated CPU cores
@@ -43,12 +52,29 @@ type ServiceInstance struct {
 	activeRequests int     // Number of active requests
 
 	// Queue
-	requestQueue []string // Queue of request IDs waiting to be processed
+	requestQueue []QueuedRequest // Requests waiting to be processed
+	// queueEmptyAt is when the queue last went from empty to non-empty: codel and adaptive_lifo
+	// instances are overloaded once it has stayed non-empty for their interval.
+	queueEmptyAt time.Time
+
+	// Processor-sharing state: the CPU ms each admitted request still needs, as of sharedAt.
+	// sharedVersion changes whenever the set changes, so completion events from before are stale.
+	shared        map[string]float64
+	sharedAt      time.Time
+	sharedVersion int
 
 	// cpuNextFree is simulation time when the next CPU work may begin (end of the last
 	// reserved [cpuStart, cpuEnd) interval). Zero means no backlog from prior reservations.
 	cpuNextFree time.Time
 
+	// CFS quota state of instances with a CPU limit: the period of the last reservation, the CPU ms
+	// used in it, and the last period that was throttled. prevCFS* undo the last reservation.
+	cfsPeriodStart     time.Time
+	cfsUsedMs          float64
+	cfsThrottledPeriod time.Time
+	prevCFSPeriodStart time.Time
+	prevCFSUsedMs      float64
+
 	// dbSlotFree tracks per-slot next-free times for datastore connection pools (parallel FIFO).
 	// Each slot schedules sequential work; the pool picks the earliest available slot.
 	dbSlotFree []time.Time
@@ -63,7 +89,8 @@ const maxDBSlotsCap = 64
 
 // NewServiceInstance creates a new service instance
 func NewServiceInstance(id, serviceName, hostID string, cpuCores, memoryMB float64) *ServiceInstance {
-	now := time.Now()
+	// windowStartTime stays zero until the first allocation opens a window at simulation time, so
+	// utilization never depends on the wall clock at construction.
 	return &ServiceInstance{
 		id:               id,
 		serviceName:      serviceName,
@@ -73,9 +100,7 @@ func NewServiceInstance(id, serviceName, hostID string, cpuCores, memoryMB float
 		memoryMB:         memoryMB,
 		cpuUsageWindow:   1 * time.Second, // Default 1-second window for utilization calculation
 		cpuUsageInWindow: 0,
-		windowStartTime:  now,
-		requestQueue:     make([]string, 0),
-		lastUpdate:       now,
+		requestQueue:     make([]QueuedRequest, 0),
 	}
 }
 
@@ -143,6 +168,43 @@ func (s *ServiceInstance) SetDraining(deadline time.Time) {
 	s.drainDeadline = deadline
 }
 
+// ReadyAt returns when a starting instance becomes routable (zero when ready from the start).
+func (s *ServiceInstance) ReadyAt() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.readyAt
+}
+
+// SetStarting marks the instance as starting until readyAt; startup supplies its warm-up.
+func (s *ServiceInstance) SetStarting(readyAt time.Time, startup *config.ServiceStartup) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lifecycle = InstanceStarting
+	s.readyAt = readyAt
+	s.startup = startup
+}
+
+// markReadyAt makes a starting instance active if it is ready at simTime.
+func (s *ServiceInstance) markReadyAt(simTime time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lifecycle != InstanceStarting || simTime.Before(s.readyAt) {
+		return false
+	}
+	s.lifecycle = InstanceActive
+	return true
+}
+
+// CPUCostMultiplierAt returns the warm-up CPU cost multiplier at simTime (1 once warm).
+func (s *ServiceInstance) CPUCostMultiplierAt(simTime time.Time) float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.startup == nil || s.lifecycle == InstanceStarting {
+		return 1
+	}
+	return s.startup.WarmupCPUFactor(simTime.Sub(s.readyAt))
+}
+
 // SetCPUCores updates the allocated CPU cores for this instance.
 func (s *ServiceInstance) SetCPUCores(cores float64) {
 	s.mu.Lock()
@@ -278,7 +340,7 @@ func (s *ServiceInstance) ReleaseMemory(memoryMB float64) {
 func (s *ServiceInstance) EnqueueRequest(requestID string) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.requestQueue = append(s.requestQueue, requestID)
+	s.requestQueue = append(s.requestQueue, QueuedRequest{ID: requestID})
 }
 
 // DequeueRequest removes and returns the next request from the queue
@@ -288,16 +350,26 @@ func (s *ServiceInstance) DequeueRequest() (string, bool) {
 	if len(s.requestQueue) == 0 {
 		return "", false
 	}
-	requestID := s.requestQueue[0]
-	// Clear the reference to the dequeued element to avoid retaining it in the backing array.
-	s.requestQueue[0] = ""
-	if len(s.requestQueue) == 1 {
+	return s.removeQueuedLocked(0), true
+}
+
+// removeQueuedLocked removes and returns the ID of the i-th queued request. Caller must hold s.mu.
+func (s *ServiceInstance) removeQueuedLocked(i int) string {
+	requestID := s.requestQueue[i].ID
+	if i == 0 {
+		// Clear the reference to the dequeued element to avoid retaining it in the backing array.
+		s.requestQueue[0] = QueuedRequest{}
+		s.requestQueue = s.requestQueue[1:]
+	} else {
+		copy(s.requestQueue[i:], s.requestQueue[i+1:])
+		s.requestQueue[len(s.requestQueue)-1] = QueuedRequest{}
+		s.requestQueue = s.requestQueue[:len(s.requestQueue)-1]
+	}
+	if len(s.requestQueue) == 0 {
 		// When the queue becomes empty, release the backing array.
 		s.requestQueue = nil
-	} else {
-		s.requestQueue = s.requestQueue[1:]
 	}
-	return requestID, true
+	return requestID
 }
 
 // QueueLength returns the current queue length
@@ -351,6 +423,73 @@ func (s *ServiceInstance) ReserveCPUWork(arrivalTime time.Time, cpuDemandMs floa
 	return cpuStart, cpuEnd
 }
 
+// CPUThrottle is the CFS throttling of one CPU reservation.
+type CPUThrottle struct {
+	// ThrottledMs is the simulated time the work waited with its quota used up.
+	ThrottledMs float64
+	// Periods is the number of CFS periods newly throttled.
+	Periods int
+}
+
+// ReserveCPUWorkLimited schedules cpuDemandMs of FIFO CPU work on an instance with a CFS quota of
+// limitCores per period. While running, the work uses its cpuCores plus burstCores idle host
+// cores; once the CPU used in a period reaches the quota, it waits for the next period.
+func (s *ServiceInstance) ReserveCPUWorkLimited(arrivalTime time.Time, cpuDemandMs, limitCores, burstCores float64) (cpuStart, cpuEnd time.Time, throttle CPUThrottle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rate := s.cpuCores + math.Max(0, burstCores)
+	if rate < 1e-9 {
+		rate = 1e-9
+	}
+	period := time.Duration(config.CFSPeriodMs) * time.Millisecond
+	quotaMs := math.Max(limitCores, s.cpuCores) * config.CFSPeriodMs
+	cpuStart = arrivalTime
+	if !s.cpuNextFree.IsZero() && s.cpuNextFree.After(cpuStart) {
+		cpuStart = s.cpuNextFree
+	}
+	s.prevCFSPeriodStart, s.prevCFSUsedMs = s.cfsPeriodStart, s.cfsUsedMs
+	t := cpuStart
+	remaining := math.Max(0, cpuDemandMs)
+	for remaining > 1e-9 {
+		ps := t.Truncate(period)
+		if !ps.Equal(s.cfsPeriodStart) {
+			s.cfsPeriodStart, s.cfsUsedMs = ps, 0
+		}
+		pe := ps.Add(period)
+		avail := quotaMs - s.cfsUsedMs
+		if avail <= 1e-9 {
+			throttle.ThrottledMs += float64(pe.Sub(t).Nanoseconds()) / 1e6
+			if !s.cfsThrottledPeriod.Equal(ps) {
+				s.cfsThrottledPeriod = ps
+				throttle.Periods++
+			}
+			t = pe
+			continue
+		}
+		run := math.Min(remaining, avail)
+		runMs := run / rate
+		if toEndMs := float64(pe.Sub(t).Nanoseconds()) / 1e6; runMs >= toEndMs {
+			runMs = toEndMs
+			run = math.Min(run, toEndMs*rate)
+			t = pe
+		} else {
+			t = t.Add(time.Duration(math.Round(runMs * float64(time.Millisecond))))
+		}
+		s.cfsUsedMs += run
+		remaining -= run
+	}
+	cpuEnd = t
+	s.cpuNextFree = cpuEnd
+	return cpuStart, cpuEnd, throttle
+}
+
+// busyAt reports whether CPU work reserved on the instance is still running at at.
+func (s *ServiceInstance) busyAt(at time.Time) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.cpuNextFree.After(at)
+}
+
 // ReserveDBWork schedules IO-style work on a logical connection pool after CPU completes.
 // maxSlots <= 0 disables pooling (immediate start at arrival). durMs is wall time for the IO phase.
 func (s *ServiceInstance) ReserveDBWork(arrival time.Time, durMs float64, maxSlots int) (start, end time.Time, slotIdx int, waitMs float64) {
@@ -422,6 +561,7 @@ func (s *ServiceInstance) RollbackCPUTailReservation(cpuStart, cpuEnd time.Time)
 		return
 	}
 	s.cpuNextFree = cpuStart
+	s.cfsPeriodStart, s.cfsUsedMs = s.prevCFSPeriodStart, s.prevCFSUsedMs
 }
 
 // ActiveMemoryMB returns the active memory usage in MB (for internal use)
@@ -440,9 +580,9 @@ func (s *ServiceInstance) EvictResourceState(simTime time.Time) []string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	var dropped []string
-	for _, id := range s.requestQueue {
-		if id != "" {
-			dropped = append(dropped, id)
+	for _, q := range s.requestQueue {
+		if q.ID != "" {
+			dropped = append(dropped, q.ID)
 		}
 	}
 	s.activeRequests = 0
@@ -451,6 +591,8 @@ func (s *ServiceInstance) EvictResourceState(simTime time.Time) []string {
 	s.cpuUsageInWindow = 0
 	s.windowStartTime = simTime
 	s.cpuNextFree = time.Time{}
+	s.shared = nil
+	s.sharedVersion++
 	s.dbSlotFree = nil
 	s.dbActiveConnections = 0
 	s.lastUpdate = simTime
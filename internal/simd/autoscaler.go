@@ -0,0 +1,281 @@
+package simd
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/logger"
+)
+
+// Scaling decision actions recorded on the run.
+const (
+	ScalingActionScaleUp   = "scale_up"
+	ScalingActionScaleDown = "scale_down"
+)
+
+// ScalingDecision is one replica change chosen by the in-simulation autoscaler (HPA emulation).
+// Decisions are exposed through the run export and the metrics SSE stream.
+type ScalingDecision struct {
+	SimTime      time.Time `json:"sim_time"`
+	ServiceID    string    `json:"service_id"`
+	Action       string    `json:"action"`
+	FromReplicas int       `json:"from_replicas"`
+	ToReplicas   int       `json:"to_replicas"`
+	AvgCPUUtil   float64   `json:"avg_cpu_util"`
+	// ReadyAt is when scaled-up replicas enter rotation (SimTime + startup delay); zero for scale-down.
+	ReadyAt time.Time `json:"ready_at"`
+	// Error is set when the resource manager rejected the change (e.g. no host capacity).
+	Error string `json:"error,omitempty"`
+}
+
+type replicaRecommendation struct {
+	at       time.Time
+	replicas int
+}
+
+// autoscalerState holds HPA emulation bookkeeping for one run.
+type autoscalerState struct {
+	mu sync.Mutex
+	// recommendations keeps per-service desired replica history for the stabilization window.
+	recommendations map[string][]replicaRecommendation
+	// pendingStartup counts replicas decided but still within the startup delay, per service.
+	pendingStartup map[string]int
+	decisions      []ScalingDecision
+	onDecision     func(ScalingDecision)
+}
+
+func newAutoscalerState() *autoscalerState {
+	return &autoscalerState{
+		recommendations: make(map[string][]replicaRecommendation),
+		pendingStartup:  make(map[string]int),
+	}
+}
+
+// Decisions returns a copy of the scaling decisions recorded so far.
+func (a *autoscalerState) Decisions() []ScalingDecision {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make([]ScalingDecision, len(a.decisions))
+	copy(out, a.decisions)
+	return out
+}
+
+func (a *autoscalerState) record(d ScalingDecision) {
+	a.mu.Lock()
+	a.decisions = append(a.decisions, d)
+	hook := a.onDecision
+	a.mu.Unlock()
+	if hook != nil {
+		hook(d)
+	}
+}
+
+// stabilizedReplicas records desired and returns the replica count to apply. Scale-down uses the
+// highest recommendation within window (Kubernetes HPA downscale stabilization); scale-up is immediate.
+// The first observation of a service seeds the history with current so a fresh run does not shed
+// replicas before a full window of low utilization has been seen.
+func (a *autoscalerState) stabilizedReplicas(serviceID string, simTime time.Time, current, desired int, window time.Duration) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	recs, seen := a.recommendations[serviceID]
+	if !seen {
+		recs = append(recs, replicaRecommendation{at: simTime, replicas: current})
+	}
+	recs = append(recs, replicaRecommendation{at: simTime, replicas: desired})
+	cutoff := simTime.Add(-window)
+	kept := recs[:0]
+	for _, r := range recs {
+		if !r.at.Before(cutoff) {
+			kept = append(kept, r)
+		}
+	}
+	a.recommendations[serviceID] = kept
+	out := desired
+	for _, r := range kept {
+		if r.replicas > out {
+			out = r.replicas
+		}
+	}
+	return out
+}
+
+// SetScalingDecisionHook registers fn to be called for every autoscaler decision (e.g. persist to the run store).
+func (s *scenarioState) SetScalingDecisionHook(fn func(ScalingDecision)) {
+	s.autoscaler.mu.Lock()
+	defer s.autoscaler.mu.Unlock()
+	s.autoscaler.onDecision = fn
+}
+
+// ScheduleAutoscaleSyncKickoff schedules the first autoscaler metrics sync. The sync reschedules itself
+// every sync period so autoscaling enabled at runtime (UpdatePolicies) takes effect without a restart.
+func ScheduleAutoscaleSyncKickoff(eng *engine.Engine, state *scenarioState, startTime time.Time) {
+	eng.ScheduleAt(engine.EventTypeAutoscaleSync, startTime.Add(autoscaleSyncPeriod(state)), nil, "", nil)
+}
+
+func autoscaleSyncPeriod(state *scenarioState) time.Duration {
+	if state.policies == nil {
+		return config.DefaultAutoscalingSyncPeriod
+	}
+	return state.policies.AutoscalingSyncPeriod()
+}
+
+// autoscaleEligible reports whether the HPA emulator may change replicas for svc.
+// Brokers and external dependencies are not scaled on CPU.
+func autoscaleEligible(svc *config.Service) bool {
+	switch strings.ToLower(strings.TrimSpace(svc.Kind)) {
+	case "queue", "topic", "external":
+		return false
+	}
+	return config.ServiceAllowsHorizontalScaling(svc)
+}
+
+func handleAutoscaleSync(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		simTime := eng.GetSimTime()
+		if state.policies != nil && state.policies.HasAutoscaling() {
+			evaluateAutoscaling(eng, state, simTime)
+		}
+		next := simTime.Add(autoscaleSyncPeriod(state))
+		if state.simEndTime.IsZero() || next.Before(state.simEndTime) {
+			eng.ScheduleAt(engine.EventTypeAutoscaleSync, next, nil, "", nil)
+		}
+		return nil
+	}
+}
+
+// evaluateAutoscaling samples routable-instance CPU for each eligible service, applies the service's
+// effective autoscaling policy (service override, then scenario policies), and either schedules a
+// delayed scale-up (startup delay) or drains replicas immediately.
+func evaluateAutoscaling(eng *engine.Engine, state *scenarioState, simTime time.Time) {
+	as := state.autoscaler
+	for i := range state.scenario.Services {
+		svc := &state.scenario.Services[i]
+		if !autoscaleEligible(svc) {
+			continue
+		}
+		cfg := state.policies.GetAutoscalingConfigFor(svc.ID)
+		pol := state.policies.GetAutoscalingFor(svc.ID)
+		if cfg == nil || pol == nil {
+			continue
+		}
+		instances := state.rm.GetInstancesForService(svc.ID)
+		sort.Slice(instances, func(a, b int) bool { return instances[a].ID() < instances[b].ID() })
+		var cpuSum float64
+		var active int
+		for _, inst := range instances {
+			if !inst.IsRoutable() {
+				continue
+			}
+			cpuSum += inst.CPUUtilizationAt(simTime)
+			active++
+		}
+		if active == 0 {
+			continue
+		}
+		avgCPU := cpuSum / float64(active)
+
+		as.mu.Lock()
+		pending := as.pendingStartup[svc.ID]
+		as.mu.Unlock()
+		// Replicas already added but still starting (service startup) count toward the current size.
+		current := state.rm.ProvisionedReplicas(svc.ID) + pending
+
+		desired := pol.GetTargetReplicas(svc.ID, current, avgCPU)
+		target := as.stabilizedReplicas(svc.ID, simTime, current, desired, cfg.EffectiveStabilizationWindow())
+		metrics.RecordAutoscalingDesiredReplicas(state.collector, float64(target), simTime, metrics.CreateServiceLabels(svc.ID))
+
+		switch {
+		case target > current:
+			delta := target - current
+			readyAt := simTime.Add(cfg.EffectiveStartupDelay())
+			as.mu.Lock()
+			as.pendingStartup[svc.ID] += delta
+			as.mu.Unlock()
+			eng.ScheduleAt(engine.EventTypeScaleUp, readyAt, nil, svc.ID, map[string]interface{}{
+				"service_id": svc.ID,
+				"replicas":   delta,
+			})
+			as.record(ScalingDecision{
+				SimTime:      simTime,
+				ServiceID:    svc.ID,
+				Action:       ScalingActionScaleUp,
+				FromReplicas: current,
+				ToReplicas:   target,
+				AvgCPUUtil:   avgCPU,
+				ReadyAt:      readyAt,
+			})
+		case target < current && pending == 0:
+			d := ScalingDecision{
+				SimTime:      simTime,
+				ServiceID:    svc.ID,
+				Action:       ScalingActionScaleDown,
+				FromReplicas: current,
+				ToReplicas:   target,
+				AvgCPUUtil:   avgCPU,
+			}
+			if err := state.rm.ScaleServiceWithOptions(svc.ID, target, resource.ScaleServiceOptions{SimTime: simTime}); err != nil {
+				d.Error = err.Error()
+				logger.Warn("autoscaler scale-down failed", "service_id", svc.ID, "target", target, "error", err)
+			}
+			as.record(d)
+		}
+	}
+}
+
+// handleAutoscaleScaleUp brings replicas decided by the autoscaler into rotation once their startup delay elapses.
+func handleAutoscaleScaleUp(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		simTime := eng.GetSimTime()
+		serviceID, _ := evt.Data["service_id"].(string)
+		delta := metadataInt(evt.Data, "replicas")
+		if serviceID == "" || delta <= 0 {
+			return nil
+		}
+		as := state.autoscaler
+		as.mu.Lock()
+		as.pendingStartup[serviceID] -= delta
+		if as.pendingStartup[serviceID] < 0 {
+			as.pendingStartup[serviceID] = 0
+		}
+		as.mu.Unlock()
+
+		target := state.rm.ProvisionedReplicas(serviceID) + delta
+		if err := state.rm.ScaleServiceWithOptions(serviceID, target, resource.ScaleServiceOptions{SimTime: simTime}); err != nil {
+			logger.Warn("autoscaler scale-up failed", "service_id", serviceID, "target", target, "error", err)
+			as.record(ScalingDecision{
+				SimTime:      simTime,
+				ServiceID:    serviceID,
+				Action:       ScalingActionScaleUp,
+				FromReplicas: target - delta,
+				ToReplicas:   target,
+				Error:        err.Error(),
+			})
+		}
+		return nil
+	}
+}
+
+// scalingDecisionToJSON renders a decision for export and SSE payloads.
+func scalingDecisionToJSON(d ScalingDecision) map[string]any {
+	out := map[string]any{
+		"sim_time":      d.SimTime.Format(time.RFC3339Nano),
+		"service_id":    d.ServiceID,
+		"action":        d.Action,
+		"from_replicas": d.FromReplicas,
+		"to_replicas":   d.ToReplicas,
+		"avg_cpu_util":  d.AvgCPUUtil,
+	}
+	if !d.ReadyAt.IsZero() {
+		out["ready_at"] = d.ReadyAt.Format(time.RFC3339Nano)
+	}
+	if d.Error != "" {
+		out["error"] = d.Error
+	}
+	return out
+}
@@ -0,0 +1,198 @@
+package simd
+
+import (
+	"testing"
+	"time"
+
+	simulationv1 "github.com/GoSim-25-26J-441/simulation-core/gen/go/simulation/v1"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/policy"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+func autoscalerTestScenario(replicas int, rateRPS float64, as *config.AutoscalingPolicy) *config.Scenario {
+	return &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 16, MemoryGB: 32}},
+		Services: []config.Service{
+			{
+				ID: "api", Replicas: replicas, Model: "cpu", CPUCores: 1, MemoryMB: 256,
+				Endpoints: []config.Endpoint{
+					{Path: "/work", MeanCPUMs: 20, CPUSigmaMs: 0, NetLatencyMs: config.LatencySpec{Mean: 0.5}},
+				},
+			},
+		},
+		Workload: []config.WorkloadPattern{
+			{From: "client", To: "api:/work", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: rateRPS}},
+		},
+		Policies: &config.Policies{Autoscaling: as},
+	}
+}
+
+// runAutoscalerScenario runs the DES loop like RunScenarioForMetrics and returns the state for inspection.
+func runAutoscalerScenario(t *testing.T, scenario *config.Scenario, dur time.Duration) (*scenarioState, *resource.Manager) {
+	t.Helper()
+	eng := engine.NewEngine("autoscaler-test")
+	rm := resource.NewManager()
+	if err := rm.InitializeFromScenario(scenario); err != nil {
+		t.Fatalf("InitializeFromScenario: %v", err)
+	}
+	collector := metrics.NewCollector()
+	collector.Start()
+	defer collector.Stop()
+	state, err := newScenarioState(scenario, rm, collector, policy.NewPolicyManager(scenario.Policies), 42)
+	if err != nil {
+		t.Fatalf("newScenarioState: %v", err)
+	}
+	RegisterHandlers(eng, state)
+	start := eng.GetSimTime()
+	end := start.Add(dur)
+	state.SetSimEndTime(end)
+	ScheduleDrainSweepKickoff(eng, start)
+	ScheduleAutoscaleSyncKickoff(eng, state, start)
+	ws := NewWorkloadState("autoscaler-test", eng, end, 42)
+	if err := ws.Start(scenario, start, false); err != nil {
+		t.Fatalf("workload start: %v", err)
+	}
+	defer ws.Stop()
+	if err := eng.Run(dur); err != nil {
+		t.Fatalf("engine run: %v", err)
+	}
+	return state, rm
+}
+
+func TestAutoscalerScalesUpAfterStartupDelay(t *testing.T) {
+	as := &config.AutoscalingPolicy{
+		Enabled: true, TargetCPUUtil: 0.5, ScaleStep: 1, MaxReplicas: 3,
+		SyncPeriodMs: 1000, StartupDelayMs: 2000,
+	}
+	// 100 rps * 20ms = 2 cores of demand on a single 1-core replica.
+	state, rm := runAutoscalerScenario(t, autoscalerTestScenario(1, 100, as), 12*time.Second)
+
+	decisions := state.autoscaler.Decisions()
+	if len(decisions) == 0 {
+		t.Fatal("expected autoscaler decisions under CPU saturation")
+	}
+	first := decisions[0]
+	if first.Action != ScalingActionScaleUp || first.ServiceID != "api" {
+		t.Fatalf("expected first decision scale_up for api, got %+v", first)
+	}
+	if got := first.ReadyAt.Sub(first.SimTime); got != 2*time.Second {
+		t.Fatalf("expected ReadyAt = SimTime + startup delay, got %v", got)
+	}
+	for _, d := range decisions {
+		if d.ToReplicas > 3 {
+			t.Fatalf("decision exceeds max_replicas: %+v", d)
+		}
+	}
+	if got := rm.ActiveReplicas("api"); got < 2 || got > 3 {
+		t.Fatalf("expected api to scale to 2..3 replicas, got %d", got)
+	}
+}
+
+func TestAutoscalerScaleOutWaitsForServiceStartup(t *testing.T) {
+	as := &config.AutoscalingPolicy{
+		Enabled: true, TargetCPUUtil: 0.5, ScaleStep: 1, MaxReplicas: 3, SyncPeriodMs: 1000,
+	}
+	scenario := func() *config.Scenario {
+		s := autoscalerTestScenario(1, 100, as)
+		s.Services[0].Startup = &config.ServiceStartup{
+			DelayMs:             config.LatencySpec{Mean: 4000, Distribution: config.DistributionFixed},
+			WarmupMs:            2000,
+			WarmupCPUMultiplier: 2,
+		}
+		return s
+	}
+
+	// The first scale-up happens at 1s; its replica is not ready before 5s.
+	state, rm := runAutoscalerScenario(t, scenario(), 3500*time.Millisecond)
+	if len(state.autoscaler.Decisions()) == 0 {
+		t.Fatal("expected autoscaler decisions under CPU saturation")
+	}
+	if a, p := rm.ActiveReplicas("api"), rm.ProvisionedReplicas("api"); a != 1 || p < 2 || p > 3 {
+		t.Fatalf("expected starting replicas to be provisioned but not routable, got %d active of %d", a, p)
+	}
+	for _, d := range state.autoscaler.Decisions() {
+		if d.ToReplicas > 3 {
+			t.Fatalf("starting replicas must count toward max_replicas: %+v", d)
+		}
+	}
+
+	_, rm = runAutoscalerScenario(t, scenario(), 8*time.Second)
+	if got := rm.ActiveReplicas("api"); got < 2 {
+		t.Fatalf("expected scaled-up replicas to be routable once started, got %d", got)
+	}
+}
+
+func TestAutoscalerScaleDownRespectsStabilizationWindow(t *testing.T) {
+	base := config.AutoscalingPolicy{
+		Enabled: true, TargetCPUUtil: 0.7, ScaleStep: 1, MinReplicas: 1,
+		SyncPeriodMs: 1000,
+	}
+	noWindow := base
+	_, rm := runAutoscalerScenario(t, autoscalerTestScenario(3, 1, &noWindow), 6*time.Second)
+	if got := rm.ActiveReplicas("api"); got != 1 {
+		t.Fatalf("expected idle service to scale down to min_replicas=1, got %d", got)
+	}
+
+	withWindow := base
+	withWindow.StabilizationWindowMs = 60000
+	state, rm := runAutoscalerScenario(t, autoscalerTestScenario(3, 1, &withWindow), 6*time.Second)
+	if got := rm.ActiveReplicas("api"); got != 3 {
+		t.Fatalf("expected stabilization window to hold replicas at 3, got %d", got)
+	}
+	if n := len(state.autoscaler.Decisions()); n != 0 {
+		t.Fatalf("expected no decisions within stabilization window, got %d", n)
+	}
+}
+
+func TestAutoscalerDisabledRecordsNoDecisions(t *testing.T) {
+	state, rm := runAutoscalerScenario(t, autoscalerTestScenario(1, 100, &config.AutoscalingPolicy{Enabled: false}), 5*time.Second)
+	if n := len(state.autoscaler.Decisions()); n != 0 {
+		t.Fatalf("expected no decisions with autoscaling disabled, got %d", n)
+	}
+	if got := rm.ActiveReplicas("api"); got != 1 {
+		t.Fatalf("expected replicas unchanged, got %d", got)
+	}
+}
+
+func TestAutoscalerStabilizedReplicas(t *testing.T) {
+	a := newAutoscalerState()
+	t0 := time.Unix(0, 0)
+	window := 10 * time.Second
+	if got := a.stabilizedReplicas("svc", t0, 4, 2, window); got != 4 {
+		t.Fatalf("first observation should hold current replicas, got %d", got)
+	}
+	if got := a.stabilizedReplicas("svc", t0.Add(5*time.Second), 4, 2, window); got != 4 {
+		t.Fatalf("within window expected 4, got %d", got)
+	}
+	if got := a.stabilizedReplicas("svc", t0.Add(11*time.Second), 4, 2, window); got != 2 {
+		t.Fatalf("after window expected 2, got %d", got)
+	}
+	if got := a.stabilizedReplicas("svc", t0.Add(12*time.Second), 2, 5, window); got != 5 {
+		t.Fatalf("scale-up should be immediate, got %d", got)
+	}
+}
+
+func TestRunStoreAppendScalingDecision(t *testing.T) {
+	store := NewRunStore()
+	if _, err := store.Create("run-scale", &simulationv1.RunInput{ScenarioYaml: "x"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	d := ScalingDecision{ServiceID: "api", Action: ScalingActionScaleUp, FromReplicas: 1, ToReplicas: 2}
+	if err := store.AppendScalingDecision("run-scale", d); err != nil {
+		t.Fatalf("AppendScalingDecision: %v", err)
+	}
+	rec, ok := store.Get("run-scale")
+	if !ok || len(rec.ScalingDecisions) != 1 || rec.ScalingDecisions[0] != d {
+		t.Fatalf("expected decision on record, got %+v", rec)
+	}
+	if err := store.AppendScalingDecision("missing", d); err == nil {
+		t.Fatal("expected error for unknown run")
+	}
+	js := scalingDecisionToJSON(d)
+	if js["action"] != "scale_up" || js["to_replicas"] != 2 {
+		t.Fatalf("unexpected JSON: %v", js)
+	}
+}
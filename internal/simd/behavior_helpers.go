@@ -19,7 +19,7 @@ func isDatastoreWorkload(svc *config.Service, ep *config.Endpoint) bool {
 	}
 	// External services keep prior one-phase behavior unless IO/pool fields are set explicitly.
 	if k == "external" {
-		if ep.IOMs.Mean > 0 || ep.IOMs.Sigma > 0 {
+		if ep.IOMs.IsSet() {
 			return true
 		}
 		if ep.ConnectionPool > 0 {
@@ -46,18 +46,10 @@ func sampleEndpointIOWorkloadMs(ep *config.Endpoint, rng *utils.RandSource) floa
 	if ep == nil || rng == nil {
 		return 0
 	}
-	if ep.IOMs.Mean > 0 || ep.IOMs.Sigma > 0 {
-		v := rng.NormFloat64(ep.IOMs.Mean, ep.IOMs.Sigma)
-		if v < 0 {
-			return 0
-		}
-		return v
-	}
-	v := rng.NormFloat64(ep.NetLatencyMs.Mean, ep.NetLatencyMs.Sigma)
-	if v < 0 {
-		return 0
+	if ep.IOMs.IsSet() {
+		return ep.IOMs.Sample(rng)
 	}
-	return v
+	return ep.NetLatencyMs.Sample(rng)
 }
 
 func mergedDependencyFailureRate(ds config.DownstreamCall, tgt *config.Service) float64 {
@@ -0,0 +1,308 @@
+package simd
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/interaction"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// Cache key-space metadata on requests to a cache with behavior.cache.key_space.
+const (
+	metaCacheKey       = "cache_key"
+	metaCacheLoad      = "cache_load"
+	metaCacheCoalesced = "cache_coalesced"
+)
+
+// cacheEntry is one key held by a cache.
+type cacheEntry struct {
+	key       string
+	expiresAt time.Time // zero: no expiry
+	uses      int64
+	lastUsed  uint64 // recency sequence
+	index     int
+}
+
+// cacheEvictionOrder is a min-heap of entries, least recently used first (lru) or least used,
+// then least recently used, first (lfu).
+type cacheEvictionOrder struct {
+	entries []*cacheEntry
+	lfu     bool
+}
+
+func (o *cacheEvictionOrder) Len() int { return len(o.entries) }
+func (o *cacheEvictionOrder) Less(i, j int) bool {
+	a, b := o.entries[i], o.entries[j]
+	if o.lfu && a.uses != b.uses {
+		return a.uses < b.uses
+	}
+	return a.lastUsed < b.lastUsed
+}
+func (o *cacheEvictionOrder) Swap(i, j int) {
+	o.entries[i], o.entries[j] = o.entries[j], o.entries[i]
+	o.entries[i].index = i
+	o.entries[j].index = j
+}
+func (o *cacheEvictionOrder) Push(x any) {
+	e := x.(*cacheEntry)
+	e.index = len(o.entries)
+	o.entries = append(o.entries, e)
+}
+func (o *cacheEvictionOrder) Pop() any {
+	n := len(o.entries)
+	e := o.entries[n-1]
+	o.entries = o.entries[:n-1]
+	return e
+}
+
+// cacheStore is the simulated key space of one cache service, shared by its replicas.
+type cacheStore struct {
+	spec    *config.CacheKeySpace
+	cdf     []float64 // cumulative Zipf weights of keys by popularity rank
+	entries map[string]*cacheEntry
+	order   cacheEvictionOrder
+	seq     uint64
+	// loaders holds the requests loading each key; waiters the coalesced misses waiting for them.
+	loaders map[string]map[string]bool
+	waiters map[string][]string
+}
+
+func newCacheStore(spec *config.CacheKeySpace) *cacheStore {
+	s := spec.EffectiveZipfS()
+	cdf := make([]float64, spec.Keys)
+	total := 0.0
+	for i := range cdf {
+		total += 1 / math.Pow(float64(i+1), s)
+		cdf[i] = total
+	}
+	return &cacheStore{
+		spec:    spec,
+		cdf:     cdf,
+		entries: make(map[string]*cacheEntry),
+		order:   cacheEvictionOrder{lfu: spec.EvictionPolicy() == config.CacheEvictionLFU},
+		loaders: make(map[string]map[string]bool),
+		waiters: make(map[string][]string),
+	}
+}
+
+// sampleKey draws a key by Zipf popularity from u in [0,1).
+func (c *cacheStore) sampleKey(u float64) string {
+	i := sort.SearchFloat64s(c.cdf, u*c.cdf[len(c.cdf)-1])
+	if i >= len(c.cdf) {
+		i = len(c.cdf) - 1
+	}
+	return "k" + strconv.Itoa(i+1)
+}
+
+// get reports whether key is held and fresh at now, counting the use. expired is true when the
+// entry was found past its TTL (it is dropped).
+func (c *cacheStore) get(key string, now time.Time) (hit, expired bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		return false, false
+	}
+	if !e.expiresAt.IsZero() && !now.Before(e.expiresAt) {
+		heap.Remove(&c.order, e.index)
+		delete(c.entries, key)
+		return false, true
+	}
+	c.seq++
+	e.uses++
+	e.lastUsed = c.seq
+	heap.Fix(&c.order, e.index)
+	return true, false
+}
+
+// put writes key back at now and returns the number of entries evicted to make room.
+func (c *cacheStore) put(key string, now time.Time) int {
+	c.seq++
+	var expiresAt time.Time
+	if c.spec.TTLMs > 0 {
+		expiresAt = now.Add(time.Duration(c.spec.TTLMs * float64(time.Millisecond)))
+	}
+	if e, ok := c.entries[key]; ok {
+		e.expiresAt = expiresAt
+		e.lastUsed = c.seq
+		heap.Fix(&c.order, e.index)
+		return 0
+	}
+	evicted := 0
+	for c.spec.CapacityKeys > 0 && len(c.entries) >= c.spec.CapacityKeys {
+		e := heap.Pop(&c.order).(*cacheEntry)
+		delete(c.entries, e.key)
+		evicted++
+	}
+	e := &cacheEntry{key: key, expiresAt: expiresAt, uses: 1, lastUsed: c.seq}
+	c.entries[key] = e
+	heap.Push(&c.order, e)
+	return evicted
+}
+
+// liveLoaders drops loaders of key that failed without settling and returns how many remain.
+func (c *cacheStore) liveLoaders(rm *engine.RunManager, key string) int {
+	for id := range c.loaders[key] {
+		if r, ok := rm.GetRequest(id); !ok || r.Status == models.RequestStatusFailed {
+			delete(c.loaders[key], id)
+		}
+	}
+	return len(c.loaders[key])
+}
+
+// cacheStoreFor returns the key space of a cache service, created on first use.
+func cacheStoreFor(state *scenarioState, svc *config.Service) *cacheStore {
+	c, ok := state.caches[svc.ID]
+	if !ok {
+		c = newCacheStore(svc.Behavior.Cache.KeySpace)
+		state.caches[svc.ID] = c
+	}
+	return c
+}
+
+// lookupCacheKey decides whether a request to a cache with a key space hits. A miss loads the
+// value, unless it is coalesced onto a load already in flight. The decision is kept on the request,
+// so a deferred start does not look the key up again.
+func lookupCacheKey(state *scenarioState, rm *engine.RunManager, svc *config.Service, request *models.Request, simTime time.Time) bool {
+	if _, ok := request.Metadata[metaCacheKey]; ok {
+		return metadataBool(request.Metadata, "cache_hit")
+	}
+	cfg := svc.Behavior.Cache
+	labels := labelsForRequestMetrics(request, request.ServiceName, request.Endpoint)
+	state.cacheMu.Lock()
+	defer state.cacheMu.Unlock()
+	c := cacheStoreFor(state, svc)
+	key := ""
+	if from := cfg.KeySpace.KeyFrom; from != "" {
+		if v, ok := request.Metadata[from]; ok {
+			key = fmt.Sprint(v)
+		}
+	}
+	if key == "" {
+		key = c.sampleKey(state.rng.Float64())
+	}
+	request.Metadata[metaCacheKey] = key
+	hit, expired := c.get(key, simTime)
+	if expired {
+		metrics.RecordCacheExpiredCount(state.collector, 1.0, simTime, labels)
+	}
+	if hit {
+		return true
+	}
+	if c.liveLoaders(rm, key) > 0 {
+		metrics.RecordCacheStampedeCount(state.collector, 1.0, simTime, labels)
+		if cfg.CoalesceMisses {
+			request.Metadata[metaCacheCoalesced] = true
+			metrics.RecordCacheCoalescedCount(state.collector, 1.0, simTime, labels)
+			return false
+		}
+	}
+	c.addLoader(key, request)
+	metrics.RecordCacheLoadCount(state.collector, 1.0, simTime, labels)
+	return false
+}
+
+func (c *cacheStore) addLoader(key string, request *models.Request) {
+	if c.loaders[key] == nil {
+		c.loaders[key] = make(map[string]bool)
+	}
+	c.loaders[key][request.ID] = true
+	request.Metadata[metaCacheLoad] = true
+}
+
+// awaitCacheLoad parks a coalesced miss at its local completion until the load of its key settles.
+// loaded is true when the value was written back meanwhile; when neither holds (the load failed),
+// the miss loads the value itself and continues as a loader.
+func awaitCacheLoad(state *scenarioState, rm *engine.RunManager, request *models.Request, simTime time.Time) (waiting, loaded bool) {
+	svc := state.services[request.ServiceName]
+	if svc == nil || svc.Behavior == nil || svc.Behavior.Cache == nil || svc.Behavior.Cache.KeySpace == nil {
+		return false, false
+	}
+	key := metadataString(request.Metadata, metaCacheKey)
+	state.cacheMu.Lock()
+	defer state.cacheMu.Unlock()
+	c := cacheStoreFor(state, svc)
+	if c.liveLoaders(rm, key) > 0 {
+		c.waiters[key] = append(c.waiters[key], request.ID)
+		return true, false
+	}
+	if e, ok := c.entries[key]; ok && (e.expiresAt.IsZero() || simTime.Before(e.expiresAt)) {
+		return false, true
+	}
+	delete(request.Metadata, metaCacheCoalesced)
+	c.addLoader(key, request)
+	metrics.RecordCacheLoadCount(state.collector, 1.0, simTime, labelsForRequestMetrics(request, request.ServiceName, request.Endpoint))
+	return false, false
+}
+
+// cacheMissCall returns the miss_call of the cache a loading request misses on.
+func cacheMissCall(state *scenarioState, request *models.Request) (interaction.ResolvedCall, bool) {
+	svc := state.services[request.ServiceName]
+	if svc == nil || svc.Behavior == nil || svc.Behavior.Cache == nil || svc.Behavior.Cache.MissCall == "" {
+		return interaction.ResolvedCall{}, false
+	}
+	if svc.Behavior.Cache.KeySpace != nil && !metadataBool(request.Metadata, metaCacheLoad) {
+		return interaction.ResolvedCall{}, false
+	}
+	to := svc.Behavior.Cache.MissCall
+	serviceID, path, err := interaction.ParseDownstreamTarget(to)
+	if err != nil {
+		return interaction.ResolvedCall{}, false
+	}
+	return interaction.ResolvedCall{ServiceID: serviceID, Path: path, Call: config.DownstreamCall{To: to}}, true
+}
+
+// settleCacheLoad ends the load of a finalized or abandoned loader: a completed load writes the
+// value back and completes the misses waiting for it; a failed one fails them, unless another load
+// of the key is still in flight.
+func settleCacheLoad(state *scenarioState, eng *engine.Engine, request *models.Request, simTime time.Time) {
+	if !metadataBool(request.Metadata, metaCacheLoad) {
+		return
+	}
+	delete(request.Metadata, metaCacheLoad)
+	svc := state.services[request.ServiceName]
+	if svc == nil || svc.Behavior == nil || svc.Behavior.Cache == nil || svc.Behavior.Cache.KeySpace == nil {
+		return
+	}
+	rm := eng.GetRunManager()
+	key := metadataString(request.Metadata, metaCacheKey)
+	labels := labelsForRequestMetrics(request, request.ServiceName, request.Endpoint)
+	loaded := request.Status == models.RequestStatusCompleted
+	state.cacheMu.Lock()
+	c := cacheStoreFor(state, svc)
+	delete(c.loaders[key], request.ID)
+	if loaded {
+		if evicted := c.put(key, simTime); evicted > 0 {
+			metrics.RecordCacheEvictionCount(state.collector, float64(evicted), simTime, labels)
+		}
+		metrics.RecordCacheEntries(state.collector, float64(len(c.entries)), simTime, metrics.CreateServiceLabels(svc.ID))
+	}
+	var waiters []string
+	if loaded || c.liveLoaders(rm, key) == 0 {
+		waiters = c.waiters[key]
+		delete(c.waiters, key)
+	}
+	if len(c.loaders[key]) == 0 {
+		delete(c.loaders, key)
+	}
+	state.cacheMu.Unlock()
+
+	for _, id := range waiters {
+		w, ok := rm.GetRequest(id)
+		if !ok || w.Status == models.RequestStatusCompleted || w.Status == models.RequestStatusFailed {
+			continue
+		}
+		wl := labelsForRequestMetrics(w, w.ServiceName, w.Endpoint)
+		if loaded {
+			finalizeRequestCompletion(state, eng, rm, w, simTime, wl)
+		} else {
+			finalizeRequestFailure(state, eng, rm, w, simTime, wl, metrics.ReasonDownstreamFailure)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package simd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+// cacheTestScenario calls cache:/get from api:/in; misses load the value from db:/query, which
+// takes dbMs of CPU.
+func cacheTestScenario(c *config.CacheBehavior, dbMs float64) *config.Scenario {
+	c.HitLatencyMs = config.LatencySpec{Mean: 1}
+	c.MissLatencyMs = config.LatencySpec{Mean: 1}
+	c.MissCall = "db:/query"
+	return &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 16}},
+		Services: []config.Service{
+			{ID: "api", Replicas: 1, Model: "cpu", Endpoints: []config.Endpoint{{
+				Path: "/in", MeanCPUMs: 1, DefaultMemoryMB: 16,
+				Downstream: []config.DownstreamCall{{To: "cache:/get"}},
+			}}},
+			{ID: "cache", Kind: "cache", Replicas: 1, Model: "cpu", Behavior: &config.ServiceBehavior{Cache: c},
+				Endpoints: []config.Endpoint{{Path: "/get", MeanCPUMs: 1, DefaultMemoryMB: 16}}},
+			{ID: "db", Replicas: 4, Model: "cpu", Endpoints: []config.Endpoint{{Path: "/query", MeanCPUMs: dbMs, DefaultMemoryMB: 16}}},
+		},
+		Workload: []config.WorkloadPattern{{From: "client", To: "api:/in", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 100}}},
+	}
+}
+
+// runCache sends n requests to api:/in, every gap, and checks they all complete.
+func runCache(t *testing.T, scenario *config.Scenario, n int, gap time.Duration) *metrics.Collector {
+	t.Helper()
+	collector := runCacheRequests(t, scenario, n, gap)
+	if f := collector.SumMetric(metrics.MetricIngressLogicalFailure); f != 0 {
+		t.Fatalf("expected no root request to fail, got %v", f)
+	}
+	return collector
+}
+
+// runCacheRequests sends n requests to api:/in, every gap, and checks they all finish.
+func runCacheRequests(t *testing.T, scenario *config.Scenario, n int, gap time.Duration) *metrics.Collector {
+	t.Helper()
+	r := newTestRun(t, scenario, 11)
+	r.scheduleArrivals("api", "/in", every(gap, n)...)
+	r.run(t, time.Duration(n)*gap+5*time.Second)
+	roots := r.collector.GetMetricAggregation(metrics.MetricRootRequestLatency)
+	if roots == nil || roots.Count != int64(n) {
+		t.Fatalf("expected %d root requests to finish, got %+v", n, roots)
+	}
+	return r.collector
+}
+
+func cacheHitRatio(collector *metrics.Collector) float64 {
+	hits := collector.SumMetric(metrics.MetricCacheHitCount)
+	return hits / (hits + collector.SumMetric(metrics.MetricCacheMissCount))
+}
+
+func TestCacheKeySpaceHitRateGrowsWithCapacity(t *testing.T) {
+	const n = 2000
+	ratios := map[int]float64{}
+	for _, capacity := range []int{10, 200} {
+		scenario := cacheTestScenario(&config.CacheBehavior{KeySpace: &config.CacheKeySpace{Keys: 1000, CapacityKeys: capacity}}, 2)
+		collector := runCache(t, scenario, n, 5*time.Millisecond)
+		ratios[capacity] = cacheHitRatio(collector)
+
+		// Every miss loads its key from the database once.
+		loads := collector.SumMetric(metrics.MetricCacheLoadCount)
+		if db := collector.SumMetricWhere(metrics.MetricRequestCount, "service", "db"); db != loads || loads != collector.SumMetric(metrics.MetricCacheMissCount) {
+			t.Fatalf("capacity %d: expected one db query per miss, got %v queries for %v loads", capacity, db, loads)
+		}
+		if agg := collector.GetMetricAggregation(metrics.MetricCacheEntries); agg == nil || agg.Max != float64(capacity) {
+			t.Fatalf("capacity %d: expected the cache to fill up, got %+v", capacity, agg)
+		}
+		if ev := collector.SumMetric(metrics.MetricCacheEvictionCount); ev <= 0 {
+			t.Fatalf("capacity %d: expected evictions, got %v", capacity, ev)
+		}
+	}
+	if ratios[10] < 0.2 || ratios[200] < ratios[10]+0.2 {
+		t.Fatalf("expected a larger cache to hit more often, got %v", ratios)
+	}
+}
+
+func TestCacheKeySpaceTTLExpiryCausesStampede(t *testing.T) {
+	// One hot key, read every millisecond; it expires every 100ms while the reload takes 20ms.
+	scenario := cacheTestScenario(&config.CacheBehavior{KeySpace: &config.CacheKeySpace{Keys: 1, TTLMs: 100}}, 20)
+	collector := runCache(t, scenario, 1000, time.Millisecond)
+	expired := collector.SumMetric(metrics.MetricCacheExpiredCount)
+	if expired < 3 {
+		t.Fatalf("expected the hot key to expire repeatedly, got %v", expired)
+	}
+	if s := collector.SumMetric(metrics.MetricCacheStampedeCount); s < 10*expired {
+		t.Fatalf("expected reads during each reload to miss and load again, got %v stampede misses for %v expiries", s, expired)
+	}
+}
+
+func TestCacheCoalesceMissesLoadsOnce(t *testing.T) {
+	spec := func(coalesce bool) *config.CacheBehavior {
+		return &config.CacheBehavior{KeySpace: &config.CacheKeySpace{Keys: 1}, CoalesceMisses: coalesce}
+	}
+	plain := runCache(t, cacheTestScenario(spec(false), 50), 40, time.Millisecond)
+	if loads := plain.SumMetric(metrics.MetricCacheLoadCount); loads < 30 {
+		t.Fatalf("expected a stampede of loads without coalescing, got %v", loads)
+	}
+
+	collector := runCache(t, cacheTestScenario(spec(true), 50), 40, time.Millisecond)
+	if loads := collector.SumMetric(metrics.MetricCacheLoadCount); loads != 1 {
+		t.Fatalf("expected coalesced misses to share one load, got %v", loads)
+	}
+	if db := collector.SumMetricWhere(metrics.MetricRequestCount, "service", "db"); db != 1 {
+		t.Fatalf("expected one db query, got %v", db)
+	}
+	if c := collector.SumMetric(metrics.MetricCacheCoalescedCount); c < 30 {
+		t.Fatalf("expected the misses during the load to be coalesced, got %v", c)
+	}
+	// Coalesced misses answer when the load does, about 50ms after the first request.
+	if agg := collector.GetMetricAggregation(metrics.MetricRootRequestLatency); agg.Max > 70 {
+		t.Fatalf("expected coalesced misses to complete with the load, got max latency %v", agg.Max)
+	}
+}
+
+func TestCacheCoalescedMissesFailWithTheLoad(t *testing.T) {
+	scenario := cacheTestScenario(&config.CacheBehavior{KeySpace: &config.CacheKeySpace{Keys: 1}, CoalesceMisses: true}, 50)
+	scenario.Services[2].Behavior = &config.ServiceBehavior{FailureRate: 1}
+	collector := runCacheRequests(t, scenario, 20, time.Millisecond)
+	if f := collector.SumMetric(metrics.MetricIngressLogicalFailure); f != 20 {
+		t.Fatalf("expected every root request to fail with the load, got %v", f)
+	}
+	if e := collector.SumMetric(metrics.MetricCacheEntries); e != 0 {
+		t.Fatalf("expected failed loads not to be written back, got %v entries", e)
+	}
+}
+
+func TestCacheStoreEviction(t *testing.T) {
+	now := time.Unix(0, 0)
+	for _, tc := range []struct {
+		eviction string
+		kept     string
+	}{
+		{config.CacheEvictionLRU, "k2"},
+		{config.CacheEvictionLFU, "k1"},
+	} {
+		c := newCacheStore(&config.CacheKeySpace{Keys: 3, CapacityKeys: 2, Eviction: tc.eviction})
+		c.put("k1", now)
+		c.get("k1", now)
+		c.get("k1", now)
+		c.put("k2", now)
+		if evicted := c.put("k3", now); evicted != 1 {
+			t.Fatalf("%s: expected one eviction, got %d", tc.eviction, evicted)
+		}
+		if _, ok := c.entries[tc.kept]; !ok || len(c.entries) != 2 {
+			t.Fatalf("%s: expected %s to be kept, got %v", tc.eviction, tc.kept, c.entries)
+		}
+	}
+
+	c := newCacheStore(&config.CacheKeySpace{Keys: 1, TTLMs: 10})
+	c.put("k1", now)
+	if hit, _ := c.get("k1", now.Add(9*time.Millisecond)); !hit {
+		t.Fatal("expected a hit before the TTL")
+	}
+	if hit, expired := c.get("k1", now.Add(10*time.Millisecond)); hit || !expired || len(c.entries) != 0 {
+		t.Fatalf("expected the entry to expire, got hit=%v expired=%v", hit, expired)
+	}
+}
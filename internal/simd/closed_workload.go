@@ -0,0 +1,144 @@
+package simd
+
+import (
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+// Closed-workload keys: arrival event data carries the pattern and user of a closed arrival, and the
+// root request keeps them in metadata until its user is released.
+const (
+	metaClosedPattern = "closed_workload_pattern"
+	metaClosedUser    = "closed_workload_user"
+	// metaClosedFailed marks a user return whose root request failed.
+	metaClosedFailed = "closed_workload_failed"
+	// metaWorkloadReleased marks a root request already handed back to its closed user or flow session.
+	metaWorkloadReleased = "workload_request_released"
+)
+
+// closedFailureBackoff is the least time a virtual user waits after a failed request. A request
+// rejected at arrival releases its user at the same sim time, so without it a user with no think
+// time would re-issue at that instant for ever.
+const closedFailureBackoff = 100 * time.Millisecond
+
+// closedMinThinkTime is the least time a virtual user waits between requests. A user with no think
+// time whose requests finish instantly would otherwise re-issue at one sim instant for ever.
+const closedMinThinkTime = time.Millisecond
+
+// workloadEventKeys are the arrival event data keys a root request keeps in its metadata.
+var workloadEventKeys = []string{metaClosedPattern, metaClosedUser, metaFlowID, metaFlowStep, metaFlowSessionStart}
+
+// adoptWorkloadEventKeys copies closed-user and flow-session keys from arrival event data into the
+// request; a flow's first step starts its session at the arrival, and the started flow is returned.
+func adoptWorkloadEventKeys(request *models.Request, data map[string]interface{}, simTime time.Time) (startedFlow string) {
+	for _, k := range workloadEventKeys {
+		if v, ok := data[k]; ok {
+			request.Metadata[k] = v
+		}
+	}
+	if flowID := metadataString(request.Metadata, metaFlowID); flowID != "" {
+		if _, ok := request.Metadata[metaFlowSessionStart]; !ok {
+			request.Metadata[metaFlowSessionStart] = simTime
+			return flowID
+		}
+	}
+	return ""
+}
+
+// releaseWorkloadRequest is called from every path that ends a root request (completion, failure,
+// admission rejection, start failure, drain eviction) and acts at most once per request. A flow
+// request moves its session on; once the session is over, or for a plain closed arrival, the virtual
+// user goes back to its closed workload pattern, which samples a think time and schedules the user's
+// next request.
+func releaseWorkloadRequest(state *scenarioState, eng *engine.Engine, request *models.Request, simTime time.Time) {
+	if request == nil || request.ParentID != "" || metadataBool(request.Metadata, metaWorkloadReleased) {
+		return
+	}
+	flowID := metadataString(request.Metadata, metaFlowID)
+	key := metadataString(request.Metadata, metaClosedPattern)
+	if flowID == "" && key == "" {
+		return
+	}
+	request.Metadata[metaWorkloadReleased] = true
+	if flowID != "" && advanceFlowSession(state, eng, request, flowID, simTime) {
+		return
+	}
+	if key == "" {
+		return
+	}
+	eng.ScheduleAt(engine.EventTypeWorkloadUserReturn, simTime, nil, "", map[string]interface{}{
+		metaClosedPattern: key,
+		metaClosedUser:    metadataInt(request.Metadata, metaClosedUser),
+		metaClosedFailed:  request.Status == models.RequestStatusFailed,
+	})
+}
+
+// startClosedUsersLocked schedules the first request of every virtual user of a closed pattern,
+// spreading user starts evenly over ramp_up_seconds. Caller holds ps.mu or owns ps.
+func (ws *WorkloadState) startClosedUsersLocked(ps *WorkloadPatternState, startTime time.Time) {
+	arrival := ps.Pattern.Arrival
+	ramp := time.Duration(arrival.RampUpSeconds * float64(time.Second))
+	for user := 0; user < arrival.Users; user++ {
+		at := startTime.Add(ramp * time.Duration(user) / time.Duration(arrival.Users))
+		ws.scheduleClosedArrivalLocked(ps, user, at)
+	}
+}
+
+// scheduleClosedArrivalLocked schedules a request of one virtual user at `at` (dropped at or past the end).
+func (ws *WorkloadState) scheduleClosedArrivalLocked(ps *WorkloadPatternState, user int, at time.Time) {
+	if !at.Before(ws.endTime) {
+		return
+	}
+	serviceID, data := workloadArrivalEventData(ps, ws.generator)
+	data[metaClosedPattern] = ps.key
+	data[metaClosedUser] = user
+	ws.engine.ScheduleAt(engine.EventTypeRequestArrival, at, nil, serviceID, data)
+	if at.After(ps.LastEventTime) {
+		ps.LastEventTime = at
+	}
+}
+
+// handleUserReturn thinks for a returned virtual user, for at least closedMinThinkTime (closedFailureBackoff
+// after a failed request), and schedules its next request.
+func (ws *WorkloadState) handleUserReturn() engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		select {
+		case <-ws.ctx.Done():
+			return nil
+		default:
+		}
+		key, _ := evt.Data[metaClosedPattern].(string)
+		user, _ := evt.Data[metaClosedUser].(int)
+		ws.mu.RLock()
+		ps, ok := ws.patterns[key]
+		ws.mu.RUnlock()
+		if !ok {
+			return nil
+		}
+		ps.mu.Lock()
+		defer ps.mu.Unlock()
+		// A pattern switched to an open arrival type mid-run retires its users.
+		if !ps.Active || ps.Pattern.Arrival.Type != "closed" {
+			return nil
+		}
+		think := max(sampleThinkTime(ws.generator, ps.Pattern.Arrival.ThinkTimeMs), closedMinThinkTime)
+		if failed, _ := evt.Data[metaClosedFailed].(bool); failed {
+			think = max(think, closedFailureBackoff)
+		}
+		ws.scheduleClosedArrivalLocked(ps, user, eng.GetSimTime().Add(think))
+		return nil
+	}
+}
+
+// sampleThinkTime draws a think time in ms from spec's distribution.
+func sampleThinkTime(rng *utils.RandSource, spec config.LatencySpec) time.Duration {
+	if !spec.IsSet() {
+		return 0
+	}
+	ms := spec.Sample(rng)
+	return time.Duration(ms * float64(time.Millisecond))
+}
@@ -0,0 +1,147 @@
+package simd
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+const closedScenarioYAML = `
+hosts:
+  - id: host-1
+    cores: 1
+services:
+  - id: api
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /work
+        mean_cpu_ms: 50
+        cpu_sigma_ms: 0
+workload:
+  - from: users
+    to: api:/work
+    arrival:
+      type: closed
+      users: 4
+      think_time_ms: {mean: 150, sigma: 0}
+`
+
+func TestClosedWorkloadSelfThrottles(t *testing.T) {
+	scenario, err := config.ParseScenarioYAMLString(closedScenarioYAML)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rm, err := RunScenarioForMetrics(scenario, 20*time.Second, 3, false)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	// 4 users at 150ms think share one core at 50ms per request. The demand (4 per 200ms cycle)
+	// saturates the core, so throughput settles at the service rate of 20 rps instead of queueing without bound.
+	if math.Abs(rm.ThroughputRPS-20) > 2 {
+		t.Fatalf("expected closed-loop throughput near 20 rps, got %f (%d requests)", rm.ThroughputRPS, rm.TotalRequests)
+	}
+	// With at most 4 requests in the system, no request waits behind more than 3 others.
+	if rm.LatencyP99 > 4*50+5 {
+		t.Fatalf("expected latency bounded by the user count, got p99 %f ms", rm.LatencyP99)
+	}
+
+	heavier, err := config.ParseScenarioYAMLString(strings.Replace(closedScenarioYAML, "think_time_ms: {mean: 150, sigma: 0}", "think_time_ms: {mean: 950, sigma: 0}", 1))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	light, err := RunScenarioForMetrics(heavier, 20*time.Second, 3, false)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	// Below saturation each user cycles every think+service = 1s.
+	if math.Abs(light.ThroughputRPS-4) > 0.5 {
+		t.Fatalf("expected about 4 rps with 1s user cycles, got %f", light.ThroughputRPS)
+	}
+}
+
+func TestClosedWorkloadRampUpAndFailedRequests(t *testing.T) {
+	eng := engine.NewEngine("closed-ramp")
+	start := eng.GetSimTime()
+	end := start.Add(10 * time.Second)
+	scenario := &config.Scenario{
+		Hosts:    []config.Host{{ID: "host-1", Cores: 2}},
+		Services: []config.Service{{ID: "svc1", Endpoints: []config.Endpoint{{Path: "/test"}}}},
+		Workload: []config.WorkloadPattern{{
+			From:    "users",
+			To:      "svc1:/test",
+			Arrival: config.ArrivalSpec{Type: "closed", Users: 5, RampUpSeconds: 5, ThinkTimeMs: config.LatencySpec{Mean: 1000}},
+		}},
+	}
+	ws := NewWorkloadState("closed-ramp", eng, end, 1)
+	if err := ws.Start(scenario, start, false); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	arrivals := map[int][]time.Duration{}
+	// Every request fails at arrival; the user must still come back after its think time.
+	eng.RegisterHandler(engine.EventTypeRequestArrival, func(e *engine.Engine, evt *engine.Event) error {
+		user := evt.Data[metaClosedUser].(int)
+		arrivals[user] = append(arrivals[user], e.GetSimTime().Sub(start))
+		req := &models.Request{Metadata: map[string]interface{}{
+			metaClosedPattern: evt.Data[metaClosedPattern],
+			metaClosedUser:    user,
+		}}
+		releaseWorkloadRequest(&scenarioState{}, e, req, e.GetSimTime())
+		releaseWorkloadRequest(&scenarioState{}, e, req, e.GetSimTime()) // released once only
+		return nil
+	})
+	if err := eng.Run(10 * time.Second); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(arrivals) != 5 {
+		t.Fatalf("expected 5 users, got %d", len(arrivals))
+	}
+	for user, times := range arrivals {
+		if times[0] != time.Duration(user)*time.Second {
+			t.Fatalf("user %d: expected ramp-up start at %ds, got %v", user, user, times[0])
+		}
+		if want := 10 - user; len(times) != want {
+			t.Fatalf("user %d: expected %d requests one second apart, got %v", user, want, times)
+		}
+	}
+	if err := ws.UpdateRate(patternKey("users", "svc1:/test"), 3); err == nil {
+		t.Fatal("expected UpdateRate on a closed workload to fail")
+	}
+}
+
+func TestClosedWorkloadBacksOffAfterRejectedRequests(t *testing.T) {
+	// Without think time, a user whose requests are rejected at arrival must not re-issue at the same instant.
+	scenario := &config.Scenario{
+		Hosts:    []config.Host{{ID: "host-1", Cores: 1}},
+		Services: []config.Service{{ID: "api", Replicas: 1, Model: "cpu", Endpoints: []config.Endpoint{{Path: "/work", MeanCPUMs: 5}}}},
+		Workload: []config.WorkloadPattern{{From: "users", To: "api:/work", Arrival: config.ArrivalSpec{Type: "closed", Users: 2}}},
+		Policies: &config.Policies{RateLimiting: &config.RateLimitingPolicy{Enabled: true, RateLimitPerSecond: 1}},
+	}
+	collector := runResilienceScenario(t, scenario, 2*time.Second)
+	rejected := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonRateLimited)
+	// Two users retry every 100ms for 2s: about 40 attempts, of which the limiter admits a few.
+	if rejected < 30 || rejected > 42 {
+		t.Fatalf("expected rejected users to back off %v between attempts, got %v rejections", closedFailureBackoff, rejected)
+	}
+}
+
+func TestClosedWorkloadWithoutThinkTimeAdvancesSimTime(t *testing.T) {
+	// Zero think time and zero-latency successes must not re-issue at the same instant.
+	scenario := &config.Scenario{
+		Hosts:    []config.Host{{ID: "host-1", Cores: 1}},
+		Services: []config.Service{{ID: "api", Replicas: 1, Model: "cpu", Endpoints: []config.Endpoint{{Path: "/noop"}}}},
+		Workload: []config.WorkloadPattern{{From: "users", To: "api:/noop", Arrival: config.ArrivalSpec{Type: "closed", Users: 2}}},
+	}
+	collector := runResilienceScenario(t, scenario, time.Second)
+	requests := collector.SumMetricWhere(metrics.MetricRequestCount, "", "")
+	// Two users issuing at most one request per closedMinThinkTime for 1s.
+	if requests == 0 || requests > 2*1000 {
+		t.Fatalf("expected users to wait %v between instant requests, got %v requests", closedMinThinkTime, requests)
+	}
+}
@@ -0,0 +1,109 @@
+package simd
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+const conditionalRoutingYAML = `
+hosts:
+  - id: host-1
+    cores: 8
+services:
+  - id: api
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /checkout
+        mean_cpu_ms: 2
+        cpu_sigma_ms: 0
+        downstream:
+          - to: payments:/charge
+  - id: payments
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /charge
+        mean_cpu_ms: 2
+        cpu_sigma_ms: 0
+        downstream:
+          - to: fraud:/check
+            when:
+              - {key: tier, equals: premium}
+          - to: eu-ledger:/write
+            when:
+              - {key: region, regex: "^eu-"}
+  - id: fraud
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /check
+        mean_cpu_ms: 2
+        cpu_sigma_ms: 0
+  - id: eu-ledger
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /write
+        mean_cpu_ms: 2
+        cpu_sigma_ms: 0
+workload:
+  - from: shoppers
+    to: api:/checkout
+    metadata: {region: us-east-1}
+    weighted_metadata:
+      tier: {premium: 1, free: 3}
+    arrival:
+      type: poisson
+      rate_rps: 40
+`
+
+func TestConditionalDownstreamFollowsWorkloadMetadata(t *testing.T) {
+	scenario, err := config.ParseScenarioYAMLString(conditionalRoutingYAML)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rm, err := RunScenarioForMetrics(scenario, 30*time.Second, 7, false)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	api, fraud := rm.ServiceMetrics["api"], rm.ServiceMetrics["fraud"]
+	if api == nil || api.RequestCount == 0 {
+		t.Fatalf("expected api traffic, got %+v", rm.ServiceMetrics)
+	}
+	// The tier is drawn at the edge and still visible two hops down.
+	if fraud == nil {
+		t.Fatalf("expected premium checkouts to reach fraud, got %+v", rm.ServiceMetrics)
+	}
+	if share := float64(fraud.RequestCount) / float64(api.RequestCount); math.Abs(share-0.25) > 0.05 {
+		t.Fatalf("expected a quarter of checkouts to call fraud, got %v", share)
+	}
+	if ledger := rm.ServiceMetrics["eu-ledger"]; ledger != nil && ledger.RequestCount > 0 {
+		t.Fatalf("expected no us-east-1 request to call the eu ledger, got %d", ledger.RequestCount)
+	}
+}
+
+func TestFlowWeightedMetadataDrawnPerSession(t *testing.T) {
+	f := &config.Flow{
+		ID:               "checkout",
+		WeightedMetadata: map[string]map[string]float64{"tier": {"premium": 1, "free": 1}},
+		Steps:            []config.FlowStep{{To: "web:/browse"}, {To: "cart:/pay", Metadata: map[string]string{"cart": "yes"}}},
+	}
+	drawn := config.DrawWeightedMetadata(f.WeightedMetadata, utils.NewRandSource(3))
+	_, data := flowStepEventData(f, 0, "checkout-1", drawn)
+	md := data["metadata"].(map[string]interface{})
+	if md["tier"] != drawn["tier"] {
+		t.Fatalf("expected the first step to carry the drawn tier, got %v", md)
+	}
+	request := &models.Request{Metadata: md}
+	_, data = flowStepEventData(f, 1, "checkout-1", sessionDrawnMetadata(f, request))
+	next := data["metadata"].(map[string]interface{})
+	if next["tier"] != drawn["tier"] || next["cart"] != "yes" {
+		t.Fatalf("expected the next step to keep the session's tier, got %v", next)
+	}
+}
@@ -0,0 +1,59 @@
+package simd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/policy"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+func TestCPULimitBurstsAndThrottles(t *testing.T) {
+	scenario := &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 4, MemoryGB: 16}},
+		Services: []config.Service{{
+			ID: "api", Replicas: 1, CPUCores: 0.5, CPULimit: 1, MemoryMB: 512,
+			Endpoints: []config.Endpoint{{Path: "/work", MeanCPUMs: 150}},
+		}},
+	}
+	eng := engine.NewEngineWithSimStart("cpu-limit", time.Unix(1000, 0)) // on a CFS period boundary
+	rm := resource.NewManager()
+	if err := rm.InitializeFromScenario(scenario); err != nil {
+		t.Fatalf("init rm: %v", err)
+	}
+	collector := metrics.NewCollector()
+	collector.Start()
+	state, err := newScenarioState(scenario, rm, collector, policy.NewPolicyManager(nil), 5)
+	if err != nil {
+		t.Fatalf("scenario state: %v", err)
+	}
+	RegisterHandlers(eng, state)
+	eng.ScheduleAt(engine.EventTypeRequestArrival, eng.GetSimTime(), nil, "api", map[string]interface{}{
+		"service_id":    "api",
+		"endpoint_path": "/work",
+	})
+	if err := eng.Run(5 * time.Second); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	// On an idle host the replica runs on all four cores: it uses the 100ms quota of its one-core
+	// limit in 25ms, waits 75ms for the next period and does the last 50ms in 12.5ms. Without the
+	// burst, 150ms of work on half a core would take 300ms.
+	if got := collector.SumMetric(metrics.MetricCPUThrottledMs); got != 75 {
+		t.Fatalf("expected 75ms throttled, got %v", got)
+	}
+	if got := collector.SumMetric(metrics.MetricCPUThrottledPeriodCount); got != 1 {
+		t.Fatalf("expected one throttled period, got %v", got)
+	}
+	roots := collector.GetMetricAggregation(metrics.MetricRootRequestLatency)
+	if roots == nil || roots.Count != 1 || roots.Max < 112 || roots.Max > 113 {
+		t.Fatalf("expected a 112.5ms request, got %+v", roots)
+	}
+	rmMetrics := metrics.ConvertToRunMetrics(collector, nil, nil)
+	if rmMetrics.CPUThrottledPeriodsTotal != 1 || len(rmMetrics.InstanceCPUThrottleStats) != 1 || rmMetrics.InstanceCPUThrottleStats[0].ServiceName != "api" {
+		t.Fatalf("unexpected throttle rollups: %+v", rmMetrics.InstanceCPUThrottleStats)
+	}
+}
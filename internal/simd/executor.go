@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -64,7 +65,11 @@ type RunExecutor struct {
 	onlineLeaseDeadline    map[string]time.Time         // wall-clock heartbeat deadline per run
 	// runScenarios holds the parsed scenario per active run for configuration/metadata export.
 	runScenarios map[string]*config.Scenario
-	progress     map[string]*RunProgress
+	// runStates holds the handler state per active run, for faults injected through the API.
+	runStates map[string]*scenarioState
+	progress  map[string]*RunProgress
+	// running counts run goroutines started by Start, so Shutdown can wait for them.
+	running sync.WaitGroup
 }
 
 type RunProgress struct {
@@ -139,6 +144,7 @@ func NewRunExecutor(store *RunStore, callbackWhitelist []string) *RunExecutor {
 		onlineCompletionReason: make(map[string]string),
 		onlineLeaseDeadline:    make(map[string]time.Time),
 		runScenarios:           make(map[string]*config.Scenario),
+		runStates:              make(map[string]*scenarioState),
 		progress:               make(map[string]*RunProgress),
 	}
 }
@@ -194,18 +200,45 @@ func (e *RunExecutor) Start(runID string) (*RunRecord, error) {
 	// Optimization runs can use either the batch optimizer (multi-run) or the
 	// online controller mode, which adjusts configuration within a single long-
 	// running simulation.
+	run := e.runSimulation
 	if opt := updated.Input.Optimization; opt != nil {
 		if opt.Online {
-			go e.runOnlineOptimization(ctx, runID)
+			run = e.runOnlineOptimization
 		} else {
-			go e.runOptimization(ctx, runID)
+			run = e.runOptimization
 		}
-	} else {
-		go e.runSimulation(ctx, runID)
 	}
+	e.running.Add(1)
+	go func() {
+		defer e.running.Done()
+		run(ctx, runID)
+	}()
 	return updated, nil
 }
 
+// Shutdown cancels every active run and waits for their goroutines to return, so that a final run
+// store flush sees each run's last state. It returns ctx.Err() if ctx ends first. Runs still RUNNING
+// in the store are marked interrupted when simd next loads it.
+func (e *RunExecutor) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	for _, cancel := range e.cancels {
+		cancel()
+	}
+	e.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		e.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // Stop requests cancellation for a running run and marks it stopped.
 func (e *RunExecutor) Stop(runID string) (*RunRecord, error) {
 	if runID == "" {
@@ -250,6 +283,7 @@ func (e *RunExecutor) cleanup(runID string) {
 	delete(e.resourceManagers, runID)
 	delete(e.policyManagers, runID)
 	delete(e.runScenarios, runID)
+	delete(e.runStates, runID)
 	delete(e.progress, runID)
 	delete(e.onlineCompletionReason, runID)
 	delete(e.onlineLeaseDeadline, runID)
@@ -729,8 +763,10 @@ func (e *RunExecutor) runOnlineOptimization(ctx context.Context, runID string) {
 	var policies *policy.Manager
 	if scenario.Policies != nil {
 		configPolicies := &config.Policies{
-			Autoscaling: scenario.Policies.Autoscaling,
-			Retries:     scenario.Policies.Retries,
+			Autoscaling:    scenario.Policies.Autoscaling,
+			Retries:        scenario.Policies.Retries,
+			CircuitBreaker: scenario.Policies.CircuitBreaker,
+			RateLimiting:   scenario.Policies.RateLimiting,
 		}
 		policies = policy.NewPolicyManager(configPolicies)
 	} else {
@@ -738,6 +774,7 @@ func (e *RunExecutor) runOnlineOptimization(ctx context.Context, runID string) {
 	}
 
 	runSeed := effectiveRunSeed(rec.Input)
+	rm.SetRoutingSeed(runSeed + 3)
 
 	// Create scenario state and register handlers
 	state, err := newScenarioState(scenario, rm, metricsCollector, policies, runSeed)
@@ -751,12 +788,25 @@ func (e *RunExecutor) runOnlineOptimization(ctx context.Context, runID string) {
 		return
 	}
 	RegisterHandlers(eng, state)
+	state.SetScalingDecisionHook(func(d ScalingDecision) {
+		if err := e.store.AppendScalingDecision(runID, d); err != nil {
+			logger.Debug("failed to record scaling decision", "run_id", runID, "error", err)
+		}
+	})
+	state.SetFaultEventHook(func(ev FaultEvent) {
+		if err := e.store.AppendFaultEvent(runID, ev); err != nil {
+			logger.Debug("failed to record fault event", "run_id", runID, "error", err)
+		}
+	})
 
 	// Initialize workload state for continuous event generation
 	startTime := eng.GetSimTime()
 	endTime := startTime.Add(onlineRunDuration)
 	state.SetSimEndTime(endTime)
 	ScheduleDrainSweepKickoff(eng, startTime)
+	ScheduleAutoscaleSyncKickoff(eng, state, startTime)
+	ScheduleHostFailureKickoff(eng, state, startTime)
+	ScheduleFaultKickoff(eng, state, startTime)
 	workloadState := NewWorkloadState(runID, eng, endTime, runSeed)
 	if err := workloadState.Start(scenario, startTime, true); err != nil {
 		logger.Error("failed to start workload state", "run_id", runID, "error", err)
@@ -774,6 +824,7 @@ func (e *RunExecutor) runOnlineOptimization(ctx context.Context, runID string) {
 	e.resourceManagers[runID] = rm
 	e.policyManagers[runID] = policies
 	e.runScenarios[runID] = scenario
+	e.runStates[runID] = state
 	e.mu.Unlock()
 
 	if opt.GetLeaseTtlMs() > 0 {
@@ -919,6 +970,7 @@ func (e *RunExecutor) runSimulation(ctx context.Context, runID string) {
 	// Create engine
 	eng := engine.NewEngine(runID)
 	eng.SetRuntimeLimits(e.limits.toEngineRuntimeLimits())
+	runSeed := effectiveRunSeed(rec.Input)
 	eng.GetRunManager().SetMaxRequestsTracked(e.limits.MaxRequestsTracked, func(currentCount, max int) {
 		eng.TriggerLimitExceeded(&engine.LimitExceededError{
 			Limit: "max_requests_tracked",
@@ -957,6 +1009,7 @@ func (e *RunExecutor) runSimulation(ctx context.Context, runID string) {
 		}
 		return
 	}
+	rm.SetRoutingSeed(runSeed + 3)
 
 	// Initialize metrics collector
 	metricsCollector := metrics.NewCollector()
@@ -987,16 +1040,16 @@ func (e *RunExecutor) runSimulation(ctx context.Context, runID string) {
 	if scenario.Policies != nil {
 		// Convert scenario.Policies to config.Policies for PolicyManager
 		configPolicies := &config.Policies{
-			Autoscaling: scenario.Policies.Autoscaling,
-			Retries:     scenario.Policies.Retries,
+			Autoscaling:    scenario.Policies.Autoscaling,
+			Retries:        scenario.Policies.Retries,
+			CircuitBreaker: scenario.Policies.CircuitBreaker,
+			RateLimiting:   scenario.Policies.RateLimiting,
 		}
 		policies = policy.NewPolicyManager(configPolicies)
 	} else {
 		policies = policy.NewPolicyManager(nil)
 	}
 
-	runSeed := effectiveRunSeed(rec.Input)
-
 	// Create scenario state and register handlers
 	state, err := newScenarioState(scenario, rm, metricsCollector, policies, runSeed)
 	if err != nil {
@@ -1009,12 +1062,25 @@ func (e *RunExecutor) runSimulation(ctx context.Context, runID string) {
 		return
 	}
 	RegisterHandlers(eng, state)
+	state.SetScalingDecisionHook(func(d ScalingDecision) {
+		if err := e.store.AppendScalingDecision(runID, d); err != nil {
+			logger.Debug("failed to record scaling decision", "run_id", runID, "error", err)
+		}
+	})
+	state.SetFaultEventHook(func(ev FaultEvent) {
+		if err := e.store.AppendFaultEvent(runID, ev); err != nil {
+			logger.Debug("failed to record fault event", "run_id", runID, "error", err)
+		}
+	})
 
 	// Initialize workload state for continuous event generation
 	startTime := eng.GetSimTime()
 	endTime := startTime.Add(duration)
 	state.SetSimEndTime(endTime)
 	ScheduleDrainSweepKickoff(eng, startTime)
+	ScheduleAutoscaleSyncKickoff(eng, state, startTime)
+	ScheduleHostFailureKickoff(eng, state, startTime)
+	ScheduleFaultKickoff(eng, state, startTime)
 	workloadState := NewWorkloadState(runID, eng, endTime, runSeed)
 	if err := workloadState.Start(scenario, startTime, rec.Input.RealTimeMode); err != nil {
 		logger.Error("failed to start workload state", "run_id", runID, "error", err)
@@ -1032,6 +1098,7 @@ func (e *RunExecutor) runSimulation(ctx context.Context, runID string) {
 	e.resourceManagers[runID] = rm
 	e.policyManagers[runID] = policies
 	e.runScenarios[runID] = scenario
+	e.runStates[runID] = state
 	e.mu.Unlock()
 
 	// Run simulation
@@ -1062,6 +1129,25 @@ func (e *RunExecutor) runSimulation(ctx context.Context, runID string) {
 			}
 		}
 	}()
+	// Further replications run alongside this simulation, which is replication 0; they are summarized
+	// once it completes.
+	var replications *replicationRunner
+	var extraReplications chan replicationBatch
+	if opts := replicationOptionsFromInput(rec.Input, e.limits.MaxReplications); opts.enabled() && !rec.Input.RealTimeMode {
+		repLimits := e.limits.toEngineRuntimeLimits()
+		replications = &replicationRunner{
+			scenarioYAML: rec.Input.ScenarioYaml,
+			duration:     duration,
+			seed:         runSeed,
+			opts:         opts.normalized(),
+			limits:       &repLimits,
+		}
+		extraReplications = make(chan replicationBatch, 1)
+		go func() {
+			runs, err := replications.runBatch(ctx, 1, replications.opts.Replications)
+			extraReplications <- replicationBatch{runs: runs, err: err}
+		}()
+	}
 	if err := eng.Run(duration); err != nil {
 		// Check if it was cancelled
 		if ctx.Err() != nil {
@@ -1125,6 +1211,35 @@ func (e *RunExecutor) runSimulation(ctx context.Context, runID string) {
 		logger.Error("failed to set metrics", "run_id", runID, "error", err)
 	}
 
+	if replications != nil {
+		batch := <-extraReplications
+		err := batch.err
+		var summary *models.ReplicationSummary
+		if err == nil {
+			summary, _, err = replications.complete(ctx, append([]*models.RunMetrics{engineMetrics}, batch.runs...))
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				logger.Info("replications cancelled", "run_id", runID)
+				return
+			}
+			logger.Error("replications failed", "run_id", runID, "error", err)
+			if updated, setErr := e.store.SetStatus(runID, simulationv1.RunStatus_RUN_STATUS_FAILED, fmt.Sprintf("replications failed: %v", err)); setErr != nil {
+				logger.Error("failed to set failed status", "run_id", runID, "error", setErr)
+			} else {
+				e.sendNotificationIfConfigured(updated)
+			}
+			return
+		}
+		logger.Info("replications completed", "run_id", runID,
+			"replications", summary.Replications,
+			"max_relative_half_width", summary.MaxRelativeHalfWidth,
+			"precision_reached", summary.PrecisionReached)
+		if err := e.store.SetReplicationSummary(runID, summary); err != nil {
+			logger.Error("failed to set replication summary", "run_id", runID, "error", err)
+		}
+	}
+
 	// Mark as completed if still running
 	rec, ok = e.store.Get(runID)
 	if ok && rec.Run.Status == simulationv1.RunStatus_RUN_STATUS_RUNNING {
@@ -1590,7 +1705,7 @@ func (e *RunExecutor) runOnlineController(
 			if p95Guard && currentP95 > targetP95*1.05 && hostCount > 0 {
 				if hostCount < maxHosts && maxHostCPU >= hostCPUHighThreshold {
 					prevConfig, _ := e.GetRunConfiguration(runID)
-					if err := rm.ScaleOutHosts(hostCount + 1); err != nil {
+					if err := rm.ScaleOutHosts(hostCount+1, ""); err != nil {
 						logger.Error("online controller failed to scale out hosts",
 							"run_id", runID,
 							"current_hosts", hostCount,
@@ -1753,7 +1868,7 @@ func (e *RunExecutor) runOnlineController(
 			for i := range scenario.Services {
 				svc := &scenario.Services[i]
 				// Current replicas from resource manager.
-				currentReplicas := rm.ActiveReplicas(svc.ID)
+				currentReplicas := rm.ProvisionedReplicas(svc.ID)
 				if currentReplicas < 1 {
 					currentReplicas = 1
 				}
@@ -2114,11 +2229,35 @@ func convertMetricsToProto(engineMetrics *models.RunMetrics) *simulationv1.RunMe
 		ExternalLatencyMsMean:          engineMetrics.ExternalLatencyMsMean,
 		TopologyLatencyPenaltyMsTotal:  engineMetrics.TopologyLatencyPenaltyMsTotal,
 		TopologyLatencyPenaltyMsMean:   engineMetrics.TopologyLatencyPenaltyMsMean,
-	}
-
-	// Convert service metrics
+		HedgedCallsTotal:               engineMetrics.HedgedCallsTotal,
+		HedgeRequestsTotal:             engineMetrics.HedgeRequestsTotal,
+		HedgeRate:                      engineMetrics.HedgeRate,
+		HedgeWinsTotal:                 engineMetrics.HedgeWinsTotal,
+		HedgeCancelledTotal:            engineMetrics.HedgeCancelledTotal,
+		HedgeWastedCpuMsTotal:          engineMetrics.HedgeWastedCPUMsTotal,
+		HedgeLatencyGainMsMean:         engineMetrics.HedgeLatencyGainMsMean,
+		HedgeLatencyGainMsP95:          engineMetrics.HedgeLatencyGainMsP95,
+		OomKillsTotal:                  engineMetrics.OOMKillsTotal,
+		InstanceRestartsTotal:          engineMetrics.InstanceRestartsTotal,
+		HostFailuresTotal:              engineMetrics.HostFailuresTotal,
+		InstancesLostTotal:             engineMetrics.InstancesLostTotal,
+		InstancesRescheduledTotal:      engineMetrics.InstancesRescheduledTotal,
+		OutageRequests:                 engineMetrics.OutageRequests,
+		OutageFailedRequests:           engineMetrics.OutageFailedRequests,
+		OutageAvailability:             engineMetrics.OutageAvailability,
+		CpuThrottledMsTotal:            engineMetrics.CPUThrottledMsTotal,
+		CpuThrottledPeriodsTotal:       engineMetrics.CPUThrottledPeriodsTotal,
+	}
+
+	// Convert service and host metrics (ordered by name so equal runs produce equal messages)
 	if engineMetrics.ServiceMetrics != nil {
-		for serviceName, svcMetrics := range engineMetrics.ServiceMetrics {
+		serviceNames := make([]string, 0, len(engineMetrics.ServiceMetrics))
+		for serviceName := range engineMetrics.ServiceMetrics {
+			serviceNames = append(serviceNames, serviceName)
+		}
+		sort.Strings(serviceNames)
+		for _, serviceName := range serviceNames {
+			svcMetrics := engineMetrics.ServiceMetrics[serviceName]
 			// Safe conversion: ActiveReplicas is int, ensure it fits in int32
 			var activeReplicas int32
 			switch {
@@ -2174,7 +2313,13 @@ func convertMetricsToProto(engineMetrics *models.RunMetrics) *simulationv1.RunMe
 	}
 
 	if engineMetrics.HostMetrics != nil {
-		for _, hm := range engineMetrics.HostMetrics {
+		hostIDs := make([]string, 0, len(engineMetrics.HostMetrics))
+		for hostID := range engineMetrics.HostMetrics {
+			hostIDs = append(hostIDs, hostID)
+		}
+		sort.Strings(hostIDs)
+		for _, hostID := range hostIDs {
+			hm := engineMetrics.HostMetrics[hostID]
 			if hm == nil {
 				continue
 			}
@@ -2226,6 +2371,53 @@ func convertMetricsToProto(engineMetrics *models.RunMetrics) *simulationv1.RunMe
 			})
 		}
 	}
+	for _, ts := range engineMetrics.InstanceCPUThrottleStats {
+		pbMetrics.InstanceCpuThrottleStats = append(pbMetrics.InstanceCpuThrottleStats, &simulationv1.InstanceCPUThrottleStats{
+			ServiceName:      ts.ServiceName,
+			InstanceId:       ts.InstanceID,
+			ThrottledMs:      ts.ThrottledMs,
+			ThrottledPeriods: ts.ThrottledPeriods,
+		})
+	}
+	for _, pc := range engineMetrics.PriorityClassStats {
+		pbMetrics.PriorityClassStats = append(pbMetrics.PriorityClassStats, &simulationv1.PriorityClassStats{
+			PriorityClass:  pc.PriorityClass,
+			Requests:       pc.Requests,
+			FailedRequests: pc.FailedRequests,
+			ErrorRate:      pc.ErrorRate,
+			ShedAttempts:   pc.ShedAttempts,
+			LatencyP50Ms:   pc.LatencyP50Ms,
+			LatencyP95Ms:   pc.LatencyP95Ms,
+			LatencyP99Ms:   pc.LatencyP99Ms,
+			LatencyMeanMs:  pc.LatencyMeanMs,
+		})
+	}
+	for i := range engineMetrics.FlowStats {
+		fs := &engineMetrics.FlowStats[i]
+		row := &simulationv1.FlowStats{
+			FlowId:                fs.FlowID,
+			Sessions:              fs.Sessions,
+			CompletedSessions:     fs.CompletedSessions,
+			AbandonedSessions:     fs.AbandonedSessions,
+			FailedSessions:        fs.FailedSessions,
+			InProgressSessions:    fs.InProgressSessions,
+			CompletionRate:        fs.CompletionRate,
+			SessionDurationP50Ms:  fs.SessionDurationP50Ms,
+			SessionDurationP95Ms:  fs.SessionDurationP95Ms,
+			SessionDurationP99Ms:  fs.SessionDurationP99Ms,
+			SessionDurationMeanMs: fs.SessionDurationMeanMs,
+		}
+		for _, st := range fs.Steps {
+			row.Steps = append(row.Steps, &simulationv1.FlowStepStats{
+				Step:            st.Step,
+				Requests:        st.Requests,
+				Abandoned:       st.Abandoned,
+				Failed:          st.Failed,
+				AbandonmentRate: st.AbandonmentRate,
+			})
+		}
+		pbMetrics.FlowStats = append(pbMetrics.FlowStats, row)
+	}
 
 	return pbMetrics
 }
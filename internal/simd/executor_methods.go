@@ -8,6 +8,7 @@ import (
 
 	simulationv1 "github.com/GoSim-25-26J-441/simulation-core/gen/go/simulation/v1"
 	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/policy"
 	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
 	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
 )
@@ -113,6 +114,28 @@ func (e *RunExecutor) UpdatePolicies(runID string, policies *config.Policies) er
 	return nil
 }
 
+// InjectFaults adds chaos faults to a running simulation. Each fault's at_ms counts from the current
+// simulation time; it returns the fault IDs.
+func (e *RunExecutor) InjectFaults(runID string, faults []config.Fault) ([]string, error) {
+	if runID == "" {
+		return nil, ErrRunIDMissing
+	}
+	if len(faults) == 0 {
+		return nil, fmt.Errorf("faults is required")
+	}
+
+	e.mu.Lock()
+	state, ok := e.runStates[runID]
+	ws, wsOk := e.workloadStates[runID]
+	e.mu.Unlock()
+
+	if !ok || !wsOk || ws.Engine() == nil {
+		return nil, fmt.Errorf("%w: %s", ErrRunNotFound, runID)
+	}
+
+	return injectFaults(ws.Engine(), state, faults)
+}
+
 // UpdateWorkloadRate updates the rate for a specific workload pattern in a running simulation
 func (e *RunExecutor) UpdateWorkloadRate(runID string, patternKey string, newRateRPS float64) error {
 	if runID == "" {
@@ -182,6 +205,20 @@ func (e *RunExecutor) GetScenarioSnapshot(runID string) (*config.Scenario, bool)
 	return s, true
 }
 
+// GetPolicyManager returns the policy manager of an active run (for reporting effective policies).
+func (e *RunExecutor) GetPolicyManager(runID string) (*policy.Manager, bool) {
+	if runID == "" {
+		return nil, false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	pm, ok := e.policyManagers[runID]
+	if !ok || pm == nil {
+		return nil, false
+	}
+	return pm, true
+}
+
 // GetRunConfiguration returns the current effective configuration for a running run (replicas per service, workload rates).
 func (e *RunExecutor) GetRunConfiguration(runID string) (*simulationv1.RunConfiguration, bool) {
 	if runID == "" {
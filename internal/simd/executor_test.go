@@ -440,6 +440,63 @@ workload:
 	t.Fatalf("expected optimization run to fail")
 }
 
+func TestRunExecutorShutdownCancelsAndWaitsForRuns(t *testing.T) {
+	store := NewRunStore()
+	exec := NewRunExecutor(store, nil)
+	optScenario := `
+hosts:
+  - id: host-1
+    cores: 2
+services:
+  - id: svc1
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /test
+        mean_cpu_ms: 10
+        cpu_sigma_ms: 2
+        downstream: []
+        net_latency_ms: {mean: 1, sigma: 0.5}
+workload:
+  - from: client
+    to: svc1:/test
+    arrival: {type: poisson, rate_rps: 10}
+`
+	if _, err := store.Create("opt-long", &simulationv1.RunInput{
+		ScenarioYaml: optScenario,
+		Optimization: &simulationv1.OptimizationConfig{Objective: "p95_latency_ms"},
+	}); err != nil {
+		t.Fatalf("Create opt-long error: %v", err)
+	}
+
+	started := make(chan struct{})
+	returned := make(chan struct{})
+	exec.SetOptimizationRunner(&configurableOptimizationRunner{
+		runFn: func(ctx context.Context, _ string, _ *config.Scenario, _ int64, _ *OptimizationParams) (string, float64, int32, []string, error) {
+			close(started)
+			<-ctx.Done()
+			time.Sleep(20 * time.Millisecond)
+			close(returned)
+			return "", 0, 0, nil, ctx.Err()
+		},
+	})
+	if _, err := exec.Start("opt-long"); err != nil {
+		t.Fatalf("Start error: %v", err)
+	}
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := exec.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown: %v", err)
+	}
+	select {
+	case <-returned:
+	default:
+		t.Fatal("Shutdown returned before the run goroutine finished")
+	}
+}
+
 // Test that online optimization mode selects the online path without panicking.
 func TestRunExecutorStartOnlineOptimizationWithoutRunner(t *testing.T) {
 	store := NewRunStore()
@@ -1427,7 +1484,7 @@ func TestRunExecutorOnlineControllerScaleInHosts(t *testing.T) {
 	if err := rm.InitializeFromScenario(scenario); err != nil {
 		t.Fatalf("InitializeFromScenario: %v", err)
 	}
-	if err := rm.ScaleOutHosts(2); err != nil {
+	if err := rm.ScaleOutHosts(2, ""); err != nil {
 		t.Fatalf("ScaleOutHosts(2): %v", err)
 	}
 	if rm.HostCount() != 2 {
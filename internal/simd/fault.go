@@ -0,0 +1,369 @@
+package simd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// Fault event phases recorded on the run.
+const (
+	FaultPhaseStart = "start"
+	FaultPhaseEnd   = "end"
+)
+
+// FaultEvent is a chaos fault injected (start) or lifted (end). Events are exposed through the run
+// export, the time series annotations and the metrics SSE stream.
+type FaultEvent struct {
+	SimTime time.Time `json:"sim_time"`
+	FaultID string    `json:"fault_id"`
+	Type    string    `json:"type"`
+	Target  string    `json:"target"`
+	Phase   string    `json:"phase"`
+	// Instances lists the replicas a kill_instances fault killed (start only).
+	Instances []string `json:"instances,omitempty"`
+}
+
+// faultState holds the faults of a run: the scenario timeline plus faults injected through the API.
+type faultState struct {
+	mu     sync.Mutex
+	faults []config.Fault // IDs filled in, in injection order
+	ids    map[string]bool
+	// onEvent is called for every fault started or lifted (e.g. persist to the run store).
+	onEvent func(FaultEvent)
+
+	// active is only touched by the engine loop.
+	active []*activeFault
+}
+
+// activeFault is a fault inside its window.
+type activeFault struct {
+	index int
+	fault config.Fault
+	// held records the queue_fill slots taken per broker shard, given back when the fault ends.
+	held map[*resource.BrokerQueueShard]int
+}
+
+func newFaultState(scenario *config.Scenario) *faultState {
+	fs := &faultState{ids: make(map[string]bool)}
+	for _, f := range scenario.Faults {
+		// The scenario was validated, so its IDs are unique.
+		fs.add(f)
+	}
+	return fs
+}
+
+// add registers a scenario fault, filling in its default ID.
+func (fs *faultState) add(f config.Fault) {
+	f.ID = f.EffectiveID(len(fs.faults))
+	fs.ids[f.ID] = true
+	fs.faults = append(fs.faults, f)
+}
+
+func (fs *faultState) get(i int) (config.Fault, bool) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	if i < 0 || i >= len(fs.faults) {
+		return config.Fault{}, false
+	}
+	return fs.faults[i], true
+}
+
+func (fs *faultState) emit(ev FaultEvent) {
+	fs.mu.Lock()
+	hook := fs.onEvent
+	fs.mu.Unlock()
+	if hook != nil {
+		hook(ev)
+	}
+}
+
+// SetFaultEventHook registers fn to be called for every fault started or lifted (e.g. persist to the run store).
+func (s *scenarioState) SetFaultEventHook(fn func(FaultEvent)) {
+	s.faults.mu.Lock()
+	defer s.faults.mu.Unlock()
+	s.faults.onEvent = fn
+}
+
+// ScheduleFaultKickoff schedules the scenario faults, relative to startTime.
+func ScheduleFaultKickoff(eng *engine.Engine, state *scenarioState, startTime time.Time) {
+	state.faults.mu.Lock()
+	defer state.faults.mu.Unlock()
+	for i := range state.faults.faults {
+		eng.ScheduleAt(engine.EventTypeFaultStart, startTime.Add(state.faults.faults[i].At()), nil, "", map[string]interface{}{
+			"fault": i,
+		})
+	}
+}
+
+// injectFaults adds faults to a running simulation; their at_ms counts from now, the current
+// simulation time. Either all faults are added or none, and their IDs are returned.
+func injectFaults(eng *engine.Engine, state *scenarioState, faults []config.Fault) ([]string, error) {
+	fs := state.faults
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	ids := make([]string, len(faults))
+	seen := make(map[string]bool, len(faults))
+	for k := range faults {
+		f := &faults[k]
+		id := f.EffectiveID(len(fs.faults) + k)
+		if fs.ids[id] || seen[id] {
+			return nil, fmt.Errorf("fault %s already exists", id)
+		}
+		seen[id] = true
+		if err := config.ValidateFault(f, state.scenario); err != nil {
+			return nil, fmt.Errorf("fault %s: %w", id, err)
+		}
+		ids[k] = id
+	}
+	now := eng.GetSimTime()
+	for k, f := range faults {
+		f.ID = ids[k]
+		fs.ids[f.ID] = true
+		fs.faults = append(fs.faults, f)
+		eng.ScheduleAt(engine.EventTypeFaultStart, now.Add(f.At()), nil, "", map[string]interface{}{
+			"fault": len(fs.faults) - 1,
+		})
+	}
+	return ids, nil
+}
+
+// handleFaultStart injects a fault and schedules its end.
+func handleFaultStart(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		i := metadataInt(evt.Data, "fault")
+		f, ok := state.faults.get(i)
+		if !ok {
+			return nil
+		}
+		simTime := eng.GetSimTime()
+		state.rm.NoteSimTime(simTime)
+		end := simTime.Add(f.Duration())
+		af := &activeFault{index: i, fault: f}
+		ev := FaultEvent{SimTime: simTime, FaultID: f.ID, Type: f.Type, Target: f.Target.Describe(), Phase: FaultPhaseStart}
+		switch f.Type {
+		case config.FaultKillInstances:
+			ev.Instances = killFaultInstances(state, eng, &f, simTime, end)
+		case config.FaultQueueFill:
+			af.held = holdQueueSlots(state, &f)
+		}
+		state.faults.active = append(state.faults.active, af)
+		metrics.RecordFaultActive(state.collector, true, simTime, metrics.CreateFaultLabels(f.ID, f.Type, ev.Target))
+		state.faults.emit(ev)
+		eng.ScheduleAt(engine.EventTypeFaultEnd, end, nil, "", map[string]interface{}{
+			"fault": i,
+		})
+		return nil
+	}
+}
+
+// handleFaultEnd lifts a fault at the end of its window.
+func handleFaultEnd(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		i := metadataInt(evt.Data, "fault")
+		fs := state.faults
+		var af *activeFault
+		for j, a := range fs.active {
+			if a.index == i {
+				af = a
+				fs.active = append(fs.active[:j], fs.active[j+1:]...)
+				break
+			}
+		}
+		if af == nil {
+			return nil
+		}
+		simTime := eng.GetSimTime()
+		for shard, n := range af.held {
+			shard.Hold(-n)
+		}
+		f := &af.fault
+		target := f.Target.Describe()
+		metrics.RecordFaultActive(state.collector, false, simTime, metrics.CreateFaultLabels(f.ID, f.Type, target))
+		fs.emit(FaultEvent{SimTime: simTime, FaultID: f.ID, Type: f.Type, Target: target, Phase: FaultPhaseEnd})
+		return nil
+	}
+}
+
+// killFaultInstances kills replicas of the target service, chosen at random among those in rotation;
+// their requests fail with instance_killed and they restart at restartAt. It returns the killed IDs.
+func killFaultInstances(state *scenarioState, eng *engine.Engine, f *config.Fault, simTime, restartAt time.Time) []string {
+	var candidates []string
+	for _, inst := range state.rm.GetInstancesForService(f.Target.Service) {
+		if inst.Lifecycle() == resource.InstanceActive {
+			candidates = append(candidates, inst.ID())
+		}
+	}
+	sort.Strings(candidates)
+	n := f.EffectiveInstances()
+	if n > len(candidates) {
+		n = len(candidates)
+	}
+	for k := 0; k < n; k++ {
+		j := k + state.rng.Intn(len(candidates)-k)
+		candidates[k], candidates[j] = candidates[j], candidates[k]
+	}
+	killed := candidates[:n]
+	for _, id := range killed {
+		dropped, readyAt := state.rm.KillInstance(id, simTime, restartAt)
+		metrics.RecordFaultKill(state.collector, f.Target.Service, id, f.ID, simTime)
+		failKilledInstanceRequests(state, eng, id, dropped, metrics.ReasonInstanceKilled, simTime)
+		if !readyAt.IsZero() {
+			eng.ScheduleAt(engine.EventTypeInstanceRestart, readyAt, nil, f.Target.Service, map[string]interface{}{
+				"service_id":  f.Target.Service,
+				"instance_id": id,
+			})
+		}
+		recordInstanceAndHostGauges(state, f.Target.Service, id, simTime)
+	}
+	return killed
+}
+
+// holdQueueSlots fills the target queue's topics (or the target topic): each takes messages slots,
+// or its whole capacity.
+func holdQueueSlots(state *scenarioState, f *config.Fault) map[*resource.BrokerQueueShard]int {
+	svc := state.services[f.Target.Service]
+	if svc == nil {
+		return nil
+	}
+	eff := effectiveQueueForBroker(state, svc.ID)
+	held := make(map[*resource.BrokerQueueShard]int)
+	for _, ep := range svc.Endpoints {
+		if f.Target.Endpoint != "" && ep.Path != f.Target.Endpoint {
+			continue
+		}
+		shard := state.rm.GetBrokerQueue(svc.ID, ep.Path, eff)
+		n := f.Messages
+		if n <= 0 {
+			n = shard.Capacity
+		}
+		shard.Hold(n)
+		held[shard] += n
+	}
+	return held
+}
+
+// cpuStealFactor returns how many times longer CPU work takes on hostID under cpu_steal faults.
+func (fs *faultState) cpuStealFactor(hostID string) float64 {
+	factor := 1.0
+	for _, a := range fs.active {
+		if a.fault.Type == config.FaultCPUSteal && a.fault.Target.Host == hostID {
+			factor /= 1 - a.fault.CPUSteal
+		}
+	}
+	return factor
+}
+
+// faultCallerService returns the service that called a request, or "" for ingress requests.
+func faultCallerService(rm *engine.RunManager, request *models.Request) string {
+	if request.ParentID == "" {
+		return ""
+	}
+	if parent, ok := rm.GetRequest(request.ParentID); ok {
+		return parent.ServiceName
+	}
+	return ""
+}
+
+// faultLatencyMs returns the latency faults add to a request.
+func faultLatencyMs(state *scenarioState, rm *engine.RunManager, request *models.Request) float64 {
+	var ms float64
+	caller, callerKnown := "", false
+	for _, a := range state.faults.active {
+		if a.fault.Type != config.FaultLatency {
+			continue
+		}
+		if !callerKnown {
+			caller, callerKnown = faultCallerService(rm, request), true
+		}
+		if a.fault.Target.MatchesRequest(request.ServiceName, request.Endpoint, caller) {
+			ms += a.fault.LatencyMs
+		}
+	}
+	return ms
+}
+
+// faultFailureReason decides whether faults fail a request at its start on instanceID: a partition
+// between the caller's zone and the instance's zone, or a failure_rate fault on its endpoint or edge.
+// A request whose CPU start was deferred was already checked.
+func faultFailureReason(state *scenarioState, rm *engine.RunManager, request *models.Request, instanceID string) string {
+	if len(state.faults.active) == 0 || metadataBool(request.Metadata, metaCPUDeferredStart) {
+		return ""
+	}
+	var callerZone, calleeZone string
+	caller, callerKnown := "", false
+	pSuccess := 1.0
+	for _, a := range state.faults.active {
+		switch a.fault.Type {
+		case config.FaultPartition:
+			if request.ParentID == "" {
+				continue
+			}
+			if calleeZone == "" {
+				callerZone = downstreamCallerHostZone(state, request)
+				calleeZone = calleeZoneForInstance(state, instanceID)
+			}
+			if a.fault.Target.PartitionsZones(strings.TrimSpace(callerZone), calleeZone) {
+				return metrics.ReasonNetworkPartition
+			}
+		case config.FaultFailureRate:
+			if !callerKnown {
+				caller, callerKnown = faultCallerService(rm, request), true
+			}
+			if a.fault.Target.MatchesRequest(request.ServiceName, request.Endpoint, caller) {
+				pSuccess *= 1 - a.fault.FailureRate
+			}
+		}
+	}
+	if pSuccess < 1 && state.rng.Float64() >= pSuccess {
+		return metrics.ReasonFaultInjected
+	}
+	return ""
+}
+
+func faultEventToJSON(ev FaultEvent) map[string]any {
+	out := map[string]any{
+		"sim_time": ev.SimTime.Format(time.RFC3339Nano),
+		"fault_id": ev.FaultID,
+		"type":     ev.Type,
+		"target":   ev.Target,
+		"phase":    ev.Phase,
+	}
+	if len(ev.Instances) > 0 {
+		out["instances"] = ev.Instances
+	}
+	return out
+}
+
+// faultWindowsJSON pairs fault start and end events into windows for time series annotations; a
+// fault still active has no end.
+func faultWindowsJSON(events []FaultEvent) []map[string]any {
+	windows := make([]map[string]any, 0, len(events)/2+1)
+	open := make(map[string]int)
+	for _, ev := range events {
+		switch ev.Phase {
+		case FaultPhaseStart:
+			open[ev.FaultID] = len(windows)
+			windows = append(windows, map[string]any{
+				"fault_id": ev.FaultID,
+				"type":     ev.Type,
+				"target":   ev.Target,
+				"start":    ev.SimTime.Format(time.RFC3339Nano),
+			})
+		case FaultPhaseEnd:
+			if i, ok := open[ev.FaultID]; ok {
+				windows[i]["end"] = ev.SimTime.Format(time.RFC3339Nano)
+				delete(open, ev.FaultID)
+			}
+		}
+	}
+	return windows
+}
@@ -0,0 +1,190 @@
+package simd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+// faultScenario runs api in both zones and ledger, which api calls, only in zone-b.
+func faultScenario(faults ...config.Fault) *config.Scenario {
+	return &config.Scenario{
+		Hosts: []config.Host{
+			{ID: "a-1", Cores: 8, Zone: "zone-a"},
+			{ID: "b-1", Cores: 8, Zone: "zone-b"},
+		},
+		Services: []config.Service{
+			{ID: "api", Replicas: 2, Model: "cpu", CPUCores: 1, Endpoints: []config.Endpoint{{
+				Path: "/work", MeanCPUMs: 5, NetLatencyMs: config.LatencySpec{Mean: 10},
+				Downstream: []config.DownstreamCall{{To: "ledger:/post"}},
+			}}},
+			{ID: "ledger", Replicas: 1, Model: "cpu", CPUCores: 1, Placement: &config.PlacementPolicy{RequiredZones: []string{"zone-b"}},
+				Endpoints: []config.Endpoint{{Path: "/post", MeanCPUMs: 5, NetLatencyMs: config.LatencySpec{Mean: 10}}}},
+		},
+		Workload: []config.WorkloadPattern{{From: "client", To: "api:/work", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 20}}},
+		Faults:   faults,
+	}
+}
+
+// runFaults sends a request to api:/work every 50ms for 5s and returns the collector, the resource
+// manager and the fault events recorded.
+func runFaults(t *testing.T, scenario *config.Scenario) (*metrics.Collector, *resource.Manager, []FaultEvent) {
+	t.Helper()
+	r := newTestRun(t, scenario, 9)
+	var events []FaultEvent
+	r.state.SetFaultEventHook(func(ev FaultEvent) { events = append(events, ev) })
+	ScheduleFaultKickoff(r.eng, r.state, r.eng.GetSimTime())
+	r.scheduleArrivals("api", "/work", every(50*time.Millisecond, 100)...)
+	r.run(t, 10*time.Second)
+	return r.collector, r.rm, events
+}
+
+func TestLatencyFaultSlowsTheTargetEdge(t *testing.T) {
+	base, _, _ := runFaults(t, faultScenario())
+	slow, _, events := runFaults(t, faultScenario(config.Fault{
+		Type: config.FaultLatency, DurationMs: 6000, LatencyMs: 200,
+		Target: config.FaultTarget{Service: "ledger", Endpoint: "/post", From: "api"},
+	}))
+	// 100 ledger calls, each 200ms slower.
+	added := slow.SumMetricWhere(metrics.MetricRequestLatency, "service", "ledger") - base.SumMetricWhere(metrics.MetricRequestLatency, "service", "ledger")
+	if added < 100*200*0.95 || added > 100*200*1.05 {
+		t.Fatalf("expected ~20000ms of added ledger latency, got %v", added)
+	}
+	if len(events) != 2 || events[0].Phase != FaultPhaseStart || events[1].Phase != FaultPhaseEnd ||
+		events[0].FaultID != "latency-1" || events[0].Target != "api->ledger:/post" {
+		t.Fatalf("unexpected fault events: %+v", events)
+	}
+	if active, ok := slow.GetLastValue(metrics.MetricFaultActive, metrics.CreateFaultLabels("latency-1", config.FaultLatency, "api->ledger:/post")); !ok || active != 0 {
+		t.Fatalf("expected fault_active back at 0, got %v", active)
+	}
+}
+
+func TestFailureRateFaultFailsRequestsInItsWindow(t *testing.T) {
+	collector, _, events := runFaults(t, faultScenario(config.Fault{
+		ID: "ledger-down", Type: config.FaultFailureRate, AtMs: 1000, DurationMs: 1000, FailureRate: 1,
+		Target: config.FaultTarget{Service: "ledger"},
+	}))
+	got := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonFaultInjected)
+	if got < 19 || got > 21 {
+		t.Fatalf("expected the ~20 ledger calls of the 1s window to fail, got %v", got)
+	}
+	if len(events) != 2 || events[1].SimTime.Sub(events[0].SimTime) != time.Second {
+		t.Fatalf("expected a 1s fault window, got %+v", events)
+	}
+}
+
+func TestPartitionFaultFailsCrossZoneCallsOnly(t *testing.T) {
+	collector, _, _ := runFaults(t, faultScenario(config.Fault{
+		Type: config.FaultPartition, DurationMs: 10000, Target: config.FaultTarget{Zones: []string{"zone-a", "zone-b"}},
+	}))
+	// Only calls from the api replica in zone-a cross to ledger in zone-b.
+	got := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonNetworkPartition)
+	if got < 30 || got > 70 {
+		t.Fatalf("expected about half the ledger calls cut by the partition, got %v", got)
+	}
+}
+
+func TestKillInstancesFaultRestartsWhenItEnds(t *testing.T) {
+	collector, rm, events := runFaults(t, faultScenario(config.Fault{
+		Type: config.FaultKillInstances, AtMs: 1000, DurationMs: 1000, Instances: 5,
+		Target: config.FaultTarget{Service: "api"},
+	}))
+	if len(events) != 2 || len(events[0].Instances) != 2 {
+		t.Fatalf("expected both api replicas killed, got %+v", events)
+	}
+	if got := collector.SumMetricWhere(metrics.MetricFaultKillCount, "fault", "kill_instances-1"); got != 2 {
+		t.Fatalf("expected 2 kills recorded, got %v", got)
+	}
+	if got := collector.SumMetricWhere(metrics.MetricInstanceRestartCount, "service", "api"); got != 2 {
+		t.Fatalf("expected both replicas restarted, got %v", got)
+	}
+	if rm.ActiveReplicas("api") != 2 {
+		t.Fatalf("expected api back at 2 replicas, got %d", rm.ActiveReplicas("api"))
+	}
+	if collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonNoInstance) < 10 {
+		t.Fatal("expected requests arriving while api was down to fail")
+	}
+}
+
+func TestCPUStealFaultStretchesWorkOnTheHost(t *testing.T) {
+	fs := newFaultState(faultScenario())
+	fs.active = []*activeFault{
+		{fault: config.Fault{Type: config.FaultCPUSteal, Target: config.FaultTarget{Host: "b-1"}, CPUSteal: 0.5}},
+		{fault: config.Fault{Type: config.FaultCPUSteal, Target: config.FaultTarget{Host: "b-1"}, CPUSteal: 0.5}},
+	}
+	if got := fs.cpuStealFactor("b-1"); got != 4 {
+		t.Fatalf("expected two 50%% steals to make work 4x longer, got %v", got)
+	}
+	if got := fs.cpuStealFactor("a-1"); got != 1 {
+		t.Fatalf("expected a-1 unaffected, got %v", got)
+	}
+}
+
+func TestQueueFillFaultHoldsCapacityUntilItEnds(t *testing.T) {
+	scenario := &config.Scenario{
+		Hosts: []config.Host{{ID: "h-1", Cores: 4}},
+		Services: []config.Service{
+			{ID: "jobs", Kind: "queue", Replicas: 1, Model: "cpu", CPUCores: 1,
+				Endpoints: []config.Endpoint{{Path: "/q"}},
+				Behavior:  &config.ServiceBehavior{Queue: &config.QueueBehavior{Capacity: 3, DropPolicy: "reject", ConsumerTarget: "worker:/job"}},
+			},
+			{ID: "worker", Replicas: 1, Model: "cpu", CPUCores: 1, Endpoints: []config.Endpoint{{Path: "/job", MeanCPUMs: 1}}},
+		},
+		Workload: []config.WorkloadPattern{{From: "client", To: "jobs:/q", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 1}}},
+		Faults:   []config.Fault{{Type: config.FaultQueueFill, DurationMs: 1000, Messages: 2, Target: config.FaultTarget{Service: "jobs"}}},
+	}
+	r := newTestRun(t, scenario, 1)
+	evt := &engine.Event{Data: map[string]interface{}{"fault": 0}}
+	if err := handleFaultStart(r.state)(r.eng, evt); err != nil {
+		t.Fatalf("fault start: %v", err)
+	}
+	shard := r.rm.GetBrokerQueue("jobs", "/q", effectiveQueueForBroker(r.state, "jobs"))
+	if !shard.Enqueue(&resource.QueuedMessage{}).Accepted || shard.Enqueue(&resource.QueuedMessage{}).Accepted {
+		t.Fatal("expected one free slot while 2 of 3 are held")
+	}
+	if err := handleFaultEnd(r.state)(r.eng, evt); err != nil {
+		t.Fatalf("fault end: %v", err)
+	}
+	if !shard.Enqueue(&resource.QueuedMessage{}).Accepted || !shard.Enqueue(&resource.QueuedMessage{}).Accepted {
+		t.Fatal("expected the held slots back after the fault")
+	}
+}
+
+func TestInjectFaultsValidatesAndSchedulesFromNow(t *testing.T) {
+	scenario := faultScenario(config.Fault{ID: "slow", Type: config.FaultLatency, DurationMs: 100, LatencyMs: 1, Target: config.FaultTarget{Service: "api"}})
+	r := newTestRun(t, scenario, 1)
+	eng, state := r.eng, r.state
+	var events []FaultEvent
+	state.SetFaultEventHook(func(ev FaultEvent) { events = append(events, ev) })
+
+	if _, err := injectFaults(eng, state, []config.Fault{{ID: "slow", Type: config.FaultLatency, DurationMs: 1, LatencyMs: 1, Target: config.FaultTarget{Service: "api"}}}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected duplicate id error, got %v", err)
+	}
+	bad := []config.Fault{
+		{Type: config.FaultFailureRate, DurationMs: 1, FailureRate: 0.5, Target: config.FaultTarget{Service: "api"}},
+		{Type: config.FaultCPUSteal, DurationMs: 1, CPUSteal: 0.5, Target: config.FaultTarget{Host: "nope"}},
+	}
+	if _, err := injectFaults(eng, state, bad); err == nil || !strings.Contains(err.Error(), "fault cpu_steal-3: host nope does not exist") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	ids, err := injectFaults(eng, state, []config.Fault{{Type: config.FaultFailureRate, AtMs: 500, DurationMs: 250, FailureRate: 0.5, Target: config.FaultTarget{Service: "api"}}})
+	if err != nil || len(ids) != 1 || ids[0] != "failure_rate-2" {
+		t.Fatalf("expected failure_rate-2 injected, got %v, %v", ids, err)
+	}
+	start := eng.GetSimTime()
+	if err := eng.Run(time.Second); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(events) != 2 || !events[0].SimTime.Equal(start.Add(500*time.Millisecond)) || !events[1].SimTime.Equal(start.Add(750*time.Millisecond)) {
+		t.Fatalf("expected the injected fault from 500ms to 750ms, got %+v", events)
+	}
+	windows := faultWindowsJSON(events)
+	if len(windows) != 1 || windows[0]["fault_id"] != "failure_rate-2" || windows[0]["end"] == nil {
+		t.Fatalf("unexpected fault windows: %v", windows)
+	}
+}
@@ -0,0 +1,145 @@
+package simd
+
+import (
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/interaction"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/workload"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// Flow-session keys: arrival event data and root request metadata carry the flow, the step index and
+// the session start so that a finished step can schedule the next one.
+const (
+	metaFlowID           = "flow_id"
+	metaFlowStep         = "flow_step"
+	metaFlowSessionStart = "flow_session_start"
+)
+
+// flowPatternKey is the workload pattern key of a flow's session arrivals.
+func flowPatternKey(flowID string) string {
+	return "flow:" + flowID
+}
+
+// flowWorkloadPattern is the workload pattern that generates a flow's session arrivals at its first step.
+func flowWorkloadPattern(f *config.Flow) config.WorkloadPattern {
+	from := f.From
+	if from == "" {
+		from = f.ID
+	}
+	return config.WorkloadPattern{
+		From:          from,
+		SourceKind:    f.SourceKind,
+		TrafficClass:  f.TrafficClass,
+		PriorityClass: f.PriorityClass,
+		Metadata:      f.Metadata,
+		To:            f.Steps[0].To,
+		Arrival:       f.Arrival,
+	}
+}
+
+// flowSteps converts a flow's steps for the user flow generator. A step's probability is the chance that
+// a session which finished the previous step goes on to it.
+func flowSteps(f *config.Flow) []workload.FlowStep {
+	steps := make([]workload.FlowStep, len(f.Steps))
+	for i := range f.Steps {
+		// Step targets are checked by scenario validation.
+		serviceID, endpointPath, _ := interaction.ParseDownstreamTarget(f.Steps[i].To)
+		steps[i] = workload.FlowStep{
+			ServiceID:   serviceID,
+			Endpoint:    endpointPath,
+			ThinkTimeMs: f.Steps[i].ThinkTimeMs,
+			Probability: f.Steps[i].ContinueProbability(),
+		}
+	}
+	return steps
+}
+
+// flowStepEventData returns the target service and arrival event data of step i of a session whose
+// weighted metadata drew `drawn`.
+func flowStepEventData(f *config.Flow, step int, sessionID string, drawn map[string]string) (string, map[string]interface{}) {
+	// Step targets are checked by scenario validation.
+	serviceID, endpointPath, _ := interaction.ParseDownstreamTarget(f.Steps[step].To)
+	sessionMetadata := f.SessionMetadata(step)
+	md := make(map[string]interface{}, len(drawn)+len(sessionMetadata)+1)
+	for k, v := range drawn {
+		md[k] = v
+	}
+	for k, v := range sessionMetadata {
+		md[k] = v
+	}
+	md[config.SessionIDMetadataKey] = sessionID
+	pattern := flowWorkloadPattern(f)
+	data := map[string]interface{}{
+		"service_id":    serviceID,
+		"endpoint_path": endpointPath,
+		"from":          pattern.From,
+		"source_kind":   pattern.SourceKind,
+		"traffic_class": pattern.TrafficClass,
+		"metadata":      md,
+		metaFlowID:      f.ID,
+		metaFlowStep:    step,
+	}
+	if pattern.PriorityClass != "" {
+		data["priority_class"] = pattern.PriorityClass
+	}
+	return serviceID, data
+}
+
+// advanceFlowSession records the finished step of a flow request and moves its session on: to the next
+// step after a think time, or to its end (completed, abandoned or failed). It reports whether the
+// session is still going.
+func advanceFlowSession(state *scenarioState, eng *engine.Engine, request *models.Request, flowID string, simTime time.Time) bool {
+	f := state.flows[flowID]
+	step := metadataInt(request.Metadata, metaFlowStep)
+	if f == nil || step >= len(f.Steps) {
+		return false
+	}
+	labels := metrics.CreateFlowStepLabels(f.ID, f.StepName(step), step)
+	metrics.RecordFlowStepCount(state.collector, 1.0, simTime, labels)
+	start, _ := metadataTime(request.Metadata, metaFlowSessionStart)
+
+	outcome := metrics.FlowOutcomeCompleted
+	switch {
+	case request.Status != models.RequestStatusCompleted:
+		outcome = metrics.FlowOutcomeFailed
+	case step+1 < len(f.Steps):
+		next, delay, ok := state.flowGen.NextStep(state.flowSteps[flowID], step)
+		if !ok {
+			outcome = metrics.FlowOutcomeAbandoned
+			break
+		}
+		at := simTime.Add(delay)
+		// A next step due after the end leaves the session in progress (counted from its start).
+		if !state.simEndTime.IsZero() && !at.Before(state.simEndTime) {
+			return true
+		}
+		serviceID, data := flowStepEventData(f, next, metadataString(request.Metadata, config.SessionIDMetadataKey), sessionDrawnMetadata(f, request))
+		data[metaFlowSessionStart] = start
+		if key := metadataString(request.Metadata, metaClosedPattern); key != "" {
+			data[metaClosedPattern] = key
+			data[metaClosedUser] = metadataInt(request.Metadata, metaClosedUser)
+		}
+		eng.ScheduleAt(engine.EventTypeRequestArrival, at, nil, serviceID, data)
+		return true
+	}
+	metrics.RecordFlowSessionEnd(state.collector, outcome, float64(simTime.Sub(start).Milliseconds()), simTime, labels)
+	return false
+}
+
+// sessionDrawnMetadata returns the weighted metadata drawn for the session of a flow request.
+func sessionDrawnMetadata(f *config.Flow, request *models.Request) map[string]string {
+	if len(f.WeightedMetadata) == 0 {
+		return nil
+	}
+	drawn := make(map[string]string, len(f.WeightedMetadata))
+	for k := range f.WeightedMetadata {
+		if v := metadataString(request.Metadata, k); v != "" {
+			drawn[k] = v
+		}
+	}
+	return drawn
+}
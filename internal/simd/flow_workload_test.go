@@ -0,0 +1,175 @@
+package simd
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/workload"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+const flowScenarioYAML = `
+hosts:
+  - id: host-1
+    cores: 8
+services:
+  - id: web
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /browse
+        mean_cpu_ms: 5
+        cpu_sigma_ms: 0
+  - id: cart
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /add
+        mean_cpu_ms: 5
+        cpu_sigma_ms: 0
+      - path: /pay
+        mean_cpu_ms: 5
+        cpu_sigma_ms: 0
+flows:
+  - id: checkout
+    arrival:
+      type: poisson
+      rate_rps: 20
+    steps:
+      - name: browse
+        to: web:/browse
+      - name: add-to-cart
+        to: cart:/add
+        probability: 0.5
+        think_time_ms: {mean: 100, sigma: 0}
+      - name: pay
+        to: cart:/pay
+        probability: 0.8
+        think_time_ms: {mean: 100, sigma: 0}
+`
+
+func TestFlowSessionsFunnel(t *testing.T) {
+	scenario, err := config.ParseScenarioYAMLString(flowScenarioYAML)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rm, err := RunScenarioForMetrics(scenario, 30*time.Second, 5, false)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if len(rm.FlowStats) != 1 {
+		t.Fatalf("expected stats for one flow, got %+v", rm.FlowStats)
+	}
+	fs := rm.FlowStats[0]
+	if fs.FlowID != "checkout" || len(fs.Steps) != 3 || fs.Steps[0].Step != "browse" || fs.Steps[2].Step != "pay" {
+		t.Fatalf("unexpected flow stats %+v", fs)
+	}
+	n := float64(fs.Sessions)
+	if math.Abs(n-600) > 100 {
+		t.Fatalf("expected about 600 sessions, got %d", fs.Sessions)
+	}
+	// Half the sessions leave after browsing, a fifth of the rest after adding to the cart.
+	if math.Abs(fs.Steps[0].AbandonmentRate-0.5) > 0.08 || math.Abs(fs.Steps[1].AbandonmentRate-0.2) > 0.08 {
+		t.Fatalf("unexpected step abandonment %+v", fs.Steps)
+	}
+	if math.Abs(fs.CompletionRate-0.4) > 0.08 || fs.CompletedSessions != fs.Steps[2].Requests || fs.FailedSessions != 0 {
+		t.Fatalf("unexpected session outcomes %+v", fs)
+	}
+	if fs.CompletedSessions+fs.AbandonedSessions+fs.InProgressSessions != fs.Sessions {
+		t.Fatalf("outcomes do not add up: %+v", fs)
+	}
+	// Three 5ms steps and two 100ms think times on an idle host.
+	if math.Abs(fs.SessionDurationP50Ms-215) > 5 {
+		t.Fatalf("expected completed sessions to take about 215ms, got p50 %f", fs.SessionDurationP50Ms)
+	}
+	if rm.IngressRequests != fs.Steps[0].Requests+fs.Steps[1].Requests+fs.Steps[2].Requests {
+		t.Fatalf("expected every ingress request to be a flow step: %d ingress, steps %+v", rm.IngressRequests, fs.Steps)
+	}
+}
+
+func TestFlowSessionsRunningAtTheEndCountAsInProgress(t *testing.T) {
+	// The next step of every session is due after the run ends.
+	yaml := strings.Replace(flowScenarioYAML, "probability: 0.5\n        think_time_ms: {mean: 100, sigma: 0}", "probability: 1\n        think_time_ms: {mean: 60000, sigma: 0}", 1)
+	scenario, err := config.ParseScenarioYAMLString(yaml)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rm, err := RunScenarioForMetrics(scenario, 5*time.Second, 5, false)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	fs := rm.FlowStats[0]
+	if fs.Sessions < 50 || fs.InProgressSessions != fs.Sessions || fs.CompletedSessions != 0 || fs.CompletionRate != 0 {
+		t.Fatalf("expected every session in progress at the end, got %+v", fs)
+	}
+	if fs.Steps[0].Requests != fs.Sessions || fs.Steps[0].Abandoned != 0 {
+		t.Fatalf("expected every session to finish browsing without abandoning, got %+v", fs.Steps)
+	}
+}
+
+func TestFlowSessionsFailAtFailingStep(t *testing.T) {
+	yaml := strings.Replace(flowScenarioYAML, "      - path: /pay\n", "      - path: /pay\n        failure_rate: 1\n", 1)
+	yaml = strings.Replace(yaml, "probability: 0.5", "probability: 1", 1)
+	yaml = strings.Replace(yaml, "probability: 0.8", "probability: 1", 1)
+	scenario, err := config.ParseScenarioYAMLString(yaml)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	rm, err := RunScenarioForMetrics(scenario, 10*time.Second, 5, false)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	fs := rm.FlowStats[0]
+	if fs.Sessions == 0 || fs.FailedSessions != fs.Sessions-fs.InProgressSessions || fs.Steps[2].Failed != fs.FailedSessions || fs.CompletionRate != 0 {
+		t.Fatalf("expected every finished session to fail at pay, got %+v", fs)
+	}
+}
+
+func TestFlowSessionMetadataCarriesToNextStep(t *testing.T) {
+	scenario, err := config.ParseScenarioYAMLString(strings.Replace(flowScenarioYAML, "      - name: add-to-cart\n", "      - name: add-to-cart\n        metadata: {cart: \"yes\"}\n", 1))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	f := &scenario.Flows[0]
+	f.Metadata = map[string]string{"tier": "gold"}
+	one := 1.0
+	f.Steps[1].Probability = &one
+	state := &scenarioState{
+		flows:     map[string]*config.Flow{f.ID: f},
+		flowSteps: map[string][]workload.FlowStep{f.ID: flowSteps(f)},
+		flowGen:   workload.NewUserFlowGenerator(1),
+		rng:       utils.NewRandSource(1),
+		collector: metrics.NewCollector(),
+	}
+	eng := engine.NewEngine("flow-metadata")
+	start := eng.GetSimTime()
+
+	_, data := flowStepEventData(f, 0, "checkout-7", nil)
+	req := &models.Request{Status: models.RequestStatusCompleted, Metadata: map[string]interface{}{}}
+	for k, v := range data["metadata"].(map[string]interface{}) {
+		req.Metadata[k] = v
+	}
+	adoptWorkloadEventKeys(req, data, start)
+	releaseWorkloadRequest(state, eng, req, start.Add(5*time.Millisecond))
+
+	evt := eng.GetEventQueue().Next()
+	if evt == nil || evt.Type != engine.EventTypeRequestArrival || evt.ServiceID != "cart" {
+		t.Fatalf("expected the add-to-cart arrival, got %+v", evt)
+	}
+	if want := start.Add(105 * time.Millisecond); !evt.Time.Equal(want) {
+		t.Fatalf("expected the next step after the think time, at %v got %v", want.Sub(start), evt.Time.Sub(start))
+	}
+	md := evt.Data["metadata"].(map[string]interface{})
+	if md[config.SessionIDMetadataKey] != "checkout-7" || md["tier"] != "gold" || md["cart"] != "yes" {
+		t.Fatalf("expected session id, flow and step metadata on the next step, got %v", md)
+	}
+	if evt.Data[metaFlowStep] != 1 || !evt.Data[metaFlowSessionStart].(time.Time).Equal(start) {
+		t.Fatalf("unexpected flow keys %v", evt.Data)
+	}
+}
@@ -24,6 +24,10 @@ type scenarioState struct {
 	scenario  *config.Scenario
 	services  map[string]*config.Service  // service ID -> service
 	endpoints map[string]*config.Endpoint // "serviceID:path" -> endpoint
+	flows     map[string]*config.Flow     // flow ID -> flow
+	// flowSteps holds each flow's steps for flowGen, which moves sessions from step to step.
+	flowSteps map[string][]workload.FlowStep
+	flowGen   *workload.UserFlowGenerator
 	rng       *utils.RandSource
 	rm        *resource.Manager    // Resource manager for tracking CPU/memory/queueing
 	collector *metrics.Collector   // Metrics collector for time-series metrics
@@ -35,8 +39,34 @@ type scenarioState struct {
 	pendingSyncMu sync.Mutex
 	// pendingSync counts synchronous downstream subtrees not yet reported complete for a request ID.
 	pendingSync map[string]int
+	// syncExecutions holds the stage progress of requests whose endpoint sets execution stages, in place of pendingSync.
+	syncExecutions map[string]*syncExecution
+
+	hedgeMu sync.Mutex
+	// hedgedCalls tracks the attempts of sync calls on hedged edges, by logical call ID.
+	hedgedCalls map[string]*hedgedCall
+	// hedgesByParent lists the hedged calls of each request, dropped when it is finalized.
+	hedgesByParent map[string][]string
+	// hedgeLatencies keeps recent latencies per hedged edge for percentile-based hedge delays.
+	hedgeLatencies map[string]*hedgeLatencyWindow
+
+	cacheMu sync.Mutex
+	// caches holds the key space of each cache service with behavior.cache.key_space.
+	caches map[string]*cacheStore
 	// topicPartitionCursor keeps deterministic round-robin partition assignment per topic path.
 	topicPartitionCursor map[string]int
+	// autoscaler tracks HPA emulation state (stabilization history, pending startups, decisions).
+	autoscaler *autoscalerState
+	// oom tracks the CrashLoopBackOff of OOM-killed instances (nil unless policies.oom is enabled).
+	oom *oomState
+	// hostFailures tracks failed hosts and their lost instances (nil unless the scenario has host_failures).
+	hostFailures *hostFailureState
+	// faults holds the scenario fault timeline and faults injected online, and tracks the active ones.
+	faults *faultState
+	// scheduling tracks the queue dispatch of instances of priority, adaptive_lifo and codel services.
+	scheduling *schedulingState
+	// propagatedKeys are the metadata keys read by `when` conditions, cache key_from and priority scheduling; they follow requests downstream.
+	propagatedKeys []string
 }
 
 // SetSimEndTime sets the simulation end time used by periodic drain sweeps.
@@ -60,13 +90,27 @@ func newScenarioState(scenario *config.Scenario, rm *resource.Manager, collector
 		scenario:             scenario,
 		services:             make(map[string]*config.Service),
 		endpoints:            make(map[string]*config.Endpoint),
+		flows:                make(map[string]*config.Flow),
+		flowSteps:            make(map[string][]workload.FlowStep),
+		flowGen:              workload.NewUserFlowGenerator(rngSeed + 4),
 		rng:                  utils.NewRandSource(rngSeed),
 		rm:                   rm,
 		collector:            collector,
 		policies:             policies,
 		interact:             interact,
 		pendingSync:          make(map[string]int),
+		syncExecutions:       make(map[string]*syncExecution),
+		hedgedCalls:          make(map[string]*hedgedCall),
+		hedgesByParent:       make(map[string][]string),
+		hedgeLatencies:       make(map[string]*hedgeLatencyWindow),
+		caches:               make(map[string]*cacheStore),
 		topicPartitionCursor: make(map[string]int),
+		autoscaler:           newAutoscalerState(),
+		oom:                  newOOMState(scenario),
+		hostFailures:         newHostFailureState(scenario),
+		faults:               newFaultState(scenario),
+		scheduling:           newSchedulingState(scenario),
+		propagatedKeys:       scenario.PropagatedMetadataKeys(),
 	}
 
 	// Build service and endpoint maps (kept for backward compatibility and quick lookups)
@@ -79,6 +123,12 @@ func newScenarioState(scenario *config.Scenario, rm *resource.Manager, collector
 			state.endpoints[key] = ep
 		}
 	}
+	for i := range scenario.Flows {
+		f := &scenario.Flows[i]
+		state.flows[f.ID] = f
+		state.flowSteps[f.ID] = flowSteps(f)
+	}
+	wireResiliencePolicies(state)
 
 	return state, nil
 }
@@ -110,6 +160,7 @@ func RegisterHandlers(eng *engine.Engine, state *scenarioState) {
 	eng.RegisterHandler(engine.EventTypeRequestComplete, handleRequestComplete(state, eng))
 	eng.RegisterHandler(engine.EventTypeDownstreamCall, handleDownstreamCall(state, eng))
 	eng.RegisterHandler(engine.EventTypeDownstreamRetry, handleDownstreamRetry(state, eng))
+	eng.RegisterHandler(engine.EventTypeDownstreamHedge, handleDownstreamHedge(state, eng))
 	eng.RegisterHandler(engine.EventTypeDownstreamCallerOverheadStart, handleDownstreamCallerOverheadStart(state, eng))
 	eng.RegisterHandler(engine.EventTypeDownstreamCallerOverheadEnd, handleDownstreamCallerOverheadEnd(state, eng))
 	eng.RegisterHandler(engine.EventTypeQueueEnqueue, handleQueueEnqueue(state, eng))
@@ -125,6 +176,23 @@ func RegisterHandlers(eng *engine.Engine, state *scenarioState) {
 	eng.RegisterHandler(engine.EventTypeTopicDLQ, handleTopicDLQ(state, eng))
 	eng.RegisterHandler(engine.EventTypeDownstreamTimeout, handleDownstreamTimeout(state, eng))
 	eng.RegisterHandler(engine.EventTypeDrainSweep, handleDrainSweep(state))
+	eng.RegisterHandler(engine.EventTypeAutoscaleSync, handleAutoscaleSync(state))
+	eng.RegisterHandler(engine.EventTypeScaleUp, handleAutoscaleScaleUp(state))
+	eng.RegisterHandler(engine.EventTypeInstanceRestart, handleInstanceRestart(state))
+	eng.RegisterHandler(engine.EventTypeInstanceReady, handleInstanceReady(state))
+	// Readiness runs ahead of other events at the same instant, so they see the instance in rotation.
+	state.rm.SetReadinessHook(func(readyAt time.Time) {
+		eng.ScheduleAtPriority(engine.EventTypeInstanceReady, readyAt, -1, nil, "", nil)
+	})
+	eng.RegisterHandler(engine.EventTypeHostFailure, handleHostFailure(state))
+	eng.RegisterHandler(engine.EventTypeHostRecover, handleHostRecover(state))
+	eng.RegisterHandler(engine.EventTypeInstanceReschedule, handleInstanceReschedule(state))
+	eng.RegisterHandler(engine.EventTypeFaultStart, handleFaultStart(state))
+	eng.RegisterHandler(engine.EventTypeFaultEnd, handleFaultEnd(state))
+	eng.RegisterHandler(engine.EventTypeInstanceDispatch, handleInstanceDispatch(state))
+	eng.RegisterHandler(engine.EventTypeInstanceQueueTimeout, handleInstanceQueueTimeout(state))
+	eng.RegisterHandler(engine.EventTypeCPUShareDone, handleCPUShareDone(state))
+	eng.RegisterHandler(engine.EventTypeCPUShareTimeout, handleCPUShareTimeout(state))
 }
 
 func recordInstanceAndHostGauges(state *scenarioState, serviceID, instanceID string, simTime time.Time) {
@@ -146,6 +214,14 @@ func recordInstanceAndHostGauges(state *scenarioState, serviceID, instanceID str
 	}
 }
 
+// recordCPUThrottle records the CFS throttling of a CPU reservation, if it was throttled.
+func recordCPUThrottle(state *scenarioState, serviceID, instanceID string, throttle resource.CPUThrottle, simTime time.Time) {
+	if throttle.ThrottledMs <= 0 && throttle.Periods == 0 {
+		return
+	}
+	metrics.RecordCPUThrottle(state.collector, throttle.ThrottledMs, throttle.Periods, simTime, metrics.CreateInstanceLabels(serviceID, instanceID))
+}
+
 const drainSweepInterval = 100 * time.Millisecond
 
 // CPU scheduler metadata (DES): deferred RequestStart until cpu_service_start simulation time.
@@ -177,6 +253,18 @@ func metadataInt(m map[string]interface{}, key string) int {
 	}
 }
 
+// copyPropagatedMetadata copies the propagated metadata keys that src has and dst lacks, so that
+// conditions, caches and schedulers deeper in a trace see the attributes of the request that started it.
+func copyPropagatedMetadata(state *scenarioState, dst, src map[string]interface{}) {
+	for _, k := range state.propagatedKeys {
+		if v, ok := src[k]; ok {
+			if _, set := dst[k]; !set {
+				dst[k] = v
+			}
+		}
+	}
+}
+
 func metadataBool(m map[string]interface{}, key string) bool {
 	if m == nil {
 		return false
@@ -338,6 +426,9 @@ func labelsForRequestMetrics(req *models.Request, serviceID, endpointPath string
 		if tc, ok := req.Metadata["workload_traffic_class"].(string); ok && tc != "" {
 			lbl[metrics.LabelTrafficClass] = tc
 		}
+		if pc, ok := req.Metadata[metaPriorityClass].(string); ok && pc != "" {
+			lbl[metrics.LabelPriorityClass] = pc
+		}
 		if sk, ok := req.Metadata["workload_source_kind"].(string); ok && sk != "" {
 			lbl[metrics.LabelSourceKind] = sk
 		}
@@ -404,15 +495,8 @@ func partitionDownstreamFiltered(state *scenarioState, downstreamCalls []interac
 
 func scheduleDownstreamCallEvent(state *scenarioState, eng *engine.Engine, parentRequest *models.Request, downstreamCall interaction.ResolvedCall, simTime time.Time, nextTD, nextAD int, isAsync bool) {
 	callLatencyMs := 0.0
-	if downstreamCall.Call.CallLatencyMs.Mean > 0 {
-		sigma := downstreamCall.Call.CallLatencyMs.Sigma
-		if sigma < 0 {
-			sigma = 0
-		}
-		callLatencyMs = state.rng.NormFloat64(downstreamCall.Call.CallLatencyMs.Mean, sigma)
-		if callLatencyMs < 0 {
-			callLatencyMs = 0
-		}
+	if spec := downstreamCall.Call.CallLatencyMs; spec.MeanMs() > 0 {
+		callLatencyMs = spec.Sample(state.rng)
 	}
 	scheduleTime := simTime.Add(time.Duration(callLatencyMs * float64(time.Millisecond)))
 	callerInstanceID, callerHostZone, callerHostID := resolveCallerTopologyForSpawn(state, parentRequest, downstreamCallerTopology{})
@@ -462,7 +546,8 @@ func failDroppedQueueRequests(eng *engine.Engine, state *scenarioState, simTime
 		el := metrics.EndpointErrorLabels(lbl, metrics.ReasonDrainEvicted)
 		metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
 		if req.ParentID == "" {
-			metrics.RecordIngressLogicalFailure(state.collector, 1.0, simTime, el)
+			recordIngressFailure(state, req, simTime, el)
+			releaseWorkloadRequest(state, eng, req, simTime)
 		}
 	}
 }
@@ -524,37 +609,38 @@ func handleRequestArrival(state *scenarioState) engine.EventHandler {
 		if v, ok := evt.Data["traffic_class"]; ok {
 			request.Metadata["workload_traffic_class"] = v
 		}
+		if pc := arrivalPriorityClass(state, evt.Data); pc != "" {
+			request.Metadata[metaPriorityClass] = pc
+			ingressLabels[metrics.LabelPriorityClass] = pc
+		}
 		if md, ok := evt.Data["metadata"].(map[string]interface{}); ok {
 			for k, v := range md {
 				request.Metadata[k] = v
 			}
 		}
+		startedFlow := adoptWorkloadEventKeys(request, evt.Data, simTime)
 
 		rm := eng.GetRunManager()
 		rm.AddRequest(request)
 
 		// Count every workload arrival as ingress (including those rejected below) so ingress_error_rate has a correct denominator.
 		metrics.RecordRequestCount(state.collector, 1.0, simTime, ingressLabels)
+		if startedFlow != "" {
+			metrics.RecordFlowSessionStart(state.collector, startedFlow, simTime)
+		}
+		noteOutageArrival(state, request, simTime)
 
-		// Check rate limiting policy
-		if state.policies != nil {
-			rateLimiting := state.policies.GetRateLimiting()
-			if rateLimiting != nil && !rateLimiting.AllowRequest(serviceID, endpointPath, simTime) {
-				request.Status = models.RequestStatusFailed
-				el := metrics.EndpointErrorLabels(ingressLabels, metrics.ReasonRateLimited)
-				metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
-				metrics.RecordIngressLogicalFailure(state.collector, 1.0, simTime, el)
+		// Check rate limiting and circuit breaker policies
+		if reason := admissionRejectReason(state, serviceID, endpointPath, simTime, ingressLabels); reason != "" {
+			request.Status = models.RequestStatusFailed
+			el := metrics.EndpointErrorLabels(ingressLabels, reason)
+			metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
+			recordIngressFailure(state, request, simTime, el)
+			releaseWorkloadRequest(state, eng, request, simTime)
+			if reason == metrics.ReasonRateLimited {
 				return fmt.Errorf("rate limit exceeded for %s:%s", serviceID, endpointPath)
 			}
-
-			circuitBreaker := state.policies.GetCircuitBreaker()
-			if circuitBreaker != nil && !circuitBreaker.AllowRequest(serviceID, endpointPath, simTime) {
-				request.Status = models.RequestStatusFailed
-				el := metrics.EndpointErrorLabels(ingressLabels, metrics.ReasonCircuitOpen)
-				metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
-				metrics.RecordIngressLogicalFailure(state.collector, 1.0, simTime, el)
-				return fmt.Errorf("circuit breaker open for %s:%s", serviceID, endpointPath)
-			}
+			return fmt.Errorf("circuit breaker open for %s:%s", serviceID, endpointPath)
 		}
 
 		// Select an instance for this service and emit routing/locality metrics.
@@ -563,12 +649,14 @@ func handleRequestArrival(state *scenarioState) engine.EventHandler {
 			request.Status = models.RequestStatusFailed
 			el := metrics.EndpointErrorLabels(ingressLabels, metrics.ReasonNoInstance)
 			metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
-			metrics.RecordIngressLogicalFailure(state.collector, 1.0, simTime, el)
+			recordIngressFailure(state, request, simTime, el)
+			releaseWorkloadRequest(state, eng, request, simTime)
 			return fmt.Errorf("no instances available for service %s: %w", serviceID, err)
 		}
 
 		// CPU admission is serialized in handleRequestStart via per-instance ReserveCPUWork
-		// (FIFO). Same-timestamp arrivals schedule RequestStart at the same sim time; the
+		// (FIFO), or the instance queue for services with a queueing scheduling discipline.
+		// Same-timestamp arrivals schedule RequestStart at the same sim time; the
 		// scheduler orders work without relying on HasCapacityAt + enqueue.
 		request.Metadata["instance_id"] = instance.ID()
 		eng.ScheduleAt(engine.EventTypeRequestStart, simTime, request, serviceID, map[string]interface{}{
@@ -595,6 +683,12 @@ func handleRequestStart(state *scenarioState) engine.EventHandler {
 		request := evt.Request
 		serviceID := request.ServiceName
 		endpointPath := request.Endpoint
+		if metadataBool(request.Metadata, metaInstanceKilled) {
+			return nil
+		}
+		if dropCancelledHedgeAttempt(state, eng, request, simTime) {
+			return nil
+		}
 
 		// Find endpoint configuration
 		endpointKey := fmt.Sprintf("%s:%s", serviceID, endpointPath)
@@ -645,6 +739,17 @@ func handleRequestStart(state *scenarioState) engine.EventHandler {
 			}
 		}
 
+		// Requests of services with a queueing discipline or queue limits wait in the instance queue
+		// while its CPU is busy; once dispatched, their CPU work starts at dispatch, not at arrival.
+		// Load shedding rejects low priority requests reaching an overloaded instance before they queue.
+		reserveFrom := request.ArrivalTime
+		if metadataBool(request.Metadata, metaSchedDispatched) {
+			delete(request.Metadata, metaSchedDispatched)
+			reserveFrom = simTime
+		} else if shedForOverload(state, eng, request, instanceID, simTime) || queueForScheduling(state, eng, request, instanceID, simTime) {
+			return nil
+		}
+
 		cpuTimeMs := prof.CPUTimeMs
 		netLatencyMs := prof.NetworkLatencyMs
 		memoryMB := prof.MemoryMB
@@ -658,45 +763,54 @@ func handleRequestStart(state *scenarioState) engine.EventHandler {
 			cpuTimeMs *= f
 			netLatencyMs *= f
 		}
+		if inst, ok := state.rm.GetServiceInstance(instanceID); ok {
+			cpuTimeMs *= inst.CPUCostMultiplierAt(simTime)
+			cpuTimeMs *= state.faults.cpuStealFactor(inst.HostID())
+		}
 
 		if svc.Behavior != nil && svc.Behavior.Cache != nil {
 			c := svc.Behavior.Cache
-			if state.rng.Float64() < c.HitRate {
+			var hit bool
+			if c.KeySpace != nil {
+				hit = lookupCacheKey(state, eng.GetRunManager(), svc, request, simTime)
+			} else {
+				hit = state.rng.Float64() < c.HitRate
+			}
+			if hit {
 				request.Metadata["cache_hit"] = true
-				hitTotal := state.rng.NormFloat64(c.HitLatencyMs.Mean, c.HitLatencyMs.Sigma)
-				if hitTotal < 0 {
-					hitTotal = 0
-				}
+				hitTotal := c.HitLatencyMs.Sample(state.rng)
 				cpuTimeMs = hitTotal * 0.4
 				netLatencyMs = hitTotal * 0.6
 				memoryMB *= 0.85
 			} else {
 				request.Metadata["cache_miss"] = true
-				miss := state.rng.NormFloat64(c.MissLatencyMs.Mean, c.MissLatencyMs.Sigma)
-				if miss < 0 {
-					miss = 0
-				}
+				miss := c.MissLatencyMs.Sample(state.rng)
 				cpuTimeMs += miss * 0.5
 				netLatencyMs += miss * 0.5
 			}
 		}
 
 		pLocal := mergedLocalFailureRate(svc, endpoint)
-		if pLocal > 0 && state.rng.Float64() < pLocal {
+		reason := faultFailureReason(state, eng.GetRunManager(), request, instanceID)
+		if reason == "" && pLocal > 0 && state.rng.Float64() < pLocal {
+			reason = metrics.ReasonLocalFailure
+		}
+		if reason != "" {
 			request.Status = models.RequestStatusFailed
 			lbl := labelsForRequestMetricsWithRetry(request, serviceID, endpointPath)
 			rm := eng.GetRunManager()
-			if maybeRetrySyncStartFailure(state, eng, rm, request, simTime, metrics.ReasonLocalFailure) {
-				el := metrics.EndpointErrorLabels(lbl, metrics.ReasonLocalFailure)
+			if maybeRetrySyncStartFailure(state, eng, rm, request, simTime, reason) {
+				el := metrics.EndpointErrorLabels(lbl, reason)
 				metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
 				return nil
 			}
-			finalizeRequestFailure(state, eng, rm, request, simTime, lbl, metrics.ReasonLocalFailure)
+			finalizeRequestFailure(state, eng, rm, request, simTime, lbl, reason)
 			return nil
 		}
 
 		var cpuStart, cpuEnd time.Time
 		deferredExec := false
+		sharedCPU := serviceScheduling(state, serviceID).EffectiveDiscipline() == config.SchedulingProcessorSharing
 		if b, ok := request.Metadata[metaCPUDeferredStart].(bool); ok && b {
 			t0, ok0 := metadataTime(request.Metadata, metaCPUServiceStart)
 			t1, ok1 := metadataTime(request.Metadata, metaCPUServiceEnd)
@@ -708,16 +822,21 @@ func handleRequestStart(state *scenarioState) engine.EventHandler {
 				delete(request.Metadata, metaCPUServiceEnd)
 			}
 		}
-		if !deferredExec {
+		if sharedCPU {
+			// Processor sharing admits the work at once; it ends when its share of the cores has served it.
+			cpuStart = simTime
+		} else if !deferredExec {
 			var err error
-			cpuStart, cpuEnd, err = state.rm.ReserveCPUWork(instanceID, request.ArrivalTime, cpuTimeMs)
+			var throttle resource.CPUThrottle
+			cpuStart, cpuEnd, throttle, err = state.rm.ReserveCPUWork(instanceID, reserveFrom, cpuTimeMs)
+			recordCPUThrottle(state, serviceID, instanceID, throttle, simTime)
 			if err != nil {
 				request.Status = models.RequestStatusFailed
 				lbl := labelsForRequestMetricsWithRetry(request, serviceID, endpointPath)
 				el := metrics.EndpointErrorLabels(lbl, metrics.ReasonNoInstance)
 				metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
 				if request.ParentID == "" {
-					metrics.RecordIngressLogicalFailure(state.collector, 1.0, simTime, el)
+					recordIngressFailure(state, request, simTime, el)
 				}
 				if state.policies != nil {
 					circuitBreaker := state.policies.GetCircuitBreaker()
@@ -748,6 +867,7 @@ func handleRequestStart(state *scenarioState) engine.EventHandler {
 		if topologyPenaltyMs := applyTopologyNetworkPenaltyMs(state, serviceID, endpointPath, request, instanceID, simTime); topologyPenaltyMs > 0 {
 			netLatencyMs += topologyPenaltyMs
 		}
+		netLatencyMs += faultLatencyMs(state, eng.GetRunManager(), request)
 		request.CPUTimeMs = cpuTimeMs
 		request.NetworkLatencyMs = netLatencyMs
 
@@ -774,7 +894,7 @@ func handleRequestStart(state *scenarioState) engine.EventHandler {
 			el := metrics.EndpointErrorLabels(lbl, reason)
 			metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
 			if request.ParentID == "" {
-				metrics.RecordIngressLogicalFailure(state.collector, 1.0, simTime, el)
+				recordIngressFailure(state, request, simTime, el)
 			}
 			if state.policies != nil {
 				circuitBreaker := state.policies.GetCircuitBreaker()
@@ -804,7 +924,7 @@ func handleRequestStart(state *scenarioState) engine.EventHandler {
 			el := metrics.EndpointErrorLabels(lbl, metrics.ReasonCPUCapacity)
 			metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
 			if request.ParentID == "" {
-				metrics.RecordIngressLogicalFailure(state.collector, 1.0, simTime, el)
+				recordIngressFailure(state, request, simTime, el)
 			}
 			if state.policies != nil {
 				circuitBreaker := state.policies.GetCircuitBreaker()
@@ -822,6 +942,7 @@ func handleRequestStart(state *scenarioState) engine.EventHandler {
 
 		request.Metadata["allocated_cpu_ms"] = cpuTimeMs
 		request.Metadata["allocated_memory_mb"] = memoryMB
+		recordHedgeLoserStart(state, request, cpuTimeMs, simTime)
 
 		if _, ok := state.rm.GetServiceInstance(instanceID); ok {
 			recordInstanceAndHostGauges(state, serviceID, instanceID, simTime)
@@ -830,50 +951,86 @@ func handleRequestStart(state *scenarioState) engine.EventHandler {
 			}
 		}
 
-		ioEnd := cpuEnd
-		if isDatastoreWorkload(svc, endpoint) {
-			maxConn := effectiveDBMaxConnections(svc, endpoint)
-			ioDur := sampleEndpointIOWorkloadMs(endpoint, state.rng)
-			ioStart, ioEndSlot, _, dbWaitMs, err := state.rm.ReserveDBWork(instanceID, cpuEnd, ioDur, maxConn)
-			if err != nil {
+		if sharedCPU {
+			if err := startSharedCPUWork(state, eng, request, instanceID, cpuTimeMs, endpoint.TimeoutMs, simTime); err != nil {
 				return err
 			}
-			_ = ioStart
-			ioEnd = ioEndSlot
-			if dbWaitMs > 0 {
-				metrics.RecordDbWait(state.collector, dbWaitMs, simTime, labelsForQueueWaitMetrics(request, serviceID, endpointPath, instanceID))
-			}
-			request.Metadata["db_wait_ms"] = dbWaitMs
-			if maxConn > 0 {
-				request.Metadata["db_reserved"] = true
-			}
-			if inst, ok := state.rm.GetServiceInstance(instanceID); ok {
-				metrics.RecordActiveConnections(state.collector, float64(inst.ActiveDBConnections()), simTime, metrics.CreateInstanceLabels(serviceID, instanceID))
-			}
+		} else if err := scheduleRequestCompletion(state, eng, request, instanceID, cpuStart, cpuEnd, simTime); err != nil {
+			return err
 		}
 
-		// Completion after CPU + optional datastore IO, then network latency (same hop).
-		completionTime := ioEnd.Add(time.Duration(netLatencyMs * float64(time.Millisecond)))
-		if endpoint.TimeoutMs > 0 {
-			deadline := cpuStart.Add(time.Duration(endpoint.TimeoutMs) * time.Millisecond)
-			if completionTime.After(deadline) {
-				request.Metadata["local_timeout"] = true
-				completionTime = deadline
-			}
+		enforceMemoryLimits(state, eng, instanceID, simTime)
+		return nil
+	}
+}
+
+// scheduleRequestCompletion schedules the completion of a request whose CPU work runs over
+// [cpuStart, cpuEnd): after optional datastore IO and the hop's network latency, or at the
+// endpoint's local timeout if that comes first.
+func scheduleRequestCompletion(state *scenarioState, eng *engine.Engine, request *models.Request, instanceID string, cpuStart, cpuEnd, simTime time.Time) error {
+	serviceID := request.ServiceName
+	endpointPath := request.Endpoint
+	svc := state.services[serviceID]
+	endpoint := state.endpoints[serviceID+":"+endpointPath]
+	netLatencyMs := request.NetworkLatencyMs
+
+	ioEnd := cpuEnd
+	if isDatastoreWorkload(svc, endpoint) {
+		maxConn := effectiveDBMaxConnections(svc, endpoint)
+		ioDur := sampleEndpointIOWorkloadMs(endpoint, state.rng)
+		ioStart, ioEndSlot, _, dbWaitMs, err := state.rm.ReserveDBWork(instanceID, cpuEnd, ioDur, maxConn)
+		if err != nil {
+			return err
 		}
-		eng.ScheduleAt(engine.EventTypeRequestComplete, completionTime, request, serviceID, map[string]interface{}{
-			"endpoint_path": endpointPath,
-			"instance_id":   instanceID,
-		})
+		_ = ioStart
+		ioEnd = ioEndSlot
+		if dbWaitMs > 0 {
+			metrics.RecordDbWait(state.collector, dbWaitMs, simTime, labelsForQueueWaitMetrics(request, serviceID, endpointPath, instanceID))
+		}
+		request.Metadata["db_wait_ms"] = dbWaitMs
+		if maxConn > 0 {
+			request.Metadata["db_reserved"] = true
+		}
+		if inst, ok := state.rm.GetServiceInstance(instanceID); ok {
+			metrics.RecordActiveConnections(state.collector, float64(inst.ActiveDBConnections()), simTime, metrics.CreateInstanceLabels(serviceID, instanceID))
+		}
+	}
 
-		return nil
+	// Completion after CPU + optional datastore IO, then network latency (same hop).
+	completionTime := ioEnd.Add(time.Duration(netLatencyMs * float64(time.Millisecond)))
+	if endpoint.TimeoutMs > 0 {
+		deadline := cpuStart.Add(time.Duration(endpoint.TimeoutMs) * time.Millisecond)
+		if completionTime.After(deadline) {
+			request.Metadata["local_timeout"] = true
+			completionTime = deadline
+		}
 	}
+	eng.ScheduleAt(engine.EventTypeRequestComplete, completionTime, request, serviceID, map[string]interface{}{
+		"endpoint_path": endpointPath,
+		"instance_id":   instanceID,
+	})
+	return nil
 }
 
 // dequeueNextRequestForInstance schedules the next queued request after the instance is free until scheduleAt
-// (caller downstream CPU overhead is ordered before the next hop start).
+// (caller downstream CPU overhead is ordered before the next hop start). The service's scheduling discipline
+// picks the request; instances that queue requests wait until their CPU is free, and fail the requests codel drops.
 func dequeueNextRequestForInstance(state *scenarioState, eng *engine.Engine, rm *engine.RunManager, instanceID, serviceID, endpointPath string, scheduleAt time.Time) error {
-	nextRequestID, hasNext := state.rm.DequeueRequest(instanceID)
+	queued := queuesRequests(state, serviceID)
+	if queued {
+		busyUntil, ok := state.rm.CPUBusyUntil(instanceID)
+		if ok && (busyUntil.After(scheduleAt) || state.scheduling.claimed[instanceID]) {
+			if busyUntil.Before(scheduleAt) {
+				busyUntil = scheduleAt
+			}
+			if state.rm.GetQueueLength(instanceID) > 0 {
+				scheduleInstanceDispatch(state, eng, instanceID, busyUntil)
+			}
+			return nil
+		}
+	}
+	nextRequestID, dropped, hasNext := state.rm.DequeueScheduledRequest(instanceID, scheduleAt)
+	failQueuedRequests(state, eng, rm, dropped, scheduleAt, metrics.ReasonCoDelDropped)
 	if !hasNext {
 		return nil
 	}
@@ -881,11 +1038,22 @@ func dequeueNextRequestForInstance(state *scenarioState, eng *engine.Engine, rm
 	if !found {
 		return fmt.Errorf("queued request %s not found in run manager", nextRequestID)
 	}
+	if endpointPath == "" || queued {
+		endpointPath = nextRequest.Endpoint
+	}
+	if queued {
+		nextRequest.Metadata[metaSchedDispatched] = true
+		state.scheduling.claimed[instanceID] = true
+	}
 	eng.ScheduleAt(engine.EventTypeRequestStart, scheduleAt, nextRequest, serviceID, map[string]interface{}{
 		"service_id":    serviceID,
 		"endpoint_path": endpointPath,
 		"instance_id":   instanceID,
 	})
+	if queued {
+		// Runs after the dispatched start: releases the claim and dispatches again once the CPU is free.
+		postInstanceDispatch(state, eng, instanceID, scheduleAt)
+	}
 	recordInstanceAndHostGauges(state, serviceID, instanceID, scheduleAt)
 	return nil
 }
@@ -930,6 +1098,10 @@ func handleRequestComplete(state *scenarioState, eng *engine.Engine) engine.Even
 		if !brokerTimedOut && metadataBool(request.Metadata, metaTopicConsumer) {
 			metaTopicConsumerDone(state, eng, request, simTime)
 		}
+		// Killed with its instance: already failed, and the instance's queue is gone.
+		if metadataBool(request.Metadata, metaInstanceKilled) {
+			return nil
+		}
 		if brokerTimedOut {
 			if hasInstance {
 				if err := dequeueNextRequestForInstance(state, eng, rm, instanceID, serviceID, endpointPath, simTime); err != nil {
@@ -974,10 +1146,35 @@ func handleRequestComplete(state *scenarioState, eng *engine.Engine) engine.Even
 			return nil
 		}
 
-		downstreamCalls, err := state.interact.GetDownstreamCalls(serviceID, endpointPath)
+		if metadataBool(request.Metadata, metaCacheCoalesced) {
+			if waiting, loaded := awaitCacheLoad(state, rm, request, simTime); waiting || loaded {
+				metrics.RecordServiceRequestLatency(state.collector, localServiceHopLatencyMs(request, simTime), simTime, labels)
+				metrics.RecordServiceProcessingLatency(state.collector, localServiceProcessingLatencyMs(request, simTime), simTime, labels)
+				metrics.RecordCacheMissCount(state.collector, 1.0, simTime, labels)
+				if loaded {
+					finalizeRequestCompletion(state, eng, rm, request, simTime, labels)
+				}
+				if hasInstance {
+					if err := dequeueNextRequestForInstance(state, eng, rm, instanceID, serviceID, endpointPath, simTime); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+		}
+
+		downstreamCalls, err := state.interact.GetDownstreamCallsForRequest(serviceID, endpointPath, request.Metadata)
 		if err != nil {
 			return fmt.Errorf("failed to get downstream calls for %s:%s: %w", serviceID, endpointPath, err)
 		}
+		if metadataBool(request.Metadata, "cache_miss") {
+			if call, ok := cacheMissCall(state, request); ok {
+				downstreamCalls = append([]interaction.ResolvedCall{call}, downstreamCalls...)
+			}
+		}
+		if len(downstreamCalls) > 0 && hedgeAttemptSkipsDownstream(request) {
+			downstreamCalls = nil
+		}
 
 		td := metadataInt(request.Metadata, "trace_depth")
 		ad := metadataInt(request.Metadata, "async_depth")
@@ -1067,9 +1264,13 @@ func handleRequestComplete(state *scenarioState, eng *engine.Engine) engine.Even
 			return nil
 		}
 
-		state.pendingSyncMu.Lock()
-		state.pendingSync[request.ID] = len(syncCalls)
-		state.pendingSyncMu.Unlock()
+		ep := state.endpoints[serviceID+":"+endpointPath]
+		staged := ep != nil && len(ep.Execution) > 0
+		if !staged {
+			state.pendingSyncMu.Lock()
+			state.pendingSync[request.ID] = len(syncCalls)
+			state.pendingSyncMu.Unlock()
+		}
 
 		if deferQueueFinalize {
 			eng.ScheduleAt(engine.EventTypeAsyncParentFinalize, maxAck, request, serviceID, map[string]interface{}{
@@ -1077,11 +1278,15 @@ func handleRequestComplete(state *scenarioState, eng *engine.Engine) engine.Even
 			})
 		}
 
-		for i := range syncCalls {
-			downstreamCall := &syncCalls[i]
-			nextTD := td + 1
-			nextAD := ad
-			tAfter = scheduleDownstreamWithCallerOverhead(state, eng, request, *downstreamCall, tAfter, nextTD, nextAD, false, false, 0, "", callerTopology)
+		if staged {
+			tAfter = startSyncExecution(state, eng, request, ep, syncCalls, td, ad, callerTopology, tAfter)
+		} else {
+			for i := range syncCalls {
+				downstreamCall := &syncCalls[i]
+				nextTD := td + 1
+				nextAD := ad
+				tAfter = scheduleDownstreamWithCallerOverhead(state, eng, request, *downstreamCall, tAfter, nextTD, nextAD, false, false, 0, "", callerTopology)
+			}
 		}
 		if hasInstance {
 			dequeueAt := tAfter
@@ -19,12 +19,14 @@ const (
 
 // referenceCPUForDownstream picks a deterministic CPU reference (ms) for downstream_fraction_cpu.
 func referenceCPUForDownstream(state *scenarioState, downstreamCall interaction.ResolvedCall) float64 {
-	if downstreamCall.Call.CallLatencyMs.Mean > 0 {
-		return downstreamCall.Call.CallLatencyMs.Mean
+	if m := downstreamCall.Call.CallLatencyMs.MeanMs(); m > 0 {
+		return m
 	}
 	tgtEp, ok := state.endpoints[fmt.Sprintf("%s:%s", downstreamCall.ServiceID, downstreamCall.Path)]
-	if ok && tgtEp.MeanCPUMs > 0 {
-		return tgtEp.MeanCPUMs
+	if ok {
+		if m := tgtEp.CPUSpec().MeanMs(); m > 0 {
+			return m
+		}
 	}
 	return 0
 }
@@ -77,7 +79,8 @@ func scheduleDownstreamWithCallerOverhead(state *scenarioState, eng *engine.Engi
 		if !ok || instanceID == "" {
 			return scheduleTopicPublishFromOverhead(state, eng, parent, downstreamCall, tCursor, nextTD, nextAD, fromRetry, retryAttempt, logicalID, -1, callerTopology)
 		}
-		cpuStart, cpuEnd, err := state.rm.ReserveCPUWork(instanceID, tCursor, overhead)
+		cpuStart, cpuEnd, throttle, err := state.rm.ReserveCPUWork(instanceID, tCursor, overhead)
+		recordCPUThrottle(state, parent.ServiceName, instanceID, throttle, tCursor)
 		if err != nil {
 			return scheduleTopicPublishFromOverhead(state, eng, parent, downstreamCall, tCursor, nextTD, nextAD, fromRetry, retryAttempt, logicalID, -1, callerTopology)
 		}
@@ -119,7 +122,8 @@ func scheduleDownstreamWithCallerOverhead(state *scenarioState, eng *engine.Engi
 		if !ok || instanceID == "" {
 			return scheduleQueuePublishFromOverhead(state, eng, parent, downstreamCall, tCursor, nextTD, nextAD, fromRetry, retryAttempt, logicalID, -1, callerTopology)
 		}
-		cpuStart, cpuEnd, err := state.rm.ReserveCPUWork(instanceID, tCursor, overhead)
+		cpuStart, cpuEnd, throttle, err := state.rm.ReserveCPUWork(instanceID, tCursor, overhead)
+		recordCPUThrottle(state, parent.ServiceName, instanceID, throttle, tCursor)
 		if err != nil {
 			return scheduleQueuePublishFromOverhead(state, eng, parent, downstreamCall, tCursor, nextTD, nextAD, fromRetry, retryAttempt, logicalID, -1, callerTopology)
 		}
@@ -171,7 +175,8 @@ func scheduleDownstreamWithCallerOverhead(state *scenarioState, eng *engine.Engi
 		scheduleDownstreamCallEvent(state, eng, parent, downstreamCall, tCursor, nextTD, nextAD, isAsync)
 		return tCursor
 	}
-	cpuStart, cpuEnd, err := state.rm.ReserveCPUWork(instanceID, tCursor, overhead)
+	cpuStart, cpuEnd, throttle, err := state.rm.ReserveCPUWork(instanceID, tCursor, overhead)
+	recordCPUThrottle(state, parent.ServiceName, instanceID, throttle, tCursor)
 	if err != nil {
 		if fromRetry {
 			execRetrySpawnImmediate(state, eng, parent, downstreamCall, nextTD, nextAD, isAsync, retryAttempt, logicalID, callerTopology)
@@ -260,11 +265,12 @@ func handleDownstreamCallerOverheadStart(state *scenarioState, _ *engine.Engine)
 			el := metrics.EndpointErrorLabels(lbl, metrics.ReasonCPUCapacity)
 			metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
 			rm := eng.GetRunManager()
-			if maybeRetrySyncCallerOverheadFailure(state, eng, rm, parent, evt, simTime, metrics.ReasonCPUCapacity) {
+			retried := maybeRetrySyncCallerOverheadFailure(state, eng, rm, parent, evt, simTime, metrics.ReasonCPUCapacity)
+			if hedgeLaunchFailed(state, evt.Data, retried) || retried {
 				return nil
 			}
 			isAsync := metadataBool(evt.Data, "is_async_downstream")
-			propagateSyncPendingFromCallerOverheadFailure(state, eng, parent, simTime, metrics.ReasonCPUCapacity, isAsync)
+			propagateSyncPendingFromCallerOverheadFailure(state, eng, parent, evt.ServiceID+":"+childPath, simTime, metrics.ReasonCPUCapacity, isAsync)
 			return nil
 		}
 		lbl := labelsDownstreamCallerCPU(state, parent, callerSvc, callerEp, resolved, evt.Data)
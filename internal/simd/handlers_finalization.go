@@ -40,6 +40,9 @@ func finalizeRequestCompletion(state *scenarioState, eng *engine.Engine, rm *eng
 	if metadataBool(request.Metadata, metaDESFinalized) {
 		return
 	}
+	defer releaseWorkloadRequest(state, eng, request, simTime)
+	defer forgetHedgedCalls(state, request.ID)
+	defer settleCacheLoad(state, eng, request, simTime)
 	// Async attempt superseded by retry scheduling: release path in handleRequestComplete already ran;
 	// skip success latency / circuit success for this abandoned attempt.
 	if metadataBool(request.Metadata, metaAsyncAttemptAbandoned) {
@@ -63,6 +66,7 @@ func finalizeRequestCompletion(state *scenarioState, eng *engine.Engine, rm *eng
 	request.Status = models.RequestStatusCompleted
 	request.CompletionTime = simTime
 	request.Duration = simTime.Sub(request.ArrivalTime)
+	recordHedgeLatencyGain(state, request, simTime, labels)
 
 	// Async downstream op already timed out: local work finished without success latency / root series.
 	if metadataBool(request.Metadata, metaAsyncOpTimedOut) && metadataBool(request.Metadata, metaDownstreamAsync) {
@@ -105,6 +109,9 @@ func finalizeRequestFailure(state *scenarioState, eng *engine.Engine, rm *engine
 	if metadataBool(request.Metadata, metaDESFinalized) {
 		return
 	}
+	defer releaseWorkloadRequest(state, eng, request, simTime)
+	defer forgetHedgedCalls(state, request.ID)
+	defer settleCacheLoad(state, eng, request, simTime)
 	request.Metadata[metaDESFinalized] = true
 	request.Status = models.RequestStatusFailed
 	if reason != "" {
@@ -123,10 +130,10 @@ func finalizeRequestFailure(state *scenarioState, eng *engine.Engine, rm *engine
 	errLabels := metrics.EndpointErrorLabels(labels, reason)
 	metrics.RecordErrorCount(state.collector, 1.0, simTime, errLabels)
 	if request.ParentID == "" {
-		metrics.RecordIngressLogicalFailure(state.collector, 1.0, simTime, errLabels)
+		recordIngressFailure(state, request, simTime, errLabels)
 	}
 
-	if state.policies != nil {
+	if state.policies != nil && countsAgainstCircuitBreaker(reason) {
 		if cb := state.policies.GetCircuitBreaker(); cb != nil {
 			cb.RecordFailure(request.ServiceName, request.Endpoint, simTime)
 		}
@@ -138,6 +145,14 @@ func finalizeRequestFailure(state *scenarioState, eng *engine.Engine, rm *engine
 	rm.FinalizeRequest(request)
 }
 
+// countsAgainstCircuitBreaker reports whether a failure with reason counts against the callee's
+// circuit breaker. Requests rejected by the breaker or limiter never reached the callee. Shed
+// requests did, but the callee rejected them on purpose: opening its breaker would also reject the
+// higher priority classes shedding protects.
+func countsAgainstCircuitBreaker(reason string) bool {
+	return reason != metrics.ReasonCircuitOpen && reason != metrics.ReasonRateLimited && reason != metrics.ReasonLoadShed
+}
+
 func notifyParentSyncChildResolved(state *scenarioState, eng *engine.Engine, rm *engine.RunManager, child *models.Request, parentID string, simTime time.Time, childFailed bool, failureReason string) {
 	if parentID == "" {
 		return
@@ -149,6 +164,12 @@ func notifyParentSyncChildResolved(state *scenarioState, eng *engine.Engine, rm
 		return
 	}
 	child.Metadata[metaCallerSyncResolved] = true
+	if resolveHedgedAttempt(state, eng, child, simTime, childFailed) {
+		return
+	}
+	if resolveSyncExecutionChild(state, eng, parentID, child.ServiceName+":"+child.Endpoint, child.ID, simTime, childFailed, failureReason) {
+		return
+	}
 
 	state.pendingSyncMu.Lock()
 	n, ok := state.pendingSync[parentID]
@@ -192,11 +213,15 @@ func notifyParentSyncChildResolved(state *scenarioState, eng *engine.Engine, rm
 }
 
 // propagateSyncPendingFromCallerOverheadFailure handles CPU allocation failure for caller-side downstream overhead
-// before any child request exists. Decrements pendingSync for a parent that is waiting on sync children.
-func propagateSyncPendingFromCallerOverheadFailure(state *scenarioState, eng *engine.Engine, parent *models.Request, simTime time.Time, reason string, isAsync bool) {
+// before any child request exists. Decrements pendingSync for a parent that is waiting on sync children;
+// target ("serviceID:path") names the call for parents with execution stages.
+func propagateSyncPendingFromCallerOverheadFailure(state *scenarioState, eng *engine.Engine, parent *models.Request, target string, simTime time.Time, reason string, isAsync bool) {
 	if isAsync {
 		return
 	}
+	if resolveSyncExecutionChild(state, eng, parent.ID, target, "", simTime, true, reason) {
+		return
+	}
 	rm := eng.GetRunManager()
 	parentID := parent.ID
 	state.pendingSyncMu.Lock()
@@ -224,7 +249,9 @@ func propagateSyncPendingFromCallerOverheadFailure(state *scenarioState, eng *en
 
 // propagateSyncChildFailureFromStartFailure is used when a downstream child fails before local completion (e.g. CPU allocation).
 func propagateSyncChildFailureFromStartFailure(state *scenarioState, eng *engine.Engine, request *models.Request, simTime time.Time, reason string) {
+	settleCacheLoad(state, eng, request, simTime)
 	if request.ParentID == "" {
+		releaseWorkloadRequest(state, eng, request, simTime)
 		return
 	}
 	if metadataBool(request.Metadata, metaDownstreamAsync) {
@@ -234,6 +261,12 @@ func propagateSyncChildFailureFromStartFailure(state *scenarioState, eng *engine
 		return
 	}
 	request.Metadata[metaCallerSyncResolved] = true
+	if resolveHedgedAttempt(state, eng, request, simTime, true) {
+		return
+	}
+	if resolveSyncExecutionChild(state, eng, request.ParentID, request.ServiceName+":"+request.Endpoint, request.ID, simTime, true, reason) {
+		return
+	}
 
 	rm := eng.GetRunManager()
 	parentID := request.ParentID
@@ -44,11 +44,17 @@ func retryReasonError(reason string) error {
 	return errors.New(reason)
 }
 
-func getRetryPolicy(pm *policy.Manager) policy.RetryPolicy {
-	if pm == nil {
+// retryPolicyForCall returns the retry policy for the call from parent to childSvc/childPath, resolved
+// most-specific-first (downstream call, callee endpoint, callee service, scenario), or nil when disabled.
+func retryPolicyForCall(state *scenarioState, parent *models.Request, childSvc, childPath string) policy.RetryPolicy {
+	if state.policies == nil {
 		return nil
 	}
-	rp := pm.GetRetry()
+	var call *config.DownstreamCall
+	if dsCall, ok := resolveDownstreamCallSpec(state, parent, childSvc, childPath); ok {
+		call = &dsCall
+	}
+	rp := state.policies.GetRetryFor(childSvc, childPath, call)
 	if rp == nil || !rp.Enabled() {
 		return nil
 	}
@@ -141,59 +147,6 @@ func execDownstreamSpawn(state *scenarioState, eng *engine.Engine, parentRequest
 		return err
 	}
 
-	dsCall, _ := resolveDownstreamCallSpec(state, parentRequest, downstreamServiceID, endpointPath)
-	tgtSvc := state.services[downstreamServiceID]
-	pFail := mergedDependencyFailureRate(dsCall, tgtSvc)
-	if pFail > 0 && state.rng.Float64() < pFail {
-		downstreamRequest.Metadata["trace_depth"] = traceDepth
-		downstreamRequest.Metadata["async_depth"] = asyncDepth
-		downstreamRequest.Metadata[metaDownstreamAsync] = isAsync
-		downstreamRequest.Metadata[metaRetryAttempt] = retryAttempt
-		if logicalCallID != "" {
-			downstreamRequest.Metadata[metaLogicalCallID] = logicalCallID
-		} else {
-			downstreamRequest.Metadata[metaLogicalCallID] = downstreamRequest.ID
-		}
-		if retryAttempt > 0 {
-			downstreamRequest.Metadata[metaIsRetry] = true
-		}
-		for _, k := range []string{"workload_from", "workload_source_kind", "workload_traffic_class"} {
-			if v, ok := parentRequest.Metadata[k]; ok {
-				downstreamRequest.Metadata[k] = v
-			}
-		}
-		cid, cz, chid := resolveCallerTopologyForSpawn(state, parentRequest, callerTopology)
-		if cid != "" {
-			downstreamRequest.Metadata["caller_instance_id"] = cid
-		}
-		if cz != "" {
-			downstreamRequest.Metadata["caller_host_zone"] = cz
-		}
-		if chid != "" {
-			downstreamRequest.Metadata["caller_host_id"] = chid
-		}
-		downstreamRequest.ArrivalTime = simTime
-		downstreamRequest.Status = models.RequestStatusFailed
-		if timeoutMs > 0 {
-			downstreamRequest.Metadata["downstream_timeout_ms"] = timeoutMs
-		}
-		reason := metrics.ReasonDependencyFailure
-		if tgtSvc != nil && strings.ToLower(strings.TrimSpace(tgtSvc.Kind)) == "external" {
-			reason = metrics.ReasonExternalFailure
-		}
-		rm := eng.GetRunManager()
-		rm.AddRequest(downstreamRequest)
-		dsLabels := labelsForRequestMetricsWithRetry(downstreamRequest, downstreamServiceID, endpointPath)
-		metrics.RecordRequestCount(state.collector, 1.0, simTime, dsLabels)
-		if maybeRetrySyncDependencyFailure(state, eng, rm, downstreamRequest, simTime, reason, dsCall) {
-			el := metrics.EndpointErrorLabels(dsLabels, reason)
-			metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
-			return nil
-		}
-		finalizeRequestFailure(state, eng, rm, downstreamRequest, simTime, dsLabels, reason)
-		return nil
-	}
-
 	downstreamRequest.Metadata["trace_depth"] = traceDepth
 	downstreamRequest.Metadata["async_depth"] = asyncDepth
 	downstreamRequest.Metadata[metaDownstreamAsync] = isAsync
@@ -206,11 +159,20 @@ func execDownstreamSpawn(state *scenarioState, eng *engine.Engine, parentRequest
 	if retryAttempt > 0 {
 		downstreamRequest.Metadata[metaIsRetry] = true
 	}
-	for _, k := range []string{"workload_from", "workload_source_kind", "workload_traffic_class"} {
+	for _, k := range []string{"workload_from", "workload_source_kind", "workload_traffic_class", metaPriorityClass} {
 		if v, ok := parentRequest.Metadata[k]; ok {
 			downstreamRequest.Metadata[k] = v
 		}
 	}
+	copyPropagatedMetadata(state, downstreamRequest.Metadata, parentRequest.Metadata)
+	if !isAsync && logicalCallID != "" && !noteHedgeAttempt(state, logicalCallID, retryAttempt, downstreamRequest) {
+		// The hedged call was answered while this attempt was being sent.
+		metrics.RecordHedgeCancelled(state.collector, simTime, labelsForRequestMetrics(downstreamRequest, downstreamServiceID, endpointPath))
+		return nil
+	}
+	if !isAsync {
+		noteSyncExecutionChild(state, parentRequest.ID, downstreamRequest)
+	}
 	cid, cz, chid := resolveCallerTopologyForSpawn(state, parentRequest, callerTopology)
 	if cid != "" {
 		downstreamRequest.Metadata["caller_instance_id"] = cid
@@ -229,12 +191,47 @@ func execDownstreamSpawn(state *scenarioState, eng *engine.Engine, parentRequest
 	dsLabels := labelsForRequestMetricsWithRetry(downstreamRequest, downstreamServiceID, endpointPath)
 	metrics.RecordRequestCount(state.collector, 1.0, simTime, dsLabels)
 
+	// Rate-limited or circuit-open hops fail fast: the callee is never reached.
+	if reason := admissionRejectReason(state, downstreamServiceID, endpointPath, simTime, dsLabels); reason != "" {
+		rm := eng.GetRunManager()
+		rm.AddRequest(downstreamRequest)
+		finalizeRequestFailure(state, eng, rm, downstreamRequest, simTime, dsLabels, reason)
+		return nil
+	}
+
+	dsCall, _ := resolveDownstreamCallSpec(state, parentRequest, downstreamServiceID, endpointPath)
+	tgtSvc := state.services[downstreamServiceID]
+	pFail := mergedDependencyFailureRate(dsCall, tgtSvc)
+	if pFail > 0 && state.rng.Float64() < pFail {
+		downstreamRequest.Status = models.RequestStatusFailed
+		reason := metrics.ReasonDependencyFailure
+		if tgtSvc != nil && strings.ToLower(strings.TrimSpace(tgtSvc.Kind)) == "external" {
+			reason = metrics.ReasonExternalFailure
+		}
+		rm := eng.GetRunManager()
+		rm.AddRequest(downstreamRequest)
+		if maybeRetrySyncDependencyFailure(state, eng, rm, downstreamRequest, simTime, reason, dsCall) {
+			el := metrics.EndpointErrorLabels(dsLabels, reason)
+			metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
+			return nil
+		}
+		finalizeRequestFailure(state, eng, rm, downstreamRequest, simTime, dsLabels, reason)
+		return nil
+	}
+
 	inst, err := selectInstanceForRequest(state, downstreamRequest, simTime)
 	if err != nil {
+		// No replica in rotation (e.g. all lost with their hosts): the call fails and the caller sees it.
 		downstreamRequest.Status = models.RequestStatusFailed
-		el := metrics.EndpointErrorLabels(dsLabels, metrics.ReasonNoInstance)
-		metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
-		return fmt.Errorf("no instances available for service %s: %w", downstreamServiceID, err)
+		rm := eng.GetRunManager()
+		rm.AddRequest(downstreamRequest)
+		if maybeRetrySyncStartFailure(state, eng, rm, downstreamRequest, simTime, metrics.ReasonNoInstance) {
+			el := metrics.EndpointErrorLabels(dsLabels, metrics.ReasonNoInstance)
+			metrics.RecordErrorCount(state.collector, 1.0, simTime, el)
+			return nil
+		}
+		finalizeRequestFailure(state, eng, rm, downstreamRequest, simTime, dsLabels, metrics.ReasonNoInstance)
+		return nil
 	}
 	downstreamRequest.Metadata["instance_id"] = inst.ID()
 
@@ -254,10 +251,18 @@ func execDownstreamSpawn(state *scenarioState, eng *engine.Engine, parentRequest
 			"is_async_downstream": isAsync,
 		})
 	}
+	if !isAsync && logicalCallID == "" && dsCall.Hedge != nil {
+		hedged := interaction.ResolvedCall{ServiceID: downstreamServiceID, Path: endpointPath, Call: dsCall}
+		startHedgedCall(state, eng, parentRequest, downstreamRequest, hedged, traceDepth, asyncDepth, callerTopology, dsLabels, simTime)
+	}
 	return nil
 }
 
 func execDownstreamSpawnFromEvent(state *scenarioState, eng *engine.Engine, parentRequest *models.Request, evt *engine.Event) error {
+	// The caller's instance was killed (OOM, host failure) before the call went out.
+	if metadataBool(parentRequest.Metadata, metaInstanceKilled) {
+		return nil
+	}
 	downstreamServiceID := evt.ServiceID
 	endpointPath, ok := evt.Data["endpoint_path"].(string)
 	if !ok {
@@ -327,7 +332,11 @@ func handleDownstreamRetry(state *scenarioState, _ *engine.Engine) engine.EventH
 
 // maybeRetrySyncTimeout handles sync downstream timeout when retries may apply.
 func maybeRetrySyncTimeout(state *scenarioState, eng *engine.Engine, rm *engine.RunManager, child *models.Request, parentID string, _ time.Time) bool {
-	rp := getRetryPolicy(state.policies)
+	parent, ok := rm.GetRequest(parentID)
+	if !ok {
+		return false
+	}
+	rp := retryPolicyForCall(state, parent, child.ServiceName, child.Endpoint)
 	if rp == nil {
 		return false
 	}
@@ -335,10 +344,6 @@ func maybeRetrySyncTimeout(state *scenarioState, eng *engine.Engine, rm *engine.
 	if !rp.ShouldRetry(attempt, retryReasonError(metrics.ReasonTimeout)) {
 		return false
 	}
-	parent, ok := rm.GetRequest(parentID)
-	if !ok {
-		return false
-	}
 	logical := metadataString(child.Metadata, metaLogicalCallID)
 	if logical == "" {
 		logical = child.ID
@@ -366,7 +371,11 @@ func maybeRetrySyncTimeout(state *scenarioState, eng *engine.Engine, rm *engine.
 
 // maybeRetryAsyncTimeout schedules a downstream retry for async children without blocking parents.
 func maybeRetryAsyncTimeout(state *scenarioState, eng *engine.Engine, rm *engine.RunManager, child *models.Request, parentID string, _ time.Time) bool {
-	rp := getRetryPolicy(state.policies)
+	parent, ok := rm.GetRequest(parentID)
+	if !ok {
+		return false
+	}
+	rp := retryPolicyForCall(state, parent, child.ServiceName, child.Endpoint)
 	if rp == nil {
 		return false
 	}
@@ -374,10 +383,6 @@ func maybeRetryAsyncTimeout(state *scenarioState, eng *engine.Engine, rm *engine
 	if !rp.ShouldRetry(attempt, retryReasonError(metrics.ReasonTimeout)) {
 		return false
 	}
-	parent, ok := rm.GetRequest(parentID)
-	if !ok {
-		return false
-	}
 	if child.Metadata == nil {
 		child.Metadata = make(map[string]interface{})
 	}
@@ -410,19 +415,22 @@ func maybeRetryAsyncTimeout(state *scenarioState, eng *engine.Engine, rm *engine
 // maybeRetrySyncCallerOverheadFailure schedules a downstream retry after CPU reservation failure on caller-side
 // downstream overhead (no child request exists yet).
 func maybeRetrySyncCallerOverheadFailure(state *scenarioState, eng *engine.Engine, _ *engine.RunManager, parent *models.Request, evt *engine.Event, _ time.Time, reason string) bool {
-	rp := getRetryPolicy(state.policies)
-	if rp == nil || evt == nil || evt.Data == nil {
+	if evt == nil || evt.Data == nil {
 		return false
 	}
 	if metadataBool(evt.Data, "is_async_downstream") {
 		return false
 	}
+	childSvc := evt.ServiceID
+	childPath := metadataString(evt.Data, "child_endpoint_path")
+	rp := retryPolicyForCall(state, parent, childSvc, childPath)
+	if rp == nil {
+		return false
+	}
 	attempt := metadataInt(evt.Data, metaRetryAttempt)
 	if !rp.ShouldRetry(attempt, retryReasonError(reason)) {
 		return false
 	}
-	childSvc := evt.ServiceID
-	childPath := metadataString(evt.Data, "child_endpoint_path")
 	logical := metadataString(evt.Data, metaLogicalCallID)
 	if logical == "" {
 		logical = parent.ID + ":" + childSvc + ":" + childPath
@@ -459,20 +467,20 @@ func maybeRetrySyncCallerOverheadFailure(state *scenarioState, eng *engine.Engin
 	return true
 }
 
-func maybeRetrySyncStartFailure(state *scenarioState, eng *engine.Engine, rm *engine.RunManager, child *models.Request, _ time.Time, reason string) bool {
-	rp := getRetryPolicy(state.policies)
-	if rp == nil {
+func maybeRetrySyncStartFailure(state *scenarioState, eng *engine.Engine, rm *engine.RunManager, child *models.Request, simTime time.Time, reason string) bool {
+	if child.ParentID == "" || metadataBool(child.Metadata, metaDownstreamAsync) {
 		return false
 	}
-	if child.ParentID == "" || metadataBool(child.Metadata, metaDownstreamAsync) {
+	parent, ok := rm.GetRequest(child.ParentID)
+	if !ok {
 		return false
 	}
-	attempt := metadataInt(child.Metadata, metaRetryAttempt)
-	if !rp.ShouldRetry(attempt, retryReasonError(reason)) {
+	rp := retryPolicyForCall(state, parent, child.ServiceName, child.Endpoint)
+	if rp == nil {
 		return false
 	}
-	parent, ok := rm.GetRequest(child.ParentID)
-	if !ok {
+	attempt := metadataInt(child.Metadata, metaRetryAttempt)
+	if !rp.ShouldRetry(attempt, retryReasonError(reason)) {
 		return false
 	}
 	logical := metadataString(child.Metadata, metaLogicalCallID)
@@ -497,5 +505,6 @@ func maybeRetrySyncStartFailure(state *scenarioState, eng *engine.Engine, rm *en
 		callerHostID,
 		delay,
 	)
+	settleCacheLoad(state, eng, child, simTime)
 	return true
 }
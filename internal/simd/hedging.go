@@ -0,0 +1,337 @@
+package simd
+
+import (
+	"sort"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/interaction"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// Hedged call metadata on attempt requests.
+const (
+	metaHedgeAttempt           = "hedge_attempt"
+	metaHedgeLoser             = "hedge_loser"
+	metaHedgeCancelled         = "hedge_cancelled"
+	metaHedgeWonAt             = "hedge_won_at"
+	metaHedgeSkippedDownstream = "hedge_skipped_downstream"
+)
+
+const (
+	// hedgeLatencyWindowSize is the number of recent latencies kept per hedged edge.
+	hedgeLatencyWindowSize = 512
+	// hedgeMinLatencySamples is the number of latencies an edge needs before delay_percentile replaces delay_ms.
+	hedgeMinLatencySamples = 20
+)
+
+// hedgeLatencyWindow is a ring buffer of recent call latencies (ms) on one hedged edge.
+type hedgeLatencyWindow struct {
+	samples []float64
+	next    int
+}
+
+func (w *hedgeLatencyWindow) add(ms float64) {
+	if len(w.samples) < hedgeLatencyWindowSize {
+		w.samples = append(w.samples, ms)
+		return
+	}
+	w.samples[w.next] = ms
+	w.next = (w.next + 1) % hedgeLatencyWindowSize
+}
+
+// percentile returns the p-th percentile (nearest rank) of the window, or false with too few samples.
+func (w *hedgeLatencyWindow) percentile(p float64) (float64, bool) {
+	if len(w.samples) < hedgeMinLatencySamples {
+		return 0, false
+	}
+	sorted := append([]float64(nil), w.samples...)
+	sort.Float64s(sorted)
+	i := int(p / 100 * float64(len(sorted)))
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i], true
+}
+
+// hedgedCall is one sync call on an edge with a hedge policy. Its attempts share the logical call ID
+// of the first one; the parent counts the call once in pendingSync, so only the first successful
+// attempt, or the last failing one, reports back to it.
+type hedgedCall struct {
+	parentID       string
+	edge           string
+	policy         *config.HedgePolicy
+	call           interaction.ResolvedCall
+	traceDepth     int
+	asyncDepth     int
+	callerTopology downstreamCallerTopology
+	labels         map[string]string
+	// firstArrival is the arrival of the first attempt, which call latencies are measured from.
+	firstArrival time.Time
+	// live holds the attempt request IDs that may still answer.
+	live map[string]bool
+	// launching counts hedge attempts sent but not yet spawned (caller overhead in progress).
+	launching int
+	sent      int
+	done      bool
+}
+
+func hedgeEdgeKey(parent *models.Request, serviceID, path string) string {
+	return parent.ServiceName + ":" + parent.Endpoint + "->" + serviceID + ":" + path
+}
+
+// hedgeDelay returns the delay before each hedge of a call on edge. Callers hold hedgeMu.
+func hedgeDelay(state *scenarioState, edge string, h *config.HedgePolicy) (time.Duration, bool) {
+	ms := h.DelayMs
+	if h.DelayPercentile > 0 {
+		if w := state.hedgeLatencies[edge]; w != nil {
+			if p, ok := w.percentile(h.DelayPercentile); ok {
+				ms = p
+			}
+		}
+	}
+	if ms <= 0 {
+		return 0, false
+	}
+	return time.Duration(ms * float64(time.Millisecond)), true
+}
+
+// startHedgedCall tracks the first attempt of a sync call on a hedged edge and schedules its first hedge.
+func startHedgedCall(state *scenarioState, eng *engine.Engine, parent, child *models.Request, call interaction.ResolvedCall, traceDepth, asyncDepth int, callerTopology downstreamCallerTopology, labels map[string]string, simTime time.Time) {
+	hc := &hedgedCall{
+		parentID:       parent.ID,
+		edge:           hedgeEdgeKey(parent, call.ServiceID, call.Path),
+		policy:         call.Call.Hedge,
+		call:           call,
+		traceDepth:     traceDepth,
+		asyncDepth:     asyncDepth,
+		callerTopology: callerTopology,
+		labels:         labels,
+		firstArrival:   child.ArrivalTime,
+		live:           map[string]bool{child.ID: true},
+	}
+	if hc.firstArrival.IsZero() {
+		hc.firstArrival = simTime
+	}
+	state.hedgeMu.Lock()
+	state.hedgedCalls[child.ID] = hc
+	state.hedgesByParent[parent.ID] = append(state.hedgesByParent[parent.ID], child.ID)
+	delay, ok := hedgeDelay(state, hc.edge, hc.policy)
+	state.hedgeMu.Unlock()
+	metrics.RecordHedgedCall(state.collector, simTime, labels)
+	if ok {
+		eng.ScheduleAt(engine.EventTypeDownstreamHedge, simTime.Add(delay), parent, call.ServiceID, map[string]interface{}{
+			metaLogicalCallID: child.ID,
+		})
+	}
+}
+
+// handleDownstreamHedge sends a hedge attempt of a call that has not answered yet, and schedules the next one.
+func handleDownstreamHedge(state *scenarioState, _ *engine.Engine) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		simTime := eng.GetSimTime()
+		state.rm.NoteSimTime(simTime)
+		parent := evt.Request
+		if parent == nil || metadataBool(parent.Metadata, metaDESFinalized) {
+			return nil
+		}
+		logicalID := metadataString(evt.Data, metaLogicalCallID)
+		state.hedgeMu.Lock()
+		hc, ok := state.hedgedCalls[logicalID]
+		if !ok || hc.done || hc.sent >= hc.policy.Hedges() {
+			state.hedgeMu.Unlock()
+			return nil
+		}
+		hc.sent++
+		hc.launching++
+		more := hc.sent < hc.policy.Hedges()
+		delay, hasDelay := hedgeDelay(state, hc.edge, hc.policy)
+		state.hedgeMu.Unlock()
+
+		metrics.RecordHedgeRequest(state.collector, simTime, hc.labels)
+		scheduleDownstreamWithCallerOverhead(state, eng, parent, hc.call, simTime, hc.traceDepth, hc.asyncDepth, false, true, 0, logicalID, hc.callerTopology)
+		if more && hasDelay {
+			eng.ScheduleAt(engine.EventTypeDownstreamHedge, simTime.Add(delay), parent, evt.ServiceID, evt.Data)
+		}
+		return nil
+	}
+}
+
+// noteHedgeAttempt records a later attempt (hedge or retry) of a sync call spawned with a logical call
+// ID. It returns false when the call has already been answered and the attempt should not be sent.
+func noteHedgeAttempt(state *scenarioState, logicalID string, retryAttempt int, child *models.Request) bool {
+	state.hedgeMu.Lock()
+	defer state.hedgeMu.Unlock()
+	hc, ok := state.hedgedCalls[logicalID]
+	if !ok {
+		return true
+	}
+	hedge := retryAttempt == 0
+	if hedge && hc.launching > 0 {
+		hc.launching--
+	}
+	if hc.done {
+		return false
+	}
+	if hedge {
+		child.Metadata[metaHedgeAttempt] = true
+	}
+	hc.live[child.ID] = true
+	return true
+}
+
+// hedgeLaunchFailed records a hedge attempt that failed in caller overhead, before any child existed,
+// and was retried or not. It reports whether the parent must not be notified: the call is not
+// failed while a retry or another attempt may still answer it.
+func hedgeLaunchFailed(state *scenarioState, data map[string]interface{}, retried bool) bool {
+	logicalID := metadataString(data, metaLogicalCallID)
+	if logicalID == "" || metadataInt(data, metaRetryAttempt) != 0 {
+		return false
+	}
+	state.hedgeMu.Lock()
+	defer state.hedgeMu.Unlock()
+	hc, ok := state.hedgedCalls[logicalID]
+	if !ok {
+		return false
+	}
+	if hc.launching > 0 {
+		hc.launching--
+	}
+	if hc.done || retried || hc.launching > 0 || len(hc.live) > 0 {
+		return true
+	}
+	hc.done = true
+	return false
+}
+
+// resolveHedgedAttempt records the outcome of an attempt of a hedged call before the parent is
+// notified. It reports true when the outcome is absorbed: a failure while other attempts may still
+// answer. The first success answers the call and turns the other attempts into losers.
+func resolveHedgedAttempt(state *scenarioState, eng *engine.Engine, child *models.Request, simTime time.Time, failed bool) bool {
+	logicalID := metadataString(child.Metadata, metaLogicalCallID)
+	rm := eng.GetRunManager()
+	state.hedgeMu.Lock()
+	hc, ok := state.hedgedCalls[logicalID]
+	if !ok {
+		state.hedgeMu.Unlock()
+		return false
+	}
+	delete(hc.live, child.ID)
+	var others []*models.Request
+	for id := range hc.live {
+		if c, ok := rm.GetRequest(id); ok && !metadataBool(c.Metadata, metaCallerSyncResolved) {
+			others = append(others, c)
+		} else {
+			delete(hc.live, id)
+		}
+	}
+	if hc.done {
+		state.hedgeMu.Unlock()
+		return failed
+	}
+	if failed {
+		if len(others) > 0 || hc.launching > 0 {
+			state.hedgeMu.Unlock()
+			return true
+		}
+		hc.done = true
+		state.hedgeMu.Unlock()
+		return false
+	}
+	hc.done = true
+	hc.live = make(map[string]bool)
+	w := state.hedgeLatencies[hc.edge]
+	if w == nil {
+		w = &hedgeLatencyWindow{}
+		state.hedgeLatencies[hc.edge] = w
+	}
+	// The window holds call latencies from the first attempt's arrival. A winning hedge measured
+	// from its own arrival would leave out the hedge delay and pull delay_percentile down call after call.
+	w.add(float64(simTime.Sub(hc.firstArrival).Microseconds()) / 1000)
+	state.hedgeMu.Unlock()
+
+	if metadataBool(child.Metadata, metaHedgeAttempt) {
+		metrics.RecordHedgeWin(state.collector, simTime, hc.labels)
+	}
+	cancel := hc.policy.CancelsLosers()
+	for _, c := range others {
+		c.Metadata[metaCallerSyncResolved] = true
+		c.Metadata[metaHedgeLoser] = true
+		c.Metadata[metaHedgeWonAt] = simTime
+		rolledBack := false
+		if cancel {
+			c.Metadata[metaHedgeCancelled] = true
+			if metadataBool(c.Metadata, metaCPUDeferredStart) {
+				t0, ok0 := metadataTime(c.Metadata, metaCPUServiceStart)
+				t1, ok1 := metadataTime(c.Metadata, metaCPUServiceEnd)
+				if ok0 && ok1 {
+					state.rm.RollbackCPUTailReservation(metadataString(c.Metadata, "instance_id"), t0, t1)
+					rolledBack = true
+				}
+			}
+		}
+		// Only CPU an attempt has started using is wasted; a rolled-back reservation is never used,
+		// and a loser that starts later is counted by recordHedgeLoserStart.
+		if cpuMs, ok := c.Metadata["allocated_cpu_ms"].(float64); ok && !rolledBack {
+			metrics.RecordHedgeWastedCPU(state.collector, cpuMs, simTime, labelsForRequestMetrics(c, c.ServiceName, c.Endpoint))
+		}
+	}
+	return false
+}
+
+// dropCancelledHedgeAttempt finalizes a cancelled losing attempt at its start, before it uses the
+// callee, and reports whether it did.
+func dropCancelledHedgeAttempt(state *scenarioState, eng *engine.Engine, request *models.Request, simTime time.Time) bool {
+	if !metadataBool(request.Metadata, metaHedgeCancelled) {
+		return false
+	}
+	request.Metadata[metaDESFinalized] = true
+	request.Status = models.RequestStatusFailed
+	request.Error = metaHedgeCancelled
+	request.CompletionTime = simTime
+	request.Duration = simTime.Sub(request.ArrivalTime)
+	eng.GetRunManager().FinalizeRequest(request)
+	metrics.RecordHedgeCancelled(state.collector, simTime, labelsForRequestMetrics(request, request.ServiceName, request.Endpoint))
+	return true
+}
+
+// recordHedgeLoserStart counts the CPU of a losing attempt that was not cancelled as wasted when it starts.
+func recordHedgeLoserStart(state *scenarioState, request *models.Request, cpuMs float64, simTime time.Time) {
+	if metadataBool(request.Metadata, metaHedgeLoser) {
+		metrics.RecordHedgeWastedCPU(state.collector, cpuMs, simTime, labelsForRequestMetrics(request, request.ServiceName, request.Endpoint))
+	}
+}
+
+// hedgeAttemptSkipsDownstream reports whether a cancelled losing attempt should skip its downstream calls.
+func hedgeAttemptSkipsDownstream(request *models.Request) bool {
+	if !metadataBool(request.Metadata, metaHedgeCancelled) {
+		return false
+	}
+	request.Metadata[metaHedgeSkippedDownstream] = true
+	return true
+}
+
+// recordHedgeLatencyGain records, for a first attempt that lost to a hedge and still completed in
+// full, how much later than the winner it answered.
+func recordHedgeLatencyGain(state *scenarioState, request *models.Request, simTime time.Time, labels map[string]string) {
+	if metadataBool(request.Metadata, metaHedgeAttempt) || metadataBool(request.Metadata, metaHedgeSkippedDownstream) {
+		return
+	}
+	wonAt, ok := metadataTime(request.Metadata, metaHedgeWonAt)
+	if !ok {
+		return
+	}
+	metrics.RecordHedgeLatencyGain(state.collector, float64(simTime.Sub(wonAt).Microseconds())/1000, simTime, labels)
+}
+
+// forgetHedgedCalls drops the hedged calls of a finalized parent.
+func forgetHedgedCalls(state *scenarioState, parentID string) {
+	state.hedgeMu.Lock()
+	defer state.hedgeMu.Unlock()
+	for _, id := range state.hedgesByParent[parentID] {
+		delete(state.hedgedCalls, id)
+	}
+	delete(state.hedgesByParent, parentID)
+}
@@ -0,0 +1,136 @@
+package simd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// hedgeTestScenario calls b:/b from a:/a; one b call in ten takes about 200ms instead of a few.
+func hedgeTestScenario(hedge *config.HedgePolicy) *config.Scenario {
+	b := config.Endpoint{Path: "/b", MeanCPUMs: 1, DefaultMemoryMB: 16, NetLatencyMs: config.LatencySpec{
+		Distribution: "empirical",
+		Histogram:    []config.HistogramBin{{UpToMs: 5, Weight: 9}, {UpToMs: 195, Weight: 0}, {UpToMs: 200, Weight: 1}},
+	}}
+	return &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 16}},
+		Services: []config.Service{
+			{ID: "a", Replicas: 1, Model: "cpu", Endpoints: []config.Endpoint{{
+				Path: "/a", MeanCPUMs: 1, DefaultMemoryMB: 16,
+				Downstream: []config.DownstreamCall{{To: "b:/b", Hedge: hedge}},
+			}}},
+			{ID: "b", Replicas: 2, Model: "cpu", Endpoints: []config.Endpoint{b}},
+		},
+		Workload: []config.WorkloadPattern{{From: "client", To: "a:/a", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 100}}},
+	}
+}
+
+// runHedged sends n requests to a:/a, 10ms apart, and returns the collector and the root latency aggregation.
+func runHedged(t *testing.T, scenario *config.Scenario, n int) (*metrics.Collector, *models.Aggregation) {
+	t.Helper()
+	collector, roots, _ := runHedgedState(t, scenario, n)
+	return collector, roots
+}
+
+// runHedgedState is runHedged that also returns the scenario state.
+func runHedgedState(t *testing.T, scenario *config.Scenario, n int) (*metrics.Collector, *models.Aggregation, *scenarioState) {
+	t.Helper()
+	r := newTestRun(t, scenario, 7)
+	r.scheduleArrivals("a", "/a", every(10*time.Millisecond, n)...)
+	r.run(t, time.Duration(n)*10*time.Millisecond+time.Second)
+	r.state.hedgeMu.Lock()
+	defer r.state.hedgeMu.Unlock()
+	if len(r.state.hedgedCalls) != 0 {
+		t.Fatalf("expected hedged calls to be dropped with their parents, got %d", len(r.state.hedgedCalls))
+	}
+	roots := r.collector.GetMetricAggregation(metrics.MetricRootRequestLatency)
+	if roots == nil || roots.Count != int64(n) {
+		t.Fatalf("expected %d root requests to finish, got %+v", n, roots)
+	}
+	if f := r.collector.SumMetric(metrics.MetricIngressLogicalFailure); f != 0 {
+		t.Fatalf("expected no root request to fail, got %v", f)
+	}
+	return r.collector, roots, r.state
+}
+
+func TestHedgingCutsTailLatency(t *testing.T) {
+	const n = 400
+	_, plain := runHedged(t, hedgeTestScenario(nil), n)
+	collector, hedged := runHedged(t, hedgeTestScenario(&config.HedgePolicy{DelayMs: 20}), n)
+	if plain.P95 < 150 || hedged.P95 > 60 {
+		t.Fatalf("expected hedging to cut p95 root latency from ~200ms, got %v -> %v", plain.P95, hedged.P95)
+	}
+
+	rm := metrics.ConvertToRunMetrics(collector, nil, nil)
+	if rm.HedgedCallsTotal != n {
+		t.Fatalf("expected every call to be hedged, got %d", rm.HedgedCallsTotal)
+	}
+	if rm.HedgeRate < 0.05 || rm.HedgeRate > 0.2 {
+		t.Fatalf("expected about one call in ten to send a hedge, got rate %v", rm.HedgeRate)
+	}
+	if rm.HedgeWinsTotal == 0 || rm.HedgeWinsTotal > rm.HedgeRequestsTotal {
+		t.Fatalf("expected hedges to win some calls, got %d wins of %d hedges", rm.HedgeWinsTotal, rm.HedgeRequestsTotal)
+	}
+	// Slow first attempts have already used their CPU when they lose, and still answer later.
+	if rm.HedgeWastedCPUMsTotal < float64(rm.HedgeWinsTotal) || rm.HedgeLatencyGainMsMean < 100 {
+		t.Fatalf("expected wasted CPU and a latency gain from losing first attempts, got %+v", rm)
+	}
+}
+
+func TestHedgingCancelsQueuedLosers(t *testing.T) {
+	// b has one replica that takes 50ms per call, so the hedge waits for CPU behind the first
+	// attempt, which wins; the hedge is cancelled before it starts.
+	scenario := hedgeTestScenario(&config.HedgePolicy{DelayMs: 10})
+	scenario.Services[1].Replicas = 1
+	scenario.Services[1].CPUCores = 1
+	scenario.Services[1].Endpoints[0] = config.Endpoint{Path: "/b", MeanCPUMs: 50, DefaultMemoryMB: 16}
+	collector, roots := runHedged(t, scenario, 1)
+	rm := metrics.ConvertToRunMetrics(collector, nil, nil)
+	if rm.HedgeRequestsTotal != 1 || rm.HedgeWinsTotal != 0 || rm.HedgeCancelledTotal != 1 || rm.HedgeWastedCPUMsTotal != 0 {
+		t.Fatalf("expected one cancelled hedge and no wasted CPU, got %+v", rm)
+	}
+	if roots.Max > 60 {
+		t.Fatalf("expected the first attempt to answer in about 50ms, got %v", roots.Max)
+	}
+	if n := collector.SumMetricWhere(metrics.MetricRequestCount, "service", "b"); n != 2 {
+		t.Fatalf("expected two attempts to b, got %v", n)
+	}
+}
+
+func TestHedgeDelayPercentileMeasuresFromFirstAttempt(t *testing.T) {
+	// Every b call answers within 5ms unless it is one of the slow ones, which hedges win 20ms in.
+	// Measured from their own arrival the winning hedges would pull p99 under 5ms.
+	_, _, state := runHedgedState(t, hedgeTestScenario(&config.HedgePolicy{DelayMs: 20, DelayPercentile: 99}), 400)
+	state.hedgeMu.Lock()
+	defer state.hedgeMu.Unlock()
+	w := state.hedgeLatencies["a:/a->b:/b"]
+	if w == nil {
+		t.Fatal("expected latencies recorded for the hedged edge")
+	}
+	if p, ok := w.percentile(99); !ok || p < 20 {
+		t.Fatalf("expected the p99 call latency to include the hedge delay, got %v", p)
+	}
+}
+
+func TestHedgeLatencyWindowPercentile(t *testing.T) {
+	w := &hedgeLatencyWindow{}
+	for i := 1; i < hedgeMinLatencySamples; i++ {
+		w.add(float64(i))
+	}
+	if _, ok := w.percentile(95); ok {
+		t.Fatalf("expected no percentile before %d samples", hedgeMinLatencySamples)
+	}
+	for i := hedgeMinLatencySamples; i <= hedgeLatencyWindowSize+100; i++ {
+		w.add(float64(i))
+	}
+	if len(w.samples) != hedgeLatencyWindowSize {
+		t.Fatalf("expected the window to keep %d samples, got %d", hedgeLatencyWindowSize, len(w.samples))
+	}
+	// The window holds 101..612 after wrapping.
+	if p, _ := w.percentile(50); p != 357 {
+		t.Fatalf("expected median 357 of the recent samples, got %v", p)
+	}
+}
@@ -0,0 +1,214 @@
+package simd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/logger"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// metaOutageArrival marks an ingress request that arrived while a host was down; its failure counts
+// against the outage availability.
+const metaOutageArrival = "outage_arrival"
+
+// hostFailureState tracks the hosts taken down by scenario host_failures.
+type hostFailureState struct {
+	failures []config.HostFailure
+	hosts    []config.Host
+
+	mu   sync.Mutex
+	down map[string]bool
+	// pending holds replacements no host could take when they were due; they are retried whenever a
+	// host recovers.
+	pending []pendingReplacement
+}
+
+// pendingReplacement is a lost instance waiting for a host.
+type pendingReplacement struct {
+	serviceID string
+	cpuCores  float64
+	memoryMB  float64
+}
+
+// newHostFailureState returns nil unless the scenario has host_failures.
+func newHostFailureState(scenario *config.Scenario) *hostFailureState {
+	if len(scenario.HostFailures) == 0 {
+		return nil
+	}
+	return &hostFailureState{
+		failures: scenario.HostFailures,
+		hosts:    scenario.Hosts,
+		down:     make(map[string]bool),
+	}
+}
+
+// inOutage reports whether any host is down.
+func (h *hostFailureState) inOutage() bool {
+	if h == nil {
+		return false
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.down) > 0
+}
+
+// setDown records a host going down or coming back and returns the number of hosts down.
+func (h *hostFailureState) setDown(hostID string, down bool) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if down {
+		h.down[hostID] = true
+	} else {
+		delete(h.down, hostID)
+	}
+	return len(h.down)
+}
+
+// ScheduleHostFailureKickoff schedules the scenario host failures, relative to startTime.
+func ScheduleHostFailureKickoff(eng *engine.Engine, state *scenarioState, startTime time.Time) {
+	if state.hostFailures == nil {
+		return
+	}
+	for i := range state.hostFailures.failures {
+		eng.ScheduleAt(engine.EventTypeHostFailure, startTime.Add(state.hostFailures.failures[i].At()), nil, "", map[string]interface{}{
+			"failure": i,
+		})
+	}
+}
+
+// noteOutageArrival counts an ingress request arriving while a host is down.
+func noteOutageArrival(state *scenarioState, request *models.Request, simTime time.Time) {
+	if !state.hostFailures.inOutage() {
+		return
+	}
+	request.Metadata[metaOutageArrival] = true
+	metrics.RecordOutageRequest(state.collector, simTime, metrics.CreateEndpointLabels(request.ServiceName, request.Endpoint))
+}
+
+// recordIngressFailure records a user-visible failure of a root request, also against the outage
+// availability when the request arrived while a host was down.
+func recordIngressFailure(state *scenarioState, request *models.Request, simTime time.Time, errLabels map[string]string) {
+	metrics.RecordIngressLogicalFailure(state.collector, 1.0, simTime, errLabels)
+	if metadataBool(request.Metadata, metaOutageArrival) {
+		metrics.RecordOutageFailure(state.collector, simTime, metrics.CreateEndpointLabels(request.ServiceName, request.Endpoint))
+	}
+}
+
+// handleHostFailure takes down the hosts of one scenario host failure. Their instances die with
+// their work, replacements are scheduled after the reschedule delay, and the hosts recover after
+// recover_after_ms when set.
+func handleHostFailure(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		hf := state.hostFailures
+		i := metadataInt(evt.Data, "failure")
+		if hf == nil || i < 0 || i >= len(hf.failures) {
+			return nil
+		}
+		simTime := eng.GetSimTime()
+		state.rm.NoteSimTime(simTime)
+		f := &hf.failures[i]
+		for _, hostID := range f.HostIDs(hf.hosts) {
+			if !failHost(state, eng, hostID, f.RescheduleDelay(), simTime) {
+				continue
+			}
+			if f.RecoverAfterMs > 0 {
+				eng.ScheduleAt(engine.EventTypeHostRecover, simTime.Add(f.RecoverAfter()), nil, "", map[string]interface{}{
+					"host_id": hostID,
+				})
+			}
+		}
+		return nil
+	}
+}
+
+// failHost takes a host down: requests on its instances fail with host_failure and a replacement
+// for each instance that was not draining is due after rescheduleDelay. It reports false when the
+// host was already down.
+func failHost(state *scenarioState, eng *engine.Engine, hostID string, rescheduleDelay time.Duration, simTime time.Time) bool {
+	host, ok := state.rm.GetHost(hostID)
+	if !ok || host.IsDown() {
+		return false
+	}
+	lost, dropped := state.rm.FailHost(hostID, simTime)
+	metrics.RecordHostFailure(state.collector, simTime, metrics.CreateHostZoneLabels(hostID, host.Zone()))
+	metrics.RecordHostsDown(state.collector, float64(state.hostFailures.setDown(hostID, true)), simTime)
+	for _, inst := range lost {
+		metrics.RecordInstanceLost(state.collector, simTime, metrics.CreateInstanceHostLabels(inst.ServiceName(), inst.ID(), hostID))
+		failKilledInstanceRequests(state, eng, inst.ID(), dropped, metrics.ReasonHostFailure, simTime)
+		if inst.Lifecycle() == resource.InstanceDraining {
+			continue
+		}
+		eng.ScheduleAt(engine.EventTypeInstanceReschedule, simTime.Add(rescheduleDelay), nil, inst.ServiceName(), map[string]interface{}{
+			"service_id": inst.ServiceName(),
+			"cpu_cores":  inst.CPUCores(),
+			"memory_mb":  inst.MemoryMB(),
+		})
+	}
+	return true
+}
+
+// handleHostRecover brings a failed host back up and places the replacements that were waiting for a host.
+func handleHostRecover(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		hf := state.hostFailures
+		hostID := metadataString(evt.Data, "host_id")
+		if hf == nil || !state.rm.RecoverHost(hostID) {
+			return nil
+		}
+		simTime := eng.GetSimTime()
+		state.rm.NoteSimTime(simTime)
+		if host, ok := state.rm.GetHost(hostID); ok {
+			metrics.RecordHostRecovery(state.collector, simTime, metrics.CreateHostZoneLabels(hostID, host.Zone()))
+		}
+		metrics.RecordHostsDown(state.collector, float64(hf.setDown(hostID, false)), simTime)
+
+		hf.mu.Lock()
+		pending := hf.pending
+		hf.pending = nil
+		hf.mu.Unlock()
+		for _, p := range pending {
+			placeReplacement(state, p, simTime)
+		}
+		return nil
+	}
+}
+
+// handleInstanceReschedule places a replacement for an instance lost with its host.
+func handleInstanceReschedule(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		if state.hostFailures == nil {
+			return nil
+		}
+		simTime := eng.GetSimTime()
+		state.rm.NoteSimTime(simTime)
+		placeReplacement(state, pendingReplacement{
+			serviceID: metadataString(evt.Data, "service_id"),
+			cpuCores:  metadataFloat64(evt.Data, "cpu_cores"),
+			memoryMB:  metadataFloat64(evt.Data, "memory_mb"),
+		}, simTime)
+		return nil
+	}
+}
+
+// placeReplacement places a replacement on a host that fits it and the service placement; it waits
+// for a host to recover when none does. It is dropped once the service is back at its desired replicas.
+func placeReplacement(state *scenarioState, p pendingReplacement, simTime time.Time) {
+	inst, err := state.rm.PlaceReplacement(p.serviceID, p.cpuCores, p.memoryMB, simTime)
+	if err != nil {
+		logger.Debug("replacement instance not placed", "service_id", p.serviceID, "error", err)
+		state.hostFailures.mu.Lock()
+		state.hostFailures.pending = append(state.hostFailures.pending, p)
+		state.hostFailures.mu.Unlock()
+		return
+	}
+	if inst == nil {
+		return
+	}
+	metrics.RecordInstanceReschedule(state.collector, simTime, metrics.CreateInstanceHostLabels(p.serviceID, inst.ID(), inst.HostID()))
+	recordInstanceAndHostGauges(state, p.serviceID, inst.ID(), simTime)
+}
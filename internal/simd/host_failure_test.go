@@ -0,0 +1,131 @@
+package simd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// zoneFailureScenario runs api in both zones and ledger, which api calls, only in zone-a. Zone-a
+// fails at 1s.
+func zoneFailureScenario(f config.HostFailure) *config.Scenario {
+	f.Zone, f.AtMs = "zone-a", 1000
+	return &config.Scenario{
+		Hosts: []config.Host{
+			{ID: "a-1", Cores: 8, Zone: "zone-a"},
+			{ID: "b-1", Cores: 8, Zone: "zone-b"},
+		},
+		Services: []config.Service{
+			{ID: "api", Replicas: 2, Model: "cpu", CPUCores: 1, Endpoints: []config.Endpoint{{
+				Path: "/work", MeanCPUMs: 5, NetLatencyMs: config.LatencySpec{Mean: 100},
+				Downstream: []config.DownstreamCall{{To: "ledger:/post"}},
+			}}},
+			{ID: "ledger", Replicas: 1, Model: "cpu", CPUCores: 1, Placement: &config.PlacementPolicy{RequiredZones: []string{"zone-a"}},
+				Endpoints: []config.Endpoint{{Path: "/post", MeanCPUMs: 5, NetLatencyMs: config.LatencySpec{Mean: 100}}}},
+		},
+		Workload:     []config.WorkloadPattern{{From: "client", To: "api:/work", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 20}}},
+		HostFailures: []config.HostFailure{f},
+	}
+}
+
+// runHostFailure sends a request to api:/work every 50ms for 5s and returns the run metrics.
+func runHostFailure(t *testing.T, scenario *config.Scenario) (*metrics.Collector, *resource.Manager, *models.RunMetrics) {
+	t.Helper()
+	r := newTestRun(t, scenario, 9)
+	ScheduleHostFailureKickoff(r.eng, r.state, r.eng.GetSimTime())
+	r.scheduleArrivals("api", "/work", every(50*time.Millisecond, 100)...)
+	r.run(t, 10*time.Second)
+	out := &models.RunMetrics{}
+	metrics.AttachHostFailureStats(r.collector, out)
+	return r.collector, r.rm, out
+}
+
+func TestZoneFailureReschedulesOutsideTheZoneAndRecovers(t *testing.T) {
+	collector, rm, rmx := runHostFailure(t, zoneFailureScenario(config.HostFailure{RescheduleDelayMs: 500, RecoverAfterMs: 2000}))
+
+	if rmx.HostFailuresTotal != 1 || rmx.InstancesLostTotal != 2 {
+		t.Fatalf("expected a-1 to fail with api and ledger, got %d failures / %d lost", rmx.HostFailuresTotal, rmx.InstancesLostTotal)
+	}
+	if got := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonHostFailure); got < 1 {
+		t.Fatalf("expected in-flight requests on a-1 to fail with host_failure, got %v", got)
+	}
+	// api is replaced on b-1 after 500ms; ledger may only run in zone-a, so it waits for a-1 at 3s.
+	if rmx.InstancesRescheduledTotal != 2 {
+		t.Fatalf("expected both lost instances replaced, got %d", rmx.InstancesRescheduledTotal)
+	}
+	if got := collector.SumMetricWhere(metrics.MetricInstanceRescheduleCount, "service", "api"); got != 1 ||
+		collector.SumMetricWhere(metrics.MetricInstanceRescheduleCount, "host", "b-1") != 1 {
+		t.Fatalf("expected the api replacement on b-1")
+	}
+	ledger := rm.GetInstancesForService("ledger")
+	if len(ledger) != 1 || ledger[0].HostID() != "a-1" {
+		t.Fatalf("expected the ledger replacement on recovered a-1, got %v", ledger)
+	}
+	if rm.ActiveReplicas("api") != 2 || rm.IsHostDown("a-1") {
+		t.Fatal("expected both api replicas serving and a-1 up after recovery")
+	}
+	if down, ok := collector.GetLastValue(metrics.MetricHostsDown, map[string]string{}); !ok || down != 0 {
+		t.Fatalf("expected hosts_down back at 0, got %v", down)
+	}
+
+	// Requests arriving in the 2s outage cannot reach ledger; the rest of the run is served. The
+	// arrival at 3s may be handled before the recovery.
+	if rmx.OutageRequests < 40 || rmx.OutageRequests > 41 {
+		t.Fatalf("expected 40 requests during the outage, got %d", rmx.OutageRequests)
+	}
+	if rmx.OutageAvailability > 0.1 {
+		t.Fatalf("expected the outage to be almost unavailable, got %v", rmx.OutageAvailability)
+	}
+	if failed := collector.SumMetric(metrics.MetricIngressLogicalFailure); failed > float64(rmx.OutageRequests)+5 {
+		t.Fatalf("expected failures confined to the outage, got %v ingress failures", failed)
+	}
+}
+
+func TestHostFailureWithoutRecoveryKeepsHostDown(t *testing.T) {
+	scenario := zoneFailureScenario(config.HostFailure{})
+	// Without the zone constraint ledger can move to zone-b.
+	scenario.Services[1].Placement = nil
+	collector, rm, rmx := runHostFailure(t, scenario)
+	if !rm.IsHostDown("a-1") || collector.SumMetric(metrics.MetricHostRecoveryCount) != 0 {
+		t.Fatal("expected a-1 to stay down")
+	}
+	for _, svc := range []string{"api", "ledger"} {
+		for _, inst := range rm.GetInstancesForService(svc) {
+			if inst.HostID() != "b-1" {
+				t.Fatalf("expected %s only on b-1, got %s", svc, inst.HostID())
+			}
+		}
+	}
+	if rmx.InstancesRescheduledTotal != 2 || rm.ActiveReplicas("api") != 2 || rm.ActiveReplicas("ledger") != 1 {
+		t.Fatalf("expected both lost replicas replaced at once, got %d", rmx.InstancesRescheduledTotal)
+	}
+	if rmx.OutageAvailability < 0.95 {
+		t.Fatalf("expected requests after the failure to be served, got availability %v", rmx.OutageAvailability)
+	}
+}
+
+func TestHostFailureDoesNotReplaceDrainingInstances(t *testing.T) {
+	scenario := zoneFailureScenario(config.HostFailure{RescheduleDelayMs: 500})
+	scenario.Services[1].Placement = nil
+	r := newTestRun(t, scenario, 9)
+	start := r.eng.GetSimTime()
+	for _, inst := range r.rm.GetInstancesForService("api") {
+		if inst.HostID() == "a-1" {
+			inst.SetDraining(start.Add(time.Hour))
+		}
+	}
+	ScheduleHostFailureKickoff(r.eng, r.state, start)
+	r.run(t, 3*time.Second)
+	out := &models.RunMetrics{}
+	metrics.AttachHostFailureStats(r.collector, out)
+	if out.InstancesLostTotal != 2 || out.InstancesRescheduledTotal != 1 {
+		t.Fatalf("expected only ledger replaced of the 2 lost instances, got %d of %d", out.InstancesRescheduledTotal, out.InstancesLostTotal)
+	}
+	if r.rm.ProvisionedReplicas("api") != 1 || r.rm.ProvisionedReplicas("ledger") != 1 {
+		t.Fatalf("expected the draining api replica gone for good, got api=%d ledger=%d", r.rm.ProvisionedReplicas("api"), r.rm.ProvisionedReplicas("ledger"))
+	}
+}
@@ -289,6 +289,17 @@ func (s *HTTPServer) handleRunByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Check for /faults suffix
+	if strings.HasSuffix(path, "/faults") {
+		runID := strings.TrimSuffix(path, "/faults")
+		if r.Method == http.MethodPost {
+			s.handleInjectFaults(w, r, runID)
+		} else {
+			s.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+		return
+	}
+
 	// Check for /configuration suffix
 	if strings.HasSuffix(path, "/configuration") {
 		runID := strings.TrimSuffix(path, "/configuration")
@@ -438,6 +449,9 @@ func (s *HTTPServer) handleGetRun(w http.ResponseWriter, _ *http.Request, runID
 	if len(rec.OptimizationHistory) > 0 {
 		runJSON["optimization_history"] = convertOptimizationHistoryToJSON(rec.OptimizationHistory)
 	}
+	if rec.Replications != nil {
+		runJSON["replications"] = rec.Replications
+	}
 	s.writeJSON(w, http.StatusOK, map[string]any{
 		"run": runJSON,
 	})
@@ -591,12 +605,13 @@ func (s *HTTPServer) handleUpdateWorkload(w http.ResponseWriter, r *http.Request
 }
 
 type httpWorkloadPatternRequest struct {
-	From         string                          `json:"from"`
-	SourceKind   string                          `json:"source_kind,omitempty"`
-	TrafficClass string                          `json:"traffic_class,omitempty"`
-	Metadata     map[string]string               `json:"metadata,omitempty"`
-	To           string                          `json:"to"`
-	Arrival      *httpWorkloadArrivalSpecRequest `json:"arrival"`
+	From          string                          `json:"from"`
+	SourceKind    string                          `json:"source_kind,omitempty"`
+	TrafficClass  string                          `json:"traffic_class,omitempty"`
+	PriorityClass string                          `json:"priority_class,omitempty"`
+	Metadata      map[string]string               `json:"metadata,omitempty"`
+	To            string                          `json:"to"`
+	Arrival       *httpWorkloadArrivalSpecRequest `json:"arrival"`
 }
 
 type httpWorkloadArrivalSpecRequest struct {
@@ -606,6 +621,8 @@ type httpWorkloadArrivalSpecRequest struct {
 	BurstRateRPS         float64 `json:"burst_rate_rps,omitempty"`
 	BurstDurationSeconds float64 `json:"burst_duration_seconds,omitempty"`
 	QuietDurationSeconds float64 `json:"quiet_duration_seconds,omitempty"`
+	ParetoShape          float64 `json:"pareto_shape,omitempty"`
+	InterarrivalCV       float64 `json:"interarrival_cv,omitempty"`
 }
 
 func (p *httpWorkloadPatternRequest) toConfigWorkloadPattern() (config.WorkloadPattern, error) {
@@ -632,11 +649,12 @@ func (p *httpWorkloadPatternRequest) toConfigWorkloadPattern() (config.WorkloadP
 		return config.WorkloadPattern{}, fmt.Errorf("pattern.arrival.rate_rps must be positive")
 	}
 	return config.WorkloadPattern{
-		From:         p.From,
-		SourceKind:   p.SourceKind,
-		TrafficClass: p.TrafficClass,
-		Metadata:     p.Metadata,
-		To:           p.To,
+		From:          p.From,
+		SourceKind:    p.SourceKind,
+		TrafficClass:  p.TrafficClass,
+		PriorityClass: p.PriorityClass,
+		Metadata:      p.Metadata,
+		To:            p.To,
 		Arrival: config.ArrivalSpec{
 			Type:                 arrivalType,
 			RateRPS:              p.Arrival.RateRPS,
@@ -644,10 +662,103 @@ func (p *httpWorkloadPatternRequest) toConfigWorkloadPattern() (config.WorkloadP
 			BurstRateRPS:         p.Arrival.BurstRateRPS,
 			BurstDurationSeconds: p.Arrival.BurstDurationSeconds,
 			QuietDurationSeconds: p.Arrival.QuietDurationSeconds,
+			ParetoShape:          p.Arrival.ParetoShape,
+			InterarrivalCV:       p.Arrival.InterarrivalCV,
 		},
 	}, nil
 }
 
+// handleInjectFaults handles POST /v1/runs/{id}/faults
+// Body: {"faults": [{"type": "latency", "at_ms": 0, "duration_ms": 5000, "target": {...}, ...}]}; at_ms
+// counts from the current simulation time.
+func (s *HTTPServer) handleInjectFaults(w http.ResponseWriter, r *http.Request, runID string) {
+	var req struct {
+		Faults []httpFaultRequest `json:"faults"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if len(req.Faults) == 0 {
+		s.writeError(w, http.StatusBadRequest, "faults is required")
+		return
+	}
+
+	rec, ok := s.store.Get(runID)
+	if !ok {
+		s.writeError(w, http.StatusNotFound, "run not found")
+		return
+	}
+	if rec.Run.Status != simulationv1.RunStatus_RUN_STATUS_RUNNING {
+		s.writeError(w, http.StatusBadRequest, "run is not running (status: "+rec.Run.Status.String()+")")
+		return
+	}
+
+	faults := make([]config.Fault, len(req.Faults))
+	for i := range req.Faults {
+		faults[i] = req.Faults[i].toConfigFault()
+	}
+	ids, err := s.Executor.InjectFaults(runID, faults)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrRunNotFound):
+			s.writeError(w, http.StatusNotFound, err.Error())
+		default:
+			s.writeError(w, http.StatusBadRequest, err.Error())
+		}
+		return
+	}
+
+	logger.Info("faults injected (HTTP)", "run_id", runID, "faults", ids)
+	s.writeJSON(w, http.StatusOK, map[string]any{
+		"message":   "faults scheduled",
+		"run_id":    runID,
+		"fault_ids": ids,
+	})
+}
+
+type httpFaultRequest struct {
+	ID          string                 `json:"id,omitempty"`
+	Type        string                 `json:"type"`
+	AtMs        float64                `json:"at_ms"`
+	DurationMs  float64                `json:"duration_ms"`
+	Target      httpFaultTargetRequest `json:"target"`
+	LatencyMs   float64                `json:"latency_ms,omitempty"`
+	FailureRate float64                `json:"failure_rate,omitempty"`
+	Instances   int                    `json:"instances,omitempty"`
+	CPUSteal    float64                `json:"cpu_steal,omitempty"`
+	Messages    int                    `json:"messages,omitempty"`
+}
+
+type httpFaultTargetRequest struct {
+	Service  string   `json:"service,omitempty"`
+	Endpoint string   `json:"endpoint,omitempty"`
+	From     string   `json:"from,omitempty"`
+	Host     string   `json:"host,omitempty"`
+	Zones    []string `json:"zones,omitempty"`
+}
+
+func (f *httpFaultRequest) toConfigFault() config.Fault {
+	return config.Fault{
+		ID:   f.ID,
+		Type: f.Type,
+		AtMs: f.AtMs,
+		Target: config.FaultTarget{
+			Service:  f.Target.Service,
+			Endpoint: f.Target.Endpoint,
+			From:     f.Target.From,
+			Host:     f.Target.Host,
+			Zones:    f.Target.Zones,
+		},
+		DurationMs:  f.DurationMs,
+		LatencyMs:   f.LatencyMs,
+		FailureRate: f.FailureRate,
+		Instances:   f.Instances,
+		CPUSteal:    f.CPUSteal,
+		Messages:    f.Messages,
+	}
+}
+
 func normalizeCreateRunAliasesJSON(bodyReader io.Reader) ([]byte, error) {
 	var body map[string]any
 	if err := json.NewDecoder(bodyReader).Decode(&body); err != nil {
@@ -694,9 +805,14 @@ func (s *HTTPServer) handleUpdateRunConfiguration(w http.ResponseWriter, r *http
 		} `json:"workload"`
 		Policies *struct {
 			Autoscaling *struct {
-				Enabled       bool    `json:"enabled"`
-				TargetCPUUtil float64 `json:"target_cpu_util"`
-				ScaleStep     int     `json:"scale_step"`
+				Enabled               bool    `json:"enabled"`
+				TargetCPUUtil         float64 `json:"target_cpu_util"`
+				ScaleStep             int     `json:"scale_step"`
+				MinReplicas           int     `json:"min_replicas"`
+				MaxReplicas           int     `json:"max_replicas"`
+				SyncPeriodMs          int     `json:"sync_period_ms"`
+				StabilizationWindowMs int     `json:"stabilization_window_ms"`
+				StartupDelayMs        int     `json:"startup_delay_ms"`
 			} `json:"autoscaling,omitempty"`
 		} `json:"policies,omitempty"`
 	}
@@ -793,9 +909,14 @@ func (s *HTTPServer) handleUpdateRunConfiguration(w http.ResponseWriter, r *http
 
 	if req.Policies != nil && req.Policies.Autoscaling != nil {
 		cfg := &config.AutoscalingPolicy{
-			Enabled:       req.Policies.Autoscaling.Enabled,
-			TargetCPUUtil: req.Policies.Autoscaling.TargetCPUUtil,
-			ScaleStep:     req.Policies.Autoscaling.ScaleStep,
+			Enabled:               req.Policies.Autoscaling.Enabled,
+			TargetCPUUtil:         req.Policies.Autoscaling.TargetCPUUtil,
+			ScaleStep:             req.Policies.Autoscaling.ScaleStep,
+			MinReplicas:           req.Policies.Autoscaling.MinReplicas,
+			MaxReplicas:           req.Policies.Autoscaling.MaxReplicas,
+			SyncPeriodMs:          req.Policies.Autoscaling.SyncPeriodMs,
+			StabilizationWindowMs: req.Policies.Autoscaling.StabilizationWindowMs,
+			StartupDelayMs:        req.Policies.Autoscaling.StartupDelayMs,
 		}
 		if cfg.TargetCPUUtil <= 0 {
 			cfg.TargetCPUUtil = 0.7
@@ -803,6 +924,10 @@ func (s *HTTPServer) handleUpdateRunConfiguration(w http.ResponseWriter, r *http
 		if cfg.ScaleStep <= 0 {
 			cfg.ScaleStep = 1
 		}
+		if minR, maxR := cfg.EffectiveReplicaBounds(); cfg.MinReplicas < 0 || cfg.MaxReplicas < 0 || maxR < minR {
+			s.writeError(w, http.StatusBadRequest, "policies.autoscaling: invalid min_replicas/max_replicas")
+			return
+		}
 		if err := s.Executor.UpdatePolicies(runID, &config.Policies{Autoscaling: cfg}); err != nil {
 			switch {
 			case errors.Is(err, ErrRunNotFound):
@@ -849,8 +974,12 @@ func (s *HTTPServer) handleGetRunConfiguration(w http.ResponseWriter, _ *http.Re
 			scen = parsed
 		}
 	}
+	out := convertRunConfigurationToJSON(cfg, scen)
+	if pm, ok := s.Executor.GetPolicyManager(runID); ok {
+		enrichEffectivePoliciesJSON(out, pm, scen)
+	}
 	s.writeJSON(w, http.StatusOK, map[string]any{
-		"configuration": convertRunConfigurationToJSON(cfg, scen),
+		"configuration": out,
 	})
 }
 
@@ -870,6 +999,9 @@ func (s *HTTPServer) handleGetRunMetrics(w http.ResponseWriter, _ *http.Request,
 	resp := map[string]any{
 		"metrics": convertMetricsToJSON(rec.Metrics),
 	}
+	if rec.Replications != nil {
+		resp["replications"] = rec.Replications
+	}
 	if queues, topics, ok := s.brokerShardResourcesJSON(runID); ok {
 		resp["resources"] = map[string]any{
 			"queues": queues,
@@ -882,7 +1014,8 @@ func (s *HTTPServer) handleGetRunMetrics(w http.ResponseWriter, _ *http.Request,
 // handleTimeSeries handles GET /v1/runs/{id}/metrics/timeseries
 func (s *HTTPServer) handleTimeSeries(w http.ResponseWriter, r *http.Request, runID string) {
 	// Check if run exists
-	if _, ok := s.store.Get(runID); !ok {
+	rec, ok := s.store.Get(runID)
+	if !ok {
 		s.writeError(w, http.StatusNotFound, "run not found")
 		return
 	}
@@ -999,6 +1132,7 @@ func (s *HTTPServer) handleTimeSeries(w http.ResponseWriter, r *http.Request, ru
 	s.writeJSON(w, http.StatusOK, map[string]any{
 		"run_id": runID,
 		"points": pointsJSON,
+		"faults": faultWindowsJSON(rec.FaultEvents),
 	})
 }
 
@@ -1045,6 +1179,23 @@ func (s *HTTPServer) handleExportRun(w http.ResponseWriter, _ *http.Request, run
 	if rec.Metrics != nil {
 		export["metrics"] = convertMetricsToJSON(rec.Metrics)
 	}
+	if rec.Replications != nil {
+		export["replications"] = rec.Replications
+	}
+	if len(rec.ScalingDecisions) > 0 {
+		events := make([]map[string]any, 0, len(rec.ScalingDecisions))
+		for _, d := range rec.ScalingDecisions {
+			events = append(events, scalingDecisionToJSON(d))
+		}
+		export["scaling_events"] = events
+	}
+	if len(rec.FaultEvents) > 0 {
+		events := make([]map[string]any, 0, len(rec.FaultEvents))
+		for _, ev := range rec.FaultEvents {
+			events = append(events, faultEventToJSON(ev))
+		}
+		export["fault_events"] = events
+	}
 	if queues, topics, ok := s.brokerShardResourcesJSON(runID); ok {
 		export["resources"] = map[string]any{
 			"queues": queues,
@@ -1347,6 +1498,11 @@ func (s *HTTPServer) handleMetricsStream(w http.ResponseWriter, r *http.Request,
 	// Track last sent optimization step count (for optimization_step SSE events)
 	lastOptStepCount := 0
 
+	// Track last sent autoscaler decision count (for scaling_decision SSE events)
+	lastScalingCount := 0
+	// Track last sent fault event count (for fault SSE events)
+	lastFaultCount := 0
+
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
@@ -1382,6 +1538,26 @@ func (s *HTTPServer) handleMetricsStream(w http.ResponseWriter, r *http.Request,
 
 			streamLive := rec.Input != nil && rec.Input.GetRealTimeMode()
 
+			// Send new autoscaler decisions before any terminal status so none are dropped
+			if n := len(rec.ScalingDecisions); n > lastScalingCount {
+				for _, d := range rec.ScalingDecisions[lastScalingCount:] {
+					if err := s.sendSSEEvent(w, "scaling_decision", scalingDecisionToJSON(d)); err != nil {
+						s.logSSEWriteFailure(ctx, runID, "scaling_decision", err)
+						return
+					}
+				}
+				lastScalingCount = n
+			}
+			if n := len(rec.FaultEvents); n > lastFaultCount {
+				for _, ev := range rec.FaultEvents[lastFaultCount:] {
+					if err := s.sendSSEEvent(w, "fault", faultEventToJSON(ev)); err != nil {
+						s.logSSEWriteFailure(ctx, runID, "fault", err)
+						return
+					}
+				}
+				lastFaultCount = n
+			}
+
 			// Check for status changes
 			if rec.Run.Status != previousStatus {
 				if err := s.sendSSEEvent(w, "status_change", map[string]any{
@@ -2056,6 +2232,73 @@ func convertMetricsToJSON(metrics *simulationv1.RunMetrics) map[string]any {
 		"topology_latency_penalty_ms_mean":    metrics.TopologyLatencyPenaltyMsMean,
 	}
 
+	if metrics.HedgedCallsTotal > 0 {
+		result["hedged_calls_total"] = metrics.HedgedCallsTotal
+		result["hedge_requests_total"] = metrics.HedgeRequestsTotal
+		result["hedge_rate"] = metrics.HedgeRate
+		result["hedge_wins_total"] = metrics.HedgeWinsTotal
+		result["hedge_cancelled_total"] = metrics.HedgeCancelledTotal
+		result["hedge_wasted_cpu_ms_total"] = metrics.HedgeWastedCpuMsTotal
+		result["hedge_latency_gain_ms_mean"] = metrics.HedgeLatencyGainMsMean
+		result["hedge_latency_gain_ms_p95"] = metrics.HedgeLatencyGainMsP95
+	}
+
+	if metrics.OomKillsTotal > 0 || metrics.InstanceRestartsTotal > 0 {
+		result["oom_kills_total"] = metrics.OomKillsTotal
+		result["instance_restarts_total"] = metrics.InstanceRestartsTotal
+	}
+
+	if metrics.CpuThrottledMsTotal > 0 || metrics.CpuThrottledPeriodsTotal > 0 {
+		result["cpu_throttled_ms_total"] = metrics.CpuThrottledMsTotal
+		result["cpu_throttled_periods_total"] = metrics.CpuThrottledPeriodsTotal
+	}
+	if len(metrics.InstanceCpuThrottleStats) > 0 {
+		throttleStats := make([]map[string]any, 0, len(metrics.InstanceCpuThrottleStats))
+		for _, ts := range metrics.InstanceCpuThrottleStats {
+			if ts == nil {
+				continue
+			}
+			throttleStats = append(throttleStats, map[string]any{
+				"service_name":      ts.ServiceName,
+				"instance_id":       ts.InstanceId,
+				"throttled_ms":      ts.ThrottledMs,
+				"throttled_periods": ts.ThrottledPeriods,
+			})
+		}
+		result["instance_cpu_throttle_stats"] = throttleStats
+	}
+	if len(metrics.PriorityClassStats) > 0 {
+		classStats := make([]map[string]any, 0, len(metrics.PriorityClassStats))
+		for _, pc := range metrics.PriorityClassStats {
+			if pc == nil {
+				continue
+			}
+			classStats = append(classStats, map[string]any{
+				"priority_class":  pc.PriorityClass,
+				"requests":        pc.Requests,
+				"failed_requests": pc.FailedRequests,
+				"error_rate":      pc.ErrorRate,
+				"shed_attempts":   pc.ShedAttempts,
+				"latency_p50_ms":  pc.LatencyP50Ms,
+				"latency_p95_ms":  pc.LatencyP95Ms,
+				"latency_p99_ms":  pc.LatencyP99Ms,
+				"latency_mean_ms": pc.LatencyMeanMs,
+			})
+		}
+		result["priority_class_stats"] = classStats
+	}
+
+	if metrics.HostFailuresTotal > 0 {
+		result["host_failures_total"] = metrics.HostFailuresTotal
+		result["instances_lost_total"] = metrics.InstancesLostTotal
+		result["instances_rescheduled_total"] = metrics.InstancesRescheduledTotal
+		result["outage_requests"] = metrics.OutageRequests
+		result["outage_failed_requests"] = metrics.OutageFailedRequests
+		if metrics.OutageRequests > 0 {
+			result["outage_availability"] = metrics.OutageAvailability
+		}
+	}
+
 	if len(metrics.ServiceMetrics) > 0 {
 		serviceMetrics := make([]map[string]any, 0, len(metrics.ServiceMetrics))
 		for _, sm := range metrics.ServiceMetrics {
@@ -2188,5 +2431,41 @@ func convertMetricsToJSON(metrics *simulationv1.RunMetrics) map[string]any {
 		}
 	}
 
+	if len(metrics.FlowStats) > 0 {
+		flowStats := make([]map[string]any, 0, len(metrics.FlowStats))
+		for _, fs := range metrics.FlowStats {
+			if fs == nil {
+				continue
+			}
+			steps := make([]map[string]any, 0, len(fs.Steps))
+			for _, st := range fs.Steps {
+				steps = append(steps, map[string]any{
+					"step":             st.Step,
+					"requests":         st.Requests,
+					"abandoned":        st.Abandoned,
+					"failed":           st.Failed,
+					"abandonment_rate": st.AbandonmentRate,
+				})
+			}
+			flowStats = append(flowStats, map[string]any{
+				"flow_id":                  fs.FlowId,
+				"sessions":                 fs.Sessions,
+				"completed_sessions":       fs.CompletedSessions,
+				"abandoned_sessions":       fs.AbandonedSessions,
+				"failed_sessions":          fs.FailedSessions,
+				"in_progress_sessions":     fs.InProgressSessions,
+				"completion_rate":          fs.CompletionRate,
+				"session_duration_p50_ms":  fs.SessionDurationP50Ms,
+				"session_duration_p95_ms":  fs.SessionDurationP95Ms,
+				"session_duration_p99_ms":  fs.SessionDurationP99Ms,
+				"session_duration_mean_ms": fs.SessionDurationMeanMs,
+				"steps":                    steps,
+			})
+		}
+		if len(flowStats) > 0 {
+			result["flow_stats"] = flowStats
+		}
+	}
+
 	return result
 }
@@ -3769,3 +3769,99 @@ func TestConvertMetricsToJSONIncludesQueueAndErrorTaxonomy(t *testing.T) {
 		t.Fatalf("json instance_route_stats: %v", rs)
 	}
 }
+
+func TestHTTPServerInjectFaults(t *testing.T) {
+	store := NewRunStore()
+	executor := NewRunExecutor(store, nil)
+	srv := NewHTTPServer(store, executor)
+
+	post := func(runID string, body map[string]any) *httptest.ResponseRecorder {
+		bodyBytes, _ := json.Marshal(body)
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/v1/runs/"+runID+"/faults", strings.NewReader(string(bodyBytes)))
+		req.Header.Set("Content-Type", "application/json")
+		srv.Handler().ServeHTTP(rr, req)
+		return rr
+	}
+	fault := map[string]any{
+		"type":         "failure_rate",
+		"duration_ms":  100,
+		"failure_rate": 0.5,
+		"target":       map[string]any{"service": "svc1", "endpoint": "/test"},
+	}
+
+	if rr := post("missing", map[string]any{"faults": []any{fault}}); rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for unknown run, got %d", rr.Code)
+	}
+
+	rec, err := store.Create("test-run", &simulationv1.RunInput{
+		ScenarioYaml: testScenarioYAML,
+		DurationMs:   2000,
+		RealTimeMode: true,
+	})
+	if err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+	if rr := post(rec.Run.Id, map[string]any{"faults": []any{fault}}); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a run not running, got %d", rr.Code)
+	}
+
+	if _, err := executor.Start(rec.Run.Id); err != nil {
+		t.Fatalf("Start error: %v", err)
+	}
+	defer func() { _, _ = executor.Stop(rec.Run.Id) }()
+	time.Sleep(50 * time.Millisecond)
+	if updatedRec, _ := store.Get(rec.Run.Id); updatedRec.Run.Status != simulationv1.RunStatus_RUN_STATUS_RUNNING {
+		t.Skipf("run is not running (status: %v) - skipping fault injection test", updatedRec.Run.Status)
+	}
+
+	if rr := post(rec.Run.Id, map[string]any{}); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without faults, got %d", rr.Code)
+	}
+	unknown := map[string]any{"type": "latency", "duration_ms": 100, "latency_ms": 5, "target": map[string]any{"service": "nope"}}
+	if rr := post(rec.Run.Id, map[string]any{"faults": []any{unknown}}); rr.Code != http.StatusBadRequest || !strings.Contains(rr.Body.String(), "service nope does not exist") {
+		t.Fatalf("expected 400 for an unknown service, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr := post(rec.Run.Id, map[string]any{"faults": []any{fault}})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp map[string]any
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if ids, _ := resp["fault_ids"].([]any); len(ids) != 1 || ids[0] != "failure_rate-1" {
+		t.Fatalf("expected fault_ids [failure_rate-1], got %v", resp["fault_ids"])
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		updatedRec, _ := store.Get(rec.Run.Id)
+		if len(updatedRec.FaultEvents) == 2 {
+			if updatedRec.FaultEvents[0].Phase != FaultPhaseStart || updatedRec.FaultEvents[1].Phase != FaultPhaseEnd {
+				t.Fatalf("unexpected fault events: %+v", updatedRec.FaultEvents)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the fault to start and end, got %+v", updatedRec.FaultEvents)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	rr = httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/v1/runs/"+rec.Run.Id+"/metrics/timeseries", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected timeseries 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var ts struct {
+		Faults []map[string]any `json:"faults"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &ts); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(ts.Faults) != 1 || ts.Faults[0]["fault_id"] != "failure_rate-1" || ts.Faults[0]["end"] == nil {
+		t.Fatalf("expected one closed fault window, got %v", ts.Faults)
+	}
+}
@@ -0,0 +1,56 @@
+package simd
+
+import (
+	"sort"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// metaInstanceKilled marks a request failed because its instance was killed (OOM kill, host
+// failure); its pending start, completion and downstream call events are dropped.
+const metaInstanceKilled = "instance_killed"
+
+// failKilledInstanceRequests fails the requests queued on or in flight at a killed instance with
+// reason; sync calls are retried per the caller's retry policy.
+func failKilledInstanceRequests(state *scenarioState, eng *engine.Engine, instanceID string, dropped []string, reason string, simTime time.Time) {
+	rm := eng.GetRunManager()
+	byID := make(map[string]*models.Request)
+	for _, id := range dropped {
+		if r, ok := rm.GetRequest(id); ok {
+			byID[id] = r
+		}
+	}
+	for _, r := range rm.ListRequests() {
+		if metadataString(r.Metadata, "instance_id") == instanceID {
+			byID[r.ID] = r
+		}
+	}
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		r := byID[id]
+		if r.Status != models.RequestStatusPending && r.Status != models.RequestStatusProcessing {
+			continue
+		}
+		if metadataBool(r.Metadata, metaDESFinalized) {
+			continue
+		}
+		// The kill dropped the instance's accounting; nothing is left to release.
+		r.Metadata[metaInstanceKilled] = true
+		delete(r.Metadata, "allocated_cpu_ms")
+		delete(r.Metadata, "allocated_memory_mb")
+		delete(r.Metadata, "db_reserved")
+		if r.Status == models.RequestStatusPending {
+			metaQueueConsumerDone(state, eng, r, simTime)
+			metaTopicConsumerDone(state, eng, r, simTime)
+		}
+		maybeRetrySyncStartFailure(state, eng, rm, r, simTime, reason)
+		finalizeRequestFailure(state, eng, rm, r, simTime, labelsForRequestMetrics(r, r.ServiceName, r.Endpoint), reason)
+	}
+}
@@ -2,6 +2,7 @@ package simd
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -9,6 +10,7 @@ import (
 
 	simulationv1 "github.com/GoSim-25-26J-441/simulation-core/gen/go/simulation/v1"
 	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/workload"
 	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
 )
 
@@ -23,6 +25,8 @@ type SimulationLimits struct {
 	MaxMetricPoints            int
 	MaxWallClockRuntime        time.Duration
 	MaxOptimizationEvaluations int32
+	// MaxReplications caps RunInput.replications and the stopping rule's max_replications.
+	MaxReplications int
 }
 
 func defaultSimulationLimits() SimulationLimits {
@@ -36,6 +40,7 @@ func defaultSimulationLimits() SimulationLimits {
 		MaxMetricPoints:            1_000_000,
 		MaxWallClockRuntime:        15 * time.Minute,
 		MaxOptimizationEvaluations: 1_000,
+		MaxReplications:            DefaultMaxReplications,
 	}
 }
 
@@ -70,6 +75,9 @@ func simulationLimitsFromEnv() (SimulationLimits, error) {
 	if limits.MaxOptimizationEvaluations, err = parsePositiveInt32Env("SIMD_MAX_OPTIMIZATION_EVALUATIONS", limits.MaxOptimizationEvaluations); err != nil {
 		return SimulationLimits{}, err
 	}
+	if limits.MaxReplications, err = parsePositiveIntEnv("SIMD_MAX_REPLICATIONS", limits.MaxReplications); err != nil {
+		return SimulationLimits{}, err
+	}
 	return limits, nil
 }
 
@@ -149,6 +157,9 @@ func (l SimulationLimits) validatePreStart(input *simulationv1.RunInput) error {
 	if opt := input.GetOptimization(); opt != nil && !opt.GetOnline() && opt.GetMaxEvaluations() > l.MaxOptimizationEvaluations {
 		return fmt.Errorf("run rejected: optimization max_evaluations %d exceeds server limit %d", opt.GetMaxEvaluations(), l.MaxOptimizationEvaluations)
 	}
+	if err := l.validateReplications(input); err != nil {
+		return err
+	}
 	scenario, err := config.ParseScenarioYAMLString(input.GetScenarioYaml())
 	if err != nil {
 		return nil
@@ -160,22 +171,68 @@ func (l SimulationLimits) validatePreStart(input *simulationv1.RunInput) error {
 	return nil
 }
 
+func (l SimulationLimits) validateReplications(input *simulationv1.RunInput) error {
+	reps, maxReps, precision := input.GetReplications(), input.GetMaxReplications(), input.GetCiRelativePrecision()
+	if reps == 0 && maxReps == 0 && precision == 0 {
+		return nil
+	}
+	switch {
+	case reps < 0 || maxReps < 0:
+		return fmt.Errorf("run rejected: replications and max_replications must be >= 0")
+	case math.IsNaN(precision) || precision < 0 || precision >= 1:
+		return fmt.Errorf("run rejected: ci_relative_precision must be in [0, 1)")
+	case input.GetOptimization() != nil || input.GetRealTimeMode():
+		return fmt.Errorf("run rejected: replications are only supported for standard (non real-time) runs")
+	case int(reps) > l.MaxReplications:
+		return fmt.Errorf("run rejected: replications %d exceeds server limit %d", reps, l.MaxReplications)
+	case int(maxReps) > l.MaxReplications:
+		return fmt.Errorf("run rejected: max_replications %d exceeds server limit %d", maxReps, l.MaxReplications)
+	case maxReps > 0 && maxReps < reps:
+		return fmt.Errorf("run rejected: max_replications %d is below replications %d", maxReps, reps)
+	}
+	return nil
+}
+
 func estimateWorkloadArrivals(scenario *config.Scenario, duration time.Duration) int64 {
 	if scenario == nil || duration <= 0 {
 		return 0
 	}
-	seconds := duration.Seconds()
 	var total float64
 	for i := range scenario.Workload {
-		wl := &scenario.Workload[i]
-		rate := wl.Arrival.RateRPS
-		if rate <= 0 {
-			continue
-		}
-		total += rate * seconds
+		total += estimateArrivals(scenario.Workload[i].Arrival, duration)
+	}
+	for i := range scenario.Flows {
+		f := &scenario.Flows[i]
+		total += estimateArrivals(f.Arrival, duration) * f.ExpectedSteps()
 	}
 	if total < 0 {
 		return 0
 	}
 	return int64(total)
 }
+
+// estimateArrivals returns the expected number of arrivals of one arrival process within duration.
+func estimateArrivals(a config.ArrivalSpec, duration time.Duration) float64 {
+	switch {
+	case a.Type == "replay":
+		// A validated spec returns the trace parsed during validation; a load error is left to the run.
+		if records, err := config.LoadReplayTrace(a); err == nil {
+			return workload.CountReplayArrivals(records, a, duration)
+		}
+		return 0
+	case a.Type == "closed":
+		// Each user issues at most one request per think time; without think time the
+		// response time bounds the loop and there is no static estimate.
+		if think := a.ThinkTimeMs.MeanMs(); think > 0 {
+			return float64(a.Users) * duration.Seconds() / (think / 1000)
+		}
+		return 0
+	case a.RateProfile != nil:
+		return a.RateProfile.ExpectedArrivals(duration, a.RateRPS)
+	case a.Type == "mmpp":
+		return a.MMPP.MeanRate() * duration.Seconds()
+	case a.RateRPS > 0:
+		return a.RateRPS * duration.Seconds()
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+package simd
+
+import (
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// metaPriorityClass holds the scenario priority class of a workload request. Like the traffic
+// class, it follows the request to its downstream calls, retries and broker consumers.
+const metaPriorityClass = "workload_priority_class"
+
+// arrivalPriorityClass returns the declared priority class of a workload arrival: its pattern's
+// priority_class, or else the class named like its traffic_class ("" when neither is declared).
+func arrivalPriorityClass(state *scenarioState, data map[string]interface{}) string {
+	pc, _ := data["priority_class"].(string)
+	tc, _ := data["traffic_class"].(string)
+	name := state.scenario.ResolvePriorityClass(pc, tc)
+	if _, ok := state.scenario.PriorityClassNamed(name); !ok {
+		return ""
+	}
+	return name
+}
+
+// requestClassPriority returns the priority of the request's class (0 without a class).
+func requestClassPriority(state *scenarioState, m map[string]interface{}) int {
+	pc, _ := state.scenario.PriorityClassNamed(metadataString(m, metaPriorityClass))
+	return pc.Priority
+}
+
+// shedForOverload fails request with load_shed when a load shedding rule of its service covers its
+// priority class and instanceID is over one of the rule's thresholds. A rule covers its own class
+// and every lower one, so lower classes are shed first. It reports whether the request was shed.
+func shedForOverload(state *scenarioState, eng *engine.Engine, request *models.Request, instanceID string, simTime time.Time) bool {
+	svc, ok := state.services[request.ServiceName]
+	if !ok || len(svc.LoadShedding) == 0 {
+		return false
+	}
+	inst, ok := state.rm.GetServiceInstance(instanceID)
+	if !ok {
+		return false
+	}
+	priority := requestClassPriority(state, request.Metadata)
+	utilization := inst.CPUUtilizationAt(simTime)
+	queued := state.rm.GetQueueLength(instanceID)
+	for _, r := range svc.LoadShedding {
+		if pc, _ := state.scenario.PriorityClassNamed(r.PriorityClass); priority > pc.Priority {
+			continue
+		}
+		if (r.UtilizationThreshold > 0 && utilization >= r.UtilizationThreshold) || (r.QueueThreshold > 0 && queued >= r.QueueThreshold) {
+			failQueuedRequests(state, eng, eng.GetRunManager(), []string{request.ID}, simTime, metrics.ReasonLoadShed)
+			return true
+		}
+	}
+	return false
+}
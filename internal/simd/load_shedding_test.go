@@ -0,0 +1,105 @@
+package simd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+func TestLoadSheddingShedsBatchWhileCheckoutStaysHealthy(t *testing.T) {
+	scenario := &config.Scenario{
+		PriorityClasses: []config.PriorityClass{{Name: "checkout", Priority: 100}, {Name: "background", Priority: 0}},
+		Hosts:           []config.Host{{ID: "host-1", Cores: 8, MemoryGB: 16}},
+		Services: []config.Service{
+			{
+				ID: "shop", Replicas: 1, CPUCores: 1, Model: "cpu",
+				Scheduling:   &config.Scheduling{Discipline: config.SchedulingPriority},
+				LoadShedding: []config.LoadShedRule{{PriorityClass: "background", UtilizationThreshold: 0.5}},
+				Endpoints: []config.Endpoint{
+					{Path: "/pay", MeanCPUMs: 20, Downstream: []config.DownstreamCall{{To: "ledger:/write"}}},
+					{Path: "/export", MeanCPUMs: 20},
+				},
+			},
+			{ID: "ledger", Replicas: 1, CPUCores: 1, Model: "cpu", Endpoints: []config.Endpoint{{Path: "/write", MeanCPUMs: 2}}},
+		},
+		Workload: []config.WorkloadPattern{
+			{From: "web", To: "shop:/pay", PriorityClass: "checkout", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 10}},
+			// The class is picked by traffic class; the export alone needs 1.2 cores.
+			{From: "cron", To: "shop:/export", TrafficClass: "background", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 60}},
+		},
+	}
+	collector := runResilienceScenario(t, scenario, 3*time.Second)
+	rm := metrics.ConvertToRunMetrics(collector, nil, nil)
+
+	stats := map[string]models.PriorityClassStats{}
+	for _, st := range rm.PriorityClassStats {
+		stats[st.PriorityClass] = st
+	}
+	checkout, background := stats["checkout"], stats["background"]
+	if checkout.Requests < 25 || checkout.FailedRequests != 0 || checkout.ShedAttempts != 0 {
+		t.Fatalf("checkout should be served in full, got %+v", checkout)
+	}
+	if checkout.LatencyP95Ms > 50 {
+		t.Fatalf("checkout should jump the batch backlog, got p95 %vms", checkout.LatencyP95Ms)
+	}
+	if background.ShedAttempts == 0 || background.FailedRequests != background.ShedAttempts || background.ErrorRate <= 0 {
+		t.Fatalf("background traffic should be shed, got %+v", background)
+	}
+	if got := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonLoadShed); int64(got) != background.ShedAttempts {
+		t.Fatalf("expected %d load_shed errors, got %v", background.ShedAttempts, got)
+	}
+	// The class follows checkout requests to their ledger calls.
+	if got := collector.SumMetricWhere(metrics.MetricRequestCount, metrics.LabelPriorityClass, "checkout"); got < 2*float64(checkout.Requests) {
+		t.Fatalf("expected the ledger calls of checkout requests to carry the class, got %v requests", got)
+	}
+}
+
+func TestLoadSheddingOnQueueLengthSkipsCircuitBreaker(t *testing.T) {
+	configure := func(s *config.Scenario) {
+		s.PriorityClasses = []config.PriorityClass{{Name: "batch"}}
+		s.Services[0].LoadShedding = []config.LoadShedRule{{PriorityClass: "batch", QueueThreshold: 1}}
+		s.Policies = &config.Policies{CircuitBreaker: &config.CircuitBreakerPolicy{Enabled: true, FailureThreshold: 1, TimeoutMs: 10000}}
+	}
+	// Requests without a class have priority 0, so the batch rule covers them.
+	reqs, collector := runSchedulingScenario(t, configure, []schedulingArrival{
+		{"first", 0, nil}, {"a", 10, nil}, {"b", 20, nil}, {"c", 30, nil},
+	})
+	if r := reqs["a"]; r.Status != models.RequestStatusCompleted || completedAtMs(r) != 200 {
+		t.Fatalf("request a found an empty queue and should be served at 200ms, got status %s at %dms", r.Status, completedAtMs(r))
+	}
+	for _, name := range []string{"b", "c"} {
+		if r := reqs[name]; r.Status != models.RequestStatusFailed || r.Error != metrics.ReasonLoadShed {
+			t.Fatalf("request %s should be shed, got status %s error %q", name, r.Status, r.Error)
+		}
+	}
+	if got := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonCircuitOpen); got != 0 {
+		t.Fatalf("shed requests should not open the circuit breaker, got %v circuit_open errors", got)
+	}
+}
+
+func TestPriorityClassesOrderQueueWithoutSchedulingBlock(t *testing.T) {
+	scenario := &config.Scenario{
+		PriorityClasses: []config.PriorityClass{{Name: "checkout", Priority: 100}, {Name: "background", Priority: 0}},
+		Hosts:           []config.Host{{ID: "host-1", Cores: 8, MemoryGB: 16}},
+		Services: []config.Service{{
+			ID: "shop", Replicas: 1, CPUCores: 1, Model: "cpu",
+			Endpoints: []config.Endpoint{{Path: "/pay", MeanCPUMs: 20}, {Path: "/export", MeanCPUMs: 20}},
+		}},
+		Workload: []config.WorkloadPattern{
+			{From: "web", To: "shop:/pay", PriorityClass: "checkout", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 10}},
+			{From: "cron", To: "shop:/export", PriorityClass: "background", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 60}},
+		},
+	}
+	collector := runResilienceScenario(t, scenario, 3*time.Second)
+	rm := metrics.ConvertToRunMetrics(collector, nil, nil)
+	stats := map[string]models.PriorityClassStats{}
+	for _, st := range rm.PriorityClassStats {
+		stats[st.PriorityClass] = st
+	}
+	if checkout := stats["checkout"]; checkout.Requests < 25 || checkout.LatencyP95Ms > 50 {
+		t.Fatalf("checkout should jump the batch backlog without a scheduling block, got %+v", checkout)
+	}
+}
@@ -1,7 +1,6 @@
 package simd
 
 import (
-	"math"
 	"strings"
 	"time"
 
@@ -34,7 +33,7 @@ func findCrossZoneLatencySpec(net *config.NetworkConfig, callerZone, calleeZone
 		}
 	}
 	d := net.DefaultCrossZoneLatencyMs
-	if d.Mean != 0 || d.Sigma != 0 {
+	if d.IsSet() {
 		return d, true
 	}
 	return config.LatencySpec{}, false
@@ -69,14 +68,7 @@ func sampleCrossZoneNetworkPenaltyMs(state *scenarioState, spec config.LatencySp
 	if state == nil || state.rng == nil {
 		return 0
 	}
-	v := state.rng.NormFloat64(spec.Mean, spec.Sigma)
-	if math.IsNaN(v) || math.IsInf(v, 0) {
-		return 0
-	}
-	if v < 0 {
-		return 0
-	}
-	return v
+	return spec.Sample(state.rng)
 }
 
 // downstreamCallerHostZone resolves the upstream caller's zone for downstream request metadata (same order as topology metrics).
@@ -138,7 +130,7 @@ func applyTopologyNetworkPenaltyMs(state *scenarioState, serviceID, endpointPath
 		if svc.ExternalNetworkLatencyMs != nil {
 			spec = *svc.ExternalNetworkLatencyMs
 		}
-		if spec.Mean == 0 && spec.Sigma == 0 {
+		if !spec.IsSet() {
 			return 0
 		}
 		pen := sampleCrossZoneNetworkPenaltyMs(state, spec)
@@ -164,7 +156,7 @@ func applyTopologyNetworkPenaltyMs(state *scenarioState, serviceID, endpointPath
 	// Precedence: same host → same zone (different hosts) → cross zone.
 	if callerHost != "" && calleeHost != "" && callerHost == calleeHost {
 		spec := net.SameHostLatencyMs
-		if spec.Mean == 0 && spec.Sigma == 0 {
+		if !spec.IsSet() {
 			return 0
 		}
 		pen := sampleCrossZoneNetworkPenaltyMs(state, spec)
@@ -183,7 +175,7 @@ func applyTopologyNetworkPenaltyMs(state *scenarioState, serviceID, endpointPath
 			return 0
 		}
 		spec := net.SameZoneLatencyMs
-		if spec.Mean == 0 && spec.Sigma == 0 {
+		if !spec.IsSet() {
 			return 0
 		}
 		pen := sampleCrossZoneNetworkPenaltyMs(state, spec)
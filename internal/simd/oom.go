@@ -0,0 +1,114 @@
+package simd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+// oomState tracks OOM kills per instance for the CrashLoopBackOff restart delay.
+type oomState struct {
+	policy *config.OOMPolicy
+
+	mu sync.Mutex
+	// kills counts the kills of each instance since its restart delay last reset.
+	kills map[string]int
+	// readyAt is when each killed instance was due back in rotation.
+	readyAt map[string]time.Time
+}
+
+// newOOMState returns nil unless the scenario enables policies.oom.
+func newOOMState(scenario *config.Scenario) *oomState {
+	if scenario.Policies == nil || !scenario.Policies.OOM.IsEnabled() {
+		return nil
+	}
+	return &oomState{
+		policy:  scenario.Policies.OOM,
+		kills:   make(map[string]int),
+		readyAt: make(map[string]time.Time),
+	}
+}
+
+// restartAt counts a kill of instanceID at simTime and returns when the instance restarts. A replica
+// that ran for the backoff reset window since its last restart starts over at the base delay.
+func (o *oomState) restartAt(instanceID string, simTime time.Time) time.Time {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if ready, ok := o.readyAt[instanceID]; ok && simTime.Sub(ready) >= o.policy.EffectiveBackoffReset() {
+		o.kills[instanceID] = 0
+	}
+	o.kills[instanceID]++
+	return simTime.Add(o.policy.RestartBackoff(o.kills[instanceID]))
+}
+
+// enforceMemoryLimits OOM-kills instances after memory was allocated on instanceID, until neither it
+// nor its host is over its memory limit.
+func enforceMemoryLimits(state *scenarioState, eng *engine.Engine, instanceID string, simTime time.Time) {
+	if state.oom == nil {
+		return
+	}
+	for {
+		victimID, hostLevel := state.rm.OOMVictim(instanceID)
+		if victimID == "" || !killInstance(state, eng, victimID, hostLevel, simTime) {
+			return
+		}
+	}
+}
+
+// killInstance OOM-kills an instance: its requests fail with oom_killed and it restarts after the
+// backoff delay. It reports false when the instance no longer exists.
+func killInstance(state *scenarioState, eng *engine.Engine, instanceID string, hostLevel bool, simTime time.Time) bool {
+	inst, ok := state.rm.GetServiceInstance(instanceID)
+	if !ok {
+		return false
+	}
+	serviceID := inst.ServiceName()
+	scope := metrics.OOMScopeInstance
+	if hostLevel {
+		scope = metrics.OOMScopeHost
+	}
+	metrics.RecordOOMKill(state.collector, simTime, metrics.CreateOOMKillLabels(serviceID, instanceID, inst.HostID(), scope))
+
+	dropped, readyAt := state.rm.KillInstance(instanceID, simTime, state.oom.restartAt(instanceID, simTime))
+	failKilledInstanceRequests(state, eng, instanceID, dropped, metrics.ReasonOOMKilled, simTime)
+	if !readyAt.IsZero() {
+		state.oom.mu.Lock()
+		state.oom.readyAt[instanceID] = readyAt
+		state.oom.mu.Unlock()
+		eng.ScheduleAt(engine.EventTypeInstanceRestart, readyAt, nil, serviceID, map[string]interface{}{
+			"service_id":  serviceID,
+			"instance_id": instanceID,
+		})
+	}
+	recordInstanceAndHostGauges(state, serviceID, instanceID, simTime)
+	return true
+}
+
+// handleInstanceReady brings the starting instances that are ready now into rotation.
+func handleInstanceReady(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		state.rm.ActivateReadyInstances(eng.GetSimTime())
+		return nil
+	}
+}
+
+// handleInstanceRestart records the restart of an OOM-killed instance once it is back in rotation.
+func handleInstanceRestart(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		simTime := eng.GetSimTime()
+		state.rm.NoteSimTime(simTime)
+		serviceID := metadataString(evt.Data, "service_id")
+		instanceID := metadataString(evt.Data, "instance_id")
+		// Scaled away while restarting.
+		if inst, ok := state.rm.GetServiceInstance(instanceID); !ok || inst.Lifecycle() != resource.InstanceActive {
+			return nil
+		}
+		metrics.RecordInstanceRestart(state.collector, simTime, metrics.CreateInstanceLabels(serviceID, instanceID))
+		recordInstanceAndHostGauges(state, serviceID, instanceID, simTime)
+		return nil
+	}
+}
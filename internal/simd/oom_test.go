@@ -0,0 +1,122 @@
+package simd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+// oomTestScenario has one four-core api replica with memory for three in-flight requests of 20 MB.
+func oomTestScenario(oom *config.OOMPolicy) *config.Scenario {
+	return &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 16, MemoryGB: 32}},
+		Services: []config.Service{{
+			ID: "api", Replicas: 1, Model: "cpu", CPUCores: 4, MemoryMB: 64,
+			Endpoints: []config.Endpoint{{Path: "/work", MeanCPUMs: 10, NetLatencyMs: config.LatencySpec{Mean: 200}, DefaultMemoryMB: 20}},
+		}},
+		Workload: []config.WorkloadPattern{{From: "client", To: "api:/work", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 1}}},
+		Policies: &config.Policies{OOM: oom},
+	}
+}
+
+// runOOM sends a request to api:/work at each of arrivals and runs the scenario for 10s.
+func runOOM(t *testing.T, scenario *config.Scenario, arrivals ...time.Duration) (*metrics.Collector, *resource.Manager) {
+	t.Helper()
+	r := newTestRun(t, scenario, 5)
+	r.scheduleArrivals("api", "/work", arrivals...)
+	r.run(t, 10*time.Second)
+	return r.collector, r.rm
+}
+
+func TestOOMKillFailsInFlightRequestsAndRestarts(t *testing.T) {
+	// Four requests at once need 80 MB: the fourth start kills the replica and all four fail. The
+	// request at 500ms finds no replica; those after the 1s restart succeed.
+	arrivals := []time.Duration{0, 0, 0, 0, 500 * time.Millisecond, 2 * time.Second, 2100 * time.Millisecond}
+	collector, rm := runOOM(t, oomTestScenario(&config.OOMPolicy{Enabled: true, RestartBackoffMs: 1000}), arrivals...)
+
+	if got := collector.SumMetric(metrics.MetricOOMKillCount); got != 1 {
+		t.Fatalf("expected one OOM kill, got %v", got)
+	}
+	if got := collector.SumMetricWhere(metrics.MetricOOMKillCount, metrics.LabelOOMScope, metrics.OOMScopeInstance); got != 1 {
+		t.Fatalf("expected an instance-scope kill, got %v", got)
+	}
+	if got := collector.SumMetric(metrics.MetricInstanceRestartCount); got != 1 {
+		t.Fatalf("expected one restart, got %v", got)
+	}
+	if got := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonOOMKilled); got != 4 {
+		t.Fatalf("expected the four in-flight requests to fail with oom_killed, got %v", got)
+	}
+	if got := collector.SumMetric(metrics.MetricIngressLogicalFailure); got != 5 {
+		t.Fatalf("expected 4 OOM failures plus 1 request without a replica, got %v", got)
+	}
+	// Root latency covers the four OOM failures and the two requests served after the restart.
+	if roots := collector.GetMetricAggregation(metrics.MetricRootRequestLatency); roots == nil || roots.Count != 6 || roots.Max < 200 {
+		t.Fatalf("expected six finished root requests including two served ones, got %+v", roots)
+	}
+	if rm.ActiveReplicas("api") != 1 {
+		t.Fatal("expected the replica back in rotation")
+	}
+	inst := rm.GetInstancesForService("api")[0]
+	if inst.ActiveMemoryMB() != 0 || inst.ActiveRequests() != 0 {
+		t.Fatalf("expected no leaked accounting, got %v MB / %d requests", inst.ActiveMemoryMB(), inst.ActiveRequests())
+	}
+
+	// Without the policy memory_mb is not enforced.
+	collector, _ = runOOM(t, oomTestScenario(nil), arrivals...)
+	if kills, failed := collector.SumMetric(metrics.MetricOOMKillCount), collector.SumMetric(metrics.MetricIngressLogicalFailure); kills != 0 || failed != 0 {
+		t.Fatalf("expected no kills or failures without policies.oom, got %v kills, %v failures", kills, failed)
+	}
+	if roots := collector.GetMetricAggregation(metrics.MetricRootRequestLatency); roots == nil || roots.Count != int64(len(arrivals)) {
+		t.Fatalf("expected every request served without policies.oom, got %+v", roots)
+	}
+}
+
+func TestOOMHostPressureKillsLargestInstance(t *testing.T) {
+	// Neither service sets memory_mb; together they need more than the 1 GB host.
+	scenario := &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 16, MemoryGB: 1}},
+		Services: []config.Service{
+			{ID: "api", Replicas: 1, Model: "cpu", CPUCores: 4, Endpoints: []config.Endpoint{{
+				Path: "/work", MeanCPUMs: 10, NetLatencyMs: config.LatencySpec{Mean: 200}, DefaultMemoryMB: 400,
+				Downstream: []config.DownstreamCall{{To: "worker:/batch"}},
+			}}},
+			{ID: "worker", Replicas: 1, Model: "cpu", CPUCores: 4, Endpoints: []config.Endpoint{{Path: "/batch", MeanCPUMs: 10, NetLatencyMs: config.LatencySpec{Mean: 200}, DefaultMemoryMB: 300}}},
+		},
+		Workload: []config.WorkloadPattern{{From: "client", To: "api:/work", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 1}}},
+		Policies: &config.Policies{OOM: &config.OOMPolicy{Enabled: true, RestartBackoffMs: 1000}},
+	}
+	collector, _ := runOOM(t, scenario, 0, 0, 100*time.Millisecond)
+	if got := collector.SumMetricWhere(metrics.MetricOOMKillCount, metrics.LabelOOMScope, metrics.OOMScopeHost); got < 1 {
+		t.Fatalf("expected a host-scope kill, got %v", got)
+	}
+	if got := collector.SumMetricWhere(metrics.MetricOOMKillCount, "service", "api"); got < 1 {
+		t.Fatalf("expected the api replica (largest on the host) to be killed, got %v", got)
+	}
+	if got := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonOOMKilled); got < 2 {
+		t.Fatalf("expected the api requests to fail with oom_killed, got %v", got)
+	}
+}
+
+func TestOOMRestartBackoffDoublesAndResets(t *testing.T) {
+	o := newOOMState(oomTestScenario(&config.OOMPolicy{Enabled: true, RestartBackoffMs: 1000, BackoffResetMs: 10000}))
+	t0 := time.Unix(0, 0)
+	if got := o.restartAt("i", t0).Sub(t0); got != time.Second {
+		t.Fatalf("first kill: restart after %v, want 1s", got)
+	}
+	o.readyAt["i"] = t0.Add(time.Second)
+	t1 := t0.Add(5 * time.Second)
+	if got := o.restartAt("i", t1).Sub(t1); got != 2*time.Second {
+		t.Fatalf("second kill: restart after %v, want 2s", got)
+	}
+	o.readyAt["i"] = t1.Add(2 * time.Second)
+	t2 := t1.Add(20 * time.Second)
+	if got := o.restartAt("i", t2).Sub(t2); got != time.Second {
+		t.Fatalf("kill after a long healthy run: restart after %v, want 1s", got)
+	}
+	if newOOMState(oomTestScenario(nil)) != nil {
+		t.Fatal("expected no OOM state without policies.oom")
+	}
+}
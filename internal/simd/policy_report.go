@@ -0,0 +1,119 @@
+package simd
+
+import (
+	"github.com/GoSim-25-26J-441/simulation-core/internal/policy"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+// enrichEffectivePoliciesJSON adds the policies resolved per service and per hop (ingress workload and
+// downstream calls) to a run configuration payload. Blocks that are not configured at any level are omitted.
+func enrichEffectivePoliciesJSON(out map[string]any, pm *policy.Manager, scenario *config.Scenario) {
+	if out == nil || pm == nil || scenario == nil {
+		return
+	}
+	if services, ok := out["services"].([]map[string]any); ok {
+		for _, entry := range services {
+			serviceID, _ := entry["service_id"].(string)
+			if as := autoscalingConfigToJSON(pm.GetAutoscalingConfigFor(serviceID)); as != nil {
+				entry["autoscaling"] = as
+			}
+		}
+	}
+
+	hops := make([]map[string]any, 0)
+	for _, w := range scenario.Workload {
+		svcID, path, err := parseWorkloadTarget(w.To)
+		if err != nil {
+			continue
+		}
+		hops = append(hops, hopPolicyJSON(pm, w.From, w.To, svcID, path, nil, false))
+	}
+	for i := range scenario.Services {
+		svc := &scenario.Services[i]
+		for j := range svc.Endpoints {
+			ep := &svc.Endpoints[j]
+			from := svc.ID + ":" + ep.Path
+			for k := range ep.Downstream {
+				call := &ep.Downstream[k]
+				svcID, path, err := parseWorkloadTarget(call.To)
+				if err != nil {
+					continue
+				}
+				hops = append(hops, hopPolicyJSON(pm, from, call.To, svcID, path, call, true))
+			}
+		}
+	}
+	out["hop_policies"] = hops
+}
+
+// hopPolicyJSON renders the effective policies for one hop into svcID/path. Retries only apply to
+// downstream calls; ingress traffic is admitted (or rejected) but never retried by the simulator.
+func hopPolicyJSON(pm *policy.Manager, from, to, svcID, path string, call *config.DownstreamCall, downstream bool) map[string]any {
+	hop := map[string]any{
+		"from": from,
+		"to":   to,
+	}
+	if downstream {
+		if r := retryConfigToJSON(pm.EffectiveRetryConfig(svcID, path, call)); r != nil {
+			hop["retries"] = r
+		}
+	}
+	if cb := circuitBreakerConfigToJSON(pm.EffectiveCircuitBreakerConfig(svcID, path)); cb != nil {
+		hop["circuit_breaker"] = cb
+	}
+	if rl := rateLimitingConfigToJSON(pm.EffectiveRateLimitingConfig(svcID, path)); rl != nil {
+		hop["rate_limiting"] = rl
+	}
+	return hop
+}
+
+func autoscalingConfigToJSON(p *config.AutoscalingPolicy) map[string]any {
+	if p == nil {
+		return nil
+	}
+	minReplicas, maxReplicas := p.EffectiveReplicaBounds()
+	return map[string]any{
+		"enabled":                 p.Enabled,
+		"target_cpu_util":         p.TargetCPUUtil,
+		"scale_step":              p.ScaleStep,
+		"min_replicas":            minReplicas,
+		"max_replicas":            maxReplicas,
+		"sync_period_ms":          p.EffectiveSyncPeriod().Milliseconds(),
+		"stabilization_window_ms": p.StabilizationWindowMs,
+		"startup_delay_ms":        p.StartupDelayMs,
+	}
+}
+
+func retryConfigToJSON(p *config.RetryPolicy) map[string]any {
+	if p == nil {
+		return nil
+	}
+	return map[string]any{
+		"enabled":     p.Enabled,
+		"max_retries": p.MaxRetries,
+		"backoff":     p.Backoff,
+		"base_ms":     p.BaseMs,
+	}
+}
+
+func circuitBreakerConfigToJSON(p *config.CircuitBreakerPolicy) map[string]any {
+	if p == nil {
+		return nil
+	}
+	return map[string]any{
+		"enabled":           p.Enabled,
+		"failure_threshold": p.EffectiveFailureThreshold(),
+		"success_threshold": p.EffectiveSuccessThreshold(),
+		"timeout_ms":        p.EffectiveTimeout().Milliseconds(),
+	}
+}
+
+func rateLimitingConfigToJSON(p *config.RateLimitingPolicy) map[string]any {
+	if p == nil {
+		return nil
+	}
+	return map[string]any{
+		"enabled":               p.Enabled,
+		"rate_limit_per_second": p.RateLimitPerSecond,
+	}
+}
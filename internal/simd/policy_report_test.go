@@ -0,0 +1,80 @@
+package simd
+
+import (
+	"testing"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/policy"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+func TestEnrichEffectivePoliciesJSON(t *testing.T) {
+	scenario := &config.Scenario{
+		Services: []config.Service{
+			{
+				ID: "api",
+				Policies: &config.PolicyOverrides{
+					Autoscaling: &config.AutoscalingOverride{Enabled: config.BoolPtr(true), MaxReplicas: 6},
+				},
+				Endpoints: []config.Endpoint{{
+					Path: "/checkout",
+					Downstream: []config.DownstreamCall{{
+						To:       "payments:/charge",
+						Policies: &config.PolicyOverrides{Retries: &config.RetryOverride{Enabled: config.BoolPtr(true), MaxRetries: 5}},
+					}},
+				}},
+			},
+			{
+				ID: "payments",
+				Endpoints: []config.Endpoint{{
+					Path: "/charge",
+					Policies: &config.PolicyOverrides{
+						RateLimiting: &config.RateLimitingOverride{Enabled: config.BoolPtr(true), RateLimitPerSecond: 50},
+					},
+				}},
+			},
+		},
+		Workload: []config.WorkloadPattern{{From: "client", To: "api:/checkout"}},
+		Policies: &config.Policies{
+			Autoscaling:    &config.AutoscalingPolicy{Enabled: false, TargetCPUUtil: 0.7, ScaleStep: 1},
+			Retries:        &config.RetryPolicy{Enabled: true, MaxRetries: 1, Backoff: "exponential", BaseMs: 20},
+			CircuitBreaker: &config.CircuitBreakerPolicy{Enabled: true},
+		},
+	}
+	pm := policy.NewPolicyManager(scenario.Policies)
+	pm.ApplyScenarioOverrides(scenario)
+	out := map[string]any{
+		"services": []map[string]any{{"service_id": "api"}, {"service_id": "payments"}},
+	}
+	enrichEffectivePoliciesJSON(out, pm, scenario)
+
+	services := out["services"].([]map[string]any)
+	as, ok := services[0]["autoscaling"].(map[string]any)
+	if !ok || as["max_replicas"] != 6 || as["target_cpu_util"] != 0.7 {
+		t.Fatalf("expected effective autoscaling for api, got %v", services[0]["autoscaling"])
+	}
+	if _, ok := services[1]["autoscaling"]; ok {
+		t.Fatal("expected no autoscaling for payments")
+	}
+
+	hops := out["hop_policies"].([]map[string]any)
+	if len(hops) != 2 {
+		t.Fatalf("expected ingress and downstream hops, got %v", hops)
+	}
+	ingress, downstream := hops[0], hops[1]
+	if ingress["from"] != "client" || ingress["to"] != "api:/checkout" || ingress["retries"] != nil {
+		t.Fatalf("unexpected ingress hop: %v", ingress)
+	}
+	if cb, ok := ingress["circuit_breaker"].(map[string]any); !ok || cb["failure_threshold"] != config.DefaultCircuitBreakerFailureThreshold {
+		t.Fatalf("expected scenario circuit breaker with defaults on ingress hop, got %v", ingress["circuit_breaker"])
+	}
+	if downstream["from"] != "api:/checkout" || downstream["to"] != "payments:/charge" {
+		t.Fatalf("unexpected downstream hop: %v", downstream)
+	}
+	r, ok := downstream["retries"].(map[string]any)
+	if !ok || r["max_retries"] != 5 || r["backoff"] != "exponential" || r["base_ms"] != 20 {
+		t.Fatalf("expected call-level retries merged with scenario retries, got %v", downstream["retries"])
+	}
+	if rl, ok := downstream["rate_limiting"].(map[string]any); !ok || rl["rate_limit_per_second"] != 50 {
+		t.Fatalf("expected callee endpoint rate limiting, got %v", downstream["rate_limiting"])
+	}
+}
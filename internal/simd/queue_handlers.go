@@ -75,15 +75,8 @@ func queueStateLabels(brokerID, topic string) map[string]string {
 func sampleQueueDeliveryMs(state *scenarioState, brokerID string) float64 {
 	eff := effectiveQueueForBroker(state, brokerID)
 	delivery := 0.0
-	if eff.DeliveryLatencyMs.Mean > 0 {
-		sig := eff.DeliveryLatencyMs.Sigma
-		if sig < 0 {
-			sig = 0
-		}
-		delivery = state.rng.NormFloat64(eff.DeliveryLatencyMs.Mean, sig)
-		if delivery < 0 {
-			delivery = 0
-		}
+	if eff.DeliveryLatencyMs.MeanMs() > 0 {
+		delivery = eff.DeliveryLatencyMs.Sample(state.rng)
 	}
 	return delivery
 }
@@ -176,6 +169,10 @@ func handleQueueEnqueue(state *scenarioState, _ *engine.Engine) engine.EventHand
 			"workload_source_kind":   parent.Metadata["workload_source_kind"],
 			"workload_traffic_class": parent.Metadata["workload_traffic_class"],
 		}
+		if v, ok := parent.Metadata[metaPriorityClass]; ok {
+			meta[metaPriorityClass] = v
+		}
+		copyPropagatedMetadata(state, meta, parent.Metadata)
 		callerInstanceID := metadataString(evt.Data, "caller_instance_id")
 		callerHostZone := metadataString(evt.Data, "caller_host_zone")
 		callerHostID := metadataString(evt.Data, "caller_host_id")
@@ -298,6 +295,10 @@ func handleQueueDequeue(state *scenarioState, _ *engine.Engine) engine.EventHand
 		if v, ok := msg.Metadata["workload_traffic_class"]; ok {
 			child.Metadata["workload_traffic_class"] = v
 		}
+		if v, ok := msg.Metadata[metaPriorityClass]; ok {
+			child.Metadata[metaPriorityClass] = v
+		}
+		copyPropagatedMetadata(state, child.Metadata, msg.Metadata)
 		if v, ok := msg.Metadata[metaRetryAttempt]; ok {
 			child.Metadata[metaRetryAttempt] = v
 		}
@@ -427,7 +428,7 @@ func handleAsyncParentFinalize(state *scenarioState, _ *engine.Engine) engine.Ev
 		state.pendingSyncMu.Lock()
 		n, syncPending := state.pendingSync[parent.ID]
 		state.pendingSyncMu.Unlock()
-		if syncPending && n > 0 {
+		if (syncPending && n > 0) || syncExecutionPending(state, parent.ID) {
 			return nil
 		}
 
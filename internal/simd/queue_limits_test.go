@@ -0,0 +1,89 @@
+package simd
+
+import (
+	"testing"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// withQueueLimits sets the queue limits of api.
+func withQueueLimits(q config.QueueLimits) func(*config.Scenario) {
+	return func(s *config.Scenario) {
+		s.Services[0].QueueLimits = q
+	}
+}
+
+func TestQueueLimitRejectsArrivalWhenFull(t *testing.T) {
+	reqs, collector := runSchedulingScenario(t, withQueueLimits(config.QueueLimits{MaxQueueLength: 1}), []schedulingArrival{
+		{"first", 0, nil}, {"a", 10, nil}, {"b", 20, nil},
+	})
+	if r := reqs["b"]; r.Status != models.RequestStatusFailed || r.Error != metrics.ReasonQueueFull || completedAtMs(r) != 20 {
+		t.Fatalf("request b should be rejected at 20ms, got status %s error %q at %dms", r.Status, r.Error, completedAtMs(r))
+	}
+	if r := reqs["a"]; r.Status != models.RequestStatusCompleted || completedAtMs(r) != 200 {
+		t.Fatalf("request a should be served at 200ms, got status %s at %dms", r.Status, completedAtMs(r))
+	}
+	if got := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonQueueFull); got != 1 {
+		t.Fatalf("expected 1 queue_full error, got %v", got)
+	}
+}
+
+func TestQueueLimitDropsOldestWhenFull(t *testing.T) {
+	reqs, _ := runSchedulingScenario(t, withQueueLimits(config.QueueLimits{MaxQueueLength: 1, OnQueueFull: config.QueueFullDropOldest}), []schedulingArrival{
+		{"first", 0, nil}, {"a", 10, nil}, {"b", 20, nil},
+	})
+	if r := reqs["a"]; r.Status != models.RequestStatusFailed || r.Error != metrics.ReasonQueueFull || completedAtMs(r) != 20 {
+		t.Fatalf("request a should be dropped at 20ms, got status %s error %q at %dms", r.Status, r.Error, completedAtMs(r))
+	}
+	if r := reqs["b"]; r.Status != models.RequestStatusCompleted || completedAtMs(r) != 200 {
+		t.Fatalf("request b should be served at 200ms, got status %s at %dms", r.Status, completedAtMs(r))
+	}
+}
+
+func TestQueueWaitLimitTimesOutQueuedRequest(t *testing.T) {
+	// The endpoint's wait limit overrides the service's.
+	configure := func(s *config.Scenario) {
+		s.Services[0].MaxQueueWaitMs = 500
+		s.Services[0].Endpoints[0].MaxQueueWaitMs = 50
+	}
+	reqs, collector := runSchedulingScenario(t, configure, []schedulingArrival{
+		{"first", 0, nil}, {"a", 10, nil}, {"b", 80, nil},
+	})
+	if r := reqs["a"]; r.Status != models.RequestStatusFailed || r.Error != metrics.ReasonQueueTimeout || completedAtMs(r) != 60 {
+		t.Fatalf("request a should time out in the queue at 60ms, got status %s error %q at %dms", r.Status, r.Error, completedAtMs(r))
+	}
+	if r := reqs["b"]; r.Status != models.RequestStatusCompleted || completedAtMs(r) != 200 {
+		t.Fatalf("request b waited 20ms and should be served at 200ms, got status %s at %dms", r.Status, completedAtMs(r))
+	}
+	var stats *models.EndpointRequestStats
+	rm := metrics.ConvertToRunMetrics(collector, nil, nil)
+	for i := range rm.EndpointRequestStats {
+		if st := &rm.EndpointRequestStats[i]; st.ServiceName == "api" && st.EndpointPath == "/work" {
+			stats = st
+		}
+	}
+	if stats == nil || stats.ErrorCount != 1 {
+		t.Fatalf("expected 1 error in the api /work endpoint stats, got %+v", stats)
+	}
+}
+
+func TestQueueFullRejectionsOpenCircuitBreaker(t *testing.T) {
+	configure := func(s *config.Scenario) {
+		s.Services[0].MaxQueueLength = 1
+		s.Policies = &config.Policies{CircuitBreaker: &config.CircuitBreakerPolicy{Enabled: true, FailureThreshold: 1, TimeoutMs: 10000}}
+	}
+	reqs, collector := runSchedulingScenario(t, configure, []schedulingArrival{
+		{"first", 0, nil}, {"a", 10, nil}, {"b", 20, nil}, {"c", 30, nil},
+	})
+	if r := reqs["b"]; r.Error != metrics.ReasonQueueFull {
+		t.Fatalf("request b should be rejected with queue_full, got %q", r.Error)
+	}
+	if r := reqs["c"]; r.Status != models.RequestStatusFailed {
+		t.Fatalf("request c should be rejected, got status %s", r.Status)
+	}
+	if got := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonCircuitOpen); got != 1 {
+		t.Fatalf("expected request c to find the circuit open, got %v circuit_open errors", got)
+	}
+}
@@ -0,0 +1,187 @@
+package simd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+	"time"
+
+	simulationv1 "github.com/GoSim-25-26J-441/simulation-core/gen/go/simulation/v1"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// DefaultMaxReplications caps the replications of one run when SIMD_MAX_REPLICATIONS is unset.
+const DefaultMaxReplications = 50
+
+// ReplicationOptions controls independent replications of a scenario.
+type ReplicationOptions struct {
+	// Replications is the initial number of replications (raised to 2 when a stopping rule is set).
+	Replications int
+	// TargetRelativePrecision enables the stopping rule when > 0: replications are added until the
+	// 95% CI half-width of the run-level latencies and throughput is within this fraction of the mean.
+	TargetRelativePrecision float64
+	// MaxReplications bounds the stopping rule.
+	MaxReplications int
+	// Parallelism bounds the replications running at once (0 => GOMAXPROCS).
+	Parallelism int
+}
+
+// replicationOptionsFromInput reads the replication fields of a run input; serverMax bounds max_replications.
+func replicationOptionsFromInput(input *simulationv1.RunInput, serverMax int) ReplicationOptions {
+	opts := ReplicationOptions{
+		Replications:            int(input.GetReplications()),
+		TargetRelativePrecision: input.GetCiRelativePrecision(),
+		MaxReplications:         int(input.GetMaxReplications()),
+	}
+	if opts.MaxReplications <= 0 || opts.MaxReplications > serverMax {
+		opts.MaxReplications = serverMax
+	}
+	return opts
+}
+
+func (o ReplicationOptions) enabled() bool {
+	return o.Replications > 1 || o.TargetRelativePrecision > 0
+}
+
+func (o ReplicationOptions) normalized() ReplicationOptions {
+	if o.Replications < 1 {
+		o.Replications = 1
+	}
+	if o.TargetRelativePrecision > 0 && o.Replications < 2 {
+		o.Replications = 2
+	}
+	if o.MaxReplications < o.Replications {
+		o.MaxReplications = o.Replications
+	}
+	if o.Parallelism <= 0 {
+		o.Parallelism = runtime.GOMAXPROCS(0)
+	}
+	return o
+}
+
+// ReplicationSeed derives the seed of replication i from the run seed, which must be non-zero (callers
+// without a seed draw a time-based one first, as effectiveRunSeed does). Replication 0 keeps the run seed;
+// the others are spread with a SplitMix64 step so that the seed offsets each run derives for its RNG
+// streams (routing, workload, interactions) do not line up across replications.
+func ReplicationSeed(base int64, i int) int64 {
+	if i == 0 {
+		return base
+	}
+	z := uint64(base) + uint64(i)*0x9e3779b97f4a7c15 //nolint:gosec // bit mixing, wraparound intended
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	z ^= z >> 31
+	seed := int64(z & math.MaxInt64) //nolint:gosec // masked to the non-negative int64 range
+	if seed == 0 {
+		// Seed 0 would select a time-based RNG.
+		seed = 1
+	}
+	return seed
+}
+
+// replicationRunner runs replications of one scenario. Each replication parses its own copy of the
+// scenario YAML, since runs may adjust the scenario they are given.
+type replicationRunner struct {
+	scenarioYAML string
+	duration     time.Duration
+	seed         int64
+	opts         ReplicationOptions
+	limits       *engine.RuntimeLimits
+}
+
+// replicationBatch is the outcome of runBatch for a goroutine running it.
+type replicationBatch struct {
+	runs []*models.RunMetrics
+	err  error
+}
+
+// runBatch runs replications [from, to) in parallel and returns their metrics in replication order.
+func (r *replicationRunner) runBatch(ctx context.Context, from, to int) ([]*models.RunMetrics, error) {
+	if to <= from {
+		return nil, nil
+	}
+	out := make([]*models.RunMetrics, to-from)
+	errs := make([]error, to-from)
+	sem := make(chan struct{}, r.opts.Parallelism)
+	var wg sync.WaitGroup
+	for i := from; i < to; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			scenario, err := config.ParseScenarioYAMLString(r.scenarioYAML)
+			if err != nil {
+				errs[i-from] = err
+				return
+			}
+			out[i-from], errs[i-from] = runScenarioForMetrics(ctx, scenario, r.duration, ReplicationSeed(r.seed, i), false, r.limits)
+		}(i)
+	}
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("replication %d: %w", from+i, err)
+		}
+	}
+	return out, nil
+}
+
+// complete applies the stopping rule to the replications run so far (runs[i] is replication i), adding
+// batches of Parallelism replications until the target precision or MaxReplications is reached.
+func (r *replicationRunner) complete(ctx context.Context, runs []*models.RunMetrics) (*models.ReplicationSummary, []*models.RunMetrics, error) {
+	for {
+		summary := r.summarize(runs)
+		if summary.PrecisionReached || len(runs) >= r.opts.MaxReplications {
+			return summary, runs, nil
+		}
+		next := min(len(runs)+r.opts.Parallelism, r.opts.MaxReplications)
+		more, err := r.runBatch(ctx, len(runs), next)
+		if err != nil {
+			return nil, nil, err
+		}
+		runs = append(runs, more...)
+	}
+}
+
+func (r *replicationRunner) summarize(runs []*models.RunMetrics) *models.ReplicationSummary {
+	seeds := make([]int64, len(runs))
+	for i := range runs {
+		seeds[i] = ReplicationSeed(r.seed, i)
+	}
+	summary := metrics.SummarizeReplications(runs, seeds, r.duration)
+	summary.TargetRelativePrecision = r.opts.TargetRelativePrecision
+	summary.PrecisionReached = r.opts.TargetRelativePrecision <= 0 || summary.MaxRelativeHalfWidth <= r.opts.TargetRelativePrecision
+	return summary
+}
+
+// RunScenarioReplications runs scenario as independent replications in parallel goroutines (replication i
+// uses ReplicationSeed(seed, i)) and returns the summary together with the metrics of every replication.
+// A zero seed draws a time-based base seed, so every replication is time-seeded; the summary reports the
+// seeds used.
+func RunScenarioReplications(ctx context.Context, scenario *config.Scenario, simDuration time.Duration, seed int64, opts ReplicationOptions) (*models.ReplicationSummary, []*models.RunMetrics, error) {
+	if scenario == nil {
+		return nil, nil, fmt.Errorf("scenario is nil")
+	}
+	if simDuration <= 0 {
+		return nil, nil, fmt.Errorf("simDuration must be positive")
+	}
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	raw, err := config.MarshalScenarioYAML(scenario)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := &replicationRunner{scenarioYAML: raw, duration: simDuration, seed: seed, opts: opts.normalized()}
+	runs, err := r.runBatch(ctx, 0, r.opts.Replications)
+	if err != nil {
+		return nil, nil, err
+	}
+	return r.complete(ctx, runs)
+}
@@ -0,0 +1,222 @@
+package simd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	simulationv1 "github.com/GoSim-25-26J-441/simulation-core/gen/go/simulation/v1"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"google.golang.org/protobuf/proto"
+)
+
+const replicationScenarioYAML = `
+hosts:
+  - id: host-1
+    cores: 4
+  - id: host-2
+    cores: 4
+services:
+  - id: api
+    replicas: 2
+    model: cpu
+    routing: {strategy: random}
+    endpoints:
+      - path: /orders
+        mean_cpu_ms: 8
+        cpu_sigma_ms: 3
+        net_latency_ms: {mean: 1, sigma: 0.5}
+        downstream:
+          - to: db:/query
+  - id: db
+    replicas: 2
+    model: cpu
+    endpoints:
+      - path: /query
+        mean_cpu_ms: 4
+        cpu_sigma_ms: 2
+        net_latency_ms: {mean: 1, sigma: 0.5}
+workload:
+  - from: web
+    to: api:/orders
+    arrival: {type: poisson, rate_rps: 60}
+  - from: mobile
+    to: api:/orders
+    arrival: {type: uniform, rate_rps: 40}
+`
+
+func waitForRunStatus(t *testing.T, store *RunStore, runID string, want simulationv1.RunStatus) *RunRecord {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if rec, ok := store.Get(runID); ok && rec.Run.Status == want {
+			return rec
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	rec, _ := store.Get(runID)
+	t.Fatalf("run %s did not reach %v (got %v, error %q)", runID, want, rec.Run.Status, rec.Run.Error)
+	return nil
+}
+
+// Seeded standard runs are reproducible, which replications rely on.
+func TestSeededRunIsReproducible(t *testing.T) {
+	store := NewRunStore()
+	exec := NewRunExecutor(store, nil)
+	var runs []*RunRecord
+	for _, id := range []string{"first", "second"} {
+		if _, err := store.Create(id, &simulationv1.RunInput{ScenarioYaml: replicationScenarioYAML, DurationMs: 2000, Seed: 42}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if _, err := exec.Start(id); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		runs = append(runs, waitForRunStatus(t, store, id, simulationv1.RunStatus_RUN_STATUS_COMPLETED))
+	}
+	if runs[0].Metrics.GetTotalRequests() == 0 {
+		t.Fatalf("expected the run to report requests")
+	}
+	if !proto.Equal(runs[0].Metrics, runs[1].Metrics) {
+		t.Fatalf("runs with the same seed differ:\n%v\n%v", runs[0].Metrics, runs[1].Metrics)
+	}
+}
+
+func TestReplicationSeedIsStableAndDistinct(t *testing.T) {
+	if ReplicationSeed(42, 0) != 42 {
+		t.Fatalf("replication 0 must keep the run seed")
+	}
+	seen := map[int64]bool{}
+	for i := 0; i < 100; i++ {
+		s := ReplicationSeed(42, i)
+		if s == 0 || seen[s] {
+			t.Fatalf("replication %d: seed %d is zero or repeated", i, s)
+		}
+		if s != ReplicationSeed(42, i) {
+			t.Fatalf("replication %d: seed is not stable", i)
+		}
+		seen[s] = true
+	}
+}
+
+func TestRunScenarioReplicationsIsDeterministic(t *testing.T) {
+	scenario, err := config.ParseScenarioYAMLString(replicationScenarioYAML)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	opts := ReplicationOptions{Replications: 4}
+	a, runs, err := RunScenarioReplications(context.Background(), scenario, 2*time.Second, 11, opts)
+	if err != nil {
+		t.Fatalf("replications: %v", err)
+	}
+	b, _, err := RunScenarioReplications(context.Background(), scenario, 2*time.Second, 11, opts)
+	if err != nil {
+		t.Fatalf("replications: %v", err)
+	}
+	if a.Replications != 4 || len(runs) != 4 || a.Seeds[0] != 11 {
+		t.Fatalf("unexpected summary %d replications, seeds %v", a.Replications, a.Seeds)
+	}
+	if a.Run.LatencyP95 != b.Run.LatencyP95 || a.Run.ThroughputRPS != b.Run.ThroughputRPS {
+		t.Fatalf("same seed produced different summaries: %+v vs %+v", a.Run, b.Run)
+	}
+	if a.Run.LatencyP95.StdDev == 0 || a.Run.LatencyP95.CI95Low >= a.Run.LatencyP95.CI95High {
+		t.Fatalf("expected replications with distinct seeds to spread, got %+v", a.Run.LatencyP95)
+	}
+	if a.Services["api"] == nil || a.Services["db"] == nil {
+		t.Fatalf("expected per-service statistics, got %v", a.Services)
+	}
+	if !a.PrecisionReached {
+		t.Fatalf("runs without a stopping rule always report precision reached")
+	}
+}
+
+func TestRunScenarioReplicationsWithoutSeedSeedsEveryReplication(t *testing.T) {
+	scenario, err := config.ParseScenarioYAMLString(replicationScenarioYAML)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	summary, _, err := RunScenarioReplications(context.Background(), scenario, time.Second, 0, ReplicationOptions{Replications: 3})
+	if err != nil {
+		t.Fatalf("replications: %v", err)
+	}
+	seen := map[int64]bool{}
+	for i, s := range summary.Seeds {
+		if s == 0 || seen[s] {
+			t.Fatalf("replication %d: seed %d is zero or repeated (seeds %v)", i, s, summary.Seeds)
+		}
+		seen[s] = true
+	}
+}
+
+func TestRunScenarioReplicationsStoppingRule(t *testing.T) {
+	scenario, err := config.ParseScenarioYAMLString(replicationScenarioYAML)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	// An unreachable precision adds replications in batches until the cap.
+	tight, _, err := RunScenarioReplications(context.Background(), scenario, time.Second, 5,
+		ReplicationOptions{TargetRelativePrecision: 1e-9, MaxReplications: 5, Parallelism: 2})
+	if err != nil {
+		t.Fatalf("replications: %v", err)
+	}
+	if tight.Replications != 5 || tight.PrecisionReached {
+		t.Fatalf("expected the stopping rule to hit max_replications, got %d (reached=%v)", tight.Replications, tight.PrecisionReached)
+	}
+
+	loose, _, err := RunScenarioReplications(context.Background(), scenario, time.Second, 5,
+		ReplicationOptions{Replications: 3, TargetRelativePrecision: 0.99, MaxReplications: 10})
+	if err != nil {
+		t.Fatalf("replications: %v", err)
+	}
+	if loose.Replications != 3 || !loose.PrecisionReached || loose.MaxRelativeHalfWidth > 0.99 {
+		t.Fatalf("expected a loose target to stop after the initial batch, got %d (max rel %f)", loose.Replications, loose.MaxRelativeHalfWidth)
+	}
+}
+
+func TestRunWithReplicationsStoresSummary(t *testing.T) {
+	store := NewRunStore()
+	exec := NewRunExecutor(store, nil)
+	input := &simulationv1.RunInput{ScenarioYaml: replicationScenarioYAML, DurationMs: 2000, Seed: 9, Replications: 3}
+	if _, err := store.Create("reps", input); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := exec.Start("reps"); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	rec := waitForRunStatus(t, store, "reps", simulationv1.RunStatus_RUN_STATUS_COMPLETED)
+	s := rec.Replications
+	if s == nil || s.Replications != 3 || s.Seeds[0] != 9 {
+		t.Fatalf("expected a 3-replication summary seeded from the run, got %+v", s)
+	}
+	// The run's own metrics are replication 0, so they lie within the replication range.
+	p95 := rec.Metrics.GetLatencyP95Ms()
+	if p95 <= 0 || s.Run.LatencyP95.N != 3 || s.Run.LatencyP95.Mean-3*s.Run.LatencyP95.StdDev > p95 || s.Run.LatencyP95.Mean+3*s.Run.LatencyP95.StdDev < p95 {
+		t.Fatalf("run p95 %f outside replication spread %+v", p95, s.Run.LatencyP95)
+	}
+}
+
+func TestValidatePreStartRejectsInvalidReplications(t *testing.T) {
+	limits := defaultSimulationLimits()
+	limits.MaxReplications = 10
+	cases := []struct {
+		input *simulationv1.RunInput
+		want  string
+	}{
+		{&simulationv1.RunInput{Replications: -1}, "must be >= 0"},
+		{&simulationv1.RunInput{CiRelativePrecision: 1.5}, "ci_relative_precision"},
+		{&simulationv1.RunInput{Replications: 11}, "exceeds server limit"},
+		{&simulationv1.RunInput{Replications: 5, MaxReplications: 3}, "below replications"},
+		{&simulationv1.RunInput{Replications: 2, RealTimeMode: true}, "standard"},
+		{&simulationv1.RunInput{Replications: 2, Optimization: &simulationv1.OptimizationConfig{}}, "standard"},
+	}
+	for _, tc := range cases {
+		tc.input.ScenarioYaml = replicationScenarioYAML
+		if err := limits.validatePreStart(tc.input); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("input %v: expected error containing %q, got %v", tc.input, tc.want, err)
+		}
+	}
+	ok := &simulationv1.RunInput{ScenarioYaml: replicationScenarioYAML, Replications: 10, CiRelativePrecision: 0.05}
+	if err := limits.validatePreStart(ok); err != nil {
+		t.Fatalf("expected valid replication input, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package simd
+
+import (
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/policy"
+)
+
+// wireResiliencePolicies applies the scenario's circuit_breaker / rate_limiting blocks (global,
+// per-service, per-endpoint) to the policy manager and reports circuit state transitions as metrics.
+func wireResiliencePolicies(state *scenarioState) {
+	if state.policies == nil {
+		return
+	}
+	state.policies.ApplyScenarioOverrides(state.scenario)
+	collector := state.collector
+	state.policies.SetCircuitStateObserver(func(serviceID, endpointPath string, from, to policy.CircuitState, at time.Time) {
+		metrics.RecordCircuitBreakerOpen(collector, circuitStateGaugeValue(to), at, metrics.CreateEndpointLabels(serviceID, endpointPath))
+		labels := metrics.CreateEndpointLabels(serviceID, endpointPath)
+		labels["from"] = string(from)
+		labels["to"] = string(to)
+		metrics.RecordCircuitBreakerStateTransition(collector, 1.0, at, labels)
+	})
+}
+
+func circuitStateGaugeValue(s policy.CircuitState) float64 {
+	switch s {
+	case policy.CircuitStateOpen:
+		return 1
+	case policy.CircuitStateHalfOpen:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// admissionRejectReason applies rate limiting then the circuit breaker for serviceID/endpointPath.
+// It returns metrics.ReasonRateLimited or metrics.ReasonCircuitOpen when the request must be rejected,
+// or "" when it is admitted. Rate-limit rejections are also recorded as rate_limit_rejection_count.
+func admissionRejectReason(state *scenarioState, serviceID, endpointPath string, simTime time.Time, labels map[string]string) string {
+	if state.policies == nil {
+		return ""
+	}
+	if rl := state.policies.GetRateLimiting(); rl != nil && !rl.AllowRequest(serviceID, endpointPath, simTime) {
+		metrics.RecordRateLimitRejection(state.collector, 1.0, simTime, labels)
+		return metrics.ReasonRateLimited
+	}
+	if cb := state.policies.GetCircuitBreaker(); cb != nil && !cb.AllowRequest(serviceID, endpointPath, simTime) {
+		return metrics.ReasonCircuitOpen
+	}
+	return ""
+}
@@ -0,0 +1,140 @@
+package simd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/policy"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+// runResilienceScenario runs the DES loop for scenario and returns the metrics collector.
+func runResilienceScenario(t *testing.T, scenario *config.Scenario, dur time.Duration) *metrics.Collector {
+	t.Helper()
+	r := newTestRun(t, scenario, 7)
+	start := r.eng.GetSimTime()
+	end := start.Add(dur)
+	r.state.SetSimEndTime(end)
+	ScheduleDrainSweepKickoff(r.eng, start)
+	ws := NewWorkloadState("resilience-test", r.eng, end, 7)
+	if err := ws.Start(scenario, start, false); err != nil {
+		t.Fatalf("workload start: %v", err)
+	}
+	defer ws.Stop()
+	r.run(t, dur)
+	return r.collector
+}
+
+func TestScenarioEndpointRateLimitingRejectsIngress(t *testing.T) {
+	scenario := &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 8, MemoryGB: 16}},
+		Services: []config.Service{{
+			ID: "api", Replicas: 1, Model: "cpu",
+			Endpoints: []config.Endpoint{{
+				Path: "/work", MeanCPUMs: 1, NetLatencyMs: config.LatencySpec{Mean: 0.5},
+				Policies: &config.PolicyOverrides{
+					RateLimiting: &config.RateLimitingOverride{Enabled: config.BoolPtr(true), RateLimitPerSecond: 5},
+				},
+			}},
+		}},
+		Workload: []config.WorkloadPattern{
+			{From: "client", To: "api:/work", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 50}},
+		},
+	}
+	collector := runResilienceScenario(t, scenario, 2*time.Second)
+
+	rejected := collector.SumMetric(metrics.MetricRateLimitRejection)
+	if rejected < 50 {
+		t.Fatalf("expected most of 100 arrivals rate limited at 5/s, got %v rejections", rejected)
+	}
+	if got := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonRateLimited); got != rejected {
+		t.Fatalf("expected rate_limited errors (%v) to match rejections (%v)", got, rejected)
+	}
+}
+
+func TestScenarioCircuitBreakerOpensOnFailingDownstream(t *testing.T) {
+	scenario := &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 8, MemoryGB: 16}},
+		Services: []config.Service{
+			{
+				ID: "api", Replicas: 1, Model: "cpu",
+				Endpoints: []config.Endpoint{{
+					Path: "/checkout", MeanCPUMs: 1, NetLatencyMs: config.LatencySpec{Mean: 0.5},
+					Downstream: []config.DownstreamCall{{To: "payments:/charge", FailureRate: 1}},
+				}},
+			},
+			{
+				ID: "payments", Replicas: 1, Model: "cpu",
+				Policies: &config.PolicyOverrides{
+					CircuitBreaker: &config.CircuitBreakerOverride{Enabled: config.BoolPtr(true), FailureThreshold: 3, TimeoutMs: 60000},
+				},
+				Endpoints: []config.Endpoint{{Path: "/charge", MeanCPUMs: 1, NetLatencyMs: config.LatencySpec{Mean: 0.5}}},
+			},
+		},
+		Workload: []config.WorkloadPattern{
+			{From: "client", To: "api:/checkout", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 20}},
+		},
+	}
+	collector := runResilienceScenario(t, scenario, 2*time.Second)
+
+	opened := collector.SumMetricWhere(metrics.MetricCircuitBreakerStateTransition, "to", string(policy.CircuitStateOpen))
+	if opened != 1 {
+		t.Fatalf("expected exactly one closed->open transition within the timeout, got %v", opened)
+	}
+	if v, ok := collector.GetLastValue(metrics.MetricCircuitBreakerOpen, metrics.CreateEndpointLabels("payments", "/charge")); !ok || v != 1 {
+		t.Fatalf("expected circuit_breaker_open gauge 1 for payments:/charge, got %v (ok=%v)", v, ok)
+	}
+	depFailures := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonDependencyFailure)
+	if depFailures != 3 {
+		t.Fatalf("expected only failure_threshold calls to reach the failing dependency, got %v", depFailures)
+	}
+	if got := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonCircuitOpen); got < 20 {
+		t.Fatalf("expected later calls to fail fast with circuit_open, got %v", got)
+	}
+}
+
+func TestScenarioDownstreamCallRetryOverride(t *testing.T) {
+	scenario := &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 8, MemoryGB: 16}},
+		Services: []config.Service{
+			{
+				ID: "api", Replicas: 1, Model: "cpu",
+				Endpoints: []config.Endpoint{
+					{
+						Path: "/checkout", MeanCPUMs: 1, NetLatencyMs: config.LatencySpec{Mean: 0.5},
+						Downstream: []config.DownstreamCall{{
+							To: "payments:/charge", FailureRate: 1,
+							Policies: &config.PolicyOverrides{
+								Retries: &config.RetryOverride{Enabled: config.BoolPtr(true), MaxRetries: 2, BaseMs: 1},
+							},
+						}},
+					},
+					{
+						Path: "/audit", MeanCPUMs: 1, NetLatencyMs: config.LatencySpec{Mean: 0.5},
+						Downstream: []config.DownstreamCall{{To: "audit:/log", FailureRate: 1}},
+					},
+				},
+			},
+			{ID: "payments", Replicas: 1, Model: "cpu", Endpoints: []config.Endpoint{{Path: "/charge", MeanCPUMs: 1, NetLatencyMs: config.LatencySpec{Mean: 0.5}}}},
+			{ID: "audit", Replicas: 1, Model: "cpu", Endpoints: []config.Endpoint{{Path: "/log", MeanCPUMs: 1, NetLatencyMs: config.LatencySpec{Mean: 0.5}}}},
+		},
+		Workload: []config.WorkloadPattern{
+			{From: "client", To: "api:/checkout", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 5}},
+			{From: "client", To: "api:/audit", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 5}},
+		},
+		Policies: &config.Policies{Retries: &config.RetryPolicy{Enabled: false, Backoff: "constant"}},
+	}
+	collector := runResilienceScenario(t, scenario, time.Second)
+
+	audit := collector.SumMetricWhere(metrics.MetricRequestCount, "service", "audit")
+	payments := collector.SumMetricWhere(metrics.MetricRequestCount, "service", "payments")
+	retries := collector.SumMetricWhere(metrics.MetricRequestCount, metrics.LabelIsRetry, "true")
+	if audit == 0 {
+		t.Fatal("expected audit:/log to be called")
+	}
+	// Only payments:/charge is retried: each logical call makes 1 + max_retries attempts.
+	if payments != 3*audit || retries != 2*audit {
+		t.Fatalf("expected call-level override to retry payments only (audit=%v payments=%v retries=%v)", audit, payments, retries)
+	}
+}
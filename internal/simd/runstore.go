@@ -12,7 +12,9 @@ import (
 	simulationv1 "github.com/GoSim-25-26J-441/simulation-core/gen/go/simulation/v1"
 	"github.com/GoSim-25-26J-441/simulation-core/internal/batchspec"
 	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/storage"
 	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
 	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
 	"google.golang.org/protobuf/proto"
 )
@@ -27,6 +29,13 @@ type RunRecord struct {
 	// FinalConfig is a snapshot of the effective RunConfiguration taken before executor cleanup
 	// (placements, replicas, workload). Populated for terminal runs when the simulator still had state.
 	FinalConfig *simulationv1.RunConfiguration
+	// ScalingDecisions lists in-simulation autoscaler actions in decision order.
+	ScalingDecisions []ScalingDecision
+	// FaultEvents lists chaos faults started and lifted, in simulation order.
+	FaultEvents []FaultEvent
+	// Replications summarizes independent replications when RunInput.replications or the stopping rule
+	// is set. It is replaced, never mutated, so records share it.
+	Replications *models.ReplicationSummary
 }
 
 type RunStoreLifecycleConfig struct {
@@ -114,6 +123,12 @@ type RunStore struct {
 	lifecycle    RunStoreLifecycleConfig
 	stopCh       chan struct{}
 	doneCh       chan struct{}
+	// backend receives every record change when set (see NewRunStoreWithBackend); nil keeps runs in memory only.
+	backend storage.Store
+	// dirty holds the IDs of records changed since the last flush to backend (see flushLoop).
+	dirty     map[string]struct{}
+	flushCh   chan struct{}
+	flushDone chan struct{}
 }
 
 type RunStoreCounts struct {
@@ -125,14 +140,25 @@ type RunStoreCounts struct {
 }
 
 func NewRunStore() *RunStore {
+	s := newRunStore(nil)
+	go s.cleanupLoop()
+	return s
+}
+
+func newRunStore(backend storage.Store) *RunStore {
 	s := &RunStore{
 		runs:         make(map[string]*RunRecord),
 		onlineLimits: DefaultOnlineRunLimits(),
 		lifecycle:    runStoreLifecycleConfigFromEnv(),
 		stopCh:       make(chan struct{}),
 		doneCh:       make(chan struct{}),
+		backend:      backend,
+	}
+	if backend != nil {
+		s.dirty = make(map[string]struct{})
+		s.flushCh = make(chan struct{}, 1)
+		s.flushDone = make(chan struct{})
 	}
-	go s.cleanupLoop()
 	return s
 }
 
@@ -150,9 +176,13 @@ func (s *RunStore) cleanupLoop() {
 	}
 }
 
+// Stop ends the cleanup loop and, with a backend, flushes pending record changes to it.
 func (s *RunStore) Stop() {
 	close(s.stopCh)
 	<-s.doneCh
+	if s.flushDone != nil {
+		<-s.flushDone
+	}
 }
 
 func (s *RunStore) LifecycleConfig() RunStoreLifecycleConfig {
@@ -269,6 +299,7 @@ func (s *RunStore) Create(runID string, input *simulationv1.RunInput) (*RunRecor
 		IsOptimizationChild: strings.HasPrefix(runID, "opt-"),
 	}
 	s.runs[runID] = rec
+	s.persistLocked(rec)
 	return cloneRunRecord(rec), nil
 }
 
@@ -377,6 +408,7 @@ func (s *RunStore) SetStatusRunningWithOnlineConcurrencyGuard(runID string) (*Ru
 	if rec.Run.StartedAtUnixMs == 0 {
 		rec.Run.StartedAtUnixMs = nowUnixMs()
 	}
+	s.persistLocked(rec)
 	return cloneRunRecord(rec), nil
 }
 
@@ -406,6 +438,7 @@ func (s *RunStore) SetStatus(runID string, status simulationv1.RunStatus, errMsg
 		rec.Run.EndedAtUnixMs = nowUnixMs()
 		s.finalizeRunStorageLocked(rec)
 	}
+	s.persistLocked(rec)
 
 	return cloneRunRecord(rec), nil
 }
@@ -419,6 +452,7 @@ func (s *RunStore) SetOnlineCompletionReason(runID, reason string) error {
 		return fmt.Errorf("run not found: %s", runID)
 	}
 	rec.Run.OnlineCompletionReason = reason
+	s.persistLocked(rec)
 	return nil
 }
 
@@ -431,6 +465,7 @@ func (s *RunStore) SetMetrics(runID string, metrics *simulationv1.RunMetrics) er
 		return fmt.Errorf("run not found: %s", runID)
 	}
 	rec.Metrics = cloneRunMetrics(metrics)
+	s.persistLocked(rec)
 	return nil
 }
 
@@ -471,9 +506,10 @@ func (s *RunStore) SetFinalConfiguration(runID string, cfg *simulationv1.RunConf
 	}
 	if cfg == nil {
 		rec.FinalConfig = nil
-		return nil
+	} else {
+		rec.FinalConfig = proto.Clone(cfg).(*simulationv1.RunConfiguration)
 	}
-	rec.FinalConfig = proto.Clone(cfg).(*simulationv1.RunConfiguration)
+	s.persistLocked(rec)
 	return nil
 }
 
@@ -492,6 +528,7 @@ func (s *RunStore) SetBatchRecommendation(runID string, feasible bool, violation
 	rec.Run.BatchViolationScore = violationScore
 	rec.Run.BatchEfficiencyScore = efficiencyScore
 	rec.Run.BatchRecommendationSummary = summary
+	s.persistLocked(rec)
 	return nil
 }
 
@@ -516,6 +553,7 @@ func (s *RunStore) SetOptimizationResult(runID string, bestRunID string, bestSco
 	} else {
 		rec.Run.CandidateRunIds = nil
 	}
+	s.persistLocked(rec)
 	return nil
 }
 
@@ -546,9 +584,11 @@ func (s *RunStore) TrimOptimizationCandidates(parentRunID, bestRunID string) {
 		}
 		if _, ok := keep[runID]; ok {
 			s.finalizeRunStorageLocked(rec)
+			s.persistLocked(rec)
 			continue
 		}
 		delete(s.runs, runID)
+		s.unpersistLocked(runID)
 	}
 }
 
@@ -605,6 +645,49 @@ func (s *RunStore) AppendOptimizationStep(runID string, step *simulationv1.Optim
 		return fmt.Errorf("run not found: %s", runID)
 	}
 	rec.OptimizationHistory = append(rec.OptimizationHistory, proto.Clone(step).(*simulationv1.OptimizationStep))
+	s.persistLocked(rec)
+	return nil
+}
+
+// AppendScalingDecision appends an autoscaler decision to the run record.
+func (s *RunStore) AppendScalingDecision(runID string, d ScalingDecision) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.runs[runID]
+	if !ok {
+		return fmt.Errorf("run not found: %s", runID)
+	}
+	rec.ScalingDecisions = append(rec.ScalingDecisions, d)
+	s.persistLocked(rec)
+	return nil
+}
+
+// AppendFaultEvent appends a fault start or end to the run record.
+func (s *RunStore) AppendFaultEvent(runID string, ev FaultEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.runs[runID]
+	if !ok {
+		return fmt.Errorf("run not found: %s", runID)
+	}
+	rec.FaultEvents = append(rec.FaultEvents, ev)
+	s.persistLocked(rec)
+	return nil
+}
+
+// SetReplicationSummary stores the replication statistics of a run.
+func (s *RunStore) SetReplicationSummary(runID string, summary *models.ReplicationSummary) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec, ok := s.runs[runID]
+	if !ok {
+		return fmt.Errorf("run not found: %s", runID)
+	}
+	rec.Replications = summary
+	s.persistLocked(rec)
 	return nil
 }
 
@@ -646,6 +729,9 @@ func cloneRunRecord(rec *RunRecord) *RunRecord {
 		IsOptimizationChild: rec.IsOptimizationChild,
 		OptimizationHistory: history,
 		FinalConfig:         cloneRunConfiguration(rec.FinalConfig),
+		ScalingDecisions:    append([]ScalingDecision(nil), rec.ScalingDecisions...),
+		FaultEvents:         append([]FaultEvent(nil), rec.FaultEvents...),
+		Replications:        rec.Replications,
 	}
 }
 
@@ -671,6 +757,7 @@ func (s *RunStore) CleanupNow() {
 		}
 		if s.lifecycle.TTL > 0 && nowMs-ended >= s.lifecycle.TTL.Milliseconds() {
 			delete(s.runs, id)
+			s.unpersistLocked(id)
 			continue
 		}
 		terminal = append(terminal, terminalRun{id: id, ended: ended, child: rec.IsOptimizationChild})
@@ -688,6 +775,7 @@ func (s *RunStore) CleanupNow() {
 		evict := terminal[0]
 		terminal = terminal[1:]
 		delete(s.runs, evict.id)
+		s.unpersistLocked(evict.id)
 	}
 }
 
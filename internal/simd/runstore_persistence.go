@@ -0,0 +1,268 @@
+package simd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	simulationv1 "github.com/GoSim-25-26J-441/simulation-core/gen/go/simulation/v1"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/storage"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/logger"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// RunInterruptedByRestartError is the error recorded on runs that were RUNNING when simd stopped.
+const RunInterruptedByRestartError = "simulation interrupted: simd restarted while the run was in progress"
+
+// persistedRunRecord is the stored form of a RunRecord. Protobuf messages use protojson; the collector
+// is stored only for terminal runs that still retain it (see SIMD_RUNSTORE_KEEP_COLLECTOR_AFTER_COMPLETION).
+type persistedRunRecord struct {
+	Run                 json.RawMessage            `json:"run"`
+	Input               json.RawMessage            `json:"input,omitempty"`
+	Metrics             json.RawMessage            `json:"metrics,omitempty"`
+	IsOptimizationChild bool                       `json:"is_optimization_child,omitempty"`
+	OptimizationHistory []json.RawMessage          `json:"optimization_history,omitempty"`
+	FinalConfig         json.RawMessage            `json:"final_config,omitempty"`
+	ScalingDecisions    []ScalingDecision          `json:"scaling_decisions,omitempty"`
+	FaultEvents         []FaultEvent               `json:"fault_events,omitempty"`
+	Collector           *metrics.CollectorState    `json:"collector,omitempty"`
+	Replications        *models.ReplicationSummary `json:"replications,omitempty"`
+}
+
+// RunStoreBackendFromEnv opens the file-backed run store under SIMD_DATA_DIR. It returns a nil store
+// when SIMD_DATA_DIR is unset (in-memory only). SIMD_RUNSTORE_SYNC_WRITES=true fsyncs every write.
+func RunStoreBackendFromEnv() (storage.Store, error) {
+	dir := strings.TrimSpace(os.Getenv("SIMD_DATA_DIR"))
+	if dir == "" {
+		return nil, nil
+	}
+	opts := storage.FileStoreOptions{}
+	if b, ok := parseRunStoreBoolEnv("SIMD_RUNSTORE_SYNC_WRITES"); ok {
+		opts.SyncWrites = b
+	}
+	if n, ok := parseRunStoreIntEnv("SIMD_RUNSTORE_COMPACT_EVERY"); ok && n > 0 {
+		opts.CompactEvery = n
+	}
+	return storage.OpenFileStore(dir, opts)
+}
+
+// runStoreFlushInterval is the minimum time between two flushes of changed records to the backend.
+// Changes made in between are coalesced, so a record appended to many times is written a bounded
+// number of times.
+const runStoreFlushInterval = 100 * time.Millisecond
+
+// NewRunStoreWithBackend creates a run store that writes record changes to backend in the background
+// and reloads the runs already stored there. Runs that were RUNNING when simd stopped are marked FAILED
+// with RunInterruptedByRestartError, as are PENDING optimization candidates, whose parent run is gone.
+// Other PENDING runs can still be started. A nil backend behaves like NewRunStore.
+func NewRunStoreWithBackend(backend storage.Store) (*RunStore, error) {
+	s := newRunStore(backend)
+	if backend != nil {
+		if err := s.loadFromBackend(); err != nil {
+			return nil, err
+		}
+		go s.flushLoop()
+	}
+	go s.cleanupLoop()
+	return s, nil
+}
+
+func (s *RunStore) loadFromBackend() error {
+	docs, err := s.backend.LoadAll()
+	if err != nil {
+		return fmt.Errorf("load run store: %w", err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var interrupted int
+	for id, doc := range docs {
+		rec, err := decodeRunRecord(doc)
+		if err != nil {
+			logger.Warn("run store: skipping unreadable run record", "run_id", id, "error", err)
+			continue
+		}
+		s.runs[rec.Run.Id] = rec
+		if rec.Run.Status == simulationv1.RunStatus_RUN_STATUS_RUNNING ||
+			(rec.Run.Status == simulationv1.RunStatus_RUN_STATUS_PENDING && rec.IsOptimizationChild) {
+			rec.Run.Status = simulationv1.RunStatus_RUN_STATUS_FAILED
+			rec.Run.Error = RunInterruptedByRestartError
+			rec.Run.EndedAtUnixMs = nowUnixMs()
+			s.finalizeRunStorageLocked(rec)
+			s.persistLocked(rec)
+			interrupted++
+		}
+	}
+	logger.Info("run store loaded", "runs", len(s.runs), "interrupted", interrupted)
+	return nil
+}
+
+// persistLocked queues rec to be written to the backend by flushLoop.
+func (s *RunStore) persistLocked(rec *RunRecord) {
+	if s.backend == nil || rec == nil || rec.Run == nil {
+		return
+	}
+	s.markDirtyLocked(rec.Run.Id)
+}
+
+// unpersistLocked queues the removal of runID from the backend; the flush deletes records that are
+// no longer in the store.
+func (s *RunStore) unpersistLocked(runID string) {
+	if s.backend == nil {
+		return
+	}
+	s.markDirtyLocked(runID)
+}
+
+func (s *RunStore) markDirtyLocked(runID string) {
+	s.dirty[runID] = struct{}{}
+	select {
+	case s.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+// flushLoop writes changed records to the backend until Stop, then flushes what is left. Records are
+// encoded and written outside the store lock, so API calls never wait on disk I/O.
+func (s *RunStore) flushLoop() {
+	defer close(s.flushDone)
+	for {
+		select {
+		case <-s.stopCh:
+			s.flush()
+			return
+		case <-s.flushCh:
+			s.flush()
+		}
+		select {
+		case <-s.stopCh:
+			s.flush()
+			return
+		case <-time.After(runStoreFlushInterval):
+		}
+	}
+}
+
+// flush writes the latest state of every dirty record. Storage errors are logged, not returned: the
+// in-memory store stays authoritative for the running daemon.
+func (s *RunStore) flush() {
+	s.mu.Lock()
+	pending := make(map[string]*RunRecord, len(s.dirty))
+	for id := range s.dirty {
+		pending[id] = cloneRunRecord(s.runs[id])
+	}
+	s.dirty = make(map[string]struct{})
+	s.mu.Unlock()
+
+	for id, rec := range pending {
+		if rec == nil {
+			if err := s.backend.Delete(id); err != nil {
+				logger.Warn("run store: delete failed", "run_id", id, "error", err)
+			}
+			continue
+		}
+		doc, err := encodeRunRecord(rec)
+		if err == nil {
+			err = s.backend.Put(id, doc)
+		}
+		if err != nil {
+			logger.Warn("run store: persist failed", "run_id", id, "error", err)
+		}
+	}
+}
+
+func encodeRunRecord(rec *RunRecord) ([]byte, error) {
+	out := persistedRunRecord{
+		IsOptimizationChild: rec.IsOptimizationChild,
+		ScalingDecisions:    rec.ScalingDecisions,
+		FaultEvents:         rec.FaultEvents,
+		Replications:        rec.Replications,
+	}
+	var err error
+	if out.Run, err = marshalProto(rec.Run); err != nil {
+		return nil, err
+	}
+	if out.Input, err = marshalProto(rec.Input); err != nil {
+		return nil, err
+	}
+	if out.Metrics, err = marshalProto(rec.Metrics); err != nil {
+		return nil, err
+	}
+	if out.FinalConfig, err = marshalProto(rec.FinalConfig); err != nil {
+		return nil, err
+	}
+	for _, step := range rec.OptimizationHistory {
+		raw, err := marshalProto(step)
+		if err != nil {
+			return nil, err
+		}
+		if raw != nil {
+			out.OptimizationHistory = append(out.OptimizationHistory, raw)
+		}
+	}
+	if rec.Collector != nil && isTerminalStatus(rec.Run.Status) {
+		out.Collector = rec.Collector.ExportState()
+	}
+	return json.Marshal(out)
+}
+
+func decodeRunRecord(doc []byte) (*RunRecord, error) {
+	var in persistedRunRecord
+	if err := json.Unmarshal(doc, &in); err != nil {
+		return nil, err
+	}
+	rec := &RunRecord{
+		Run:                 &simulationv1.Run{},
+		IsOptimizationChild: in.IsOptimizationChild,
+		ScalingDecisions:    in.ScalingDecisions,
+		FaultEvents:         in.FaultEvents,
+		Replications:        in.Replications,
+	}
+	if err := protojson.Unmarshal(in.Run, rec.Run); err != nil {
+		return nil, fmt.Errorf("decode run: %w", err)
+	}
+	if rec.Run.Id == "" {
+		return nil, fmt.Errorf("decode run: missing id")
+	}
+	if len(in.Input) > 0 {
+		rec.Input = &simulationv1.RunInput{}
+		if err := protojson.Unmarshal(in.Input, rec.Input); err != nil {
+			return nil, fmt.Errorf("decode input: %w", err)
+		}
+	}
+	if len(in.Metrics) > 0 {
+		rec.Metrics = &simulationv1.RunMetrics{}
+		if err := protojson.Unmarshal(in.Metrics, rec.Metrics); err != nil {
+			return nil, fmt.Errorf("decode metrics: %w", err)
+		}
+	}
+	if len(in.FinalConfig) > 0 {
+		rec.FinalConfig = &simulationv1.RunConfiguration{}
+		if err := protojson.Unmarshal(in.FinalConfig, rec.FinalConfig); err != nil {
+			return nil, fmt.Errorf("decode final config: %w", err)
+		}
+	}
+	for _, raw := range in.OptimizationHistory {
+		step := &simulationv1.OptimizationStep{}
+		if err := protojson.Unmarshal(raw, step); err != nil {
+			return nil, fmt.Errorf("decode optimization step: %w", err)
+		}
+		rec.OptimizationHistory = append(rec.OptimizationHistory, step)
+	}
+	if in.Collector != nil {
+		rec.Collector = metrics.RestoreCollector(in.Collector)
+	}
+	return rec, nil
+}
+
+// marshalProto encodes m with protojson, returning nil for a nil message.
+func marshalProto(m proto.Message) (json.RawMessage, error) {
+	if m == nil || !m.ProtoReflect().IsValid() {
+		return nil, nil
+	}
+	return protojson.Marshal(m)
+}
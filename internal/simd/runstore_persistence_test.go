@@ -0,0 +1,200 @@
+package simd
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	simulationv1 "github.com/GoSim-25-26J-441/simulation-core/gen/go/simulation/v1"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/storage"
+)
+
+func openPersistentRunStore(t *testing.T, dir string) (*RunStore, *storage.FileStore) {
+	t.Helper()
+	backend, err := storage.OpenFileStore(dir, storage.FileStoreOptions{})
+	if err != nil {
+		t.Fatalf("OpenFileStore: %v", err)
+	}
+	store, err := NewRunStoreWithBackend(backend)
+	if err != nil {
+		t.Fatalf("NewRunStoreWithBackend: %v", err)
+	}
+	return store, backend
+}
+
+// restartRunStore simulates a daemon restart: stop the store, close the backend and reopen both.
+func restartRunStore(t *testing.T, dir string, store *RunStore, backend *storage.FileStore) (*RunStore, *storage.FileStore) {
+	t.Helper()
+	store.Stop()
+	if err := backend.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return openPersistentRunStore(t, dir)
+}
+
+func TestRunStoreReloadsTerminalRunsAfterRestart(t *testing.T) {
+	t.Setenv("SIMD_RUNSTORE_KEEP_COLLECTOR_AFTER_COMPLETION", "true")
+	dir := t.TempDir()
+	store, backend := openPersistentRunStore(t, dir)
+
+	if _, err := store.Create("run-done", &simulationv1.RunInput{ScenarioYaml: "hosts: []"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	c := metrics.NewCollector()
+	c.Start()
+	base := time.Unix(1_700_000_000, 0)
+	c.Record(metrics.MetricRequestCount, 1, base, metrics.CreateServiceLabels("api"))
+	c.Record(metrics.MetricRequestCount, 1, base.Add(time.Second), metrics.CreateServiceLabels("api"))
+	if err := store.SetCollector("run-done", c); err != nil {
+		t.Fatalf("SetCollector: %v", err)
+	}
+	if _, err := store.SetStatus("run-done", simulationv1.RunStatus_RUN_STATUS_RUNNING, ""); err != nil {
+		t.Fatalf("SetStatus running: %v", err)
+	}
+	if err := store.AppendScalingDecision("run-done", ScalingDecision{SimTime: base, ServiceID: "api", Action: ScalingActionScaleUp, FromReplicas: 1, ToReplicas: 2}); err != nil {
+		t.Fatalf("AppendScalingDecision: %v", err)
+	}
+	if err := store.AppendFaultEvent("run-done", FaultEvent{SimTime: base, FaultID: "kill", Type: "kill_instances", Target: "api", Phase: FaultPhaseStart, Instances: []string{"api-1"}}); err != nil {
+		t.Fatalf("AppendFaultEvent: %v", err)
+	}
+	if err := store.AppendOptimizationStep("run-done", &simulationv1.OptimizationStep{IterationIndex: 1, Reason: "scale"}); err != nil {
+		t.Fatalf("AppendOptimizationStep: %v", err)
+	}
+	if err := store.SetMetrics("run-done", &simulationv1.RunMetrics{TotalRequests: 2}); err != nil {
+		t.Fatalf("SetMetrics: %v", err)
+	}
+	if err := store.SetFinalConfiguration("run-done", &simulationv1.RunConfiguration{Services: []*simulationv1.ServiceConfigEntry{{ServiceId: "api", Replicas: 2}}}); err != nil {
+		t.Fatalf("SetFinalConfiguration: %v", err)
+	}
+	if _, err := store.SetStatus("run-done", simulationv1.RunStatus_RUN_STATUS_COMPLETED, ""); err != nil {
+		t.Fatalf("SetStatus completed: %v", err)
+	}
+
+	store, backend = restartRunStore(t, dir, store, backend)
+	defer backend.Close()
+	defer store.Stop()
+
+	rec, ok := store.Get("run-done")
+	if !ok {
+		t.Fatal("expected completed run to survive restart")
+	}
+	if rec.Run.Status != simulationv1.RunStatus_RUN_STATUS_COMPLETED || rec.Run.EndedAtUnixMs == 0 || rec.Input.GetScenarioYaml() != "hosts: []" {
+		t.Fatalf("unexpected reloaded run: %+v", rec.Run)
+	}
+	if rec.Metrics.GetTotalRequests() != 2 || len(rec.OptimizationHistory) != 1 || len(rec.FinalConfig.GetServices()) != 1 {
+		t.Fatalf("expected metrics, optimization history and final config reloaded, got %+v", rec)
+	}
+	if len(rec.ScalingDecisions) != 1 || !rec.ScalingDecisions[0].SimTime.Equal(base) || rec.ScalingDecisions[0].ToReplicas != 2 {
+		t.Fatalf("unexpected scaling decisions: %+v", rec.ScalingDecisions)
+	}
+	if len(rec.FaultEvents) != 1 || !rec.FaultEvents[0].SimTime.Equal(base) || len(rec.FaultEvents[0].Instances) != 1 {
+		t.Fatalf("unexpected fault events: %+v", rec.FaultEvents)
+	}
+	collector, ok := store.GetCollector("run-done")
+	if !ok {
+		t.Fatal("expected retained collector to survive restart")
+	}
+	if got := collector.SumMetric(metrics.MetricRequestCount); got != 2 {
+		t.Fatalf("expected restored request_count sum 2, got %v", got)
+	}
+}
+
+func TestRunStoreMarksRunningRunsFailedAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	store, backend := openPersistentRunStore(t, dir)
+	for _, id := range []string{"run-live", "run-pending", "opt-candidate"} {
+		if _, err := store.Create(id, &simulationv1.RunInput{ScenarioYaml: "x"}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	if _, err := store.SetStatus("run-live", simulationv1.RunStatus_RUN_STATUS_RUNNING, ""); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	store, backend = restartRunStore(t, dir, store, backend)
+	rec, ok := store.Get("run-live")
+	if !ok || rec.Run.Status != simulationv1.RunStatus_RUN_STATUS_FAILED || rec.Run.Error != RunInterruptedByRestartError || rec.Run.EndedAtUnixMs == 0 {
+		t.Fatalf("expected interrupted run marked FAILED, got %+v", rec)
+	}
+	if rec, ok := store.Get("run-pending"); !ok || rec.Run.Status != simulationv1.RunStatus_RUN_STATUS_PENDING {
+		t.Fatalf("expected pending run kept pending, got %+v", rec)
+	}
+	if _, err := store.SetStatusRunningWithOnlineConcurrencyGuard("run-pending"); err != nil {
+		t.Fatalf("expected reloaded pending run to be startable, got %v", err)
+	}
+	if rec, ok := store.Get("opt-candidate"); !ok || rec.Run.Status != simulationv1.RunStatus_RUN_STATUS_FAILED || rec.Run.Error != RunInterruptedByRestartError {
+		t.Fatalf("expected orphaned pending optimization candidate marked FAILED, got %+v", rec)
+	}
+
+	// The FAILED transition is itself persisted.
+	store, backend = restartRunStore(t, dir, store, backend)
+	defer backend.Close()
+	defer store.Stop()
+	if rec, ok := store.Get("run-live"); !ok || rec.Run.Status != simulationv1.RunStatus_RUN_STATUS_FAILED {
+		t.Fatalf("expected FAILED status persisted, got %+v", rec)
+	}
+}
+
+func TestRunStoreCleanupDeletesPersistedRuns(t *testing.T) {
+	t.Setenv("SIMD_RUNSTORE_TTL", "1ns")
+	dir := t.TempDir()
+	store, backend := openPersistentRunStore(t, dir)
+	if _, err := store.Create("run-old", &simulationv1.RunInput{ScenarioYaml: "x"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if _, err := store.SetStatus("run-old", simulationv1.RunStatus_RUN_STATUS_COMPLETED, ""); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+	store.CleanupNow()
+
+	store, backend = restartRunStore(t, dir, store, backend)
+	defer backend.Close()
+	defer store.Stop()
+	if _, ok := store.Get("run-old"); ok {
+		t.Fatal("expected evicted run to stay deleted after restart")
+	}
+}
+
+// countingStore counts the writes that reach a backend.
+type countingStore struct {
+	storage.Store
+	puts atomic.Int64
+}
+
+func (c *countingStore) Put(id string, doc []byte) error {
+	c.puts.Add(1)
+	return c.Store.Put(id, doc)
+}
+
+func TestRunStoreCoalescesWritesOfAppendedRecords(t *testing.T) {
+	dir := t.TempDir()
+	fs, err := storage.OpenFileStore(dir, storage.FileStoreOptions{})
+	if err != nil {
+		t.Fatalf("OpenFileStore: %v", err)
+	}
+	backend := &countingStore{Store: fs}
+	store, err := NewRunStoreWithBackend(backend)
+	if err != nil {
+		t.Fatalf("NewRunStoreWithBackend: %v", err)
+	}
+	if _, err := store.Create("run-1", &simulationv1.RunInput{ScenarioYaml: "x"}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	const decisions = 500
+	for i := 0; i < decisions; i++ {
+		if err := store.AppendScalingDecision("run-1", ScalingDecision{ServiceID: "api", ToReplicas: i}); err != nil {
+			t.Fatalf("AppendScalingDecision: %v", err)
+		}
+	}
+	store, fs = restartRunStore(t, dir, store, fs)
+	defer fs.Close()
+	defer store.Stop()
+	if got := backend.puts.Load(); got >= decisions/10 {
+		t.Fatalf("expected appends to be coalesced into a few writes, got %d", got)
+	}
+	if rec, ok := store.Get("run-1"); !ok || len(rec.ScalingDecisions) != decisions {
+		t.Fatalf("expected every scaling decision persisted, got %+v", rec)
+	}
+}
@@ -21,6 +21,7 @@ type ScenarioValidationSummary struct {
 	Hosts     int `json:"hosts"`
 	Services  int `json:"services"`
 	Workloads int `json:"workloads"`
+	Flows     int `json:"flows,omitempty"`
 }
 
 // ScenarioValidationResult contains parse + semantic + placement outcomes.
@@ -41,6 +42,7 @@ func summaryPtr(s *config.Scenario) *ScenarioValidationSummary {
 		Hosts:     len(s.Hosts),
 		Services:  len(s.Services),
 		Workloads: len(s.Workload),
+		Flows:     len(s.Flows),
 	}
 }
 
@@ -1,6 +1,7 @@
 package simd
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -37,6 +38,11 @@ func RunMetricsOptionsFromResourceManager(rm *resource.Manager) *metrics.RunMetr
 // RunScenarioForMetrics executes a discrete-event simulation for simDuration of simulation time and returns aggregated RunMetrics.
 // realTime should be false for deterministic calibration/validation (pre-generated arrivals). seed controls RNG/workload/stochastic paths.
 func RunScenarioForMetrics(scenario *config.Scenario, simDuration time.Duration, seed int64, realTime bool) (*models.RunMetrics, error) {
+	return runScenarioForMetrics(context.Background(), scenario, simDuration, seed, realTime, nil)
+}
+
+// runScenarioForMetrics is RunScenarioForMetrics with cancellation and optional engine guardrails.
+func runScenarioForMetrics(ctx context.Context, scenario *config.Scenario, simDuration time.Duration, seed int64, realTime bool, limits *engine.RuntimeLimits) (*models.RunMetrics, error) {
 	if scenario == nil {
 		return nil, fmt.Errorf("scenario is nil")
 	}
@@ -45,17 +51,25 @@ func RunScenarioForMetrics(scenario *config.Scenario, simDuration time.Duration,
 	}
 	runID := "scenario-for-metrics"
 	eng := engine.NewEngine(runID)
+	if limits != nil {
+		eng.SetRuntimeLimits(*limits)
+	}
 	rm := resource.NewManager()
 	if err := rm.InitializeFromScenario(scenario); err != nil {
 		return nil, err
 	}
+	if seed != 0 {
+		rm.SetRoutingSeed(seed + 3)
+	}
 	collector := metrics.NewCollector()
 	collector.Start()
 	policies := policy.NewPolicyManager(nil)
 	if scenario.Policies != nil {
 		policies = policy.NewPolicyManager(&config.Policies{
-			Autoscaling: scenario.Policies.Autoscaling,
-			Retries:     scenario.Policies.Retries,
+			Autoscaling:    scenario.Policies.Autoscaling,
+			Retries:        scenario.Policies.Retries,
+			CircuitBreaker: scenario.Policies.CircuitBreaker,
+			RateLimiting:   scenario.Policies.RateLimiting,
 		})
 	}
 	state, err := newScenarioState(scenario, rm, collector, policies, seed)
@@ -68,15 +82,23 @@ func RunScenarioForMetrics(scenario *config.Scenario, simDuration time.Duration,
 	endTime := startTime.Add(simDuration)
 	state.SetSimEndTime(endTime)
 	ScheduleDrainSweepKickoff(eng, startTime)
+	ScheduleAutoscaleSyncKickoff(eng, state, startTime)
+	ScheduleHostFailureKickoff(eng, state, startTime)
+	ScheduleFaultKickoff(eng, state, startTime)
 	ws := NewWorkloadState(runID, eng, endTime, seed)
 	if err := ws.Start(scenario, startTime, realTime); err != nil {
 		collector.Stop()
 		ws.Stop()
 		return nil, err
 	}
+	stop := context.AfterFunc(ctx, eng.Stop)
 	runErr := eng.Run(simDuration)
+	stop()
 	ws.Stop()
 	collector.Stop()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if runErr != nil {
 		return nil, runErr
 	}
@@ -0,0 +1,278 @@
+package simd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+const (
+	// metaSchedDispatched marks a request picked from its instance queue, so its start reserves CPU
+	// instead of queueing it again.
+	metaSchedDispatched = "sched_dispatched"
+	// metaCPUShareTimedOut marks a processor-sharing request whose local timeout fired before its CPU work ended.
+	metaCPUShareTimedOut = "cpu_share_timed_out"
+)
+
+// schedulingState tracks the dispatch of instances whose service queues requests (priority,
+// adaptive_lifo and codel scheduling, or queue limits).
+type schedulingState struct {
+	// dispatch is the pending dispatch event of each instance; events with another token are stale.
+	dispatch map[string]pendingDispatch
+	// claimed holds instances whose dispatched request has not started yet, so that requests
+	// arriving at the same instant queue behind it.
+	claimed   map[string]bool
+	nextToken int64
+	// services is the scheduling of each service (see config.Scenario.ServiceScheduling).
+	services map[string]*config.Scheduling
+}
+
+type pendingDispatch struct {
+	at    time.Time
+	token int64
+}
+
+func newSchedulingState(scenario *config.Scenario) *schedulingState {
+	st := &schedulingState{
+		dispatch: make(map[string]pendingDispatch),
+		claimed:  make(map[string]bool),
+		services: make(map[string]*config.Scheduling),
+	}
+	for i := range scenario.Services {
+		svc := &scenario.Services[i]
+		st.services[svc.ID] = scenario.ServiceScheduling(svc)
+	}
+	return st
+}
+
+func serviceScheduling(state *scenarioState, serviceID string) *config.Scheduling {
+	return state.scheduling.services[serviceID]
+}
+
+// queuesRequests reports whether requests finding an instance of the service busy wait in its
+// queue: the service has a queueing discipline, limits its queue or sheds load on queue length
+// (fcfs then serves the queue in order).
+func queuesRequests(state *scenarioState, serviceID string) bool {
+	svc, ok := state.services[serviceID]
+	return ok && (serviceScheduling(state, serviceID).QueuesRequests() || svc.HasQueueLimits() || svc.ShedsOnQueueLength())
+}
+
+// requestPriority reads the numeric priority of a request from its metadata key, or else returns
+// the priority of its priority class (0 without either).
+func requestPriority(state *scenarioState, m map[string]interface{}, key string) float64 {
+	switch v := m[key].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			return f
+		}
+	}
+	return float64(requestClassPriority(state, m))
+}
+
+// queueForScheduling puts request in the queue of instanceID when its service queues requests and
+// the instance is busy, has requests waiting, or is about to start one. A full queue rejects the
+// request, or drops its oldest request, per the endpoint's queue limits. It reports whether the
+// request was queued or rejected; a queued request is dispatched once the instance's CPU is free.
+func queueForScheduling(state *scenarioState, eng *engine.Engine, request *models.Request, instanceID string, simTime time.Time) bool {
+	if !queuesRequests(state, request.ServiceName) {
+		return false
+	}
+	busyUntil, ok := state.rm.CPUBusyUntil(instanceID)
+	if !ok {
+		return false
+	}
+	if !busyUntil.After(simTime) && state.rm.GetQueueLength(instanceID) == 0 && !state.scheduling.claimed[instanceID] {
+		return false
+	}
+	svc := state.services[request.ServiceName]
+	sc := serviceScheduling(state, request.ServiceName)
+	limits := svc.EffectiveQueueLimits(state.endpoints[request.ServiceName+":"+request.Endpoint])
+	rm := eng.GetRunManager()
+	if limits.MaxQueueLength > 0 && state.rm.GetQueueLength(instanceID) >= limits.MaxQueueLength {
+		if limits.EffectiveOnQueueFull() != config.QueueFullDropOldest {
+			failQueuedRequests(state, eng, rm, []string{request.ID}, simTime, metrics.ReasonQueueFull)
+			return true
+		}
+		if oldest, ok := state.rm.DequeueRequest(instanceID); ok {
+			failQueuedRequests(state, eng, rm, []string{oldest}, simTime, metrics.ReasonQueueFull)
+		}
+	}
+	err := state.rm.EnqueueScheduledRequest(instanceID, resource.QueuedRequest{
+		ID:         request.ID,
+		EnqueuedAt: simTime,
+		Priority:   requestPriority(state, request.Metadata, sc.EffectivePriorityKey()),
+	})
+	if err != nil {
+		return false
+	}
+	if limits.MaxQueueWaitMs > 0 {
+		eng.ScheduleAt(engine.EventTypeInstanceQueueTimeout, simTime.Add(time.Duration(limits.MaxQueueWaitMs*float64(time.Millisecond))), request, request.ServiceName, map[string]interface{}{
+			"instance_id": instanceID,
+		})
+	}
+	if busyUntil.Before(simTime) {
+		busyUntil = simTime
+	}
+	scheduleInstanceDispatch(state, eng, instanceID, busyUntil)
+	recordInstanceAndHostGauges(state, request.ServiceName, instanceID, simTime)
+	return true
+}
+
+// scheduleInstanceDispatch makes sure the instance dispatches its queue no later than at.
+func scheduleInstanceDispatch(state *scenarioState, eng *engine.Engine, instanceID string, at time.Time) {
+	if p, ok := state.scheduling.dispatch[instanceID]; ok && !p.at.After(at) {
+		return
+	}
+	postInstanceDispatch(state, eng, instanceID, at)
+}
+
+// postInstanceDispatch schedules a dispatch of the instance at at, making any pending one stale.
+func postInstanceDispatch(state *scenarioState, eng *engine.Engine, instanceID string, at time.Time) {
+	state.scheduling.nextToken++
+	token := state.scheduling.nextToken
+	state.scheduling.dispatch[instanceID] = pendingDispatch{at: at, token: token}
+	eng.ScheduleAt(engine.EventTypeInstanceDispatch, at, nil, "", map[string]interface{}{
+		"instance_id": instanceID,
+		"token":       token,
+	})
+}
+
+// handleInstanceDispatch starts the next queued request of an instance whose CPU is free.
+func handleInstanceDispatch(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		instanceID, _ := evt.Data["instance_id"].(string)
+		p, ok := state.scheduling.dispatch[instanceID]
+		if !ok || p.token != metadataInt64(evt.Data, "token") {
+			return nil
+		}
+		delete(state.scheduling.dispatch, instanceID)
+		delete(state.scheduling.claimed, instanceID)
+		inst, ok := state.rm.GetServiceInstance(instanceID)
+		if !ok {
+			return nil
+		}
+		return dequeueNextRequestForInstance(state, eng, eng.GetRunManager(), instanceID, inst.ServiceName(), "", eng.GetSimTime())
+	}
+}
+
+// handleInstanceQueueTimeout fails a request that waited max_queue_wait_ms in its instance queue.
+func handleInstanceQueueTimeout(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		instanceID, _ := evt.Data["instance_id"].(string)
+		if evt.Request == nil || !state.rm.RemoveQueuedRequest(instanceID, evt.Request.ID) {
+			return nil
+		}
+		failQueuedRequests(state, eng, eng.GetRunManager(), []string{evt.Request.ID}, eng.GetSimTime(), metrics.ReasonQueueTimeout)
+		recordInstanceAndHostGauges(state, evt.Request.ServiceName, instanceID, eng.GetSimTime())
+		return nil
+	}
+}
+
+// failQueuedRequests fails requests rejected or dropped by an instance queue, or shed by load
+// shedding, with reason. Like other start failures, they are retried under the caller's retry policy
+// and, except for shed requests, count against the callee's circuit breaker.
+func failQueuedRequests(state *scenarioState, eng *engine.Engine, rm *engine.RunManager, ids []string, simTime time.Time, reason string) {
+	for _, id := range ids {
+		request, ok := rm.GetRequest(id)
+		if !ok || request.Status != models.RequestStatusPending {
+			continue
+		}
+		request.Status = models.RequestStatusFailed
+		lbl := labelsForRequestMetricsWithRetry(request, request.ServiceName, request.Endpoint)
+		if maybeRetrySyncStartFailure(state, eng, rm, request, simTime, reason) {
+			metrics.RecordErrorCount(state.collector, 1.0, simTime, metrics.EndpointErrorLabels(lbl, reason))
+			if state.policies != nil && countsAgainstCircuitBreaker(reason) {
+				if cb := state.policies.GetCircuitBreaker(); cb != nil {
+					cb.RecordFailure(request.ServiceName, request.Endpoint, simTime)
+				}
+			}
+			continue
+		}
+		finalizeRequestFailure(state, eng, rm, request, simTime, lbl, reason)
+	}
+}
+
+// startSharedCPUWork admits the CPU work of request on a processor-sharing instance. Its completion
+// is scheduled when the work ends (handleCPUShareDone), or at its local timeout if that comes first.
+func startSharedCPUWork(state *scenarioState, eng *engine.Engine, request *models.Request, instanceID string, cpuTimeMs, timeoutMs float64, simTime time.Time) error {
+	nextDone, version, err := state.rm.StartSharedCPUWork(instanceID, request.ID, simTime, cpuTimeMs)
+	if err != nil {
+		return fmt.Errorf("failed to share CPU: %w", err)
+	}
+	scheduleCPUShareDone(eng, instanceID, nextDone, version)
+	if timeoutMs > 0 {
+		eng.ScheduleAt(engine.EventTypeCPUShareTimeout, simTime.Add(time.Duration(timeoutMs)*time.Millisecond), request, request.ServiceName, map[string]interface{}{
+			"endpoint_path": request.Endpoint,
+			"instance_id":   instanceID,
+		})
+	}
+	return nil
+}
+
+func scheduleCPUShareDone(eng *engine.Engine, instanceID string, at time.Time, version int) {
+	if at.IsZero() {
+		return
+	}
+	eng.ScheduleAt(engine.EventTypeCPUShareDone, at, nil, "", map[string]interface{}{
+		"instance_id": instanceID,
+		"version":     version,
+	})
+}
+
+// handleCPUShareDone continues the requests whose shared CPU work ended with their IO and network
+// phases, and schedules the next end of CPU work on the instance.
+func handleCPUShareDone(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		instanceID, _ := evt.Data["instance_id"].(string)
+		simTime := eng.GetSimTime()
+		done, nextDone, version, ok := state.rm.FinishSharedCPUWork(instanceID, simTime, metadataInt(evt.Data, "version"))
+		if !ok {
+			return nil
+		}
+		scheduleCPUShareDone(eng, instanceID, nextDone, version)
+		rm := eng.GetRunManager()
+		for _, id := range done {
+			request, found := rm.GetRequest(id)
+			if !found || metadataBool(request.Metadata, metaInstanceKilled) || metadataBool(request.Metadata, metaCPUShareTimedOut) || metadataBool(request.Metadata, metaDESFinalized) {
+				continue
+			}
+			if err := scheduleRequestCompletion(state, eng, request, instanceID, request.StartTime, simTime, simTime); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// handleCPUShareTimeout completes a processor-sharing request with a local timeout when its CPU work
+// is still running at its deadline. The work keeps its share until it ends, like a timed-out fcfs reservation.
+func handleCPUShareTimeout(state *scenarioState) engine.EventHandler {
+	return func(eng *engine.Engine, evt *engine.Event) error {
+		request := evt.Request
+		instanceID, _ := evt.Data["instance_id"].(string)
+		if request == nil || !state.rm.SharingCPU(instanceID, request.ID) ||
+			metadataBool(request.Metadata, metaInstanceKilled) || metadataBool(request.Metadata, metaDESFinalized) {
+			return nil
+		}
+		request.Metadata["local_timeout"] = true
+		request.Metadata[metaCPUShareTimedOut] = true
+		eng.ScheduleAt(engine.EventTypeRequestComplete, eng.GetSimTime(), request, request.ServiceName, map[string]interface{}{
+			"endpoint_path": request.Endpoint,
+			"instance_id":   instanceID,
+		})
+		return nil
+	}
+}
@@ -0,0 +1,158 @@
+package simd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/policy"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+type schedulingArrival struct {
+	name     string
+	atMs     int
+	priority interface{}
+}
+
+// runSchedulingScenario sends arrivals to one single-core replica of service api doing 100ms of
+// CPU work per request, after configure adjusts the scenario, and returns the requests by name.
+func runSchedulingScenario(t *testing.T, configure func(*config.Scenario), arrivals []schedulingArrival) (map[string]*models.Request, *metrics.Collector) {
+	t.Helper()
+	scenario := &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 4, MemoryGB: 16}},
+		Services: []config.Service{{
+			ID: "api", Replicas: 1, CPUCores: 1, MemoryMB: 512,
+			Endpoints: []config.Endpoint{{Path: "/work", MeanCPUMs: 100}},
+		}},
+	}
+	configure(scenario)
+	eng := engine.NewEngineWithSimStart("scheduling", time.Unix(1000, 0))
+	rm := resource.NewManager()
+	if err := rm.InitializeFromScenario(scenario); err != nil {
+		t.Fatalf("init rm: %v", err)
+	}
+	collector := metrics.NewCollector()
+	collector.Start()
+	state, err := newScenarioState(scenario, rm, collector, policy.NewPolicyManager(scenario.Policies), 5)
+	if err != nil {
+		t.Fatalf("scenario state: %v", err)
+	}
+	RegisterHandlers(eng, state)
+	for _, a := range arrivals {
+		md := map[string]interface{}{"name": a.name}
+		if a.priority != nil {
+			md["priority"] = a.priority
+		}
+		eng.ScheduleAt(engine.EventTypeRequestArrival, eng.GetSimTime().Add(time.Duration(a.atMs)*time.Millisecond), nil, "api", map[string]interface{}{
+			"service_id":    "api",
+			"endpoint_path": "/work",
+			"metadata":      md,
+		})
+	}
+	if err := eng.Run(5 * time.Second); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	byName := make(map[string]*models.Request)
+	for _, r := range eng.GetRunManager().ListRequests() {
+		if name, ok := r.Metadata["name"].(string); ok {
+			byName[name] = r
+		}
+	}
+	if len(byName) != len(arrivals) {
+		t.Fatalf("expected %d requests, got %d", len(arrivals), len(byName))
+	}
+	return byName, collector
+}
+
+func completedAtMs(r *models.Request) int {
+	return int(r.CompletionTime.Sub(time.Unix(1000, 0)) / time.Millisecond)
+}
+
+// withScheduling sets the scheduling of api and the timeout of its endpoint.
+func withScheduling(sc *config.Scheduling, timeoutMs float64) func(*config.Scenario) {
+	return func(s *config.Scenario) {
+		s.Services[0].Scheduling = sc
+		s.Services[0].Endpoints[0].TimeoutMs = timeoutMs
+	}
+}
+
+func TestPrioritySchedulingServesHighestPriorityFirst(t *testing.T) {
+	reqs, _ := runSchedulingScenario(t, withScheduling(&config.Scheduling{Discipline: config.SchedulingPriority}, 0), []schedulingArrival{
+		{"first", 0, nil},
+		{"low", 10, "1"},
+		{"high", 20, 9},
+	})
+	if got := completedAtMs(reqs["high"]); got != 200 {
+		t.Fatalf("high priority request should run second and finish at 200ms, got %dms", got)
+	}
+	if got := completedAtMs(reqs["low"]); got != 300 {
+		t.Fatalf("low priority request should finish last at 300ms, got %dms", got)
+	}
+	if q := reqs["high"].QueueTimeMs; q != 80 {
+		t.Fatalf("expected 80ms queue wait for the high priority request, got %v", q)
+	}
+}
+
+func TestAdaptiveLIFOServesNewestWhenOverloaded(t *testing.T) {
+	reqs, _ := runSchedulingScenario(t, withScheduling(&config.Scheduling{Discipline: config.SchedulingAdaptiveLIFO, IntervalMs: 50}, 0), []schedulingArrival{
+		{"first", 0, nil}, {"a", 10, nil}, {"b", 20, nil}, {"c", 30, nil},
+	})
+	// The queue has been non-empty for 90ms when the first request ends: the newest goes next.
+	for name, want := range map[string]int{"first": 100, "c": 200, "b": 300, "a": 400} {
+		if got := completedAtMs(reqs[name]); got != want {
+			t.Fatalf("request %s: expected completion at %dms, got %dms", name, want, got)
+		}
+	}
+}
+
+func TestCoDelDropsRequestsPastTargetWhenOverloaded(t *testing.T) {
+	reqs, collector := runSchedulingScenario(t, withScheduling(&config.Scheduling{Discipline: config.SchedulingCoDel, TargetDelayMs: 5, IntervalMs: 50}, 0), []schedulingArrival{
+		{"first", 0, nil}, {"a", 10, nil}, {"b", 20, nil}, {"c", 98, nil},
+	})
+	for _, name := range []string{"a", "b"} {
+		if r := reqs[name]; r.Status != models.RequestStatusFailed || r.Error != metrics.ReasonCoDelDropped || completedAtMs(r) != 100 {
+			t.Fatalf("request %s should be dropped at 100ms, got status %s error %q at %dms", name, r.Status, r.Error, completedAtMs(r))
+		}
+	}
+	if r := reqs["c"]; r.Status != models.RequestStatusCompleted || completedAtMs(r) != 200 {
+		t.Fatalf("request c waited 2ms and should be served, got status %s at %dms", r.Status, completedAtMs(r))
+	}
+	if got := collector.SumMetricWhere(metrics.MetricRequestErrorCount, metrics.LabelReason, metrics.ReasonCoDelDropped); got != 2 {
+		t.Fatalf("expected 2 codel_dropped errors, got %v", got)
+	}
+
+	// Without overload (a queue younger than the interval) codel serves in arrival order.
+	reqs, _ = runSchedulingScenario(t, withScheduling(&config.Scheduling{Discipline: config.SchedulingCoDel, TargetDelayMs: 5, IntervalMs: 500}, 0), []schedulingArrival{
+		{"first", 0, nil}, {"a", 10, nil},
+	})
+	if r := reqs["a"]; r.Status != models.RequestStatusCompleted || completedAtMs(r) != 200 {
+		t.Fatalf("expected request a to be served at 200ms, got status %s at %dms", r.Status, completedAtMs(r))
+	}
+}
+
+func TestProcessorSharingSharesTheCores(t *testing.T) {
+	ps := &config.Scheduling{Discipline: config.SchedulingProcessorSharing}
+	// a runs alone for 50ms, then both run at half a core: a ends at 150ms, b at 200ms.
+	reqs, _ := runSchedulingScenario(t, withScheduling(ps, 0), []schedulingArrival{{"a", 0, nil}, {"b", 50, nil}})
+	if got := completedAtMs(reqs["a"]); got != 150 {
+		t.Fatalf("expected a to finish at 150ms, got %dms", got)
+	}
+	if got := completedAtMs(reqs["b"]); got != 200 {
+		t.Fatalf("expected b to finish at 200ms, got %dms", got)
+	}
+	if q := reqs["b"].QueueTimeMs; q != 0 {
+		t.Fatalf("processor sharing should not queue, got %vms wait", q)
+	}
+
+	// Sharing the core, neither request is done within its 120ms timeout.
+	reqs, _ = runSchedulingScenario(t, withScheduling(ps, 120), []schedulingArrival{{"a", 0, nil}, {"b", 0, nil}})
+	for _, name := range []string{"a", "b"} {
+		if r := reqs[name]; r.Status != models.RequestStatusFailed || completedAtMs(r) != 120 {
+			t.Fatalf("request %s should time out at 120ms, got status %s at %dms", name, r.Status, completedAtMs(r))
+		}
+	}
+}
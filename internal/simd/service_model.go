@@ -29,14 +29,11 @@ func resolveServiceExecutionProfile(svc *config.Service, ep *config.Endpoint, ds
 		return ServiceExecutionProfile{QueueClass: "default", QueueMeanWorkMs: 0}
 	}
 
-	cpu := rng.NormFloat64(ep.MeanCPUMs, ep.CPUSigmaMs)
-	if cpu < 0 {
-		cpu = 0
-	}
-	net := rng.NormFloat64(ep.NetLatencyMs.Mean, ep.NetLatencyMs.Sigma)
-	if net < 0 {
-		net = 0
-	}
+	cpuSpec := ep.CPUSpec()
+	cpu := cpuSpec.Sample(rng)
+	net := ep.NetLatencyMs.Sample(rng)
+	cpuMean := cpuSpec.MeanMs()
+	netMean := ep.NetLatencyMs.MeanMs()
 
 	mem := ep.DefaultMemoryMB
 	if mem <= 0 {
@@ -74,13 +71,9 @@ func resolveServiceExecutionProfile(svc *config.Service, ep *config.Endpoint, ds
 	case "db_latency":
 		// Latency/IO dominated: keep CPU work below network/query latency unless
 		// the endpoint explicitly asks for more CPU than the net mean.
-		netMean := ep.NetLatencyMs.Mean
-		if netMean < 0 {
-			netMean = 0
-		}
-		ceiling := math.Max(netMean*0.55, ep.MeanCPUMs*0.85)
-		if ep.MeanCPUMs > netMean*1.1 {
-			ceiling = ep.MeanCPUMs * 1.05
+		ceiling := math.Max(netMean*0.55, cpuMean*0.85)
+		if cpuMean > netMean*1.1 {
+			ceiling = cpuMean * 1.05
 		}
 		if cpu > ceiling {
 			cpu = ceiling
@@ -108,14 +101,11 @@ func resolveServiceExecutionProfile(svc *config.Service, ep *config.Endpoint, ds
 		net *= 1.1
 	}
 
-	queueMean := ep.MeanCPUMs + ep.NetLatencyMs.Mean
+	queueMean := cpuMean + netMean
 	if model == "db_latency" {
 		// Backlog delay should track query/IO more than CPU.
-		io := ep.NetLatencyMs.Mean
-		if io < 0 {
-			io = 0
-		}
-		cpuPart := math.Min(ep.MeanCPUMs, io*0.45)
+		io := netMean
+		cpuPart := math.Min(cpuMean, io*0.45)
 		queueMean = cpuPart + io
 	}
 	if queueMean < 0 {
@@ -39,3 +39,25 @@ func TestResolveServiceExecutionProfileCacheLowerCPU(t *testing.T) {
 		t.Fatalf("cache should not increase CPU, got %.2f", p.CPUTimeMs)
 	}
 }
+
+func TestResolveServiceExecutionProfileCPUDistribution(t *testing.T) {
+	svc := &config.Service{ID: "api", Model: "cpu"}
+	ep := &config.Endpoint{
+		Path:            "/a",
+		MeanCPUMs:       10,
+		CPUSigmaMs:      20,
+		CPUDistribution: config.DistributionLognormal,
+	}
+	rng := utils.NewRandSource(3)
+	samples := make([]float64, 20000)
+	for i := range samples {
+		samples[i] = resolveServiceExecutionProfile(svc, ep, nil, rng).CPUTimeMs
+	}
+	if m := utils.Mean(samples); m < 9 || m > 11 {
+		t.Fatalf("expected a mean CPU time of about 10ms, got %.2f", m)
+	}
+	// A normal with these moments truncated at zero has p99 around 57ms; the lognormal tail is longer.
+	if p99 := utils.Percentile(samples, 99); p99 < 65 {
+		t.Fatalf("expected a heavy lognormal tail, got p99 %.2f", p99)
+	}
+}
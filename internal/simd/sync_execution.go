@@ -0,0 +1,256 @@
+package simd
+
+import (
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/interaction"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/models"
+)
+
+// syncExecution is the progress of a request whose endpoint orders its sync downstream calls into
+// execution stages. It replaces the pendingSync counter for that request: the stages run one after
+// another and the request completes when the last stage does, or fails as soon as a group misses
+// its quorum.
+type syncExecution struct {
+	stages         [][]*syncExecutionGroup
+	stage          int
+	traceDepth     int
+	asyncDepth     int
+	callerTopology downstreamCallerTopology
+}
+
+// syncExecutionGroup is one group of a stage: the calls issued for it and their outcomes so far.
+type syncExecutionGroup struct {
+	calls     []interaction.ResolvedCall
+	need      int
+	succeeded int
+	failed    int
+	done      bool
+	// inFlight holds the child request IDs the group still waits for; they are ignored once it resolves.
+	inFlight map[string]bool
+}
+
+// newSyncExecution places the selected sync calls of ep into its execution groups. Calls the stages
+// do not list run in the first stage as one group that needs all of them.
+func newSyncExecution(ep *config.Endpoint, calls []interaction.ResolvedCall, traceDepth, asyncDepth int, callerTopology downstreamCallerTopology) *syncExecution {
+	x := &syncExecution{
+		stages:         make([][]*syncExecutionGroup, len(ep.Execution)),
+		traceDepth:     traceDepth,
+		asyncDepth:     asyncDepth,
+		callerTopology: callerTopology,
+	}
+	specs := make([][]config.ExecutionGroup, len(ep.Execution))
+	at := make(map[string][2]int)
+	for i := range ep.Execution {
+		specs[i] = ep.Execution[i].StageGroups()
+		x.stages[i] = make([]*syncExecutionGroup, len(specs[i]))
+		for j := range specs[i] {
+			x.stages[i][j] = &syncExecutionGroup{inFlight: make(map[string]bool)}
+			for _, to := range specs[i][j].Calls {
+				if svc, path, err := interaction.ParseDownstreamTarget(to); err == nil {
+					at[svc+":"+path] = [2]int{i, j}
+				}
+			}
+		}
+	}
+	var unlisted *syncExecutionGroup
+	for _, rc := range calls {
+		if ij, ok := at[rc.ServiceID+":"+rc.Path]; ok {
+			g := x.stages[ij[0]][ij[1]]
+			g.calls = append(g.calls, rc)
+			continue
+		}
+		if unlisted == nil {
+			unlisted = &syncExecutionGroup{inFlight: make(map[string]bool)}
+			x.stages[0] = append(x.stages[0], unlisted)
+		}
+		unlisted.calls = append(unlisted.calls, rc)
+	}
+	for i := range specs {
+		for j := range specs[i] {
+			g := x.stages[i][j]
+			g.need = specs[i][j].Required(len(g.calls))
+		}
+	}
+	if unlisted != nil {
+		unlisted.need = len(unlisted.calls)
+	}
+	return x
+}
+
+// startStage issues the calls of the first stage from x.stage on that has any, with caller overhead
+// from at, and returns the time the caller is done issuing them. ok is false when no stage is left.
+func (x *syncExecution) startStage(state *scenarioState, eng *engine.Engine, parent *models.Request, at time.Time) (time.Time, bool) {
+	for ; x.stage < len(x.stages); x.stage++ {
+		issued := false
+		for _, g := range x.stages[x.stage] {
+			for _, rc := range g.calls {
+				at = scheduleDownstreamWithCallerOverhead(state, eng, parent, rc, at, x.traceDepth+1, x.asyncDepth, false, false, 0, "", x.callerTopology)
+				issued = true
+			}
+		}
+		if issued {
+			return at, true
+		}
+	}
+	return at, false
+}
+
+// group returns the group of the current stage that issued calls to target ("serviceID:path").
+func (x *syncExecution) group(target string) *syncExecutionGroup {
+	if x.stage >= len(x.stages) {
+		return nil
+	}
+	for _, g := range x.stages[x.stage] {
+		for _, rc := range g.calls {
+			if rc.ServiceID+":"+rc.Path == target {
+				return g
+			}
+		}
+	}
+	return nil
+}
+
+// stageDone reports whether every group of the current stage has resolved.
+func (x *syncExecution) stageDone() bool {
+	for _, g := range x.stages[x.stage] {
+		if len(g.calls) > 0 && !g.done {
+			return false
+		}
+	}
+	return true
+}
+
+// inFlight returns the children the current stage still waits for.
+func (x *syncExecution) inFlight() []string {
+	var ids []string
+	for _, g := range x.stages[x.stage] {
+		for id := range g.inFlight {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// startSyncExecution issues the first stage of the sync calls of a request whose endpoint has
+// execution stages and returns the time the caller is done issuing them.
+func startSyncExecution(state *scenarioState, eng *engine.Engine, request *models.Request, ep *config.Endpoint, calls []interaction.ResolvedCall, traceDepth, asyncDepth int, callerTopology downstreamCallerTopology, at time.Time) time.Time {
+	x := newSyncExecution(ep, calls, traceDepth, asyncDepth, callerTopology)
+	state.pendingSyncMu.Lock()
+	state.syncExecutions[request.ID] = x
+	state.pendingSyncMu.Unlock()
+	at, _ = x.startStage(state, eng, request, at)
+	return at
+}
+
+// syncExecutionPending reports whether the request still waits on execution stages.
+func syncExecutionPending(state *scenarioState, requestID string) bool {
+	state.pendingSyncMu.Lock()
+	defer state.pendingSyncMu.Unlock()
+	_, ok := state.syncExecutions[requestID]
+	return ok
+}
+
+// noteSyncExecutionChild records a sync child spawned for a request with execution stages. A child
+// of a group that already resolved (a late retry) is ignored from the start.
+func noteSyncExecutionChild(state *scenarioState, parentID string, child *models.Request) {
+	state.pendingSyncMu.Lock()
+	defer state.pendingSyncMu.Unlock()
+	x, ok := state.syncExecutions[parentID]
+	if !ok {
+		return
+	}
+	g := x.group(child.ServiceName + ":" + child.Endpoint)
+	if g == nil || g.done {
+		isolateFailedSyncAttempt(child)
+		return
+	}
+	g.inFlight[child.ID] = true
+}
+
+// resolveSyncExecutionChild records the outcome of a sync call to target made by a request with
+// execution stages: childID is the child request, or empty when the call failed before one existed.
+// It reports false when the parent has no execution stages, leaving the call to the pendingSync
+// counter. Once a group resolves, its children still in flight are ignored like timed-out ones:
+// they run to completion, but the parent no longer waits for them.
+func resolveSyncExecutionChild(state *scenarioState, eng *engine.Engine, parentID, target, childID string, simTime time.Time, childFailed bool, failureReason string) bool {
+	rm := eng.GetRunManager()
+	state.pendingSyncMu.Lock()
+	x, ok := state.syncExecutions[parentID]
+	if !ok {
+		state.pendingSyncMu.Unlock()
+		return false
+	}
+	g := x.group(target)
+	if g == nil || g.done {
+		state.pendingSyncMu.Unlock()
+		return true
+	}
+	delete(g.inFlight, childID)
+	if childFailed {
+		g.failed++
+	} else {
+		g.succeeded++
+	}
+	var ignored []string
+	groupFailed := false
+	switch {
+	case g.succeeded >= g.need:
+		g.done = true
+		for id := range g.inFlight {
+			ignored = append(ignored, id)
+		}
+	case g.failed > len(g.calls)-g.need:
+		g.done = true
+		groupFailed = true
+		ignored = x.inFlight()
+	}
+	advance := g.done && (groupFailed || x.stageDone())
+	if groupFailed {
+		delete(state.syncExecutions, parentID)
+	}
+	state.pendingSyncMu.Unlock()
+
+	for _, id := range ignored {
+		if c, ok := rm.GetRequest(id); ok {
+			isolateFailedSyncAttempt(c)
+		}
+	}
+	if !advance {
+		return true
+	}
+	parent, ok := rm.GetRequest(parentID)
+	if !ok {
+		return true
+	}
+	plabels := labelsForRequestMetrics(parent, parent.ServiceName, parent.Endpoint)
+	if groupFailed {
+		if parent.Metadata != nil {
+			parent.Metadata[metaSubtreeFailed] = true
+			if failureReason != "" {
+				parent.Metadata[metaFailureReason] = failureReason
+			}
+		}
+		finalizeRequestFailure(state, eng, rm, parent, simTime, plabels, metrics.ReasonDownstreamFailure)
+		return true
+	}
+
+	x.stage++
+	if _, more := x.startStage(state, eng, parent, simTime); more {
+		return true
+	}
+	state.pendingSyncMu.Lock()
+	delete(state.syncExecutions, parentID)
+	state.pendingSyncMu.Unlock()
+	if parent.Metadata != nil {
+		if deadline, ok := parent.Metadata[metaQueueAckDeadline].(time.Time); ok && simTime.Before(deadline) {
+			return true
+		}
+	}
+	recordDeferredQueueParentMetricsIfNeeded(state, parent, simTime, plabels)
+	finalizeRequestCompletion(state, eng, rm, parent, simTime, plabels)
+	return true
+}
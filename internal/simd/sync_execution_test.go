@@ -0,0 +1,102 @@
+package simd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+// fanOutTestScenario calls b (20ms), c (30ms) and d (60ms) from a (10ms); e always fails.
+func fanOutTestScenario(execution []config.ExecutionStage, calls ...string) *config.Scenario {
+	ep := func(path string, cpuMs float64) config.Endpoint {
+		return config.Endpoint{Path: path, MeanCPUMs: cpuMs, DefaultMemoryMB: 32}
+	}
+	a := ep("/a", 10)
+	for _, to := range calls {
+		a.Downstream = append(a.Downstream, config.DownstreamCall{To: to})
+	}
+	a.Execution = execution
+	e := ep("/e", 5)
+	e.FailureRate = 1
+	return &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 8}},
+		Services: []config.Service{
+			{ID: "a", Replicas: 1, Model: "cpu", Endpoints: []config.Endpoint{a}},
+			{ID: "b", Replicas: 1, Model: "cpu", Endpoints: []config.Endpoint{ep("/b", 20)}},
+			{ID: "c", Replicas: 1, Model: "cpu", Endpoints: []config.Endpoint{ep("/c", 30)}},
+			{ID: "d", Replicas: 1, Model: "cpu", Endpoints: []config.Endpoint{ep("/d", 60)}},
+			{ID: "e", Replicas: 1, Model: "cpu", Endpoints: []config.Endpoint{e}},
+		},
+		Workload: []config.WorkloadPattern{{From: "client", To: "a:/a", Arrival: config.ArrivalSpec{Type: "constant", RateRPS: 1}}},
+	}
+}
+
+// runFanOut sends one request to a:/a and returns the collector after the run.
+func runFanOut(t *testing.T, scenario *config.Scenario) *metrics.Collector {
+	t.Helper()
+	r := newTestRun(t, scenario, 42)
+	r.scheduleArrivals("a", "/a", 0)
+	r.run(t, 500*time.Millisecond)
+	r.state.pendingSyncMu.Lock()
+	defer r.state.pendingSyncMu.Unlock()
+	if len(r.state.syncExecutions) != 0 {
+		t.Fatalf("expected no request left waiting on execution stages, got %d", len(r.state.syncExecutions))
+	}
+	return r.collector
+}
+
+func rootLatency(collector *metrics.Collector) float64 {
+	var root float64
+	for _, labels := range collector.GetLabelsForMetric(metrics.MetricRootRequestLatency) {
+		for _, p := range collector.GetTimeSeries(metrics.MetricRootRequestLatency, labels) {
+			root = max(root, p.Value)
+		}
+	}
+	return root
+}
+
+func TestSyncExecutionStagesSetRootLatency(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		execution []config.ExecutionStage
+		min, max  float64
+	}{
+		{"parallel", nil, 69.5, 75},
+		{"sequential", []config.ExecutionStage{{Calls: []string{"b:/b"}}, {Calls: []string{"c:/c"}}, {Calls: []string{"d:/d"}}}, 119.5, 125},
+		{"quorum", []config.ExecutionStage{{Calls: []string{"b:/b", "c:/c", "d:/d"}, Quorum: 2}}, 39.5, 45},
+		{"groups", []config.ExecutionStage{
+			{Groups: []config.ExecutionGroup{{Calls: []string{"b:/b"}}, {Calls: []string{"c:/c", "d:/d"}, Quorum: 1}}},
+		}, 39.5, 45},
+		{"unlisted first", []config.ExecutionStage{{Calls: []string{"b:/b"}}, {Calls: []string{"d:/d"}}}, 99.5, 105},
+	} {
+		collector := runFanOut(t, fanOutTestScenario(tc.execution, "b:/b", "c:/c", "d:/d"))
+		if got := rootLatency(collector); got < tc.min || got > tc.max {
+			t.Fatalf("%s: expected root latency in [%v, %v]ms, got %v", tc.name, tc.min, tc.max, got)
+		}
+		if n := collector.SumMetricWhere(metrics.MetricRequestCount, "service", "d"); n != 1 {
+			t.Fatalf("%s: expected d to be called once, got %v", tc.name, n)
+		}
+	}
+}
+
+func TestSyncExecutionFailsFast(t *testing.T) {
+	// e fails in the first stage, so the second stage is never issued.
+	collector := runFanOut(t, fanOutTestScenario([]config.ExecutionStage{{Calls: []string{"e:/e"}}, {Calls: []string{"d:/d"}}}, "e:/e", "d:/d"))
+	if n := collector.SumMetricWhere(metrics.MetricRequestCount, "service", "d"); n != 0 {
+		t.Fatalf("expected the stage after a failed one not to run, got %v calls to d", n)
+	}
+	if n := collector.SumMetricWhere(metrics.MetricIngressLogicalFailure, "service", "a"); n != 1 {
+		t.Fatalf("expected the root request to fail, got %v failures", n)
+	}
+
+	// A quorum of one tolerates the failure of e; the group resolves when c does.
+	collector = runFanOut(t, fanOutTestScenario([]config.ExecutionStage{{Calls: []string{"e:/e", "c:/c"}, Quorum: 1}}, "e:/e", "c:/c"))
+	if n := collector.SumMetricWhere(metrics.MetricIngressLogicalFailure, "service", "a"); n != 0 {
+		t.Fatalf("expected the quorum to absorb the failure, got %v failures", n)
+	}
+	if got := rootLatency(collector); got < 39.5 || got > 45 {
+		t.Fatalf("expected root latency about 40ms, got %v", got)
+	}
+}
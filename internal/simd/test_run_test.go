@@ -0,0 +1,71 @@
+package simd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/metrics"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/policy"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/resource"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+)
+
+// testRun is a scenario wired to an engine with its handlers registered, ready to run.
+type testRun struct {
+	eng       *engine.Engine
+	rm        *resource.Manager
+	collector *metrics.Collector
+	state     *scenarioState
+}
+
+// newTestRun validates scenario and wires it for a run seeded with seed, under the scenario policies.
+// Its workload is not started: tests schedule arrivals or start a WorkloadState.
+func newTestRun(t *testing.T, scenario *config.Scenario, seed int64) *testRun {
+	t.Helper()
+	if err := config.ValidateScenario(scenario); err != nil {
+		t.Fatalf("ValidateScenario: %v", err)
+	}
+	eng := engine.NewEngine(t.Name())
+	rm := resource.NewManager()
+	if err := rm.InitializeFromScenario(scenario); err != nil {
+		t.Fatalf("InitializeFromScenario: %v", err)
+	}
+	collector := metrics.NewCollector()
+	collector.Start()
+	t.Cleanup(collector.Stop)
+	state, err := newScenarioState(scenario, rm, collector, policy.NewPolicyManager(scenario.Policies), seed)
+	if err != nil {
+		t.Fatalf("newScenarioState: %v", err)
+	}
+	RegisterHandlers(eng, state)
+	return &testRun{eng: eng, rm: rm, collector: collector, state: state}
+}
+
+// scheduleArrivals sends a request to serviceID:path at each offset from the start of the run.
+func (r *testRun) scheduleArrivals(serviceID, path string, offsets ...time.Duration) {
+	start := r.eng.GetSimTime()
+	for _, at := range offsets {
+		r.eng.ScheduleAt(engine.EventTypeRequestArrival, start.Add(at), nil, serviceID, map[string]interface{}{
+			"service_id":    serviceID,
+			"endpoint_path": path,
+		})
+	}
+}
+
+// every returns n offsets gap apart, starting at 0.
+func every(gap time.Duration, n int) []time.Duration {
+	offsets := make([]time.Duration, n)
+	for i := range offsets {
+		offsets[i] = time.Duration(i) * gap
+	}
+	return offsets
+}
+
+// run runs the engine for dur and fails the test on error.
+func (r *testRun) run(t *testing.T, dur time.Duration) {
+	t.Helper()
+	if err := r.eng.Run(dur); err != nil {
+		t.Fatalf("engine run: %v", err)
+	}
+}
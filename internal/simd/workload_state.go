@@ -10,6 +10,7 @@ import (
 
 	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
 	"github.com/GoSim-25-26J-441/simulation-core/internal/interaction"
+	"github.com/GoSim-25-26J-441/simulation-core/internal/workload"
 	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
 	"github.com/GoSim-25-26J-441/simulation-core/pkg/logger"
 	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
@@ -33,6 +34,8 @@ const (
 
 // WorkloadPatternState tracks the state of a workload pattern during simulation
 type WorkloadPatternState struct {
+	// key is the pattern key ("from:to") in WorkloadState.patterns.
+	key          string
 	Pattern      config.WorkloadPattern
 	ServiceID    string
 	EndpointPath string
@@ -54,8 +57,16 @@ type WorkloadPatternState struct {
 	// lazily distribute remaining arrivals to chunks via conditional binomial sampling.
 	uniformGlobalCountMode bool
 	uniformRemaining       int64
-	Active                 bool
-	mu                     sync.RWMutex
+	// replay walks the trace of a "replay" pattern; replayNext is the arrival at NextEventTime.
+	replay     *workload.ReplayCursor
+	replayNext workload.ReplayArrival
+	// process carries the hidden state of an "mmpp" or "self_similar" pattern.
+	process workload.ArrivalProcess
+	// flow is set on the session pattern of a flow; flowSessions numbers its sessions.
+	flow         *config.Flow
+	flowSessions int64
+	Active       bool
+	mu           sync.RWMutex
 }
 
 // WorkloadState manages workload patterns for a simulation run with continuous event generation
@@ -136,55 +147,96 @@ func (ws *WorkloadState) initPatternsLocked(scenario *config.Scenario, startTime
 	ws.patterns = make(map[string]*WorkloadPatternState)
 	for i := range scenario.Workload {
 		workloadPattern := &scenario.Workload[i]
-		serviceID, endpointPath, err := interaction.ParseDownstreamTarget(workloadPattern.To)
+		key := patternKey(workloadPattern.From, workloadPattern.To)
+		if err := ws.initPatternLocked(key, *workloadPattern, nil, startTime, realTime); err != nil {
+			return err
+		}
+	}
+	for i := range scenario.Flows {
+		f := &scenario.Flows[i]
+		if err := ws.initPatternLocked(flowPatternKey(f.ID), flowWorkloadPattern(f), f, startTime, realTime); err != nil {
+			return err
+		}
+	}
+	for _, ps := range ws.patterns {
+		if ps.Pattern.Arrival.Type == "closed" {
+			ws.engine.RegisterHandler(engine.EventTypeWorkloadUserReturn, ws.handleUserReturn())
+			break
+		}
+	}
+	return nil
+}
+
+// initPatternLocked adds the state of one workload pattern under key; flow is set for the session
+// arrivals of a flow.
+func (ws *WorkloadState) initPatternLocked(key string, workloadPattern config.WorkloadPattern, flow *config.Flow, startTime time.Time, realTime bool) error {
+	serviceID, endpointPath, err := interaction.ParseDownstreamTarget(workloadPattern.To)
+	if err != nil {
+		return fmt.Errorf("invalid workload target %s: %w", workloadPattern.To, err)
+	}
+	arrival := workloadPattern.Arrival
+	var firstEventTime time.Time
+	var uniformTimes []time.Time
+	uniformLazy := false
+	var uniformWatermark time.Time
+	if arrival.Type == "uniform" {
+		// Always keep uniform generation horizon-based to avoid full-duration
+		// pre-generation in standard mode while preserving deterministic seed order.
+		uniformLazy = true
+		uniformWatermark = startTime
+	} else if arrival.Type == "closed" {
+		// Closed users schedule their own arrivals; the horizon loops never pick this pattern up.
+		firstEventTime = ws.endTime
+	} else if arrival.Type != "replay" && arrival.Type != "mmpp" && arrival.Type != "self_similar" {
+		firstEventTime = ws.calculateNextArrivalTime(arrival, startTime, startTime)
+	}
+	ps := &WorkloadPatternState{
+		key:                    key,
+		Pattern:                workloadPattern,
+		ServiceID:              serviceID,
+		EndpointPath:           endpointPath,
+		Epoch:                  startTime,
+		LastEventTime:          startTime,
+		NextEventTime:          firstEventTime,
+		uniformTimes:           uniformTimes,
+		uniformCursor:          0,
+		uniformLazy:            uniformLazy,
+		uniformStreamWatermark: uniformWatermark,
+		uniformGlobalCountMode: !realTime,
+		uniformRemaining:       0,
+		flow:                   flow,
+		Active:                 true,
+	}
+	if arrival.Type == "uniform" && !realTime {
+		ps.uniformRemaining = int64(math.Round(arrival.RateRPS * ws.endTime.Sub(startTime).Seconds()))
+		if ps.uniformRemaining < 0 {
+			ps.uniformRemaining = 0
+		}
+	}
+	if arrival.Type == "replay" {
+		records, err := config.LoadReplayTrace(arrival)
 		if err != nil {
-			return fmt.Errorf("invalid workload target %s: %w", workloadPattern.To, err)
+			return fmt.Errorf("workload %s: %w", key, err)
 		}
-		key := patternKey(workloadPattern.From, workloadPattern.To)
-		arrival := workloadPattern.Arrival
-		var firstEventTime time.Time
-		var uniformTimes []time.Time
-		uniformLazy := false
-		var uniformWatermark time.Time
-		if arrival.Type == "uniform" {
-			// Always keep uniform generation horizon-based to avoid full-duration
-			// pre-generation in standard mode while preserving deterministic seed order.
-			uniformLazy = true
-			uniformWatermark = startTime
+		ps.replay = workload.NewReplayCursor(records, arrival, startTime)
+		ps.NextEventTime = ws.nextReplayArrival(ps)
+	}
+	if arrival.Type == "mmpp" || arrival.Type == "self_similar" {
+		ps.process = workload.NewArrivalProcess(arrival, startTime, ws.endTime, ws.generator)
+		ps.NextEventTime = ps.process.Next(ws.generator)
+	}
+	if arrival.Type == "closed" {
+		ws.startClosedUsersLocked(ps, startTime)
+	}
+	if arrival.Type == "uniform" {
+		ws.ensureUniformHorizon(ps, startTime.Add(EventGenerationLookaheadWindow))
+		if len(ps.uniformTimes) > 0 {
+			ps.NextEventTime = ps.uniformTimes[0]
 		} else {
-			firstEventTime = ws.calculateNextArrivalTime(arrival, startTime, startTime)
-		}
-		ps := &WorkloadPatternState{
-			Pattern:                *workloadPattern,
-			ServiceID:              serviceID,
-			EndpointPath:           endpointPath,
-			Epoch:                  startTime,
-			LastEventTime:          startTime,
-			NextEventTime:          firstEventTime,
-			uniformTimes:           uniformTimes,
-			uniformCursor:          0,
-			uniformLazy:            uniformLazy,
-			uniformStreamWatermark: uniformWatermark,
-			uniformGlobalCountMode: !realTime,
-			uniformRemaining:       0,
-			Active:                 true,
-		}
-		if arrival.Type == "uniform" && !realTime {
-			ps.uniformRemaining = int64(math.Round(arrival.RateRPS * ws.endTime.Sub(startTime).Seconds()))
-			if ps.uniformRemaining < 0 {
-				ps.uniformRemaining = 0
-			}
+			ps.NextEventTime = ws.endTime
 		}
-		if arrival.Type == "uniform" {
-			ws.ensureUniformHorizon(ps, startTime.Add(EventGenerationLookaheadWindow))
-			if len(ps.uniformTimes) > 0 {
-				ps.NextEventTime = ps.uniformTimes[0]
-			} else {
-				ps.NextEventTime = ws.endTime
-			}
-		}
-		ws.patterns[key] = ps
 	}
+	ws.patterns[key] = ps
 	return nil
 }
 
@@ -192,10 +244,7 @@ func (ws *WorkloadState) initPatternsLocked(scenario *config.Scenario, startTime
 // NextEventTime up to ws.endTime. Used when not in real-time mode.
 func (ws *WorkloadState) generateAllEventsUpToEndTime() {
 	ws.mu.RLock()
-	patterns := make([]*WorkloadPatternState, 0, len(ws.patterns))
-	for _, patternState := range ws.patterns {
-		patterns = append(patterns, patternState)
-	}
+	patterns := ws.orderedPatternsLocked()
 	ws.mu.RUnlock()
 
 	for _, patternState := range patterns {
@@ -205,13 +254,8 @@ func (ws *WorkloadState) generateAllEventsUpToEndTime() {
 			continue
 		}
 		for patternState.NextEventTime.Before(ws.endTime) {
-			ws.engine.ScheduleAt(
-				engine.EventTypeRequestArrival,
-				patternState.NextEventTime,
-				nil,
-				patternState.ServiceID,
-				workloadArrivalEventData(patternState),
-			)
+			serviceID, data := workloadArrivalEventData(patternState, ws.generator)
+			ws.engine.ScheduleAt(engine.EventTypeRequestArrival, patternState.NextEventTime, nil, serviceID, data)
 			nextTime := ws.advanceToNextArrival(patternState, patternState.NextEventTime)
 			patternState.LastEventTime = patternState.NextEventTime
 			patternState.NextEventTime = nextTime
@@ -250,22 +294,47 @@ func (ws *WorkloadState) handleWorkloadGenerate() engine.EventHandler {
 	}
 }
 
-func workloadArrivalEventData(patternState *WorkloadPatternState) map[string]interface{} {
+// workloadArrivalEventData returns the target service and event data of the arrival at NextEventTime.
+// Replay arrivals use their record's target when it has one and add the record's metadata; flow
+// arrivals start a new session at the flow's first step. Weighted metadata is drawn from rng.
+func workloadArrivalEventData(patternState *WorkloadPatternState, rng *utils.RandSource) (string, map[string]interface{}) {
+	if f := patternState.flow; f != nil {
+		patternState.flowSessions++
+		return flowStepEventData(f, 0, fmt.Sprintf("%s-%d", f.ID, patternState.flowSessions), config.DrawWeightedMetadata(f.WeightedMetadata, rng))
+	}
+	serviceID, endpointPath := patternState.ServiceID, patternState.EndpointPath
+	var recordMetadata map[string]string
+	if rec := patternState.replayNext.Record; rec != nil {
+		if rec.ServiceID != "" {
+			serviceID, endpointPath = rec.ServiceID, rec.EndpointPath
+		}
+		recordMetadata = rec.Metadata
+	}
 	data := map[string]interface{}{
-		"service_id":    patternState.ServiceID,
-		"endpoint_path": patternState.EndpointPath,
+		"service_id":    serviceID,
+		"endpoint_path": endpointPath,
 		"from":          patternState.Pattern.From,
 		"source_kind":   patternState.Pattern.SourceKind,
 		"traffic_class": patternState.Pattern.TrafficClass,
 	}
-	if len(patternState.Pattern.Metadata) > 0 {
-		md := make(map[string]interface{}, len(patternState.Pattern.Metadata))
+	if pc := patternState.Pattern.PriorityClass; pc != "" {
+		data["priority_class"] = pc
+	}
+	drawn := config.DrawWeightedMetadata(patternState.Pattern.WeightedMetadata, rng)
+	if len(patternState.Pattern.Metadata) > 0 || len(drawn) > 0 || len(recordMetadata) > 0 {
+		md := make(map[string]interface{}, len(patternState.Pattern.Metadata)+len(drawn)+len(recordMetadata))
 		for k, v := range patternState.Pattern.Metadata {
 			md[k] = v
 		}
+		for k, v := range drawn {
+			md[k] = v
+		}
+		for k, v := range recordMetadata {
+			md[k] = v
+		}
 		data["metadata"] = md
 	}
-	return data
+	return serviceID, data
 }
 
 // Engine returns the simulation engine for this run (e.g. current simulation time).
@@ -303,8 +372,19 @@ func (ws *WorkloadState) UpdateRate(patternKey string, newRateRPS float64) error
 	patternState.mu.Lock()
 	defer patternState.mu.Unlock()
 
-	// Update the rate in the pattern
+	if patternState.replay != nil {
+		return fmt.Errorf("workload pattern %s replays a trace and has no rate", patternKey)
+	}
+	if patternState.Pattern.Arrival.Type == "closed" {
+		return fmt.Errorf("workload pattern %s is a closed workload and has no rate", patternKey)
+	}
+	if patternState.Pattern.Arrival.Type == "mmpp" {
+		return fmt.Errorf("workload pattern %s is an mmpp workload with per-state rates", patternKey)
+	}
+
+	// Update the rate in the pattern; an explicit rate replaces a rate profile.
 	patternState.Pattern.Arrival.RateRPS = newRateRPS
+	patternState.Pattern.Arrival.RateProfile = nil
 	currentSimTime := ws.engine.GetSimTime()
 	if patternState.Pattern.Arrival.Type == "uniform" {
 		patternState.uniformLazy = true
@@ -327,6 +407,10 @@ func (ws *WorkloadState) UpdateRate(patternKey string, newRateRPS float64) error
 		} else {
 			patternState.NextEventTime = ws.endTime
 		}
+	} else if patternState.process != nil {
+		// Restart the on/off sources at the new mean rate.
+		patternState.process = workload.NewArrivalProcess(patternState.Pattern.Arrival, currentSimTime, ws.endTime, ws.generator)
+		patternState.NextEventTime = patternState.process.Next(ws.generator)
 	} else {
 		// Reset next event time to trigger immediate recalculation
 		patternState.NextEventTime = currentSimTime
@@ -350,6 +434,16 @@ func (ws *WorkloadState) UpdatePattern(patternKey string, pattern config.Workloa
 		return fmt.Errorf("workload pattern not found: %s", patternKey)
 	}
 
+	if pattern.Arrival.Type == "replay" || pattern.Arrival.Type == "closed" {
+		return fmt.Errorf("%s arrivals cannot be set on a running workload", pattern.Arrival.Type)
+	}
+	if patternState.flow != nil {
+		return fmt.Errorf("workload pattern %s starts flow sessions and only its rate can be updated", patternKey)
+	}
+	if err := config.ValidateArrivalSpec(&pattern.Arrival); err != nil {
+		return fmt.Errorf("invalid arrival for workload pattern %s: %w", patternKey, err)
+	}
+
 	// Parse target
 	serviceID, endpointPath, err := interaction.ParseDownstreamTarget(pattern.To)
 	if err != nil {
@@ -362,6 +456,9 @@ func (ws *WorkloadState) UpdatePattern(patternKey string, pattern config.Workloa
 	patternState.Pattern = pattern
 	patternState.ServiceID = serviceID
 	patternState.EndpointPath = endpointPath
+	patternState.replay = nil
+	patternState.replayNext = workload.ReplayArrival{}
+	patternState.process = nil
 	currentSimTime := ws.engine.GetSimTime()
 	// Restart burst/quiet cycle alignment from now so mid-run pattern changes
 	// (especially bursty timing) do not stay tied to the original simulation start.
@@ -394,6 +491,10 @@ func (ws *WorkloadState) UpdatePattern(patternKey string, pattern config.Workloa
 		patternState.uniformGlobalCountMode = false
 		patternState.uniformRemaining = 0
 		patternState.NextEventTime = currentSimTime
+		if pattern.Arrival.Type == "mmpp" || pattern.Arrival.Type == "self_similar" {
+			patternState.process = workload.NewArrivalProcess(pattern.Arrival, currentSimTime, ws.endTime, ws.generator)
+			patternState.NextEventTime = patternState.process.Next(ws.generator)
+		}
 	}
 
 	logger.Info("workload pattern updated",
@@ -421,6 +522,7 @@ func (ws *WorkloadState) GetPattern(patternKey string) (*WorkloadPatternState, b
 	defer pattern.mu.RUnlock()
 
 	copy := &WorkloadPatternState{
+		key:                    pattern.key,
 		Pattern:                pattern.Pattern,
 		ServiceID:              pattern.ServiceID,
 		EndpointPath:           pattern.EndpointPath,
@@ -433,6 +535,8 @@ func (ws *WorkloadState) GetPattern(patternKey string) (*WorkloadPatternState, b
 		uniformStreamWatermark: pattern.uniformStreamWatermark,
 		uniformGlobalCountMode: pattern.uniformGlobalCountMode,
 		uniformRemaining:       pattern.uniformRemaining,
+		flow:                   pattern.flow,
+		flowSessions:           pattern.flowSessions,
 		Active:                 pattern.Active,
 	}
 	return copy, true
@@ -451,6 +555,7 @@ func (ws *WorkloadState) GetAllPatterns() map[string]*WorkloadPatternState {
 		// to be a read-only snapshot and callers should not perform locking operations on it.
 		v.mu.RLock()
 		copy := &WorkloadPatternState{
+			key:                    v.key,
 			Pattern:                v.Pattern,
 			ServiceID:              v.ServiceID,
 			EndpointPath:           v.EndpointPath,
@@ -463,6 +568,8 @@ func (ws *WorkloadState) GetAllPatterns() map[string]*WorkloadPatternState {
 			uniformStreamWatermark: v.uniformStreamWatermark,
 			uniformGlobalCountMode: v.uniformGlobalCountMode,
 			uniformRemaining:       v.uniformRemaining,
+			flow:                   v.flow,
+			flowSessions:           v.flowSessions,
 			Active:                 v.Active,
 		}
 		v.mu.RUnlock()
@@ -471,6 +578,21 @@ func (ws *WorkloadState) GetAllPatterns() map[string]*WorkloadPatternState {
 	return result
 }
 
+// orderedPatternsLocked returns the patterns sorted by key. Patterns share one RNG stream, so a fixed
+// generation order is what keeps seeded runs reproducible. Caller holds ws.mu.
+func (ws *WorkloadState) orderedPatternsLocked() []*WorkloadPatternState {
+	keys := make([]string, 0, len(ws.patterns))
+	for k := range ws.patterns {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	patterns := make([]*WorkloadPatternState, 0, len(keys))
+	for _, k := range keys {
+		patterns = append(patterns, ws.patterns[k])
+	}
+	return patterns
+}
+
 // patternKey generates a unique key for a workload pattern
 func patternKey(from, to string) string {
 	return fmt.Sprintf("%s:%s", from, to)
@@ -506,10 +628,7 @@ func (ws *WorkloadState) generateNextEvents() {
 	}
 
 	// Make a copy of patterns to iterate over while holding read lock
-	patterns := make([]*WorkloadPatternState, 0, len(ws.patterns))
-	for _, pattern := range ws.patterns {
-		patterns = append(patterns, pattern)
-	}
+	patterns := ws.orderedPatternsLocked()
 	ws.mu.RUnlock()
 
 	for _, patternState := range patterns {
@@ -526,13 +645,8 @@ func (ws *WorkloadState) generateNextEvents() {
 		// Generate events until we've scheduled up to lookaheadTime
 		for patternState.NextEventTime.Before(lookaheadTime) && patternState.NextEventTime.Before(ws.endTime) {
 			// Schedule the arrival event
-			ws.engine.ScheduleAt(
-				engine.EventTypeRequestArrival,
-				patternState.NextEventTime,
-				nil,
-				patternState.ServiceID,
-				workloadArrivalEventData(patternState),
-			)
+			serviceID, data := workloadArrivalEventData(patternState, ws.generator)
+			ws.engine.ScheduleAt(engine.EventTypeRequestArrival, patternState.NextEventTime, nil, serviceID, data)
 
 			nextTime := ws.advanceToNextArrival(patternState, patternState.NextEventTime)
 			patternState.LastEventTime = patternState.NextEventTime
@@ -550,10 +664,7 @@ func (ws *WorkloadState) generateUpToHorizon(horizon time.Time) int {
 	if horizon.After(ws.endTime) {
 		horizon = ws.endTime
 	}
-	patterns := make([]*WorkloadPatternState, 0, len(ws.patterns))
-	for _, pattern := range ws.patterns {
-		patterns = append(patterns, pattern)
-	}
+	patterns := ws.orderedPatternsLocked()
 	ws.mu.RUnlock()
 	if !currentSimTime.Before(ws.endTime) {
 		return 0
@@ -581,13 +692,8 @@ func (ws *WorkloadState) generateUpToHorizon(horizon time.Time) int {
 				return generated
 			default:
 			}
-			ws.engine.ScheduleAt(
-				engine.EventTypeRequestArrival,
-				patternState.NextEventTime,
-				nil,
-				patternState.ServiceID,
-				workloadArrivalEventData(patternState),
-			)
+			serviceID, data := workloadArrivalEventData(patternState, ws.generator)
+			ws.engine.ScheduleAt(engine.EventTypeRequestArrival, patternState.NextEventTime, nil, serviceID, data)
 			generated++
 			if ws.engine.GuardrailError() != nil {
 				patternState.mu.Unlock()
@@ -770,6 +876,12 @@ func (ws *WorkloadState) compactUniformSchedule(ps *WorkloadPatternState) {
 // advanceToNextArrival returns the next arrival instant after scheduling at scheduledAt.
 // For type "uniform" it walks the precomputed sorted schedule (see sampleUniformArrivalTimes).
 func (ws *WorkloadState) advanceToNextArrival(patternState *WorkloadPatternState, scheduledAt time.Time) time.Time {
+	if patternState.replay != nil {
+		return ws.nextReplayArrival(patternState)
+	}
+	if patternState.process != nil {
+		return patternState.process.Next(ws.generator)
+	}
 	if patternState.Pattern.Arrival.Type != "uniform" {
 		return ws.calculateNextArrivalTime(patternState.Pattern.Arrival, scheduledAt, patternState.Epoch)
 	}
@@ -793,11 +905,26 @@ func (ws *WorkloadState) advanceToNextArrival(patternState *WorkloadPatternState
 	return ws.endTime
 }
 
+// nextReplayArrival moves a replay pattern to its next trace arrival and returns its time
+// (ws.endTime once the trace is exhausted).
+func (ws *WorkloadState) nextReplayArrival(ps *WorkloadPatternState) time.Time {
+	a, ok := ps.replay.Next(ws.generator)
+	if !ok {
+		ps.replayNext = workload.ReplayArrival{}
+		return ws.endTime
+	}
+	ps.replayNext = a
+	return a.Time
+}
+
 // calculateNextArrivalTime calculates the next arrival time after the last scheduled arrival
-// at currentTime. epoch is simulation start for bursty cycle alignment (ignored for other types).
+// at currentTime. epoch is simulation start for bursty cycle and rate profile alignment (ignored for other types).
 func (ws *WorkloadState) calculateNextArrivalTime(arrival config.ArrivalSpec, currentTime time.Time, epoch time.Time) time.Time {
 	switch arrival.Type {
 	case "poisson", "exponential":
+		if arrival.RateProfile != nil {
+			return workload.NextProfileArrival(arrival, epoch, currentTime, ws.endTime, ws.generator)
+		}
 		// Exponential inter-arrival time
 		rateRPS := arrival.RateRPS
 		if rateRPS <= 0 {
@@ -839,6 +966,9 @@ func (ws *WorkloadState) calculateNextArrivalTime(arrival config.ArrivalSpec, cu
 	case "bursty":
 		return burstyNextArrivalTime(epoch, currentTime, arrival, ws.generator)
 
+	case "pareto", "lognormal":
+		return workload.NextRenewalArrival(arrival, currentTime, ws.generator)
+
 	default:
 		panic(fmt.Sprintf("workload: unsupported arrival type %q (expected validated scenario)", arrival.Type))
 	}
@@ -937,6 +1067,7 @@ func newWorkloadStateWithPatternsStub(runID string, scenario *config.Scenario, s
 		}
 		key := patternKey(workloadPattern.From, workloadPattern.To)
 		ws.patterns[key] = &WorkloadPatternState{
+			key:           key,
 			Pattern:       *workloadPattern,
 			ServiceID:     serviceID,
 			EndpointPath:  endpointPath,
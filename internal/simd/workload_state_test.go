@@ -1,7 +1,9 @@
 package simd
 
 import (
+	"fmt"
 	"math"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -1300,3 +1302,160 @@ func TestWorkloadStateBurstyBurstQuietParamsChangeVolume(t *testing.T) {
 		t.Fatalf("expected different arrival counts when quiet duration changes; got %d for both", nLongQuiet)
 	}
 }
+
+func TestWorkloadStateReplayAcrossChunks(t *testing.T) {
+	eng := engine.NewEngine("replay")
+	start := eng.GetSimTime()
+	end := start.Add(20 * time.Second)
+	// One record per second for 30s of trace; at 2x the 20s run covers all but the tail and
+	// crosses the standard-mode generation chunks.
+	trace := "timestamp,target,tenant_id\n"
+	for i := 0; i < 60; i++ {
+		target := ""
+		if i%2 == 1 {
+			target = "svc2:/other"
+		}
+		trace += fmt.Sprintf("%d,%s,t%d\n", 1000+i, target, i)
+	}
+	scenario := &config.Scenario{
+		Hosts: []config.Host{{ID: "host-1", Cores: 2}},
+		Services: []config.Service{
+			{ID: "svc1", Endpoints: []config.Endpoint{{Path: "/test"}}},
+			{ID: "svc2", Endpoints: []config.Endpoint{{Path: "/other"}}},
+		},
+		Workload: []config.WorkloadPattern{{
+			From:     "client",
+			To:       "svc1:/test",
+			Metadata: map[string]string{"tenant_id": "default", "client_zone": "zone-a"},
+			Arrival:  config.ArrivalSpec{Type: "replay", ReplayFormat: "csv", ReplayData: trace, TimeScale: 2},
+		}},
+	}
+	ws := NewWorkloadState("replay", eng, end, 42)
+	if err := ws.Start(scenario, start, false); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	var events []*engine.Event
+	eng.RegisterHandler(engine.EventTypeRequestArrival, func(e *engine.Engine, evt *engine.Event) error {
+		events = append(events, evt)
+		return nil
+	})
+	if err := eng.Run(20 * time.Second); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(events) != 40 {
+		t.Fatalf("expected 40 replayed arrivals in 20s at 2x, got %d", len(events))
+	}
+	for i, evt := range events {
+		if want := start.Add(time.Duration(i) * 500 * time.Millisecond); !evt.Time.Equal(want) {
+			t.Fatalf("arrival %d at %v, want %v", i, evt.Time.Sub(start), want.Sub(start))
+		}
+		wantSvc := "svc1"
+		if i%2 == 1 {
+			wantSvc = "svc2"
+		}
+		md := evt.Data["metadata"].(map[string]interface{})
+		if evt.ServiceID != wantSvc || evt.Data["service_id"] != wantSvc || evt.Data["from"] != "client" {
+			t.Fatalf("arrival %d: unexpected target %v", i, evt.Data)
+		}
+		if md["tenant_id"] != fmt.Sprintf("t%d", i) || md["client_zone"] != "zone-a" {
+			t.Fatalf("arrival %d: expected record metadata over pattern metadata, got %v", i, md)
+		}
+	}
+	if err := ws.UpdateRate(patternKey("client", "svc1:/test"), 5); err == nil {
+		t.Fatal("expected UpdateRate on a replay pattern to fail")
+	}
+}
+
+func TestWorkloadStateRateProfileRamp(t *testing.T) {
+	eng := engine.NewEngine("profile")
+	start := eng.GetSimTime()
+	end := start.Add(40 * time.Second)
+	scenario := &config.Scenario{
+		Hosts:    []config.Host{{ID: "host-1", Cores: 2}},
+		Services: []config.Service{{ID: "svc1", Endpoints: []config.Endpoint{{Path: "/test"}}}},
+		Workload: []config.WorkloadPattern{{
+			From: "client",
+			To:   "svc1:/test",
+			Arrival: config.ArrivalSpec{Type: "poisson", RateProfile: &config.RateProfile{
+				Points: []config.RatePoint{{AtSeconds: 0, RateRPS: 0}, {AtSeconds: 40, RateRPS: 100}},
+			}},
+		}},
+	}
+	ws := NewWorkloadState("profile", eng, end, 7)
+	if err := ws.Start(scenario, start, false); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	var firstHalf, secondHalf int
+	eng.RegisterHandler(engine.EventTypeRequestArrival, func(e *engine.Engine, _ *engine.Event) error {
+		if e.GetSimTime().Before(start.Add(20 * time.Second)) {
+			firstHalf++
+		} else {
+			secondHalf++
+		}
+		return nil
+	})
+	if err := eng.Run(40 * time.Second); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	// The ramp integrates to 500 arrivals over the first 20s and 1500 over the second.
+	if math.Abs(float64(firstHalf)-500) > 90 || math.Abs(float64(secondHalf)-1500) > 150 {
+		t.Fatalf("expected about 500 then 1500 arrivals on the ramp, got %d and %d", firstHalf, secondHalf)
+	}
+
+	key := patternKey("client", "svc1:/test")
+	if err := ws.UpdateRate(key, 5); err != nil {
+		t.Fatalf("UpdateRate: %v", err)
+	}
+	if p, _ := ws.GetPattern(key); p.Pattern.Arrival.RateProfile != nil {
+		t.Fatal("expected an explicit rate to replace the profile")
+	}
+}
+
+func TestWorkloadStateArrivalProcessesAcrossChunks(t *testing.T) {
+	run := func(seed int64) ([]time.Duration, *WorkloadState) {
+		eng := engine.NewEngine("mmpp")
+		start := eng.GetSimTime()
+		scenario := &config.Scenario{
+			Hosts:    []config.Host{{ID: "host-1", Cores: 2}},
+			Services: []config.Service{{ID: "svc1", Endpoints: []config.Endpoint{{Path: "/test"}}}},
+			Workload: []config.WorkloadPattern{
+				{From: "client", To: "svc1:/test", Arrival: config.ArrivalSpec{Type: "mmpp", MMPP: &config.MMPPSpec{
+					States: []config.MMPPState{{RateRPS: 0, MeanDurationSeconds: 4}, {RateRPS: 100, MeanDurationSeconds: 4}},
+				}}},
+				{From: "edge", To: "svc1:/test", Arrival: config.ArrivalSpec{Type: "self_similar", RateRPS: 20}},
+			},
+		}
+		ws := NewWorkloadState("mmpp", eng, start.Add(60*time.Second), seed)
+		if err := ws.Start(scenario, start, false); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		var offsets []time.Duration
+		eng.RegisterHandler(engine.EventTypeRequestArrival, func(e *engine.Engine, evt *engine.Event) error {
+			offsets = append(offsets, evt.Time.Sub(start))
+			return nil
+		})
+		if err := eng.Run(60 * time.Second); err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		return offsets, ws
+	}
+	times, ws := run(9)
+	// Half the time at 100 rps plus a 20 rps aggregate over a minute.
+	if math.Abs(float64(len(times))-4200) > 1200 {
+		t.Fatalf("expected about 4200 arrivals, got %d", len(times))
+	}
+	again, _ := run(9)
+	if len(again) != len(times) || again[len(again)-1] != times[len(times)-1] {
+		t.Fatalf("expected the same seed to reproduce the arrivals: %d vs %d", len(times), len(again))
+	}
+	if err := ws.UpdateRate(patternKey("client", "svc1:/test"), 5); err == nil || !strings.Contains(err.Error(), "per-state rates") {
+		t.Fatalf("expected UpdateRate on an mmpp pattern to fail, got %v", err)
+	}
+	if err := ws.UpdateRate(patternKey("edge", "svc1:/test"), 5); err != nil {
+		t.Fatalf("UpdateRate on a self_similar pattern: %v", err)
+	}
+	bad := config.WorkloadPattern{From: "client", To: "svc1:/test", Arrival: config.ArrivalSpec{Type: "mmpp"}}
+	if err := ws.UpdatePattern(patternKey("client", "svc1:/test"), bad); err == nil || !strings.Contains(err.Error(), "at least one state") {
+		t.Fatalf("expected UpdatePattern to reject an mmpp without states, got %v", err)
+	}
+}
@@ -0,0 +1,314 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/logger"
+)
+
+const (
+	logFileName      = "runs.log"
+	snapshotFileName = "snapshot.json"
+
+	// DefaultCompactEvery is the number of log entries after which the log is folded into a snapshot.
+	DefaultCompactEvery = 1000
+)
+
+// FileStoreOptions tunes a FileStore.
+type FileStoreOptions struct {
+	// CompactEvery folds the log into a new snapshot after this many appended entries (0 = DefaultCompactEvery).
+	CompactEvery int
+	// SyncWrites fsyncs the log after every entry. Without it, a host crash may lose the latest entries
+	// (a process crash does not).
+	SyncWrites bool
+}
+
+type logEntry struct {
+	Op  string          `json:"op"`
+	ID  string          `json:"id"`
+	Doc json.RawMessage `json:"doc,omitempty"`
+}
+
+const (
+	opPut    = "put"
+	opDelete = "delete"
+)
+
+// FileStore is an embedded Store backed by an append-only log plus periodic snapshots in one directory.
+// Every Put/Delete appends a JSON line to runs.log; once CompactEvery entries accumulate, the current
+// state is written to snapshot.json (write to temp file, then rename) and the log is truncated.
+// Replaying a log on top of a newer snapshot is idempotent, so a crash between the two steps is safe.
+// A torn final line (crash mid-append) is dropped on open; an unreadable line anywhere else fails the open.
+type FileStore struct {
+	mu       sync.Mutex
+	dir      string
+	opts     FileStoreOptions
+	docs     map[string]json.RawMessage
+	log      *os.File
+	appended int
+	closed   bool
+}
+
+// OpenFileStore opens (creating if needed) a file-backed store in dir and replays its snapshot and log.
+func OpenFileStore(dir string, opts FileStoreOptions) (*FileStore, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("storage: data dir is required")
+	}
+	if opts.CompactEvery <= 0 {
+		opts.CompactEvery = DefaultCompactEvery
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("storage: create data dir: %w", err)
+	}
+	s := &FileStore{
+		dir:  dir,
+		opts: opts,
+		docs: make(map[string]json.RawMessage),
+	}
+	if err := s.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	replayed, torn, err := s.replayLog()
+	if err != nil {
+		return nil, err
+	}
+	// Fold a non-empty log into a fresh snapshot so a torn tail never precedes new entries.
+	if replayed > 0 || torn {
+		if err := s.writeSnapshot(); err != nil {
+			return nil, err
+		}
+	}
+	f, err := os.OpenFile(s.path(logFileName), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o640)
+	if err != nil {
+		return nil, fmt.Errorf("storage: open log: %w", err)
+	}
+	s.log = f
+	return s, nil
+}
+
+func (s *FileStore) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+func (s *FileStore) loadSnapshot() error {
+	raw, err := os.ReadFile(s.path(snapshotFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("storage: read snapshot: %w", err)
+	}
+	if err := json.Unmarshal(raw, &s.docs); err != nil {
+		return fmt.Errorf("storage: decode snapshot: %w", err)
+	}
+	if s.docs == nil {
+		s.docs = make(map[string]json.RawMessage)
+	}
+	return nil
+}
+
+// replayLog applies log entries on top of the snapshot. It returns the number of entries applied and
+// whether a torn final entry (crash mid-append) was skipped. An unreadable entry followed by further
+// entries means the log is corrupt, and replayLog fails rather than let the next compaction drop them.
+func (s *FileStore) replayLog() (int, bool, error) {
+	f, err := os.Open(s.path(logFileName))
+	if os.IsNotExist(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("storage: open log: %w", err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	applied, lineNo, badLine := 0, 0, 0
+	for {
+		line, readErr := r.ReadBytes('\n')
+		lineNo++
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			if badLine > 0 {
+				return applied, false, fmt.Errorf("storage: corrupt log entry at line %d of %s", badLine, s.path(logFileName))
+			}
+			var e logEntry
+			if err := json.Unmarshal(line, &e); err != nil || e.ID == "" {
+				// Only the final entry can be torn; remember it and fail if anything follows.
+				badLine = lineNo
+			} else {
+				switch e.Op {
+				case opPut:
+					s.docs[e.ID] = e.Doc
+				case opDelete:
+					delete(s.docs, e.ID)
+				}
+				applied++
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return applied, false, fmt.Errorf("storage: read log: %w", readErr)
+		}
+	}
+	if badLine > 0 {
+		logger.Warn("storage: dropping torn final log entry", "dir", s.dir, "entries_applied", applied)
+		return applied, true, nil
+	}
+	return applied, false, nil
+}
+
+// writeSnapshot atomically replaces snapshot.json with the current documents.
+func (s *FileStore) writeSnapshot() error {
+	raw, err := json.Marshal(s.docs)
+	if err != nil {
+		return fmt.Errorf("storage: encode snapshot: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, snapshotFileName+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("storage: create snapshot: %w", err)
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("storage: write snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("storage: sync snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("storage: close snapshot: %w", err)
+	}
+	if err := os.Rename(tmpName, s.path(snapshotFileName)); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("storage: install snapshot: %w", err)
+	}
+	return nil
+}
+
+func (s *FileStore) appendLocked(e logEntry) error {
+	if s.closed {
+		return ErrClosed
+	}
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("storage: encode log entry: %w", err)
+	}
+	line = append(line, '\n')
+	if _, err := s.log.Write(line); err != nil {
+		return fmt.Errorf("storage: append log: %w", err)
+	}
+	if s.opts.SyncWrites {
+		if err := s.log.Sync(); err != nil {
+			return fmt.Errorf("storage: sync log: %w", err)
+		}
+	}
+	s.appended++
+	return nil
+}
+
+// maybeCompactLocked compacts once enough entries have been appended. Callers apply the entry to
+// docs first so the snapshot includes it.
+func (s *FileStore) maybeCompactLocked() error {
+	if s.appended < s.opts.CompactEvery {
+		return nil
+	}
+	return s.compactLocked()
+}
+
+// compactLocked writes a snapshot of the current state and truncates the log.
+func (s *FileStore) compactLocked() error {
+	if err := s.writeSnapshot(); err != nil {
+		return err
+	}
+	if err := s.log.Truncate(0); err != nil {
+		return fmt.Errorf("storage: truncate log: %w", err)
+	}
+	if _, err := s.log.Seek(0, 0); err != nil {
+		return fmt.Errorf("storage: rewind log: %w", err)
+	}
+	s.appended = 0
+	return nil
+}
+
+// Put implements Store.
+func (s *FileStore) Put(id string, doc []byte) error {
+	if id == "" {
+		return fmt.Errorf("storage: id is required")
+	}
+	if !json.Valid(doc) {
+		return ErrInvalidDocument
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := append(json.RawMessage(nil), doc...)
+	if err := s.appendLocked(logEntry{Op: opPut, ID: id, Doc: stored}); err != nil {
+		return err
+	}
+	s.docs[id] = stored
+	return s.maybeCompactLocked()
+}
+
+// Delete implements Store.
+func (s *FileStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.docs[id]; !ok {
+		return nil
+	}
+	if err := s.appendLocked(logEntry{Op: opDelete, ID: id}); err != nil {
+		return err
+	}
+	delete(s.docs, id)
+	return s.maybeCompactLocked()
+}
+
+// LoadAll implements Store.
+func (s *FileStore) LoadAll() (map[string][]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil, ErrClosed
+	}
+	out := make(map[string][]byte, len(s.docs))
+	for id, doc := range s.docs {
+		out[id] = append([]byte(nil), doc...)
+	}
+	return out, nil
+}
+
+// Compact folds the log into a new snapshot immediately.
+func (s *FileStore) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrClosed
+	}
+	return s.compactLocked()
+}
+
+// Close implements Store. It syncs the log; the next open replays it.
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if err := s.log.Sync(); err != nil {
+		_ = s.log.Close()
+		return fmt.Errorf("storage: sync log: %w", err)
+	}
+	return s.log.Close()
+}
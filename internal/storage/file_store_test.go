@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func openTestStore(t *testing.T, dir string, opts FileStoreOptions) *FileStore {
+	t.Helper()
+	s, err := OpenFileStore(dir, opts)
+	if err != nil {
+		t.Fatalf("OpenFileStore: %v", err)
+	}
+	return s
+}
+
+func loadAll(t *testing.T, s *FileStore) map[string]string {
+	t.Helper()
+	docs, err := s.LoadAll()
+	if err != nil {
+		t.Fatalf("LoadAll: %v", err)
+	}
+	out := make(map[string]string, len(docs))
+	for id, doc := range docs {
+		out[id] = string(doc)
+	}
+	return out
+}
+
+func TestFileStoreReopenReplaysLog(t *testing.T) {
+	dir := t.TempDir()
+	s := openTestStore(t, dir, FileStoreOptions{})
+	if err := s.Put("run-1", []byte(`{"status":"running"}`)); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := s.Put("run-2", []byte(`{"status":"completed"}`)); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := s.Put("run-1", []byte(`{"status":"failed"}`)); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	if err := s.Delete("run-2"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Delete("missing"); err != nil {
+		t.Fatalf("Delete of missing id should be a no-op, got %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	reopened := openTestStore(t, dir, FileStoreOptions{})
+	defer reopened.Close()
+	got := loadAll(t, reopened)
+	if len(got) != 1 || got["run-1"] != `{"status":"failed"}` {
+		t.Fatalf("unexpected documents after reopen: %v", got)
+	}
+}
+
+func TestFileStoreCompactsIntoSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	s := openTestStore(t, dir, FileStoreOptions{CompactEvery: 3})
+	for _, id := range []string{"a", "b", "c", "d"} {
+		if err := s.Put(id, []byte(`{"id":"`+id+`"}`)); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, snapshotFileName)); err != nil {
+		t.Fatalf("expected snapshot after compaction: %v", err)
+	}
+	logData, err := os.ReadFile(filepath.Join(dir, logFileName))
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	if want := `{"op":"put","id":"d","doc":{"id":"d"}}` + "\n"; string(logData) != want {
+		t.Fatalf("expected only the post-compaction entry in the log, got %q", logData)
+	}
+	_ = s.Close()
+
+	reopened := openTestStore(t, dir, FileStoreOptions{CompactEvery: 3})
+	defer reopened.Close()
+	if got := loadAll(t, reopened); len(got) != 4 || got["a"] != `{"id":"a"}` || got["d"] != `{"id":"d"}` {
+		t.Fatalf("unexpected documents after reopen: %v", got)
+	}
+}
+
+func TestFileStoreDropsTornTail(t *testing.T) {
+	dir := t.TempDir()
+	s := openTestStore(t, dir, FileStoreOptions{})
+	if err := s.Put("run-1", []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	_ = s.Close()
+
+	f, err := os.OpenFile(filepath.Join(dir, logFileName), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	if _, err := f.WriteString(`{"op":"put","id":"run-2","doc":{"ok`); err != nil {
+		t.Fatalf("write torn entry: %v", err)
+	}
+	_ = f.Close()
+
+	reopened := openTestStore(t, dir, FileStoreOptions{})
+	if err := reopened.Put("run-3", []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("Put after torn tail: %v", err)
+	}
+	_ = reopened.Close()
+
+	again := openTestStore(t, dir, FileStoreOptions{})
+	defer again.Close()
+	got := loadAll(t, again)
+	if len(got) != 2 || got["run-1"] == "" || got["run-3"] == "" {
+		t.Fatalf("expected torn entry dropped and later writes kept, got %v", got)
+	}
+}
+
+func TestFileStoreFailsOnCorruptEntryBeforeTail(t *testing.T) {
+	dir := t.TempDir()
+	s := openTestStore(t, dir, FileStoreOptions{})
+	if err := s.Put("run-1", []byte(`{"ok":true}`)); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	_ = s.Close()
+
+	logPath := filepath.Join(dir, logFileName)
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	if _, err := f.WriteString("garbage\n" + `{"op":"put","id":"run-2","doc":{"ok":true}}` + "\n"); err != nil {
+		t.Fatalf("write entries: %v", err)
+	}
+	_ = f.Close()
+	before, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+
+	if _, err := OpenFileStore(dir, FileStoreOptions{}); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected a corrupt log error at line 2, got %v", err)
+	}
+	if after, err := os.ReadFile(logPath); err != nil || string(after) != string(before) {
+		t.Fatalf("a failed open must leave the log untouched, got %q (%v)", after, err)
+	}
+}
+
+func TestFileStoreRejectsInvalidInput(t *testing.T) {
+	s := openTestStore(t, t.TempDir(), FileStoreOptions{SyncWrites: true})
+	if err := s.Put("run-1", []byte(`not json`)); !errors.Is(err, ErrInvalidDocument) {
+		t.Fatalf("expected ErrInvalidDocument, got %v", err)
+	}
+	if err := s.Put("", []byte(`{}`)); err == nil {
+		t.Fatal("expected error for empty id")
+	}
+	_ = s.Close()
+	if err := s.Put("run-1", []byte(`{}`)); !errors.Is(err, ErrClosed) {
+		t.Fatalf("expected ErrClosed after Close, got %v", err)
+	}
+	if _, err := OpenFileStore("", FileStoreOptions{}); err == nil {
+		t.Fatal("expected error for empty dir")
+	}
+}
@@ -0,0 +1,25 @@
+// Package storage provides durable backends for simd run records.
+//
+// Backends store opaque JSON documents keyed by run ID; encoding run records is left to the caller
+// so the storage layer does not depend on simd or protobuf types.
+package storage
+
+import "errors"
+
+// ErrInvalidDocument is returned when a document is not valid JSON.
+var ErrInvalidDocument = errors.New("storage: document is not valid JSON")
+
+// ErrClosed is returned by operations on a closed store.
+var ErrClosed = errors.New("storage: store is closed")
+
+// Store persists run documents keyed by run ID.
+type Store interface {
+	// Put replaces the document stored for id. doc must be valid JSON.
+	Put(id string, doc []byte) error
+	// Delete removes the document for id. Deleting a missing id is not an error.
+	Delete(id string) error
+	// LoadAll returns every stored document keyed by id.
+	LoadAll() (map[string][]byte, error)
+	// Close flushes pending writes and releases resources.
+	Close() error
+}
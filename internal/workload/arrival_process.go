@@ -0,0 +1,176 @@
+package workload
+
+import (
+	"math"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+// NextRenewalArrival returns the first arrival after `after` of a pareto or lognormal arrival, whose
+// inter-arrival times are i.i.d. with mean 1/rate_rps.
+func NextRenewalArrival(arrival config.ArrivalSpec, after time.Time, rng *utils.RandSource) time.Time {
+	mean := 1 / arrival.RateRPS
+	var gap float64
+	switch arrival.Type {
+	case "pareto":
+		alpha := arrival.ParetoAlpha()
+		gap = rng.ParetoFloat64(mean*(alpha-1)/alpha, alpha)
+	default: // lognormal
+		cv := arrival.LognormalCV()
+		sigma2 := math.Log1p(cv * cv)
+		gap = rng.LogNormalFloat64(math.Log(mean)-sigma2/2, math.Sqrt(sigma2))
+	}
+	return after.Add(secondsDuration(gap))
+}
+
+// ArrivalProcess is an arrival process with hidden state that evolves between arrivals (mmpp and
+// self_similar types). Arrivals are generated in order from the process start.
+type ArrivalProcess interface {
+	// Next returns the next arrival time, or a time at or after the end of the run when none is left.
+	Next(rng *utils.RandSource) time.Time
+}
+
+// NewArrivalProcess returns the process of an mmpp or self_similar arrival starting at start, or nil
+// for other types. The initial state is drawn from rng.
+func NewArrivalProcess(arrival config.ArrivalSpec, start, end time.Time, rng *utils.RandSource) ArrivalProcess {
+	switch arrival.Type {
+	case "mmpp":
+		p := &mmppProcess{spec: arrival.MMPP, end: end, t: start}
+		p.enter(arrival.MMPP.InitialState, rng)
+		return p
+	case "self_similar":
+		return newOnOffProcess(arrival, start, end, rng)
+	}
+	return nil
+}
+
+// mmppProcess is a Markov-modulated Poisson process. Because both the arrivals and the state
+// sojourns are exponential, an arrival drawn past the end of the state is discarded and redrawn at
+// the next state's rate from the switch.
+type mmppProcess struct {
+	spec     *config.MMPPSpec
+	end      time.Time
+	t        time.Time // last arrival or state switch
+	state    int
+	stateEnd time.Time
+}
+
+func (p *mmppProcess) enter(state int, rng *utils.RandSource) {
+	p.state = state
+	p.stateEnd = p.t.Add(secondsDuration(rng.ExpFloat64(1 / p.spec.States[state].MeanDurationSeconds)))
+}
+
+func (p *mmppProcess) Next(rng *utils.RandSource) time.Time {
+	for p.t.Before(p.end) {
+		if rate := p.spec.States[p.state].RateRPS; rate > 0 {
+			at := p.t.Add(secondsDuration(math.Max(rng.ExpFloat64(rate), minInterArrivalSeconds)))
+			if at.Before(p.stateEnd) {
+				p.t = at
+				return at
+			}
+		}
+		p.t = p.stateEnd
+		p.enter(p.spec.NextState(p.state, rng.Float64()), rng)
+	}
+	return p.end
+}
+
+// onOffProcess superposes on/off sources with Pareto on and off periods of equal mean. An on source
+// emits Poisson arrivals; with tail index alpha in (1, 2) the aggregate is asymptotically
+// self-similar with Hurst parameter (3 - alpha) / 2 (Willinger et al.). Each source sends at twice
+// its share of the mean rate, as it is on half of the time.
+type onOffProcess struct {
+	end      time.Time
+	t        time.Time // last arrival or switch
+	onRate   float64
+	periodXm float64 // Pareto scale of the on and off periods
+	alpha    float64
+	on       []bool
+	switchAt []time.Time
+	active   int
+}
+
+func newOnOffProcess(arrival config.ArrivalSpec, start, end time.Time, rng *utils.RandSource) *onOffProcess {
+	ss := arrival.SelfSimilar
+	n := ss.SourceCount()
+	alpha := ss.PeriodShape()
+	p := &onOffProcess{
+		end:      end,
+		t:        start,
+		onRate:   2 * arrival.RateRPS / float64(n),
+		periodXm: ss.PeriodSeconds() * (alpha - 1) / alpha,
+		alpha:    alpha,
+		on:       make([]bool, n),
+		switchAt: make([]time.Time, n),
+	}
+	// Sources start on or off with equal probability, at a random phase of their first period.
+	for i := range p.on {
+		p.on[i] = rng.BernoulliBool(0.5)
+		if p.on[i] {
+			p.active++
+		}
+		p.switchAt[i] = start.Add(secondsDuration(rng.Float64() * rng.ParetoFloat64(p.periodXm, alpha)))
+	}
+	return p
+}
+
+func (p *onOffProcess) Next(rng *utils.RandSource) time.Time {
+	for p.t.Before(p.end) {
+		next := 0
+		for i := range p.switchAt {
+			if p.switchAt[i].Before(p.switchAt[next]) {
+				next = i
+			}
+		}
+		if p.active > 0 {
+			at := p.t.Add(secondsDuration(rng.ExpFloat64(float64(p.active) * p.onRate)))
+			if at.Before(p.switchAt[next]) {
+				p.t = at
+				return at
+			}
+		}
+		p.t = p.switchAt[next]
+		p.on[next] = !p.on[next]
+		if p.on[next] {
+			p.active++
+		} else {
+			p.active--
+		}
+		p.switchAt[next] = p.t.Add(secondsDuration(rng.ParetoFloat64(p.periodXm, p.alpha)))
+	}
+	return p.end
+}
+
+// scheduleRenewalArrivals schedules pareto or lognormal arrivals.
+func (g *Generator) scheduleRenewalArrivals(eng *engine.Engine, startTime, endTime time.Time, arrival config.ArrivalSpec, serviceID, endpointPath string) error {
+	for t := NextRenewalArrival(arrival, startTime, g.rng); t.Before(endTime); t = NextRenewalArrival(arrival, t, g.rng) {
+		eng.ScheduleAt(engine.EventTypeRequestArrival, t, nil, serviceID, map[string]interface{}{
+			"service_id":    serviceID,
+			"endpoint_path": endpointPath,
+		})
+	}
+	return nil
+}
+
+// scheduleProcessArrivals schedules mmpp or self_similar arrivals.
+func (g *Generator) scheduleProcessArrivals(eng *engine.Engine, startTime, endTime time.Time, arrival config.ArrivalSpec, serviceID, endpointPath string) error {
+	p := NewArrivalProcess(arrival, startTime, endTime, g.rng)
+	for t := p.Next(g.rng); t.Before(endTime); t = p.Next(g.rng) {
+		eng.ScheduleAt(engine.EventTypeRequestArrival, t, nil, serviceID, map[string]interface{}{
+			"service_id":    serviceID,
+			"endpoint_path": endpointPath,
+		})
+	}
+	return nil
+}
+
+// secondsDuration converts seconds to a duration, saturating heavy-tailed draws that overflow it.
+func secondsDuration(s float64) time.Duration {
+	if s >= float64(math.MaxInt64)/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(s * float64(time.Second))
+}
@@ -0,0 +1,119 @@
+package workload
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+// windowCounts counts arrivals in consecutive windows of the run.
+func windowCounts(times []time.Time, start time.Time, window time.Duration, n int) []float64 {
+	counts := make([]float64, n)
+	for _, at := range times {
+		if i := int(at.Sub(start) / window); i < n {
+			counts[i]++
+		}
+	}
+	return counts
+}
+
+// dispersion is the variance-to-mean ratio of window counts (1 for a Poisson process).
+func dispersion(counts []float64) float64 {
+	return math.Pow(utils.StdDev(counts), 2) / utils.Mean(counts)
+}
+
+func TestNextRenewalArrivalMeanRate(t *testing.T) {
+	start := time.Unix(1000, 0)
+	for _, arrival := range []config.ArrivalSpec{
+		{Type: "pareto", RateRPS: 20, ParetoShape: 2.5},
+		{Type: "lognormal", RateRPS: 20, InterarrivalCV: 2},
+		{Type: "lognormal", RateRPS: 20}, // cv 1, like poisson
+	} {
+		rng := utils.NewRandSource(7)
+		end := start.Add(1000 * time.Second)
+		n := 0
+		for at := NextRenewalArrival(arrival, start, rng); at.Before(end); at = NextRenewalArrival(arrival, at, rng) {
+			n++
+		}
+		if math.Abs(float64(n)-20000) > 1000 {
+			t.Fatalf("%+v: expected about 20000 arrivals at 20 rps for 1000s, got %d", arrival, n)
+		}
+	}
+}
+
+func TestMMPPProcess(t *testing.T) {
+	start := time.Unix(1000, 0)
+	end := start.Add(3000 * time.Second)
+	arrival := config.ArrivalSpec{
+		Type: "mmpp",
+		MMPP: &config.MMPPSpec{
+			States:      []config.MMPPState{{RateRPS: 10, MeanDurationSeconds: 10}, {RateRPS: 100, MeanDurationSeconds: 5}},
+			Transitions: [][]float64{{0, 1}, {1, 0}},
+		},
+	}
+	rng := utils.NewRandSource(11)
+	p := NewArrivalProcess(arrival, start, end, rng)
+	var times []time.Time
+	for at := p.Next(rng); at.Before(end); at = p.Next(rng) {
+		if len(times) > 0 && at.Before(times[len(times)-1]) {
+			t.Fatalf("arrivals out of order at %d", len(times))
+		}
+		times = append(times, at)
+	}
+	// Mean rate is (10*10 + 100*5) / 15 = 40 rps.
+	if n := float64(len(times)); math.Abs(n-120000) > 12000 {
+		t.Fatalf("expected about 120000 arrivals, got %v", n)
+	}
+	if d := dispersion(windowCounts(times, start, time.Second, 3000)); d < 10 {
+		t.Fatalf("expected state switching to overdisperse 1s counts, got dispersion %v", d)
+	}
+
+	rng2 := utils.NewRandSource(11)
+	p2 := NewArrivalProcess(arrival, start, end, rng2)
+	for i := 0; i < 1000; i++ {
+		if at := p2.Next(rng2); !at.Equal(times[i]) {
+			t.Fatalf("expected the same seed to reproduce arrival %d", i)
+		}
+	}
+}
+
+func TestSelfSimilarProcess(t *testing.T) {
+	start := time.Unix(1000, 0)
+	end := start.Add(2000 * time.Second)
+	arrival := config.ArrivalSpec{Type: "self_similar", RateRPS: 50, SelfSimilar: &config.SelfSimilarSpec{Hurst: 0.9}}
+	rng := utils.NewRandSource(5)
+	p := NewArrivalProcess(arrival, start, end, rng)
+	var times []time.Time
+	for at := p.Next(rng); at.Before(end); at = p.Next(rng) {
+		times = append(times, at)
+	}
+	if n := float64(len(times)); math.Abs(n-100000) > 20000 {
+		t.Fatalf("expected about 100000 arrivals at a mean of 50 rps, got %v", n)
+	}
+	// Long-range dependence keeps counts bursty at coarse time scales, where Poisson counts average out.
+	if d := dispersion(windowCounts(times, start, 10*time.Second, 200)); d < 3 {
+		t.Fatalf("expected overdispersed 10s counts, got dispersion %v", d)
+	}
+}
+
+func TestGeneratorHeavyTailedArrivals(t *testing.T) {
+	startTime := time.Now()
+	endTime := startTime.Add(20 * time.Second)
+	for _, arrival := range []config.ArrivalSpec{
+		{Type: "pareto", RateRPS: 50, ParetoShape: 3},
+		{Type: "mmpp", MMPP: &config.MMPPSpec{States: []config.MMPPState{{RateRPS: 50, MeanDurationSeconds: 1}}}},
+		{Type: "self_similar", RateRPS: 50},
+	} {
+		eng := engine.NewEngine("test-run")
+		if err := NewGenerator(12345).ScheduleArrivals(eng, startTime, endTime, arrival, "svc1", "/test"); err != nil {
+			t.Fatalf("%s: %v", arrival.Type, err)
+		}
+		if n := eng.GetEventQueue().Size(); n < 500 || n > 1500 {
+			t.Fatalf("%s: expected about 1000 arrivals, got %d", arrival.Type, n)
+		}
+	}
+}
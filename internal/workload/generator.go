@@ -29,6 +29,9 @@ func NewGenerator(seed int64) *Generator {
 func (g *Generator) ScheduleArrivals(eng *engine.Engine, startTime, endTime time.Time, arrival config.ArrivalSpec, serviceID, endpointPath string) error {
 	switch arrival.Type {
 	case "poisson", "exponential":
+		if arrival.RateProfile != nil {
+			return g.scheduleProfileArrivals(eng, startTime, endTime, arrival, serviceID, endpointPath)
+		}
 		return g.schedulePoissonArrivals(eng, startTime, endTime, arrival.RateRPS, serviceID, endpointPath)
 	case "uniform":
 		return g.scheduleUniformArrivals(eng, startTime, endTime, arrival.RateRPS, serviceID, endpointPath)
@@ -43,6 +46,14 @@ func (g *Generator) ScheduleArrivals(eng *engine.Engine, startTime, endTime time
 		return g.scheduleBurstyArrivals(eng, startTime, endTime, arrival, serviceID, endpointPath)
 	case "constant":
 		return g.scheduleConstantArrivals(eng, startTime, endTime, arrival.RateRPS, serviceID, endpointPath)
+	case "pareto", "lognormal":
+		return g.scheduleRenewalArrivals(eng, startTime, endTime, arrival, serviceID, endpointPath)
+	case "mmpp", "self_similar":
+		return g.scheduleProcessArrivals(eng, startTime, endTime, arrival, serviceID, endpointPath)
+	case "replay":
+		return g.scheduleReplayArrivals(eng, startTime, endTime, arrival, serviceID, endpointPath)
+	case "closed":
+		return fmt.Errorf("closed arrivals depend on request completions and cannot be pre-scheduled")
 	default:
 		return fmt.Errorf("unsupported arrival type %q", arrival.Type)
 	}
@@ -0,0 +1,45 @@
+package workload
+
+import (
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+// NextProfileArrival returns the first arrival after `after` of a poisson arrival with a rate profile,
+// or end when none falls before end. It thins a homogeneous Poisson process at the profile's peak rate
+// (Lewis-Shedler): a candidate at t is kept with probability rate(t)/peak. The profile clock starts at epoch.
+func NextProfileArrival(arrival config.ArrivalSpec, epoch, after, end time.Time, rng *utils.RandSource) time.Time {
+	profile := arrival.RateProfile
+	peak := profile.MaxRate(arrival.RateRPS)
+	if peak <= 0 {
+		return end
+	}
+	t := after
+	for {
+		interArrivalSeconds := rng.ExpFloat64(peak)
+		if interArrivalSeconds < minInterArrivalSeconds {
+			interArrivalSeconds = minInterArrivalSeconds
+		}
+		t = t.Add(time.Duration(interArrivalSeconds * float64(time.Second)))
+		if !t.Before(end) {
+			return end
+		}
+		if rng.Float64()*peak < profile.RateAt(t.Sub(epoch).Seconds(), arrival.RateRPS) {
+			return t
+		}
+	}
+}
+
+// scheduleProfileArrivals schedules non-homogeneous Poisson arrivals following arrival.RateProfile.
+func (g *Generator) scheduleProfileArrivals(eng *engine.Engine, startTime, endTime time.Time, arrival config.ArrivalSpec, serviceID, endpointPath string) error {
+	for t := NextProfileArrival(arrival, startTime, startTime, endTime, g.rng); t.Before(endTime); t = NextProfileArrival(arrival, startTime, t, endTime, g.rng) {
+		eng.ScheduleAt(engine.EventTypeRequestArrival, t, nil, serviceID, map[string]interface{}{
+			"service_id":    serviceID,
+			"endpoint_path": endpointPath,
+		})
+	}
+	return nil
+}
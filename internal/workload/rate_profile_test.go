@@ -0,0 +1,66 @@
+package workload
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+func TestNextProfileArrivalFollowsSchedule(t *testing.T) {
+	epoch := time.Unix(1000, 0)
+	end := epoch.Add(200 * time.Second)
+	arrival := config.ArrivalSpec{
+		Type: "poisson",
+		RateProfile: &config.RateProfile{
+			Interpolation: "step",
+			Points:        []config.RatePoint{{AtSeconds: 0, RateRPS: 0}, {AtSeconds: 100, RateRPS: 50}},
+		},
+	}
+	rng := utils.NewRandSource(3)
+	var before, after int
+	for at := NextProfileArrival(arrival, epoch, epoch, end, rng); at.Before(end); at = NextProfileArrival(arrival, epoch, at, end, rng) {
+		if at.Before(epoch.Add(100 * time.Second)) {
+			before++
+		} else {
+			after++
+		}
+	}
+	if before != 0 {
+		t.Fatalf("expected no arrivals while the rate is zero, got %d", before)
+	}
+	if math.Abs(float64(after)-5000) > 300 {
+		t.Fatalf("expected about 5000 arrivals at 50 rps for 100s, got %d", after)
+	}
+}
+
+func TestGeneratorProfileArrivalsWithSpike(t *testing.T) {
+	eng := engine.NewEngine("test-run")
+	g := NewGenerator(12345)
+	startTime := time.Now()
+	endTime := startTime.Add(20 * time.Second)
+	arrival := config.ArrivalSpec{
+		Type:        "poisson",
+		RateRPS:     20,
+		RateProfile: &config.RateProfile{Spikes: []config.RateSpike{{AtSeconds: 10, DurationSeconds: 10, Multiplier: 5}}},
+	}
+	if err := g.ScheduleArrivals(eng, startTime, endTime, arrival, "svc1", "/test"); err != nil {
+		t.Fatalf("ScheduleArrivals error: %v", err)
+	}
+	q := eng.GetEventQueue()
+	var quiet, spike int
+	for q.Size() > 0 {
+		evt := q.Next()
+		if evt.Time.Before(startTime.Add(10 * time.Second)) {
+			quiet++
+		} else {
+			spike++
+		}
+	}
+	if math.Abs(float64(quiet)-200) > 60 || math.Abs(float64(spike)-1000) > 130 {
+		t.Fatalf("expected about 200 arrivals before and 1000 during the spike, got %d and %d", quiet, spike)
+	}
+}
@@ -0,0 +1,139 @@
+package workload
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+// ReplayArrival is one arrival produced from a replay trace record.
+type ReplayArrival struct {
+	Time   time.Time
+	Record *config.ReplayRecord
+}
+
+// ReplayCursor walks a replay trace in time order, applying time scaling and amplification.
+// Record offsets are divided by the time scale and anchored at the epoch. Amplification a sends
+// floor(a) copies of each record plus one more with probability frac(a); copies beyond the first
+// are spread uniformly over the gap to the next record so that the trace's rate profile is kept.
+type ReplayCursor struct {
+	records       []config.ReplayRecord
+	epoch         time.Time
+	timeScale     float64
+	amplification float64
+	next          int
+	pending       []ReplayArrival
+}
+
+// NewReplayCursor returns a cursor over records starting at epoch. Zero time_scale and
+// amplification default to 1.
+func NewReplayCursor(records []config.ReplayRecord, arrival config.ArrivalSpec, epoch time.Time) *ReplayCursor {
+	c := &ReplayCursor{
+		records:       records,
+		epoch:         epoch,
+		timeScale:     arrival.TimeScale,
+		amplification: arrival.Amplification,
+	}
+	if c.timeScale <= 0 {
+		c.timeScale = 1
+	}
+	if c.amplification <= 0 {
+		c.amplification = 1
+	}
+	return c
+}
+
+// Next returns the next arrival, drawing amplification randomness from rng; ok is false once the
+// trace is exhausted.
+func (c *ReplayCursor) Next(rng *utils.RandSource) (arrival ReplayArrival, ok bool) {
+	for len(c.pending) == 0 {
+		if c.next >= len(c.records) {
+			return ReplayArrival{}, false
+		}
+		c.expand(rng)
+	}
+	arrival = c.pending[0]
+	c.pending = c.pending[1:]
+	return arrival, true
+}
+
+// expand queues the amplified copies of the next record.
+func (c *ReplayCursor) expand(rng *utils.RandSource) {
+	rec := &c.records[c.next]
+	c.next++
+	whole, frac := math.Modf(c.amplification)
+	copies := int(whole)
+	if frac > 0 && rng.BernoulliBool(frac) {
+		copies++
+	}
+	if copies == 0 {
+		return
+	}
+	at := c.scaled(rec.Offset)
+	c.pending = append(c.pending, ReplayArrival{Time: at, Record: rec})
+	if copies == 1 {
+		return
+	}
+	var gap time.Duration
+	if c.next < len(c.records) {
+		gap = c.scaled(c.records[c.next].Offset).Sub(at)
+	}
+	for i := 1; i < copies; i++ {
+		jitter := time.Duration(rng.UniformFloat64(0, float64(gap)))
+		c.pending = append(c.pending, ReplayArrival{Time: at.Add(jitter), Record: rec})
+	}
+	sort.SliceStable(c.pending, func(i, j int) bool { return c.pending[i].Time.Before(c.pending[j].Time) })
+}
+
+func (c *ReplayCursor) scaled(offset time.Duration) time.Time {
+	return c.epoch.Add(time.Duration(float64(offset) / c.timeScale))
+}
+
+// CountReplayArrivals returns the expected number of arrivals a replay trace produces within
+// duration of its start (amplification applied in expectation).
+func CountReplayArrivals(records []config.ReplayRecord, arrival config.ArrivalSpec, duration time.Duration) float64 {
+	c := NewReplayCursor(records, arrival, time.Time{})
+	n := sort.Search(len(records), func(i int) bool { return !c.scaled(records[i].Offset).Before(c.epoch.Add(duration)) })
+	return float64(n) * c.amplification
+}
+
+// replayArrivalEventData is the arrival event data of a replay arrival: the record's target (or the
+// pattern target) and its metadata.
+func replayArrivalEventData(a ReplayArrival, serviceID, endpointPath string) (string, map[string]interface{}) {
+	if a.Record.ServiceID != "" {
+		serviceID, endpointPath = a.Record.ServiceID, a.Record.EndpointPath
+	}
+	data := map[string]interface{}{
+		"service_id":    serviceID,
+		"endpoint_path": endpointPath,
+	}
+	if len(a.Record.Metadata) > 0 {
+		md := make(map[string]interface{}, len(a.Record.Metadata))
+		for k, v := range a.Record.Metadata {
+			md[k] = v
+		}
+		data["metadata"] = md
+	}
+	return serviceID, data
+}
+
+// scheduleReplayArrivals schedules the arrivals of a replay trace that fall in [startTime, endTime).
+func (g *Generator) scheduleReplayArrivals(eng *engine.Engine, startTime, endTime time.Time, arrival config.ArrivalSpec, serviceID, endpointPath string) error {
+	records, err := config.LoadReplayTrace(arrival)
+	if err != nil {
+		return err
+	}
+	cursor := NewReplayCursor(records, arrival, startTime)
+	for {
+		a, ok := cursor.Next(g.rng)
+		if !ok || !a.Time.Before(endTime) {
+			return nil
+		}
+		target, data := replayArrivalEventData(a, serviceID, endpointPath)
+		eng.ScheduleAt(engine.EventTypeRequestArrival, a.Time, nil, target, data)
+	}
+}
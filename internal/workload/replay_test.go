@@ -0,0 +1,114 @@
+package workload
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/GoSim-25-26J-441/simulation-core/internal/engine"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/config"
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+func replayRecords(offsets ...time.Duration) []config.ReplayRecord {
+	recs := make([]config.ReplayRecord, len(offsets))
+	for i, o := range offsets {
+		recs[i] = config.ReplayRecord{Offset: o}
+	}
+	return recs
+}
+
+func drainReplay(c *ReplayCursor, rng *utils.RandSource) []ReplayArrival {
+	var out []ReplayArrival
+	for {
+		a, ok := c.Next(rng)
+		if !ok {
+			return out
+		}
+		out = append(out, a)
+	}
+}
+
+func TestReplayCursorTimeScale(t *testing.T) {
+	epoch := time.Unix(1000, 0)
+	recs := replayRecords(0, time.Second, 3*time.Second)
+	got := drainReplay(NewReplayCursor(recs, config.ArrivalSpec{Type: "replay", TimeScale: 2}, epoch), utils.NewRandSource(1))
+	want := []time.Duration{0, 500 * time.Millisecond, 1500 * time.Millisecond}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d arrivals, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if got[i].Time != epoch.Add(want[i]) || got[i].Record != &recs[i] {
+			t.Fatalf("arrival %d: got %v, want %v", i, got[i].Time.Sub(epoch), want[i])
+		}
+	}
+}
+
+func TestReplayCursorAmplification(t *testing.T) {
+	epoch := time.Unix(1000, 0)
+	offsets := make([]time.Duration, 1000)
+	for i := range offsets {
+		offsets[i] = time.Duration(i) * 10 * time.Millisecond
+	}
+	recs := replayRecords(offsets...)
+
+	tripled := drainReplay(NewReplayCursor(recs, config.ArrivalSpec{Type: "replay", Amplification: 3}, epoch), utils.NewRandSource(7))
+	if len(tripled) != 3000 {
+		t.Fatalf("expected 3000 arrivals at 3x, got %d", len(tripled))
+	}
+	for i := 1; i < len(tripled); i++ {
+		if tripled[i].Time.Before(tripled[i-1].Time) {
+			t.Fatalf("arrivals out of order at %d: %v before %v", i, tripled[i].Time, tripled[i-1].Time)
+		}
+	}
+	// Copies stay within the gap to the next record.
+	last := tripled[len(tripled)-1]
+	if last.Time != epoch.Add(offsets[len(offsets)-1]) {
+		t.Fatalf("expected the last record's copies at its own time, got %v", last.Time.Sub(epoch))
+	}
+
+	half := drainReplay(NewReplayCursor(recs, config.ArrivalSpec{Type: "replay", Amplification: 0.5}, epoch), utils.NewRandSource(7))
+	if math.Abs(float64(len(half))-500) > 60 {
+		t.Fatalf("expected about 500 arrivals at 0.5x, got %d", len(half))
+	}
+	again := drainReplay(NewReplayCursor(recs, config.ArrivalSpec{Type: "replay", Amplification: 0.5}, epoch), utils.NewRandSource(7))
+	if len(again) != len(half) {
+		t.Fatalf("same seed produced %d and %d arrivals", len(half), len(again))
+	}
+}
+
+func TestCountReplayArrivals(t *testing.T) {
+	recs := replayRecords(0, time.Second, 2*time.Second, 3*time.Second)
+	arrival := config.ArrivalSpec{Type: "replay", TimeScale: 2, Amplification: 1.5}
+	// At 2x the records land at 0, 0.5, 1 and 1.5s; two fall before 1s.
+	if got := CountReplayArrivals(recs, arrival, time.Second); got != 3 {
+		t.Fatalf("expected 3 expected arrivals, got %f", got)
+	}
+}
+
+func TestGeneratorReplayArrivals(t *testing.T) {
+	eng := engine.NewEngine("test-run")
+	g := NewGenerator(12345)
+	startTime := time.Now()
+	endTime := startTime.Add(2 * time.Second)
+
+	arrival := config.ArrivalSpec{
+		Type:         "replay",
+		ReplayFormat: config.ReplayFormatCSV,
+		ReplayData:   "timestamp,target,tenant_id\n10,,t1\n10.5,svc2:/other,t2\n13,,t3\n",
+	}
+	if err := g.ScheduleArrivals(eng, startTime, endTime, arrival, "svc1", "/test"); err != nil {
+		t.Fatalf("ScheduleArrivals error: %v", err)
+	}
+	q := eng.GetEventQueue()
+	if q.Size() != 2 {
+		t.Fatalf("expected the 2 records within the horizon, got %d", q.Size())
+	}
+	first, second := q.Next(), q.Next()
+	if first.Data["service_id"] != "svc1" || first.Data["metadata"].(map[string]interface{})["tenant_id"] != "t1" {
+		t.Fatalf("unexpected first arrival %+v", first.Data)
+	}
+	if second.ServiceID != "svc2" || second.Data["endpoint_path"] != "/other" || !second.Time.Equal(startTime.Add(500*time.Millisecond)) {
+		t.Fatalf("unexpected second arrival at %v: %+v", second.Time.Sub(startTime), second.Data)
+	}
+}
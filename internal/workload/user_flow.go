@@ -21,8 +21,11 @@ type UserFlow struct {
 type FlowStep struct {
 	ServiceID   string
 	Endpoint    string
-	DelayMs     float64 // Delay before this step (relative to previous step)
-	Probability float64 // Probability of taking this step (for branching)
+	DelayMs     float64            // Delay before this step (relative to previous step)
+	ThinkTimeMs config.LatencySpec // Sampled pause before this step, added to DelayMs
+	// Probability of taking this step (for branching). ScheduleUserFlow skips a step not taken;
+	// a session advanced with NextStep ends there.
+	Probability float64
 }
 
 // UserFlowGenerator generates user flows and schedules their requests
@@ -61,9 +64,7 @@ func (g *UserFlowGenerator) ScheduleUserFlow(eng *engine.Engine, flowID string,
 		// If probability >= 1.0, always take this step
 
 		// Add delay before this step
-		if step.DelayMs > 0 {
-			currentTime = currentTime.Add(time.Duration(step.DelayMs) * time.Millisecond)
-		}
+		currentTime = currentTime.Add(g.stepDelay(step))
 
 		// Schedule the request arrival
 		eng.ScheduleAt(engine.EventTypeRequestArrival, currentTime, nil, step.ServiceID, map[string]interface{}{
@@ -77,6 +78,33 @@ func (g *UserFlowGenerator) ScheduleUserFlow(eng *engine.Engine, flowID string,
 	return nil
 }
 
+// NextStep decides how a session goes on once step `finished` of steps has completed: it returns the
+// next step and the delay before it is issued, or ok=false when the session ends because no step is left
+// or the next step is not taken.
+func (g *UserFlowGenerator) NextStep(steps []FlowStep, finished int) (next int, delay time.Duration, ok bool) {
+	next = finished + 1
+	if next >= len(steps) {
+		return 0, 0, false
+	}
+	step := steps[next]
+	if step.Probability <= 0 || (step.Probability < 1.0 && !g.rng.BernoulliBool(step.Probability)) {
+		return 0, 0, false
+	}
+	return next, g.stepDelay(step), true
+}
+
+// stepDelay returns the delay before a step: its fixed delay plus a sampled think time.
+func (g *UserFlowGenerator) stepDelay(step FlowStep) time.Duration {
+	var d time.Duration
+	if step.DelayMs > 0 {
+		d = time.Duration(step.DelayMs) * time.Millisecond
+	}
+	if step.ThinkTimeMs.IsSet() {
+		d += time.Duration(step.ThinkTimeMs.Sample(g.rng) * float64(time.Millisecond))
+	}
+	return d
+}
+
 // ScheduleUserFlows schedules multiple user flows based on arrival pattern
 func (g *UserFlowGenerator) ScheduleUserFlows(eng *engine.Engine, startTime, endTime time.Time, arrival config.ArrivalSpec, flowID string, steps []FlowStep) error {
 	// Generate user flow arrivals based on arrival pattern
@@ -184,3 +184,55 @@ func TestUserFlowGeneratorScheduleUserFlowsArrivalTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestUserFlowGeneratorNextStep(t *testing.T) {
+	g := NewUserFlowGenerator(12345)
+	steps := []FlowStep{
+		{ServiceID: "auth", Endpoint: "/login", Probability: 1.0},
+		{ServiceID: "user", Endpoint: "/profile", DelayMs: 100, ThinkTimeMs: config.LatencySpec{Mean: 50}, Probability: 1.0},
+		{ServiceID: "order", Endpoint: "/list", Probability: 0.0},
+	}
+
+	next, delay, ok := g.NextStep(steps, 0)
+	if !ok || next != 1 || delay != 150*time.Millisecond {
+		t.Fatalf("expected step 1 after 150ms, got step %d after %v (ok=%v)", next, delay, ok)
+	}
+	if _, _, ok := g.NextStep(steps, 1); ok {
+		t.Fatalf("expected a step with probability 0 to end the session")
+	}
+	if _, _, ok := g.NextStep(steps, 2); ok {
+		t.Fatalf("expected the session to end after the last step")
+	}
+}
+
+func TestUserFlowGeneratorNextStepProbability(t *testing.T) {
+	g := NewUserFlowGenerator(7)
+	steps := []FlowStep{{ServiceID: "a", Probability: 1.0}, {ServiceID: "b", Probability: 0.25}}
+	taken := 0
+	for i := 0; i < 4000; i++ {
+		if _, _, ok := g.NextStep(steps, 0); ok {
+			taken++
+		}
+	}
+	if taken < 850 || taken > 1150 {
+		t.Fatalf("expected about a quarter of sessions to continue, got %d of 4000", taken)
+	}
+}
+
+func TestUserFlowGeneratorScheduleUserFlowThinkTime(t *testing.T) {
+	eng := engine.NewEngine("test-run")
+	g := NewUserFlowGenerator(12345)
+	steps := []FlowStep{
+		{ServiceID: "auth", Endpoint: "/login", Probability: 1.0},
+		{ServiceID: "user", Endpoint: "/profile", DelayMs: 100, ThinkTimeMs: config.LatencySpec{Mean: 20}, Probability: 1.0},
+	}
+	startTime := time.Now()
+	if err := g.ScheduleUserFlow(eng, "flow-1", steps, startTime); err != nil {
+		t.Fatalf("ScheduleUserFlow error: %v", err)
+	}
+	eng.GetEventQueue().Next()
+	evt := eng.GetEventQueue().Next()
+	if evt == nil || !evt.Time.Equal(startTime.Add(120*time.Millisecond)) {
+		t.Fatalf("expected the second step after its delay and think time, got %+v", evt)
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"strings"
 )
 
@@ -16,9 +17,71 @@ func NormalizeArrivalType(t string) (string, error) {
 		t = "bursty"
 	}
 	switch t {
-	case "poisson", "exponential", "uniform", "normal", "gaussian", "bursty", "constant":
+	case "poisson", "exponential", "uniform", "normal", "gaussian", "bursty", "constant",
+		"pareto", "lognormal", "mmpp", "self_similar", "replay", "closed":
 		return t, nil
 	default:
-		return "", fmt.Errorf("invalid arrival type %q (supported: poisson, exponential, uniform, normal, gaussian, bursty, constant; alias burst -> bursty)", t)
+		return "", fmt.Errorf("invalid arrival type %q (supported: poisson, exponential, uniform, normal, gaussian, bursty, constant, pareto, lognormal, mmpp, self_similar, replay, closed; alias burst -> bursty)", t)
 	}
 }
+
+// ValidateArrivalSpec normalizes and checks an arrival outside a scenario, such as the arrival of
+// a live workload update. Replay targets are not checked.
+func ValidateArrivalSpec(a *ArrivalSpec) error {
+	return validateArrival(a, nil, nil)
+}
+
+// validateArrival normalizes the arrival type and checks the fields that type uses.
+func validateArrival(a *ArrivalSpec, serviceIDs, endpointRef map[string]bool) error {
+	if a.Type == "" {
+		return fmt.Errorf("arrival type cannot be empty")
+	}
+	norm, err := NormalizeArrivalType(a.Type)
+	if err != nil {
+		return err
+	}
+	a.Type = norm
+	switch {
+	case a.RateProfile != nil:
+		if norm != "poisson" && norm != "exponential" {
+			return fmt.Errorf("rate_profile requires arrival type poisson, got %s", norm)
+		}
+		return validateRateProfile(a.RateProfile, a.RateRPS)
+	case a.MMPP != nil && norm != "mmpp":
+		return fmt.Errorf("mmpp requires arrival type mmpp, got %s", norm)
+	case a.SelfSimilar != nil && norm != "self_similar":
+		return fmt.Errorf("self_similar settings require arrival type self_similar, got %s", norm)
+	case norm == "closed":
+		return validateClosedArrival(a)
+	case norm == "replay":
+		return validateReplayArrival(a, serviceIDs, endpointRef)
+	case norm == "mmpp":
+		return validateMMPP(a.MMPP)
+	case a.RateRPS <= 0:
+		return fmt.Errorf("arrival rate_rps must be positive")
+	case norm == "pareto" && a.ParetoShape != 0 && !(a.ParetoShape > 1 && !math.IsInf(a.ParetoShape, 1)):
+		return fmt.Errorf("pareto_shape must be greater than 1 for a finite mean inter-arrival time, got %v", a.ParetoShape)
+	case norm == "lognormal" && !isFiniteNonNegative(a.InterarrivalCV):
+		return fmt.Errorf("interarrival_cv must be non-negative, got %v", a.InterarrivalCV)
+	case norm == "self_similar":
+		return validateSelfSimilar(a.SelfSimilar)
+	}
+	return nil
+}
+
+// validateClosedArrival checks a closed-loop arrival: a positive user count and non-negative think time and ramp-up.
+func validateClosedArrival(a *ArrivalSpec) error {
+	if a.Users <= 0 {
+		return fmt.Errorf("closed arrival users must be positive, got %d", a.Users)
+	}
+	if !isFiniteNonNegative(a.ThinkTimeMs.Mean) || !isFiniteNonNegative(a.ThinkTimeMs.Sigma) {
+		return fmt.Errorf("closed arrival think_time_ms mean and sigma must be non-negative")
+	}
+	if err := validateLatencySpec(a.ThinkTimeMs); err != nil {
+		return fmt.Errorf("closed arrival think_time_ms: %w", err)
+	}
+	if !isFiniteNonNegative(a.RampUpSeconds) {
+		return fmt.Errorf("closed arrival ramp_up_seconds must be non-negative, got %v", a.RampUpSeconds)
+	}
+	return nil
+}
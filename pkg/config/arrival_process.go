@@ -0,0 +1,224 @@
+package config
+
+import (
+	"fmt"
+	"math"
+)
+
+// Defaults of the heavy-tailed and correlated arrival types.
+const (
+	DefaultParetoShape              = 1.5
+	DefaultInterarrivalCV           = 1.0
+	DefaultHurst                    = 0.8
+	DefaultSelfSimilarSources       = 32
+	DefaultSelfSimilarPeriodSeconds = 1.0
+
+	maxSelfSimilarSources      = 10000
+	mmppTransitionRowTolerance = 1e-6
+	// mmppStationaryIterations is the number of jump-chain steps MeanRate averages over.
+	mmppStationaryIterations = 1000
+)
+
+// ParetoAlpha returns the tail index of a pareto arrival (pareto_shape or DefaultParetoShape).
+func (a *ArrivalSpec) ParetoAlpha() float64 {
+	if a.ParetoShape > 0 {
+		return a.ParetoShape
+	}
+	return DefaultParetoShape
+}
+
+// LognormalCV returns the inter-arrival coefficient of variation of a lognormal arrival.
+// Zero means unset and yields DefaultInterarrivalCV.
+func (a *ArrivalSpec) LognormalCV() float64 {
+	if a.InterarrivalCV > 0 {
+		return a.InterarrivalCV
+	}
+	return DefaultInterarrivalCV
+}
+
+// HurstParameter returns the Hurst parameter (DefaultHurst when s is nil or hurst is unset).
+func (s *SelfSimilarSpec) HurstParameter() float64 {
+	if s == nil || s.Hurst == 0 {
+		return DefaultHurst
+	}
+	return s.Hurst
+}
+
+// SourceCount returns the number of on/off sources (DefaultSelfSimilarSources when unset).
+func (s *SelfSimilarSpec) SourceCount() int {
+	if s == nil || s.Sources == 0 {
+		return DefaultSelfSimilarSources
+	}
+	return s.Sources
+}
+
+// PeriodSeconds returns the mean on and off period (DefaultSelfSimilarPeriodSeconds when unset).
+func (s *SelfSimilarSpec) PeriodSeconds() float64 {
+	if s == nil || s.MeanPeriodSeconds == 0 {
+		return DefaultSelfSimilarPeriodSeconds
+	}
+	return s.MeanPeriodSeconds
+}
+
+// PeriodShape returns the Pareto shape of the on and off periods that yields the Hurst parameter:
+// the aggregate of on/off sources with tail index alpha in (1, 2) has H = (3 - alpha) / 2.
+func (s *SelfSimilarSpec) PeriodShape() float64 {
+	return 3 - 2*s.HurstParameter()
+}
+
+// NextState returns the state entered when state i ends, given u uniform in [0, 1).
+func (m *MMPPSpec) NextState(i int, u float64) int {
+	n := len(m.States)
+	if n == 1 {
+		return 0
+	}
+	if len(m.Transitions) == 0 {
+		// Equal probability over the other states.
+		j := int(u * float64(n-1))
+		if j >= n-1 {
+			j = n - 2
+		}
+		if j >= i {
+			j++
+		}
+		return j
+	}
+	row := m.Transitions[i]
+	var acc float64
+	for j, p := range row {
+		acc += p
+		if u < acc {
+			return j
+		}
+	}
+	// Rounding left u above the row sum: take the last state that can be reached.
+	for j := n - 1; j >= 0; j-- {
+		if row[j] > 0 {
+			return j
+		}
+	}
+	return i
+}
+
+// MaxRate returns the highest state rate.
+func (m *MMPPSpec) MaxRate() float64 {
+	var peak float64
+	for _, st := range m.States {
+		peak = math.Max(peak, st.RateRPS)
+	}
+	return peak
+}
+
+// MeanRate returns the long-run arrival rate from the initial state: state rates weighted by the
+// share of time spent in each state.
+func (m *MMPPSpec) MeanRate() float64 {
+	n := len(m.States)
+	if n == 0 {
+		return 0
+	}
+	// Cesàro average of the embedded jump chain's state distribution, which converges for periodic
+	// and reducible chains too.
+	dist := make([]float64, n)
+	dist[m.InitialState] = 1
+	visits := make([]float64, n)
+	next := make([]float64, n)
+	for k := 0; k < mmppStationaryIterations; k++ {
+		for i := range next {
+			next[i] = 0
+		}
+		for i, p := range dist {
+			visits[i] += p
+			if p == 0 {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				next[j] += p * m.transition(i, j)
+			}
+		}
+		dist, next = next, dist
+	}
+	var dwell, arrivals float64
+	for i, v := range visits {
+		dwell += v * m.States[i].MeanDurationSeconds
+		arrivals += v * m.States[i].MeanDurationSeconds * m.States[i].RateRPS
+	}
+	if dwell == 0 {
+		return 0
+	}
+	return arrivals / dwell
+}
+
+// transition returns the probability of moving from state i to state j.
+func (m *MMPPSpec) transition(i, j int) float64 {
+	n := len(m.States)
+	switch {
+	case len(m.Transitions) > 0:
+		return m.Transitions[i][j]
+	case n == 1:
+		return 1
+	case i == j:
+		return 0
+	default:
+		return 1 / float64(n-1)
+	}
+}
+
+// validateMMPP checks the states, the transition matrix and the initial state of an mmpp arrival.
+func validateMMPP(m *MMPPSpec) error {
+	if m == nil || len(m.States) == 0 {
+		return fmt.Errorf("mmpp arrival requires at least one state")
+	}
+	n := len(m.States)
+	for i, st := range m.States {
+		if !isFiniteNonNegative(st.RateRPS) {
+			return fmt.Errorf("mmpp state %d: rate_rps must be non-negative, got %v", i, st.RateRPS)
+		}
+		if !(st.MeanDurationSeconds > 0) || math.IsInf(st.MeanDurationSeconds, 1) {
+			return fmt.Errorf("mmpp state %d: mean_duration_seconds must be positive, got %v", i, st.MeanDurationSeconds)
+		}
+	}
+	if m.MaxRate() <= 0 {
+		return fmt.Errorf("mmpp arrival requires a state with a positive rate_rps")
+	}
+	if len(m.Transitions) > 0 {
+		if len(m.Transitions) != n {
+			return fmt.Errorf("mmpp transitions must have one row per state (%d), got %d", n, len(m.Transitions))
+		}
+		for i, row := range m.Transitions {
+			if len(row) != n {
+				return fmt.Errorf("mmpp transitions row %d must have %d entries, got %d", i, n, len(row))
+			}
+			var sum float64
+			for j, p := range row {
+				if !isFiniteNonNegative(p) {
+					return fmt.Errorf("mmpp transitions[%d][%d] must be non-negative, got %v", i, j, p)
+				}
+				sum += p
+			}
+			if math.Abs(sum-1) > mmppTransitionRowTolerance {
+				return fmt.Errorf("mmpp transitions row %d must sum to 1, got %v", i, sum)
+			}
+		}
+	}
+	if m.InitialState < 0 || m.InitialState >= n {
+		return fmt.Errorf("mmpp initial_state must be between 0 and %d, got %d", n-1, m.InitialState)
+	}
+	return nil
+}
+
+// validateSelfSimilar checks the optional settings of a self_similar arrival.
+func validateSelfSimilar(s *SelfSimilarSpec) error {
+	if s == nil {
+		return nil
+	}
+	if s.Hurst != 0 && !(s.Hurst > 0.5 && s.Hurst < 1) {
+		return fmt.Errorf("self_similar hurst must be between 0.5 and 1 (exclusive), got %v", s.Hurst)
+	}
+	if s.Sources < 0 || s.Sources > maxSelfSimilarSources {
+		return fmt.Errorf("self_similar sources must be between 1 and %d, got %d", maxSelfSimilarSources, s.Sources)
+	}
+	if !isFiniteNonNegative(s.MeanPeriodSeconds) {
+		return fmt.Errorf("self_similar mean_period_seconds must be non-negative, got %v", s.MeanPeriodSeconds)
+	}
+	return nil
+}
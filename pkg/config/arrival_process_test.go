@@ -0,0 +1,114 @@
+package config
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMMPPSpecRates(t *testing.T) {
+	// Quiet 10s at 10 rps, busy 5s at 100 rps, alternating.
+	m := &MMPPSpec{
+		States:      []MMPPState{{RateRPS: 10, MeanDurationSeconds: 10}, {RateRPS: 100, MeanDurationSeconds: 5}},
+		Transitions: [][]float64{{0, 1}, {1, 0}},
+	}
+	if got := m.MaxRate(); got != 100 {
+		t.Fatalf("expected max rate 100, got %v", got)
+	}
+	if got, want := m.MeanRate(), (10*10+100*5)/15.0; math.Abs(got-want) > 0.05 {
+		t.Fatalf("expected mean rate %v, got %v", want, got)
+	}
+	if m.NextState(0, 0.3) != 1 || m.NextState(1, 0.99) != 0 {
+		t.Fatalf("expected the states to alternate")
+	}
+
+	// Without a matrix the other states are equally likely.
+	three := &MMPPSpec{States: []MMPPState{{RateRPS: 1, MeanDurationSeconds: 1}, {RateRPS: 2, MeanDurationSeconds: 1}, {RateRPS: 3, MeanDurationSeconds: 1}}}
+	if three.NextState(1, 0.2) != 0 || three.NextState(1, 0.7) != 2 || three.NextState(0, 0.99) != 2 {
+		t.Fatalf("unexpected default transitions")
+	}
+	if got := three.MeanRate(); math.Abs(got-2) > 0.01 {
+		t.Fatalf("expected mean rate 2, got %v", got)
+	}
+}
+
+func TestSelfSimilarSpecDefaults(t *testing.T) {
+	var ss *SelfSimilarSpec
+	if ss.HurstParameter() != DefaultHurst || ss.SourceCount() != DefaultSelfSimilarSources || ss.PeriodSeconds() != DefaultSelfSimilarPeriodSeconds {
+		t.Fatalf("expected defaults for unset self_similar settings")
+	}
+	if got := (&SelfSimilarSpec{Hurst: 0.75}).PeriodShape(); math.Abs(got-1.5) > 1e-9 {
+		t.Fatalf("expected period shape 1.5 for H=0.75, got %v", got)
+	}
+}
+
+const arrivalProcessScenario = `
+hosts:
+  - id: h1
+    cores: 2
+services:
+  - id: api
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /a
+        mean_cpu_ms: 1
+        cpu_sigma_ms: 0
+workload:
+  - from: client
+    to: api:/a
+    arrival:
+      type: mmpp
+      mmpp:
+        states:
+          - {rate_rps: 5, mean_duration_seconds: 20}
+          - {rate_rps: 200, mean_duration_seconds: 2}
+        transitions: [[0, 1], [1, 0]]
+  - from: heavy
+    to: api:/a
+    arrival:
+      type: pareto
+      rate_rps: 10
+      pareto_shape: 1.2
+  - from: lognormal
+    to: api:/a
+    arrival:
+      type: lognormal
+      rate_rps: 10
+      interarrival_cv: 3
+  - from: aggregate
+    to: api:/a
+    arrival:
+      type: self_similar
+      rate_rps: 50
+      self_similar:
+        hurst: 0.9
+        sources: 16
+`
+
+func TestValidateScenarioArrivalProcesses(t *testing.T) {
+	if _, err := ParseScenarioYAMLString(arrivalProcessScenario); err != nil {
+		t.Fatalf("expected heavy-tailed and correlated arrivals to be valid, got %v", err)
+	}
+	for _, tc := range []struct{ from, to, want string }{
+		{"transitions: [[0, 1], [1, 0]]", "transitions: [[0, 1], [0.5, 0.4]]", "row 1 must sum to 1"},
+		{"transitions: [[0, 1], [1, 0]]", "transitions: [[1]]", "one row per state"},
+		{"transitions: [[0, 1], [1, 0]]", "initial_state: 2", "initial_state must be between 0 and 1"},
+		{"{rate_rps: 200, mean_duration_seconds: 2}", "{rate_rps: 200, mean_duration_seconds: 0}", "state 1: mean_duration_seconds must be positive"},
+		{"{rate_rps: 5, mean_duration_seconds: 20}", "{rate_rps: -5, mean_duration_seconds: 20}", "state 0: rate_rps must be non-negative"},
+		{"pareto_shape: 1.2", "pareto_shape: 0.9", "pareto_shape must be greater than 1"},
+		{"interarrival_cv: 3", "interarrival_cv: -1", "interarrival_cv must be non-negative"},
+		{"hurst: 0.9", "hurst: 0.4", "hurst must be between 0.5 and 1"},
+		{"type: self_similar", "type: poisson", "require arrival type self_similar"},
+		{"      type: mmpp\n", "      type: poisson\n      rate_rps: 1\n", "mmpp requires arrival type mmpp"},
+	} {
+		bad := strings.Replace(arrivalProcessScenario, tc.from, tc.to, 1)
+		if _, err := ParseScenarioYAMLString(bad); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %v", tc.to, tc.want, err)
+		}
+	}
+	noStates := &ArrivalSpec{Type: "mmpp"}
+	if err := ValidateArrivalSpec(noStates); err == nil || !strings.Contains(err.Error(), "at least one state") {
+		t.Fatalf("expected an mmpp without states to be rejected, got %v", err)
+	}
+}
@@ -15,3 +15,19 @@ func TestNormalizeArrivalType(t *testing.T) {
 		t.Fatal("expected error for unknown type")
 	}
 }
+
+func TestValidateClosedArrival(t *testing.T) {
+	t.Parallel()
+	if err := validateClosedArrival(&ArrivalSpec{Type: "closed", Users: 10, ThinkTimeMs: LatencySpec{Mean: 500, Sigma: 100}, RampUpSeconds: 30}); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	for _, a := range []ArrivalSpec{
+		{Type: "closed"},
+		{Type: "closed", Users: 1, ThinkTimeMs: LatencySpec{Mean: -1}},
+		{Type: "closed", Users: 1, RampUpSeconds: -5},
+	} {
+		if err := validateClosedArrival(&a); err == nil {
+			t.Fatalf("expected error for %+v", a)
+		}
+	}
+}
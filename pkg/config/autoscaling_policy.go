@@ -0,0 +1,51 @@
+package config
+
+import "time"
+
+const (
+	// DefaultAutoscalingSyncPeriod matches the Kubernetes HPA controller default (--horizontal-pod-autoscaler-sync-period).
+	DefaultAutoscalingSyncPeriod = 15 * time.Second
+	// DefaultAutoscalingMinReplicas is the lower replica bound when min_replicas is unset.
+	DefaultAutoscalingMinReplicas = 1
+	// DefaultAutoscalingMaxReplicas is the upper replica bound when max_replicas is unset.
+	DefaultAutoscalingMaxReplicas = 100
+)
+
+// EffectiveSyncPeriod returns the simulated interval between autoscaler evaluations.
+func (p *AutoscalingPolicy) EffectiveSyncPeriod() time.Duration {
+	if p == nil || p.SyncPeriodMs <= 0 {
+		return DefaultAutoscalingSyncPeriod
+	}
+	return time.Duration(p.SyncPeriodMs) * time.Millisecond
+}
+
+// EffectiveStabilizationWindow returns the scale-down stabilization window (zero disables it).
+func (p *AutoscalingPolicy) EffectiveStabilizationWindow() time.Duration {
+	if p == nil || p.StabilizationWindowMs <= 0 {
+		return 0
+	}
+	return time.Duration(p.StabilizationWindowMs) * time.Millisecond
+}
+
+// EffectiveStartupDelay returns the simulated delay before scaled-up replicas become routable.
+func (p *AutoscalingPolicy) EffectiveStartupDelay() time.Duration {
+	if p == nil || p.StartupDelayMs <= 0 {
+		return 0
+	}
+	return time.Duration(p.StartupDelayMs) * time.Millisecond
+}
+
+// EffectiveReplicaBounds returns min/max replicas with defaults applied.
+func (p *AutoscalingPolicy) EffectiveReplicaBounds() (minReplicas, maxReplicas int) {
+	minReplicas, maxReplicas = DefaultAutoscalingMinReplicas, DefaultAutoscalingMaxReplicas
+	if p == nil {
+		return minReplicas, maxReplicas
+	}
+	if p.MinReplicas > 0 {
+		minReplicas = p.MinReplicas
+	}
+	if p.MaxReplicas > 0 {
+		maxReplicas = p.MaxReplicas
+	}
+	return minReplicas, maxReplicas
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAutoscalingPolicyEffectiveDefaults(t *testing.T) {
+	var nilPolicy *AutoscalingPolicy
+	if got := nilPolicy.EffectiveSyncPeriod(); got != DefaultAutoscalingSyncPeriod {
+		t.Fatalf("nil sync period: got %v", got)
+	}
+	p := &AutoscalingPolicy{}
+	if got := p.EffectiveSyncPeriod(); got != 15*time.Second {
+		t.Fatalf("default sync period: got %v", got)
+	}
+	if p.EffectiveStabilizationWindow() != 0 || p.EffectiveStartupDelay() != 0 {
+		t.Fatalf("expected zero stabilization window and startup delay by default")
+	}
+	if minR, maxR := p.EffectiveReplicaBounds(); minR != 1 || maxR != 100 {
+		t.Fatalf("default bounds: got %d..%d", minR, maxR)
+	}
+
+	p = &AutoscalingPolicy{SyncPeriodMs: 500, StabilizationWindowMs: 30000, StartupDelayMs: 4000, MinReplicas: 2, MaxReplicas: 8}
+	if got := p.EffectiveSyncPeriod(); got != 500*time.Millisecond {
+		t.Fatalf("sync period: got %v", got)
+	}
+	if got := p.EffectiveStabilizationWindow(); got != 30*time.Second {
+		t.Fatalf("stabilization window: got %v", got)
+	}
+	if got := p.EffectiveStartupDelay(); got != 4*time.Second {
+		t.Fatalf("startup delay: got %v", got)
+	}
+	if minR, maxR := p.EffectiveReplicaBounds(); minR != 2 || maxR != 8 {
+		t.Fatalf("bounds: got %d..%d", minR, maxR)
+	}
+}
+
+func TestParseScenarioAutoscalingHPAFields(t *testing.T) {
+	y := `
+hosts:
+  - id: h1
+    cores: 4
+services:
+  - id: api
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /x
+        mean_cpu_ms: 5
+        cpu_sigma_ms: 1
+        downstream: []
+        net_latency_ms: {mean: 1, sigma: 0}
+workload:
+  - from: client
+    to: api:/x
+    arrival: {type: poisson, rate_rps: 10}
+policies:
+  autoscaling:
+    enabled: true
+    target_cpu_util: 0.6
+    scale_step: 1
+    min_replicas: 2
+    max_replicas: 6
+    sync_period_ms: 5000
+    stabilization_window_ms: 60000
+    startup_delay_ms: 3000
+`
+	sc, err := ParseScenarioYAMLString(y)
+	if err != nil {
+		t.Fatalf("ParseScenarioYAMLString: %v", err)
+	}
+	a := sc.Policies.Autoscaling
+	if a.MinReplicas != 2 || a.MaxReplicas != 6 || a.SyncPeriodMs != 5000 || a.StabilizationWindowMs != 60000 || a.StartupDelayMs != 3000 {
+		t.Fatalf("unexpected autoscaling fields: %+v", a)
+	}
+
+	a.MaxReplicas = 1
+	if err := ValidateScenario(sc); err == nil {
+		t.Fatal("expected ValidateScenario to reject max_replicas < min_replicas")
+	}
+}
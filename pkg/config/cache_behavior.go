@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// MaxCacheKeys bounds behavior.cache.key_space.keys (the simulator keeps one popularity weight per key).
+const MaxCacheKeys = 1_000_000
+
+// Cache eviction policies.
+const (
+	CacheEvictionLRU = "lru"
+	CacheEvictionLFU = "lfu"
+)
+
+// EffectiveZipfS returns the popularity skew of the key space (default 1).
+func (k *CacheKeySpace) EffectiveZipfS() float64 {
+	if k.ZipfS <= 0 {
+		return 1
+	}
+	return k.ZipfS
+}
+
+// EvictionPolicy returns the normalized eviction policy (default lru).
+func (k *CacheKeySpace) EvictionPolicy() string {
+	if e := strings.ToLower(strings.TrimSpace(k.Eviction)); e != "" {
+		return e
+	}
+	return CacheEvictionLRU
+}
+
+// ValidateCacheBehavior checks the key space and miss call of a service's cache behavior once all
+// endpoints are known.
+func ValidateCacheBehavior(svc *Service, endpointRef map[string]bool, serviceKindByID map[string]string) error {
+	if svc.Behavior == nil || svc.Behavior.Cache == nil {
+		return nil
+	}
+	svcID, c := svc.ID, svc.Behavior.Cache
+	if k := c.KeySpace; k != nil {
+		if c.HitRate != 0 {
+			return fmt.Errorf("service %s: behavior.cache.hit_rate and key_space cannot both be set", svcID)
+		}
+		if k.Keys <= 0 || k.Keys > MaxCacheKeys {
+			return fmt.Errorf("service %s: behavior.cache.key_space.keys must be between 1 and %d, got %d", svcID, MaxCacheKeys, k.Keys)
+		}
+		if k.ZipfS < 0 || math.IsNaN(k.ZipfS) || math.IsInf(k.ZipfS, 0) {
+			return fmt.Errorf("service %s: behavior.cache.key_space.zipf_s must be positive, got %v", svcID, k.ZipfS)
+		}
+		if k.CapacityKeys < 0 {
+			return fmt.Errorf("service %s: behavior.cache.key_space.capacity_keys cannot be negative", svcID)
+		}
+		if !isFiniteNonNegative(k.TTLMs) {
+			return fmt.Errorf("service %s: behavior.cache.key_space.ttl_ms must be non-negative, got %v", svcID, k.TTLMs)
+		}
+		if e := k.EvictionPolicy(); e != CacheEvictionLRU && e != CacheEvictionLFU {
+			return fmt.Errorf("service %s: behavior.cache.key_space.eviction must be lru or lfu, got %q", svcID, k.Eviction)
+		}
+	} else if c.CoalesceMisses {
+		return fmt.Errorf("service %s: behavior.cache.coalesce_misses requires key_space", svcID)
+	}
+	if strings.TrimSpace(c.MissCall) == "" {
+		return nil
+	}
+	ms, mp, err := parseDownstreamTargetForValidation(c.MissCall)
+	if err != nil {
+		return fmt.Errorf("service %s: behavior.cache.miss_call: %w", svcID, err)
+	}
+	if !endpointRef[ms+":"+mp] {
+		return fmt.Errorf("service %s: behavior.cache.miss_call endpoint %s:%s does not exist", svcID, ms, mp)
+	}
+	if ms == svcID {
+		return fmt.Errorf("service %s: behavior.cache.miss_call cannot target the cache itself", svcID)
+	}
+	if kind := serviceKindByID[ms]; kind == "queue" || kind == "topic" {
+		return fmt.Errorf("service %s: behavior.cache.miss_call cannot target %s service %s", svcID, kind, ms)
+	}
+	for _, ep := range svc.Endpoints {
+		if len(ep.Execution) > 0 {
+			return fmt.Errorf("service %s: behavior.cache.miss_call cannot be combined with execution stages (endpoint %s)", svcID, ep.Path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCacheBehavior(t *testing.T) {
+	endpointRef := map[string]bool{"cache:/get": true, "db:/query": true, "q:/in": true}
+	kinds := map[string]string{"cache": "cache", "db": "database", "q": "queue"}
+	svc := func(c *CacheBehavior) *Service {
+		return &Service{ID: "cache", Behavior: &ServiceBehavior{Cache: c}, Endpoints: []Endpoint{{Path: "/get"}}}
+	}
+
+	valid := &CacheBehavior{
+		KeySpace:       &CacheKeySpace{Keys: 1000, ZipfS: 1.1, CapacityKeys: 100, TTLMs: 5000, Eviction: "LFU"},
+		MissCall:       "db:/query",
+		CoalesceMisses: true,
+	}
+	if err := ValidateCacheBehavior(svc(valid), endpointRef, kinds); err != nil {
+		t.Fatalf("expected valid cache behavior, got %v", err)
+	}
+	if err := ValidateCacheBehavior(svc(&CacheBehavior{HitRate: 0.5, MissCall: "db:/query"}), endpointRef, kinds); err != nil {
+		t.Fatalf("expected miss_call with hit_rate to be valid, got %v", err)
+	}
+
+	staged := svc(&CacheBehavior{HitRate: 0.5, MissCall: "db:/query"})
+	staged.Endpoints[0].Execution = []ExecutionStage{{Calls: []string{"db:/query"}}}
+	tests := []struct {
+		name string
+		svc  *Service
+		want string
+	}{
+		{"hit rate with key space", svc(&CacheBehavior{HitRate: 0.5, KeySpace: &CacheKeySpace{Keys: 10}}), "cannot both be set"},
+		{"no keys", svc(&CacheBehavior{KeySpace: &CacheKeySpace{}}), "keys must be between 1"},
+		{"too many keys", svc(&CacheBehavior{KeySpace: &CacheKeySpace{Keys: MaxCacheKeys + 1}}), "keys must be between 1"},
+		{"negative zipf", svc(&CacheBehavior{KeySpace: &CacheKeySpace{Keys: 10, ZipfS: -1}}), "zipf_s must be positive"},
+		{"negative capacity", svc(&CacheBehavior{KeySpace: &CacheKeySpace{Keys: 10, CapacityKeys: -1}}), "capacity_keys cannot be negative"},
+		{"negative ttl", svc(&CacheBehavior{KeySpace: &CacheKeySpace{Keys: 10, TTLMs: -1}}), "ttl_ms must be non-negative"},
+		{"unknown eviction", svc(&CacheBehavior{KeySpace: &CacheKeySpace{Keys: 10, Eviction: "fifo"}}), "eviction must be lru or lfu"},
+		{"coalesce without key space", svc(&CacheBehavior{HitRate: 0.5, CoalesceMisses: true}), "coalesce_misses requires key_space"},
+		{"unknown miss call", svc(&CacheBehavior{HitRate: 0.5, MissCall: "db:/missing"}), "miss_call endpoint db:/missing does not exist"},
+		{"miss call to itself", svc(&CacheBehavior{HitRate: 0.5, MissCall: "cache:/get"}), "cannot target the cache itself"},
+		{"miss call to queue", svc(&CacheBehavior{HitRate: 0.5, MissCall: "q:/in"}), "cannot target queue service q"},
+		{"miss call with execution stages", staged, "cannot be combined with execution stages"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCacheBehavior(tc.svc, endpointRef, kinds)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
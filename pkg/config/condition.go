@@ -0,0 +1,223 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+// WhenMatches reports whether request metadata md satisfies every `when` condition of the call
+// (true when it has none).
+func (d *DownstreamCall) WhenMatches(md map[string]interface{}) bool {
+	for i := range d.When {
+		if !d.When[i].Matches(md) {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether md holds the condition's key with a value satisfying every set operator.
+func (c *MetadataCondition) Matches(md map[string]interface{}) bool {
+	v, ok := md[c.Key]
+	if !ok || v == nil {
+		return false
+	}
+	s := metadataString(v)
+	if c.Equals != nil && s != *c.Equals {
+		return false
+	}
+	if len(c.In) > 0 {
+		found := false
+		for _, want := range c.In {
+			if s == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if c.Regex != "" {
+		re := c.re
+		if re == nil {
+			// Not validated: compile on every match.
+			var err error
+			if re, err = regexp.Compile(c.Regex); err != nil {
+				return false
+			}
+		}
+		if !re.MatchString(s) {
+			return false
+		}
+	}
+	if c.GT == nil && c.GTE == nil && c.LT == nil && c.LTE == nil {
+		return true
+	}
+	x, ok := metadataNumber(v)
+	if !ok {
+		return false
+	}
+	return (c.GT == nil || x > *c.GT) &&
+		(c.GTE == nil || x >= *c.GTE) &&
+		(c.LT == nil || x < *c.LT) &&
+		(c.LTE == nil || x <= *c.LTE)
+}
+
+// metadataString renders a metadata value for string comparison.
+func metadataString(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// metadataNumber returns a numeric metadata value or a string that parses as one.
+func metadataNumber(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case float64:
+		return x, true
+	case float32:
+		return float64(x), true
+	case int:
+		return float64(x), true
+	case int64:
+		return float64(x), true
+	case int32:
+		return float64(x), true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(x), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// PropagatedMetadataKeys returns the sorted request metadata keys that the simulator carries from a
+// request to its downstream calls: the keys of downstream `when` conditions, cache key_from settings
+// and the priority keys of priority-scheduled services.
+func (s *Scenario) PropagatedMetadataKeys() []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for i := range s.Services {
+		if b := s.Services[i].Behavior; b != nil && b.Cache != nil && b.Cache.KeySpace != nil {
+			if k := b.Cache.KeySpace.KeyFrom; k != "" && !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+		if sc := s.ServiceScheduling(&s.Services[i]); sc.EffectiveDiscipline() == SchedulingPriority {
+			if k := sc.EffectivePriorityKey(); !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+		for j := range s.Services[i].Endpoints {
+			for _, ds := range s.Services[i].Endpoints[j].Downstream {
+				for _, c := range ds.When {
+					if !seen[c.Key] {
+						seen[c.Key] = true
+						keys = append(keys, c.Key)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DrawWeightedMetadata draws one value per key of weights, with probability proportional to the
+// value's weight. Keys and values are visited in sorted order so a seed reproduces the draws. It
+// returns nil without drawing when weights is empty.
+func DrawWeightedMetadata(weights map[string]map[string]float64, rng *utils.RandSource) map[string]string {
+	if len(weights) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(weights))
+	for k := range weights {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := make(map[string]string, len(keys))
+	for _, k := range keys {
+		values := make([]string, 0, len(weights[k]))
+		var total float64
+		for v, w := range weights[k] {
+			values = append(values, v)
+			total += w
+		}
+		sort.Strings(values)
+		target := rng.Float64() * total
+		var acc float64
+		for _, v := range values {
+			if w := weights[k][v]; w > 0 {
+				acc += w
+				out[k] = v
+				if target < acc {
+					break
+				}
+			}
+		}
+	}
+	return out
+}
+
+// validateConditions checks the `when` conditions of a downstream call and compiles their regexes.
+func validateConditions(conds []MetadataCondition) error {
+	for i := range conds {
+		c := &conds[i]
+		if strings.TrimSpace(c.Key) == "" {
+			return fmt.Errorf("when[%d]: key cannot be empty", i)
+		}
+		if c.Equals == nil && len(c.In) == 0 && c.Regex == "" && c.GT == nil && c.GTE == nil && c.LT == nil && c.LTE == nil {
+			return fmt.Errorf("when[%d] (%s): set at least one of equals, in, regex, gt, gte, lt or lte", i, c.Key)
+		}
+		if c.Regex != "" {
+			re, err := regexp.Compile(c.Regex)
+			if err != nil {
+				return fmt.Errorf("when[%d] (%s): invalid regex: %w", i, c.Key, err)
+			}
+			c.re = re
+		}
+		for _, bound := range []*float64{c.GT, c.GTE, c.LT, c.LTE} {
+			if bound != nil && (math.IsNaN(*bound) || math.IsInf(*bound, 0)) {
+				return fmt.Errorf("when[%d] (%s): numeric bounds must be finite", i, c.Key)
+			}
+		}
+	}
+	return nil
+}
+
+// validateWeightedMetadata checks weighted_metadata against the static metadata of the same pattern or flow.
+func validateWeightedMetadata(weights map[string]map[string]float64, static map[string]string) error {
+	keys := make([]string, 0, len(weights))
+	for k := range weights {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if strings.TrimSpace(k) == "" {
+			return fmt.Errorf("weighted_metadata: key cannot be empty")
+		}
+		if _, ok := static[k]; ok {
+			return fmt.Errorf("weighted_metadata %s: key is also set in metadata", k)
+		}
+		var total float64
+		for v, w := range weights[k] {
+			if !isFiniteNonNegative(w) {
+				return fmt.Errorf("weighted_metadata %s: weight of %q must be non-negative, got %v", k, v, w)
+			}
+			total += w
+		}
+		if total <= 0 {
+			return fmt.Errorf("weighted_metadata %s: needs a value with positive weight", k)
+		}
+	}
+	return nil
+}
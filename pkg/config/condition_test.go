@@ -0,0 +1,137 @@
+package config
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+func TestMetadataConditionMatches(t *testing.T) {
+	premium, ten, hundred := "premium", 10.0, 100.0
+	md := map[string]interface{}{"tier": "premium", "region": "eu-west-1", "amount": "250", "depth": 3}
+	for _, tc := range []struct {
+		c    MetadataCondition
+		want bool
+	}{
+		{MetadataCondition{Key: "tier", Equals: &premium}, true},
+		{MetadataCondition{Key: "tier", In: []string{"free", "basic"}}, false},
+		{MetadataCondition{Key: "region", Regex: "^eu-"}, true},
+		{MetadataCondition{Key: "region", Regex: "^us-"}, false},
+		{MetadataCondition{Key: "amount", GT: &hundred}, true},
+		{MetadataCondition{Key: "amount", GTE: &ten, LT: &hundred}, false},
+		{MetadataCondition{Key: "depth", LTE: &ten}, true},
+		{MetadataCondition{Key: "tier", GT: &ten}, false}, // not a number
+		{MetadataCondition{Key: "missing", In: []string{""}}, false},
+		{MetadataCondition{Key: "tier", Equals: &premium, Regex: "^free"}, false},
+	} {
+		if got := tc.c.Matches(md); got != tc.want {
+			t.Fatalf("%+v: expected %v, got %v", tc.c, tc.want, got)
+		}
+	}
+	ds := DownstreamCall{When: []MetadataCondition{{Key: "tier", Equals: &premium}, {Key: "amount", GT: &hundred}}}
+	if !ds.WhenMatches(md) || ds.WhenMatches(map[string]interface{}{"tier": "premium"}) {
+		t.Fatalf("expected a call to need every condition")
+	}
+	if !(&DownstreamCall{}).WhenMatches(nil) {
+		t.Fatalf("expected a call without conditions to match")
+	}
+}
+
+func TestValidateConditionsCompilesRegex(t *testing.T) {
+	conds := []MetadataCondition{{Key: "region", Regex: "^eu-"}, {Key: "tier", In: []string{"gold"}}}
+	if err := validateConditions(conds); err != nil {
+		t.Fatalf("validateConditions: %v", err)
+	}
+	if conds[0].re == nil || conds[1].re != nil {
+		t.Fatalf("expected only the regex condition to hold a compiled regex")
+	}
+	if !conds[0].Matches(map[string]interface{}{"region": "eu-west-1"}) {
+		t.Fatalf("expected the compiled regex to match")
+	}
+	if err := validateConditions([]MetadataCondition{{Key: "region", Regex: "("}}); err == nil || !strings.Contains(err.Error(), "invalid regex") {
+		t.Fatalf("expected an invalid regex error, got %v", err)
+	}
+}
+
+func TestDrawWeightedMetadata(t *testing.T) {
+	weights := map[string]map[string]float64{"tier": {"premium": 1, "free": 3, "never": 0}, "region": {"eu": 1}}
+	rng := utils.NewRandSource(2)
+	premium := 0
+	const n = 40000
+	for i := 0; i < n; i++ {
+		md := DrawWeightedMetadata(weights, rng)
+		if md["region"] != "eu" || md["tier"] == "never" {
+			t.Fatalf("unexpected draw %v", md)
+		}
+		if md["tier"] == "premium" {
+			premium++
+		}
+	}
+	if share := float64(premium) / n; math.Abs(share-0.25) > 0.01 {
+		t.Fatalf("expected a quarter of premium draws, got %v", share)
+	}
+	if DrawWeightedMetadata(nil, rng) != nil {
+		t.Fatalf("expected no draw without weights")
+	}
+}
+
+const conditionScenario = `
+hosts:
+  - id: h1
+    cores: 2
+services:
+  - id: api
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /pay
+        mean_cpu_ms: 1
+        cpu_sigma_ms: 0
+        downstream:
+          - to: fraud:/check
+            when:
+              - {key: tier, in: [premium, gold]}
+              - {key: amount, gte: 100}
+  - id: fraud
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /check
+        mean_cpu_ms: 1
+        cpu_sigma_ms: 0
+workload:
+  - from: client
+    to: api:/pay
+    metadata: {region: eu}
+    weighted_metadata:
+      tier: {premium: 1, free: 4}
+    arrival:
+      type: poisson
+      rate_rps: 10
+`
+
+func TestValidateScenarioConditions(t *testing.T) {
+	s, err := ParseScenarioYAMLString(conditionScenario)
+	if err != nil {
+		t.Fatalf("expected conditions and weighted metadata to be valid, got %v", err)
+	}
+	if keys := s.PropagatedMetadataKeys(); len(keys) != 2 || keys[0] != "amount" || keys[1] != "tier" {
+		t.Fatalf("expected condition keys [amount tier], got %v", keys)
+	}
+	for _, tc := range []struct{ from, to, want string }{
+		{"{key: amount, gte: 100}", "{key: amount}", "when[1] (amount): set at least one of"},
+		{"{key: amount, gte: 100}", "{key: \"\", gte: 100}", "when[1]: key cannot be empty"},
+		{"{key: amount, gte: 100}", "{key: amount, regex: \"[\"}", "when[1] (amount): invalid regex"},
+		{"{key: amount, gte: 100}", "{key: amount, gte: .inf}", "numeric bounds must be finite"},
+		{"tier: {premium: 1, free: 4}", "tier: {premium: 0, free: 0}", "weighted_metadata tier: needs a value with positive weight"},
+		{"tier: {premium: 1, free: 4}", "tier: {premium: -1, free: 4}", "weight of \"premium\" must be non-negative"},
+		{"tier: {premium: 1, free: 4}", "region: {eu: 1}", "weighted_metadata region: key is also set in metadata"},
+	} {
+		bad := strings.Replace(conditionScenario, tc.from, tc.to, 1)
+		if _, err := ParseScenarioYAMLString(bad); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %v", tc.to, tc.want, err)
+		}
+	}
+}
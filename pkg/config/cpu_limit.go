@@ -0,0 +1,61 @@
+package config
+
+import "fmt"
+
+// CFSPeriodMs is the CFS scheduler period over which cpu_limit quotas are enforced.
+const CFSPeriodMs = 100
+
+// EffectiveCPURequest returns the cores reserved for each instance: cpu_cores, or 1 when unset.
+func (svc *Service) EffectiveCPURequest() float64 {
+	if svc.CPUCores > 0 {
+		return svc.CPUCores
+	}
+	return 1
+}
+
+// ScaleCPU sets the instance cores (the request) and scales cpu_limit by the same ratio, so a
+// vertical resize keeps the service's burst headroom.
+func (svc *Service) ScaleCPU(cores float64) {
+	if svc.CPULimit > 0 {
+		svc.CPULimit *= cores / svc.EffectiveCPURequest()
+	}
+	svc.CPUCores = cores
+}
+
+// applyCPURequestDefaults moves cpu_request into cpu_cores, and gives a service with only a
+// cpu_limit a request equal to its limit (as Kubernetes does).
+func applyCPURequestDefaults(s *Scenario) {
+	for i := range s.Services {
+		svc := &s.Services[i]
+		if svc.CPURequest > 0 && svc.CPUCores == 0 {
+			svc.CPUCores = svc.CPURequest
+			svc.CPURequest = 0
+		}
+		if svc.CPUCores == 0 && svc.CPURequest == 0 && svc.CPULimit > 0 {
+			svc.CPUCores = svc.CPULimit
+		}
+	}
+}
+
+func validateCPULimits(s *Scenario) error {
+	for i := range s.Services {
+		svc := &s.Services[i]
+		if svc.CPURequest < 0 {
+			return fmt.Errorf("service %s: cpu_request cannot be negative", svc.ID)
+		}
+		if svc.CPURequest > 0 && svc.CPUCores > 0 && svc.CPURequest != svc.CPUCores {
+			return fmt.Errorf("service %s: cpu_request and cpu_cores are the same setting, set one of them", svc.ID)
+		}
+		if svc.CPULimit < 0 {
+			return fmt.Errorf("service %s: cpu_limit cannot be negative", svc.ID)
+		}
+		request := svc.EffectiveCPURequest()
+		if svc.CPURequest > 0 {
+			request = svc.CPURequest
+		}
+		if svc.CPULimit > 0 && svc.CPULimit < request {
+			return fmt.Errorf("service %s: cpu_limit %.3g is below the cpu request %.3g", svc.ID, svc.CPULimit, request)
+		}
+	}
+	return nil
+}
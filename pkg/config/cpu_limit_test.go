@@ -0,0 +1,65 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCPURequestDefaults(t *testing.T) {
+	s, err := UnmarshalScenarioYAML([]byte(`
+hosts:
+  - id: h1
+    cores: 8
+services:
+  - id: a
+    cpu_request: 0.5
+    cpu_limit: 2
+  - id: b
+    cpu_limit: 1.5
+  - id: c
+    cpu_cores: 2
+`))
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if a := s.Services[0]; a.CPUCores != 0.5 || a.CPURequest != 0 || a.CPULimit != 2 {
+		t.Fatalf("cpu_request should move into cpu_cores, got %+v", a)
+	}
+	if b := s.Services[1]; b.CPUCores != 1.5 {
+		t.Fatalf("a limit-only service should request its limit, got %v", b.CPUCores)
+	}
+	if c := s.Services[2]; c.CPUCores != 2 || c.CPULimit != 0 {
+		t.Fatalf("cpu_cores-only service changed: %+v", c)
+	}
+
+	a := s.Services[0]
+	a.ScaleCPU(1)
+	if a.CPUCores != 1 || a.CPULimit != 4 {
+		t.Fatalf("ScaleCPU should keep the limit/request ratio, got %+v", a)
+	}
+}
+
+func TestValidateCPULimits(t *testing.T) {
+	tests := []struct {
+		name string
+		svc  Service
+		want string
+	}{
+		{"negative request", Service{ID: "a", CPURequest: -1}, "cpu_request cannot be negative"},
+		{"request and cores differ", Service{ID: "a", CPURequest: 1, CPUCores: 2}, "set one of them"},
+		{"negative limit", Service{ID: "a", CPULimit: -1}, "cpu_limit cannot be negative"},
+		{"limit below request", Service{ID: "a", CPUCores: 2, CPULimit: 1}, "below the cpu request"},
+		{"limit below default request", Service{ID: "a", CPULimit: 0.5}, "below the cpu request"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateCPULimits(&Scenario{Services: []Service{tc.svc}})
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+	if err := validateCPULimits(&Scenario{Services: []Service{{ID: "a", CPUCores: 0.5, CPULimit: 2}}}); err != nil {
+		t.Fatalf("expected valid limit, got %v", err)
+	}
+}
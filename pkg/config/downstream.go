@@ -1,6 +1,10 @@
 package config
 
-import "strings"
+import (
+	"fmt"
+	"math"
+	"strings"
+)
 
 // IsAsync reports whether this edge should be excluded from synchronous call-graph cycle checks.
 // Default (empty mode) is synchronous REST-style behavior.
@@ -16,3 +20,42 @@ func (d DownstreamCall) IsRetryable() bool {
 	}
 	return *d.Retryable
 }
+
+// MaxHedgesLimit bounds hedge.max_hedges.
+const MaxHedgesLimit = 10
+
+// Hedges returns the number of hedge attempts the policy may send (default 1).
+func (h *HedgePolicy) Hedges() int {
+	if h.MaxHedges <= 0 {
+		return 1
+	}
+	return h.MaxHedges
+}
+
+// CancelsLosers reports whether losing attempts are cancelled (default true).
+func (h *HedgePolicy) CancelsLosers() bool {
+	if h.CancelLosers == nil {
+		return true
+	}
+	return *h.CancelLosers
+}
+
+// validateHedgePolicy checks a downstream hedge policy.
+func validateHedgePolicy(h *HedgePolicy) error {
+	if h == nil {
+		return nil
+	}
+	if !isFiniteNonNegative(h.DelayMs) {
+		return fmt.Errorf("hedge.delay_ms must be non-negative, got %v", h.DelayMs)
+	}
+	if h.DelayPercentile < 0 || h.DelayPercentile >= 100 || math.IsNaN(h.DelayPercentile) {
+		return fmt.Errorf("hedge.delay_percentile must be in [0,100), got %v", h.DelayPercentile)
+	}
+	if h.DelayMs == 0 && h.DelayPercentile == 0 {
+		return fmt.Errorf("hedge requires delay_ms or delay_percentile")
+	}
+	if h.MaxHedges < 0 || h.MaxHedges > MaxHedgesLimit {
+		return fmt.Errorf("hedge.max_hedges must be between 0 and %d, got %d", MaxHedgesLimit, h.MaxHedges)
+	}
+	return nil
+}
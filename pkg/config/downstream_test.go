@@ -1,6 +1,9 @@
 package config
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestDownstreamCallIsAsync(t *testing.T) {
 	if (DownstreamCall{Mode: "async"}).IsAsync() != true {
@@ -30,3 +33,66 @@ func TestDownstreamCallIsRetryable(t *testing.T) {
 		t.Fatal("expected retryable true")
 	}
 }
+
+func TestValidateHedgePolicy(t *testing.T) {
+	h := &HedgePolicy{DelayMs: 20}
+	if err := validateHedgePolicy(h); err != nil {
+		t.Fatalf("expected fixed delay to be valid, got %v", err)
+	}
+	if h.Hedges() != 1 || !h.CancelsLosers() {
+		t.Fatalf("expected one hedge that cancels losers by default")
+	}
+	for _, bad := range []*HedgePolicy{
+		{},
+		{DelayMs: -1},
+		{DelayPercentile: 100},
+		{DelayPercentile: 95, MaxHedges: MaxHedgesLimit + 1},
+	} {
+		if err := validateHedgePolicy(bad); err == nil {
+			t.Fatalf("expected %+v to be rejected", *bad)
+		}
+	}
+}
+
+func TestValidateScenarioHedgeSyncOnly(t *testing.T) {
+	const scenario = `
+hosts:
+  - id: h1
+    cores: 4
+services:
+  - id: api
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /read
+        mean_cpu_ms: 1
+        downstream:
+          - to: store:/get
+            hedge:
+              delay_percentile: 95
+              delay_ms: 10
+  - id: store
+    replicas: 2
+    model: cpu
+    endpoints:
+      - path: /get
+        mean_cpu_ms: 1
+workload:
+  - from: client
+    to: api:/read
+    arrival:
+      type: poisson
+      rate_rps: 10
+`
+	s, err := ParseScenarioYAMLString(scenario)
+	if err != nil {
+		t.Fatalf("expected hedged sync call to be valid, got %v", err)
+	}
+	if h := s.Services[0].Endpoints[0].Downstream[0].Hedge; h == nil || h.DelayPercentile != 95 {
+		t.Fatalf("expected hedge policy to be parsed, got %+v", h)
+	}
+	async := strings.Replace(scenario, "          - to: store:/get\n", "          - to: store:/get\n            mode: async\n", 1)
+	if _, err := ParseScenarioYAMLString(async); err == nil || !strings.Contains(err.Error(), "hedge applies to sync calls only") {
+		t.Fatalf("expected async hedge to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package config
+
+import "fmt"
+
+// StageGroups returns the groups of the stage, with the Calls/Quorum shorthand as the first group.
+func (st *ExecutionStage) StageGroups() []ExecutionGroup {
+	if len(st.Calls) == 0 {
+		return st.Groups
+	}
+	return append([]ExecutionGroup{{Calls: st.Calls, Quorum: st.Quorum}}, st.Groups...)
+}
+
+// Required returns the successes the group needs when issued of its calls were made: the quorum,
+// capped at issued, or all of them when no quorum is set.
+func (g *ExecutionGroup) Required(issued int) int {
+	if g.Quorum <= 0 || g.Quorum > issued {
+		return issued
+	}
+	return g.Quorum
+}
+
+// validateExecution checks that the execution stages of ep only name its sync downstream calls, each
+// at most once.
+func validateExecution(ep *Endpoint, serviceKindByID map[string]string) error {
+	if len(ep.Execution) == 0 {
+		return nil
+	}
+	sync := make(map[string]bool)
+	for k := range ep.Downstream {
+		ds := &ep.Downstream[k]
+		tgtSvc, tgtPath, err := parseDownstreamTargetForValidation(ds.To)
+		if err != nil || ds.IsAsync() {
+			continue
+		}
+		if kind := serviceKindByID[tgtSvc]; kind == "queue" || kind == "topic" {
+			continue
+		}
+		sync[tgtSvc+":"+tgtPath] = true
+	}
+	listed := make(map[string]bool)
+	for i := range ep.Execution {
+		st := &ep.Execution[i]
+		if len(st.Calls) == 0 && st.Quorum != 0 {
+			return fmt.Errorf("execution[%d]: quorum requires calls", i)
+		}
+		groups := st.StageGroups()
+		if len(groups) == 0 {
+			return fmt.Errorf("execution[%d]: stage needs calls or groups", i)
+		}
+		for j := range groups {
+			g := &groups[j]
+			if len(g.Calls) == 0 {
+				return fmt.Errorf("execution[%d] group %d: calls cannot be empty", i, j)
+			}
+			if g.Quorum < 0 {
+				return fmt.Errorf("execution[%d] group %d: quorum cannot be negative", i, j)
+			}
+			for _, to := range g.Calls {
+				tgtSvc, tgtPath, err := parseDownstreamTargetForValidation(to)
+				if err != nil {
+					return fmt.Errorf("execution[%d] group %d: call %q: %w", i, j, to, err)
+				}
+				key := tgtSvc + ":" + tgtPath
+				if !sync[key] {
+					return fmt.Errorf("execution[%d] group %d: %q is not a sync downstream call of the endpoint", i, j, to)
+				}
+				if listed[key] {
+					return fmt.Errorf("execution[%d] group %d: %q is listed more than once", i, j, to)
+				}
+				listed[key] = true
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+const executionScenario = `
+hosts:
+  - id: h1
+    cores: 4
+services:
+  - id: api
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /order
+        mean_cpu_ms: 1
+        downstream:
+          - to: auth:/check
+          - to: replica-a:/read
+          - to: replica-b:/read
+          - to: replica-c:/read
+          - to: audit:/log
+            mode: async
+        execution:
+          - calls: [auth:/check]
+          - groups:
+              - calls: [replica-a:/read, replica-b:/read, replica-c:/read]
+                quorum: 2
+  - id: auth
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /check
+        mean_cpu_ms: 1
+  - id: replica-a
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /read
+        mean_cpu_ms: 1
+  - id: replica-b
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /read
+        mean_cpu_ms: 1
+  - id: replica-c
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /read
+        mean_cpu_ms: 1
+  - id: audit
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /log
+        mean_cpu_ms: 1
+workload:
+  - from: client
+    to: api:/order
+    arrival:
+      type: poisson
+      rate_rps: 10
+`
+
+func TestValidateScenarioExecution(t *testing.T) {
+	s, err := ParseScenarioYAMLString(executionScenario)
+	if err != nil {
+		t.Fatalf("expected execution stages to be valid, got %v", err)
+	}
+	stages := s.Services[0].Endpoints[0].Execution
+	if len(stages) != 2 || len(stages[0].StageGroups()) != 1 || stages[1].StageGroups()[0].Quorum != 2 {
+		t.Fatalf("unexpected execution stages %+v", stages)
+	}
+	for _, tc := range []struct{ from, to, want string }{
+		{"- calls: [auth:/check]", "- calls: [audit:/log]", "not a sync downstream call"},
+		{"- calls: [auth:/check]", "- calls: [auth:/other]", "not a sync downstream call"},
+		{"- calls: [auth:/check]", "- calls: [replica-a:/read]", "listed more than once"},
+		{"- calls: [auth:/check]", "- quorum: 1", "quorum requires calls"},
+		{"- calls: [auth:/check]", "- groups: []", "needs calls or groups"},
+		{"quorum: 2", "quorum: -1", "quorum cannot be negative"},
+		{"- calls: [replica-a:/read, replica-b:/read, replica-c:/read]", "- calls: []", "calls cannot be empty"},
+	} {
+		bad := strings.Replace(executionScenario, tc.from, tc.to, 1)
+		if _, err := ParseScenarioYAMLString(bad); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %v", tc.to, tc.want, err)
+		}
+	}
+}
+
+func TestExecutionGroupRequired(t *testing.T) {
+	g := ExecutionGroup{Calls: []string{"a", "b", "c"}, Quorum: 2}
+	if g.Required(3) != 2 || g.Required(1) != 1 {
+		t.Fatalf("expected the quorum capped at the calls issued")
+	}
+	all := ExecutionGroup{Calls: []string{"a", "b"}}
+	if all.Required(2) != 2 || all.Required(0) != 0 {
+		t.Fatalf("expected a group without quorum to need every call")
+	}
+}
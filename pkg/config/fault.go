@@ -0,0 +1,210 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Fault types.
+const (
+	// FaultLatency adds LatencyMs to every request to the target endpoint (or edge, with From).
+	FaultLatency = "latency"
+	// FaultFailureRate fails requests to the target endpoint (or edge) with probability FailureRate,
+	// on top of any configured failure rate.
+	FaultFailureRate = "failure_rate"
+	// FaultKillInstances kills Instances replicas of the target service; they restart when the fault ends.
+	FaultKillInstances = "kill_instances"
+	// FaultPartition fails calls between instances in the two target zones.
+	FaultPartition = "partition"
+	// FaultCPUSteal takes CPUSteal of the target host's CPU, so work on it runs 1/(1-CPUSteal) times longer.
+	FaultCPUSteal = "cpu_steal"
+	// FaultQueueFill takes Messages slots of the target queue, so publishes past its capacity follow
+	// its drop_policy.
+	FaultQueueFill = "queue_fill"
+)
+
+// EffectiveID returns the fault ID, or "<type>-<i+1>" when none is set.
+func (f *Fault) EffectiveID(i int) string {
+	if id := strings.TrimSpace(f.ID); id != "" {
+		return id
+	}
+	return fmt.Sprintf("%s-%d", f.Type, i+1)
+}
+
+// At returns when the fault starts, from the start of the run.
+func (f *Fault) At() time.Duration {
+	return time.Duration(f.AtMs * float64(time.Millisecond))
+}
+
+// Duration returns how long the fault lasts.
+func (f *Fault) Duration() time.Duration {
+	return time.Duration(f.DurationMs * float64(time.Millisecond))
+}
+
+// EffectiveInstances returns the number of replicas kill_instances kills.
+func (f *Fault) EffectiveInstances() int {
+	if f.Instances > 0 {
+		return f.Instances
+	}
+	return 1
+}
+
+// Describe renders the target for annotations: "service:endpoint" ("from->service:endpoint" for an
+// edge), the host, or "zone-a|zone-b".
+func (t FaultTarget) Describe() string {
+	switch {
+	case t.Host != "":
+		return t.Host
+	case len(t.Zones) > 0:
+		return strings.Join(t.Zones, "|")
+	}
+	out := t.Service
+	if t.Endpoint != "" {
+		out += ":" + t.Endpoint
+	}
+	if t.From != "" {
+		out = t.From + "->" + out
+	}
+	return out
+}
+
+// MatchesRequest reports whether a request to serviceID:endpoint, called by callerService ("" for
+// ingress), is in a latency or failure_rate target.
+func (t FaultTarget) MatchesRequest(serviceID, endpoint, callerService string) bool {
+	if t.Service != serviceID || (t.Endpoint != "" && t.Endpoint != endpoint) {
+		return false
+	}
+	return t.From == "" || t.From == callerService
+}
+
+// PartitionsZones reports whether a partition target separates zones a and b.
+func (t FaultTarget) PartitionsZones(a, b string) bool {
+	if len(t.Zones) != 2 || a == "" || b == "" {
+		return false
+	}
+	x, y := t.Zones[0], t.Zones[1]
+	return (strings.EqualFold(x, a) && strings.EqualFold(y, b)) || (strings.EqualFold(x, b) && strings.EqualFold(y, a))
+}
+
+func validateFaults(s *Scenario) error {
+	seen := make(map[string]bool, len(s.Faults))
+	for i := range s.Faults {
+		f := &s.Faults[i]
+		id := f.EffectiveID(i)
+		if seen[id] {
+			return fmt.Errorf("faults %s: duplicate id", id)
+		}
+		seen[id] = true
+		if err := ValidateFault(f, s); err != nil {
+			return fmt.Errorf("faults %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// ValidateFault checks a fault against the scenario it is injected into.
+func ValidateFault(f *Fault, s *Scenario) error {
+	if f.AtMs < 0 {
+		return fmt.Errorf("at_ms cannot be negative")
+	}
+	if f.DurationMs < 0 || (f.DurationMs == 0 && f.Type != FaultKillInstances) {
+		return fmt.Errorf("duration_ms must be positive")
+	}
+	t := f.Target
+	switch f.Type {
+	case FaultLatency, FaultFailureRate:
+		if err := validateFaultService(t, s, true); err != nil {
+			return err
+		}
+		if t.From != "" && findService(s, t.From) == nil {
+			return fmt.Errorf("target.from: service %s does not exist", t.From)
+		}
+		if f.Type == FaultLatency && (f.LatencyMs <= 0 || math.IsNaN(f.LatencyMs)) {
+			return fmt.Errorf("latency_ms must be positive")
+		}
+		if f.Type == FaultFailureRate && (f.FailureRate <= 0 || f.FailureRate > 1 || math.IsNaN(f.FailureRate)) {
+			return fmt.Errorf("failure_rate must be in (0, 1]")
+		}
+	case FaultKillInstances:
+		if err := validateFaultService(t, s, false); err != nil {
+			return err
+		}
+		if f.Instances < 0 {
+			return fmt.Errorf("instances cannot be negative")
+		}
+	case FaultPartition:
+		if len(t.Zones) != 2 || strings.EqualFold(t.Zones[0], t.Zones[1]) {
+			return fmt.Errorf("partition needs two different target.zones")
+		}
+		for _, z := range t.Zones {
+			if len((&HostFailure{Zone: z}).HostIDs(s.Hosts)) == 0 {
+				return fmt.Errorf("no host in zone %s", z)
+			}
+		}
+	case FaultCPUSteal:
+		if t.Host == "" {
+			return fmt.Errorf("cpu_steal needs target.host")
+		}
+		if len((&HostFailure{Host: t.Host}).HostIDs(s.Hosts)) == 0 {
+			return fmt.Errorf("host %s does not exist", t.Host)
+		}
+		if f.CPUSteal <= 0 || f.CPUSteal >= 1 || math.IsNaN(f.CPUSteal) {
+			return fmt.Errorf("cpu_steal must be in (0, 1)")
+		}
+	case FaultQueueFill:
+		if err := validateFaultService(t, s, true); err != nil {
+			return err
+		}
+		svc := findService(s, t.Service)
+		if strings.ToLower(strings.TrimSpace(svc.Kind)) != "queue" {
+			return fmt.Errorf("queue_fill needs a queue service, %s is not one", t.Service)
+		}
+		if f.Messages < 0 {
+			return fmt.Errorf("messages cannot be negative")
+		}
+		var q *QueueBehavior
+		if svc.Behavior != nil {
+			q = svc.Behavior.Queue
+		}
+		if EffectiveQueueBehavior(q).Capacity <= 0 {
+			return fmt.Errorf("queue %s has no capacity limit to fill", t.Service)
+		}
+	default:
+		return fmt.Errorf("unknown type %q (want latency, failure_rate, kill_instances, partition, cpu_steal or queue_fill)", f.Type)
+	}
+	return nil
+}
+
+// validateFaultService checks a service target, and its endpoint when withEndpoint.
+func validateFaultService(t FaultTarget, s *Scenario, withEndpoint bool) error {
+	if t.Service == "" {
+		return fmt.Errorf("target.service is required")
+	}
+	svc := findService(s, t.Service)
+	if svc == nil {
+		return fmt.Errorf("target.service: service %s does not exist", t.Service)
+	}
+	if t.Endpoint == "" {
+		return nil
+	}
+	if !withEndpoint {
+		return fmt.Errorf("target.endpoint is not used by this fault type")
+	}
+	for _, ep := range svc.Endpoints {
+		if ep.Path == t.Endpoint {
+			return nil
+		}
+	}
+	return fmt.Errorf("target.endpoint: endpoint %s:%s does not exist", t.Service, t.Endpoint)
+}
+
+func findService(s *Scenario, id string) *Service {
+	for i := range s.Services {
+		if s.Services[i].ID == id {
+			return &s.Services[i]
+		}
+	}
+	return nil
+}
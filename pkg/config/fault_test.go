@@ -0,0 +1,97 @@
+package config
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func faultTestScenario() *Scenario {
+	return &Scenario{
+		Hosts: []Host{{ID: "a-1", Zone: "zone-a"}, {ID: "b-1", Zone: "zone-b"}},
+		Services: []Service{
+			{ID: "api", Endpoints: []Endpoint{{Path: "/work"}}},
+			{ID: "ledger", Endpoints: []Endpoint{{Path: "/post"}}},
+			{ID: "jobs", Kind: "queue", Endpoints: []Endpoint{{Path: "/q"}}},
+			{ID: "stream", Kind: "queue", Endpoints: []Endpoint{{Path: "/q"}}, Behavior: &ServiceBehavior{Queue: &QueueBehavior{Capacity: -1}}},
+		},
+	}
+}
+
+func TestFaultTargetMatching(t *testing.T) {
+	edge := FaultTarget{Service: "ledger", Endpoint: "/post", From: "api"}
+	if !edge.MatchesRequest("ledger", "/post", "api") || edge.MatchesRequest("ledger", "/post", "") || edge.MatchesRequest("ledger", "/get", "api") {
+		t.Fatal("edge target matched the wrong requests")
+	}
+	if !(FaultTarget{Service: "ledger"}).MatchesRequest("ledger", "/get", "") {
+		t.Fatal("service target should match every endpoint and caller")
+	}
+	if got := edge.Describe(); got != "api->ledger:/post" {
+		t.Fatalf("edge described as %q", got)
+	}
+	p := FaultTarget{Zones: []string{"zone-a", "zone-b"}}
+	if !p.PartitionsZones("Zone-B", "zone-a") || p.PartitionsZones("zone-a", "zone-a") || p.PartitionsZones("zone-a", "") {
+		t.Fatal("partition matched the wrong zone pairs")
+	}
+	if id := (&Fault{Type: FaultLatency}).EffectiveID(2); id != "latency-3" {
+		t.Fatalf("default id = %q", id)
+	}
+}
+
+func TestValidateFaults(t *testing.T) {
+	s := faultTestScenario()
+	s.Faults = []Fault{
+		{Type: FaultLatency, AtMs: 1000, DurationMs: 500, Target: FaultTarget{Service: "ledger", Endpoint: "/post", From: "api"}, LatencyMs: 200},
+		{Type: FaultFailureRate, DurationMs: 500, Target: FaultTarget{Service: "api"}, FailureRate: 0.5},
+		{Type: FaultKillInstances, Target: FaultTarget{Service: "api"}, Instances: 2},
+		{Type: FaultPartition, DurationMs: 500, Target: FaultTarget{Zones: []string{"zone-a", "zone-b"}}},
+		{Type: FaultCPUSteal, DurationMs: 500, Target: FaultTarget{Host: "a-1"}, CPUSteal: 0.5},
+		{Type: FaultQueueFill, DurationMs: 500, Target: FaultTarget{Service: "jobs"}},
+	}
+	if err := validateFaults(s); err != nil {
+		t.Fatalf("expected valid faults, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		f    Fault
+		want string
+	}{
+		{"unknown type", Fault{Type: "meteor", DurationMs: 1}, "unknown type"},
+		{"no duration", Fault{Type: FaultLatency, Target: FaultTarget{Service: "api"}, LatencyMs: 1}, "duration_ms must be positive"},
+		{"negative at", Fault{Type: FaultLatency, AtMs: -1, DurationMs: 1}, "at_ms cannot be negative"},
+		{"unknown service", Fault{Type: FaultLatency, DurationMs: 1, Target: FaultTarget{Service: "nope"}, LatencyMs: 1}, "service nope does not exist"},
+		{"unknown endpoint", Fault{Type: FaultLatency, DurationMs: 1, Target: FaultTarget{Service: "api", Endpoint: "/x"}, LatencyMs: 1}, "endpoint api:/x does not exist"},
+		{"unknown caller", Fault{Type: FaultLatency, DurationMs: 1, Target: FaultTarget{Service: "api", From: "web"}, LatencyMs: 1}, "service web does not exist"},
+		{"no latency", Fault{Type: FaultLatency, DurationMs: 1, Target: FaultTarget{Service: "api"}}, "latency_ms must be positive"},
+		{"rate too high", Fault{Type: FaultFailureRate, DurationMs: 1, Target: FaultTarget{Service: "api"}, FailureRate: 1.5}, "failure_rate must be in (0, 1]"},
+		{"NaN rate", Fault{Type: FaultFailureRate, DurationMs: 1, Target: FaultTarget{Service: "api"}, FailureRate: math.NaN()}, "failure_rate must be in (0, 1]"},
+		{"NaN latency", Fault{Type: FaultLatency, DurationMs: 1, Target: FaultTarget{Service: "api"}, LatencyMs: math.NaN()}, "latency_ms must be positive"},
+		{"kill endpoint", Fault{Type: FaultKillInstances, Target: FaultTarget{Service: "api", Endpoint: "/work"}}, "target.endpoint is not used"},
+		{"one zone", Fault{Type: FaultPartition, DurationMs: 1, Target: FaultTarget{Zones: []string{"zone-a"}}}, "two different target.zones"},
+		{"empty zone", Fault{Type: FaultPartition, DurationMs: 1, Target: FaultTarget{Zones: []string{"zone-a", "zone-c"}}}, "no host in zone zone-c"},
+		{"unknown host", Fault{Type: FaultCPUSteal, DurationMs: 1, Target: FaultTarget{Host: "c-1"}, CPUSteal: 0.5}, "host c-1 does not exist"},
+		{"full steal", Fault{Type: FaultCPUSteal, DurationMs: 1, Target: FaultTarget{Host: "a-1"}, CPUSteal: 1}, "cpu_steal must be in (0, 1)"},
+		{"NaN steal", Fault{Type: FaultCPUSteal, DurationMs: 1, Target: FaultTarget{Host: "a-1"}, CPUSteal: math.NaN()}, "cpu_steal must be in (0, 1)"},
+		{"not a queue", Fault{Type: FaultQueueFill, DurationMs: 1, Target: FaultTarget{Service: "api"}}, "api is not one"},
+		{"unbounded queue", Fault{Type: FaultQueueFill, DurationMs: 1, Target: FaultTarget{Service: "stream"}}, "no capacity limit to fill"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := faultTestScenario()
+			s.Faults = []Fault{tc.f}
+			err := validateFaults(s)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+
+	s.Faults = []Fault{
+		{ID: "slow", Type: FaultLatency, DurationMs: 1, Target: FaultTarget{Service: "api"}, LatencyMs: 1},
+		{ID: "slow", Type: FaultLatency, DurationMs: 1, Target: FaultTarget{Service: "api"}, LatencyMs: 1},
+	}
+	if err := validateFaults(s); err == nil || !strings.Contains(err.Error(), "faults slow: duplicate id") {
+		t.Fatalf("expected duplicate id error, got %v", err)
+	}
+}
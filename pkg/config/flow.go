@@ -0,0 +1,120 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SessionIDMetadataKey is the request metadata key holding a flow session's id; routing with
+// sticky_key_from: session_id keeps a session on one instance.
+const SessionIDMetadataKey = "session_id"
+
+// StepName returns the metrics label of step i: its name, or its target when unnamed.
+func (f *Flow) StepName(i int) string {
+	if name := strings.TrimSpace(f.Steps[i].Name); name != "" {
+		return name
+	}
+	return f.Steps[i].To
+}
+
+// ContinueProbability returns the probability that a session moves on to this step.
+func (s *FlowStep) ContinueProbability() float64 {
+	if s.Probability == nil {
+		return 1
+	}
+	return *s.Probability
+}
+
+// SessionMetadata returns the metadata of a session at step i: the flow metadata overlaid with the
+// metadata of steps 0..i.
+func (f *Flow) SessionMetadata(i int) map[string]string {
+	md := make(map[string]string, len(f.Metadata))
+	for k, v := range f.Metadata {
+		md[k] = v
+	}
+	for _, step := range f.Steps[:i+1] {
+		for k, v := range step.Metadata {
+			md[k] = v
+		}
+	}
+	return md
+}
+
+// ExpectedSteps returns the mean number of steps a session issues.
+func (f *Flow) ExpectedSteps() float64 {
+	var total float64
+	reach := 1.0
+	for i := range f.Steps {
+		if i > 0 {
+			reach *= f.Steps[i].ContinueProbability()
+		}
+		total += reach
+	}
+	return total
+}
+
+// validateFlows checks flow ids, session arrivals and step targets.
+func validateFlows(flows []Flow, serviceIDs, endpointRef map[string]bool) error {
+	seen := make(map[string]bool, len(flows))
+	for i := range flows {
+		f := &flows[i]
+		id := strings.TrimSpace(f.ID)
+		if id == "" {
+			return fmt.Errorf("flow %d: id cannot be empty", i)
+		}
+		if strings.Contains(id, ":") {
+			return fmt.Errorf("flow %s: id cannot contain ':'", id)
+		}
+		if seen[id] {
+			return fmt.Errorf("flow %s: duplicate id", id)
+		}
+		seen[id] = true
+		if err := validateArrival(&f.Arrival, serviceIDs, endpointRef); err != nil {
+			return fmt.Errorf("flow %s: %w", id, err)
+		}
+		if f.Arrival.Type == "replay" {
+			return fmt.Errorf("flow %s: replay arrivals are not supported for flows", id)
+		}
+		if len(f.Steps) == 0 {
+			return fmt.Errorf("flow %s: at least one step must be defined", id)
+		}
+		if err := validateWeightedMetadata(f.WeightedMetadata, f.Metadata); err != nil {
+			return fmt.Errorf("flow %s: %w", id, err)
+		}
+		names := make(map[string]bool, len(f.Steps))
+		for j := range f.Steps {
+			step := &f.Steps[j]
+			if step.To == "" {
+				return fmt.Errorf("flow %s, step %d: 'to' cannot be empty", id, j)
+			}
+			svc, path, err := parseDownstreamTargetForValidation(step.To)
+			if err != nil {
+				return fmt.Errorf("flow %s, step %d: invalid to %q: %w", id, j, step.To, err)
+			}
+			if !serviceIDs[svc] {
+				return fmt.Errorf("flow %s, step %d: target service %s does not exist", id, j, svc)
+			}
+			if !endpointRef[svc+":"+path] {
+				return fmt.Errorf("flow %s, step %d: target endpoint %s:%s does not exist", id, j, svc, path)
+			}
+			name := f.StepName(j)
+			if names[name] {
+				return fmt.Errorf("flow %s, step %d: duplicate step name %q (set name on repeated targets)", id, j, name)
+			}
+			names[name] = true
+			if j == 0 && (step.Probability != nil || step.ThinkTimeMs.IsSet() || step.ThinkTimeMs.Distribution != "") {
+				return fmt.Errorf("flow %s: the first step starts the session and cannot set probability or think_time_ms", id)
+			}
+			if p := step.ContinueProbability(); !(p >= 0 && p <= 1) {
+				return fmt.Errorf("flow %s, step %d: probability must be between 0 and 1, got %v", id, j, p)
+			}
+			if !isFiniteNonNegative(step.ThinkTimeMs.Mean) || !isFiniteNonNegative(step.ThinkTimeMs.Sigma) {
+				return fmt.Errorf("flow %s, step %d: think_time_ms mean and sigma must be non-negative", id, j)
+			}
+			if err := validateLatencySpec(step.ThinkTimeMs); err != nil {
+				return fmt.Errorf("flow %s, step %d: think_time_ms: %w", id, j, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+const flowScenarioBase = `
+hosts:
+  - id: h1
+    cores: 2
+services:
+  - id: web
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /browse
+        mean_cpu_ms: 1
+        cpu_sigma_ms: 0
+      - path: /buy
+        mean_cpu_ms: 1
+        cpu_sigma_ms: 0
+flows:
+  - id: shop
+    metadata: {tier: gold}
+    arrival:
+      type: poisson
+      rate_rps: 2
+    steps:
+      - to: web:/browse
+        metadata: {entry: home}
+      - name: buy
+        to: web:/buy
+        probability: 0.25
+        think_time_ms: {mean: 500, sigma: 100}
+        metadata: {entry: cart}
+`
+
+func TestValidateScenarioFlows(t *testing.T) {
+	s, err := ParseScenarioYAMLString(flowScenarioBase)
+	if err != nil {
+		t.Fatalf("expected a flows-only scenario to be valid, got %v", err)
+	}
+	f := &s.Flows[0]
+	if f.StepName(0) != "web:/browse" || f.StepName(1) != "buy" {
+		t.Fatalf("unexpected step names %q %q", f.StepName(0), f.StepName(1))
+	}
+	if f.Steps[0].ContinueProbability() != 1 || f.Steps[1].ContinueProbability() != 0.25 {
+		t.Fatalf("unexpected step probabilities")
+	}
+	if got := f.ExpectedSteps(); math.Abs(got-1.25) > 1e-9 {
+		t.Fatalf("expected 1.25 steps per session, got %f", got)
+	}
+	if md := f.SessionMetadata(0); md["tier"] != "gold" || md["entry"] != "home" {
+		t.Fatalf("unexpected first-step metadata %v", md)
+	}
+	if md := f.SessionMetadata(1); md["tier"] != "gold" || md["entry"] != "cart" {
+		t.Fatalf("expected later step metadata to override, got %v", md)
+	}
+
+	for _, tc := range []struct{ from, to, want string }{
+		{"id: shop", "id: \"a:b\"", "cannot contain ':'"},
+		{"rate_rps: 2", "rate_rps: 0", "flow shop: arrival rate_rps must be positive"},
+		{"to: web:/buy", "to: web:/missing", "flow shop, step 1: target endpoint web:/missing does not exist"},
+		{"probability: 0.25", "probability: 1.5", "probability must be between 0 and 1"},
+		{"name: buy", "name: web:/browse", "duplicate step name"},
+		{"      - to: web:/browse\n", "      - to: web:/browse\n        probability: 0.5\n", "first step starts the session"},
+		{"type: poisson\n      rate_rps: 2", "type: replay\n      replay_format: csv\n      replay_data: \"timestamp\\n0\\n\"", "replay arrivals are not supported"},
+	} {
+		bad := strings.Replace(flowScenarioBase, tc.from, tc.to, 1)
+		if _, err := ParseScenarioYAMLString(bad); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %v", tc.to, tc.want, err)
+		}
+	}
+
+	noWorkload := strings.Replace(flowScenarioBase, flowScenarioBase[strings.Index(flowScenarioBase, "flows:"):], "", 1)
+	if _, err := ParseScenarioYAMLString(noWorkload); err == nil || !strings.Contains(err.Error(), "at least one workload pattern or flow") {
+		t.Fatalf("expected a scenario without workload or flows to be rejected, got %v", err)
+	}
+}
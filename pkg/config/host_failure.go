@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// At returns when the hosts fail, from the start of the run.
+func (f *HostFailure) At() time.Duration {
+	return time.Duration(f.AtMs * float64(time.Millisecond))
+}
+
+// RecoverAfter returns how long the hosts stay down; 0 means for the rest of the run.
+func (f *HostFailure) RecoverAfter() time.Duration {
+	return time.Duration(f.RecoverAfterMs * float64(time.Millisecond))
+}
+
+// RescheduleDelay returns how long until replacements for the lost instances are placed.
+func (f *HostFailure) RescheduleDelay() time.Duration {
+	return time.Duration(f.RescheduleDelayMs * float64(time.Millisecond))
+}
+
+// HostIDs returns the scenario hosts the failure takes down, in scenario order.
+func (f *HostFailure) HostIDs(hosts []Host) []string {
+	var ids []string
+	for _, h := range hosts {
+		if (f.Host != "" && h.ID == f.Host) || (f.Zone != "" && strings.EqualFold(strings.TrimSpace(h.Zone), strings.TrimSpace(f.Zone))) {
+			ids = append(ids, h.ID)
+		}
+	}
+	return ids
+}
+
+func validateHostFailures(failures []HostFailure, hosts []Host) error {
+	for i := range failures {
+		f := &failures[i]
+		if (f.Host == "") == (f.Zone == "") {
+			return fmt.Errorf("host_failures %d: exactly one of host or zone must be set", i)
+		}
+		if len(f.HostIDs(hosts)) == 0 {
+			if f.Host != "" {
+				return fmt.Errorf("host_failures %d: host %s does not exist", i, f.Host)
+			}
+			return fmt.Errorf("host_failures %d: no host in zone %s", i, f.Zone)
+		}
+		if f.AtMs < 0 {
+			return fmt.Errorf("host_failures %d: at_ms cannot be negative", i)
+		}
+		if f.RecoverAfterMs < 0 {
+			return fmt.Errorf("host_failures %d: recover_after_ms cannot be negative", i)
+		}
+		if f.RescheduleDelayMs < 0 {
+			return fmt.Errorf("host_failures %d: reschedule_delay_ms cannot be negative", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHostFailureHostIDs(t *testing.T) {
+	hosts := []Host{{ID: "a-1", Zone: "zone-a"}, {ID: "b-1", Zone: "zone-b"}, {ID: "a-2", Zone: "Zone-A"}}
+	if got := (&HostFailure{Zone: "zone-a"}).HostIDs(hosts); !reflect.DeepEqual(got, []string{"a-1", "a-2"}) {
+		t.Fatalf("zone failure hosts = %v", got)
+	}
+	if got := (&HostFailure{Host: "b-1"}).HostIDs(hosts); !reflect.DeepEqual(got, []string{"b-1"}) {
+		t.Fatalf("host failure hosts = %v", got)
+	}
+	f := &HostFailure{AtMs: 1500, RecoverAfterMs: 30000, RescheduleDelayMs: 250}
+	if f.At() != 1500*time.Millisecond || f.RecoverAfter() != 30*time.Second || f.RescheduleDelay() != 250*time.Millisecond {
+		t.Fatalf("unexpected durations %v %v %v", f.At(), f.RecoverAfter(), f.RescheduleDelay())
+	}
+}
+
+func TestValidateHostFailures(t *testing.T) {
+	hosts := []Host{{ID: "a-1", Zone: "zone-a"}, {ID: "b-1", Zone: "zone-b"}}
+	valid := []HostFailure{{Zone: "zone-a", AtMs: 1000, RecoverAfterMs: 5000, RescheduleDelayMs: 500}, {Host: "b-1", AtMs: 2000}}
+	if err := validateHostFailures(valid, hosts); err != nil {
+		t.Fatalf("expected valid host failures, got %v", err)
+	}
+	tests := []struct {
+		name string
+		f    HostFailure
+		want string
+	}{
+		{"no target", HostFailure{AtMs: 1}, "exactly one of host or zone"},
+		{"both targets", HostFailure{Host: "a-1", Zone: "zone-a"}, "exactly one of host or zone"},
+		{"unknown host", HostFailure{Host: "c-1"}, "host c-1 does not exist"},
+		{"empty zone", HostFailure{Zone: "zone-c"}, "no host in zone zone-c"},
+		{"negative at", HostFailure{Host: "a-1", AtMs: -1}, "at_ms cannot be negative"},
+		{"negative recovery", HostFailure{Host: "a-1", RecoverAfterMs: -1}, "recover_after_ms cannot be negative"},
+		{"negative reschedule", HostFailure{Host: "a-1", RescheduleDelayMs: -1}, "reschedule_delay_ms cannot be negative"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateHostFailures([]HostFailure{tc.f}, hosts)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
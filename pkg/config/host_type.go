@@ -0,0 +1,103 @@
+package config
+
+import "fmt"
+
+// HostTypeByID returns the host_types entry with the given ID.
+func (s *Scenario) HostTypeByID(id string) (*HostType, bool) {
+	if id == "" {
+		return nil, false
+	}
+	for i := range s.HostTypes {
+		if s.HostTypes[i].ID == id {
+			return &s.HostTypes[i], true
+		}
+	}
+	return nil, false
+}
+
+// EffectiveSpeedFactor returns the per-core speed multiplier of the type (default 1).
+func (t *HostType) EffectiveSpeedFactor() float64 {
+	if t.SpeedFactor > 0 {
+		return t.SpeedFactor
+	}
+	return 1
+}
+
+// HostSpeedFactor returns the per-core speed multiplier of a host: its type's, or 1 for a host
+// without a type.
+func (s *Scenario) HostSpeedFactor(h *Host) float64 {
+	if t, ok := s.HostTypeByID(h.Type); ok {
+		return t.EffectiveSpeedFactor()
+	}
+	return 1
+}
+
+// HostPricePerHour returns the hourly price of a host: its type's, or 0 for a host without a type.
+func (s *Scenario) HostPricePerHour(h *Host) float64 {
+	if t, ok := s.HostTypeByID(h.Type); ok {
+		return t.PricePerHour
+	}
+	return 0
+}
+
+// SetHostType switches host i to the host type with the given ID, taking the type's cores and memory.
+func (s *Scenario) SetHostType(i int, typeID string) error {
+	t, ok := s.HostTypeByID(typeID)
+	if !ok {
+		return fmt.Errorf("host type %s does not exist", typeID)
+	}
+	h := &s.Hosts[i]
+	h.Type = t.ID
+	h.Cores = t.Cores
+	h.MemoryGB = t.MemoryGB
+	return nil
+}
+
+// applyHostTypeDefaults fills the cores and memory_gb a typed host leaves unset from its type.
+func applyHostTypeDefaults(s *Scenario) {
+	for i := range s.Hosts {
+		h := &s.Hosts[i]
+		t, ok := s.HostTypeByID(h.Type)
+		if !ok {
+			continue
+		}
+		if h.Cores == 0 {
+			h.Cores = t.Cores
+		}
+		if h.MemoryGB == 0 {
+			h.MemoryGB = t.MemoryGB
+		}
+	}
+}
+
+func validateHostTypes(s *Scenario) error {
+	seen := make(map[string]bool, len(s.HostTypes))
+	for i := range s.HostTypes {
+		t := &s.HostTypes[i]
+		if t.ID == "" {
+			return fmt.Errorf("host_types[%d]: id cannot be empty", i)
+		}
+		if seen[t.ID] {
+			return fmt.Errorf("duplicate host type id: %s", t.ID)
+		}
+		seen[t.ID] = true
+		if t.Cores <= 0 {
+			return fmt.Errorf("host type %s: cores must be positive", t.ID)
+		}
+		if t.MemoryGB < 0 {
+			return fmt.Errorf("host type %s: memory_gb cannot be negative", t.ID)
+		}
+		if t.SpeedFactor < 0 {
+			return fmt.Errorf("host type %s: speed_factor cannot be negative", t.ID)
+		}
+		if t.PricePerHour < 0 {
+			return fmt.Errorf("host type %s: price_per_hour cannot be negative", t.ID)
+		}
+	}
+	for _, h := range s.Hosts {
+		if h.Type != "" && !seen[h.Type] {
+			return fmt.Errorf("host %s: host type %s does not exist", h.ID, h.Type)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHostTypeDefaultsAndLookups(t *testing.T) {
+	data := []byte(`
+host_types:
+  - id: c-large
+    cores: 8
+    memory_gb: 16
+    speed_factor: 1.5
+    price_per_hour: 0.34
+  - id: m-small
+    cores: 2
+    memory_gb: 8
+hosts:
+  - id: h1
+    type: c-large
+  - id: h2
+    type: c-large
+    cores: 4
+  - id: h3
+    cores: 2
+services: []
+workload: []
+`)
+	s, err := UnmarshalScenarioYAML(data)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if h := s.Hosts[0]; h.Cores != 8 || h.MemoryGB != 16 {
+		t.Fatalf("h1 should take the type's capacity, got %+v", h)
+	}
+	if h := s.Hosts[1]; h.Cores != 4 || h.MemoryGB != 16 {
+		t.Fatalf("h2 should keep its own cores, got %+v", h)
+	}
+	if got := s.HostSpeedFactor(&s.Hosts[0]); got != 1.5 {
+		t.Fatalf("speed factor = %v", got)
+	}
+	if got := s.HostSpeedFactor(&s.Hosts[2]); got != 1 {
+		t.Fatalf("untyped host speed factor = %v", got)
+	}
+	if got := s.HostPricePerHour(&s.Hosts[0]); got != 0.34 {
+		t.Fatalf("price = %v", got)
+	}
+	if got := s.HostPricePerHour(&s.Hosts[2]); got != 0 {
+		t.Fatalf("untyped host price = %v", got)
+	}
+	if err := s.SetHostType(0, "m-small"); err != nil {
+		t.Fatalf("SetHostType: %v", err)
+	}
+	if h := s.Hosts[0]; h.Type != "m-small" || h.Cores != 2 || h.MemoryGB != 8 {
+		t.Fatalf("SetHostType did not take the type's capacity: %+v", h)
+	}
+	if got := s.HostSpeedFactor(&s.Hosts[0]); got != 1 {
+		t.Fatalf("default speed factor = %v", got)
+	}
+	if err := s.SetHostType(0, "nope"); err == nil {
+		t.Fatal("expected error for unknown host type")
+	}
+}
+
+func TestValidateHostTypes(t *testing.T) {
+	valid := func() *Scenario {
+		return &Scenario{
+			HostTypes: []HostType{{ID: "std", Cores: 4, MemoryGB: 16, SpeedFactor: 1.2, PricePerHour: 0.2}},
+			Hosts:     []Host{{ID: "h1", Type: "std", Cores: 4}},
+		}
+	}
+	if err := validateHostTypes(valid()); err != nil {
+		t.Fatalf("expected valid host types, got %v", err)
+	}
+	tests := []struct {
+		name   string
+		mutate func(s *Scenario)
+		want   string
+	}{
+		{"empty id", func(s *Scenario) { s.HostTypes[0].ID = "" }, "id cannot be empty"},
+		{"duplicate", func(s *Scenario) { s.HostTypes = append(s.HostTypes, s.HostTypes[0]) }, "duplicate host type id: std"},
+		{"no cores", func(s *Scenario) { s.HostTypes[0].Cores = 0 }, "cores must be positive"},
+		{"negative speed", func(s *Scenario) { s.HostTypes[0].SpeedFactor = -1 }, "speed_factor cannot be negative"},
+		{"negative price", func(s *Scenario) { s.HostTypes[0].PricePerHour = -1 }, "price_per_hour cannot be negative"},
+		{"unknown type", func(s *Scenario) { s.Hosts[0].Type = "gpu" }, "host h1: host type gpu does not exist"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := valid()
+			tc.mutate(s)
+			err := validateHostTypes(s)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+// LatencySpec distributions.
+const (
+	DistributionNormal      = "normal"
+	DistributionLognormal   = "lognormal"
+	DistributionGamma       = "gamma"
+	DistributionExponential = "exponential"
+	DistributionWeibull     = "weibull"
+	DistributionFixed       = "fixed"
+	DistributionEmpirical   = "empirical"
+)
+
+// Weibull shapes searched when matching a coefficient of variation (cv from about 1e4 down to 0.003).
+const (
+	weibullMinShape = 0.05
+	weibullMaxShape = 500.0
+)
+
+// weibullShapeCacheSize bounds weibullShapes. A scenario has a few distinct cvs, but a long-running
+// daemon sees many scenarios (optimizer neighbors rescale latencies), so the cache is cleared when full.
+const weibullShapeCacheSize = 256
+
+// weibullShapes caches the Weibull shape per coefficient of variation; solving for it is iterative.
+var (
+	weibullShapesMu sync.RWMutex
+	weibullShapes   = make(map[float64]float64, weibullShapeCacheSize)
+)
+
+// IsSet reports whether the spec describes any latency.
+func (l LatencySpec) IsSet() bool {
+	return l.Mean > 0 || l.Sigma > 0 || len(l.Histogram) > 0 || len(l.Percentiles) > 0
+}
+
+// MeanMs returns the mean of the distribution: Mean, or for an empirical spec without a mean the
+// mean of its table.
+func (l LatencySpec) MeanMs() float64 {
+	if l.Distribution == DistributionEmpirical && l.Mean <= 0 {
+		return l.empiricalMean()
+	}
+	return l.Mean
+}
+
+// Sample draws a latency in ms from the distribution, truncated at zero. A mean set on an empirical
+// spec rescales its table to that mean.
+func (l LatencySpec) Sample(rng *utils.RandSource) float64 {
+	var v float64
+	switch l.Distribution {
+	case DistributionFixed:
+		v = l.Mean
+	case DistributionExponential:
+		if l.Mean > 0 {
+			v = rng.ExpFloat64(1 / l.Mean)
+		}
+	case DistributionLognormal:
+		if l.Mean > 0 && l.Sigma > 0 {
+			sigma2 := math.Log1p(l.Sigma * l.Sigma / (l.Mean * l.Mean))
+			v = rng.LogNormalFloat64(math.Log(l.Mean)-sigma2/2, math.Sqrt(sigma2))
+		} else {
+			v = l.Mean
+		}
+	case DistributionGamma:
+		if l.Mean > 0 && l.Sigma > 0 {
+			v = rng.GammaFloat64(l.Mean*l.Mean/(l.Sigma*l.Sigma), l.Sigma*l.Sigma/l.Mean)
+		} else {
+			v = l.Mean
+		}
+	case DistributionWeibull:
+		if l.Mean > 0 && l.Sigma > 0 {
+			k := weibullShape(l.Sigma / l.Mean)
+			v = rng.WeibullFloat64(k, l.Mean/math.Gamma(1+1/k))
+		} else {
+			v = l.Mean
+		}
+	case DistributionEmpirical:
+		v = l.empiricalQuantile(rng.Float64())
+		if l.Mean > 0 {
+			if m := l.empiricalMean(); m > 0 {
+				v *= l.Mean / m
+			}
+		}
+	default:
+		v = rng.NormFloat64(l.Mean, math.Max(l.Sigma, 0))
+	}
+	if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return v
+}
+
+// empiricalQuantile returns the value at cumulative probability u of the histogram or percentile table.
+func (l LatencySpec) empiricalQuantile(u float64) float64 {
+	if pts := l.Percentiles; len(pts) > 0 {
+		q := u * 100
+		if q <= pts[0].P {
+			return pts[0].Ms
+		}
+		for i := 1; i < len(pts); i++ {
+			if q < pts[i].P {
+				a, b := pts[i-1], pts[i]
+				return a.Ms + (b.Ms-a.Ms)*(q-a.P)/(b.P-a.P)
+			}
+		}
+		return pts[len(pts)-1].Ms
+	}
+	var total float64
+	for _, b := range l.Histogram {
+		total += b.Weight
+	}
+	target := u * total
+	var acc, lo float64
+	for _, b := range l.Histogram {
+		if b.Weight > 0 && target < acc+b.Weight {
+			return lo + (b.UpToMs-lo)*(target-acc)/b.Weight
+		}
+		acc += b.Weight
+		lo = b.UpToMs
+	}
+	return lo
+}
+
+// empiricalMean returns the mean of the histogram or percentile table.
+func (l LatencySpec) empiricalMean() float64 {
+	if pts := l.Percentiles; len(pts) > 0 {
+		// Values are held below the first and above the last percentile.
+		mean := pts[0].P / 100 * pts[0].Ms
+		for i := 1; i < len(pts); i++ {
+			mean += (pts[i].P - pts[i-1].P) / 100 * (pts[i].Ms + pts[i-1].Ms) / 2
+		}
+		return mean + (100-pts[len(pts)-1].P)/100*pts[len(pts)-1].Ms
+	}
+	var total, sum, lo float64
+	for _, b := range l.Histogram {
+		total += b.Weight
+		sum += b.Weight * (lo + b.UpToMs) / 2
+		lo = b.UpToMs
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / total
+}
+
+// weibullShape returns the Weibull shape whose coefficient of variation is cv.
+func weibullShape(cv float64) float64 {
+	weibullShapesMu.RLock()
+	k, ok := weibullShapes[cv]
+	weibullShapesMu.RUnlock()
+	if ok {
+		return k
+	}
+	// cv decreases with the shape; bisect on a log scale.
+	lo, hi := weibullMinShape, weibullMaxShape
+	for i := 0; i < 100; i++ {
+		mid := math.Sqrt(lo * hi)
+		if weibullCV(mid) > cv {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	k = math.Sqrt(lo * hi)
+	weibullShapesMu.Lock()
+	if len(weibullShapes) >= weibullShapeCacheSize {
+		clear(weibullShapes)
+	}
+	weibullShapes[cv] = k
+	weibullShapesMu.Unlock()
+	return k
+}
+
+// weibullCV is the coefficient of variation of a Weibull distribution with shape k.
+func weibullCV(k float64) float64 {
+	lg1, _ := math.Lgamma(1 + 1/k)
+	lg2, _ := math.Lgamma(1 + 2/k)
+	return math.Sqrt(math.Exp(lg2-2*lg1) - 1)
+}
+
+// CPUSpec returns the endpoint's CPU time distribution.
+func (ep *Endpoint) CPUSpec() LatencySpec {
+	return LatencySpec{
+		Mean:         ep.MeanCPUMs,
+		Sigma:        ep.CPUSigmaMs,
+		Distribution: ep.CPUDistribution,
+		Histogram:    ep.CPUHistogram,
+		Percentiles:  ep.CPUPercentiles,
+	}
+}
+
+// validateLatencySpec checks the mean, sigma, distribution and empirical table of l.
+func validateLatencySpec(l LatencySpec) error {
+	if !isFiniteNonNegative(l.Mean) || !isFiniteNonNegative(l.Sigma) {
+		return fmt.Errorf("mean and sigma must be non-negative")
+	}
+	tables := len(l.Histogram) > 0 || len(l.Percentiles) > 0
+	switch l.Distribution {
+	case "", DistributionNormal:
+	case DistributionLognormal, DistributionGamma, DistributionWeibull:
+		if l.Mean <= 0 {
+			return fmt.Errorf("%s distribution requires a positive mean", l.Distribution)
+		}
+	case DistributionExponential, DistributionFixed:
+		if l.Distribution == DistributionExponential && l.Mean <= 0 {
+			return fmt.Errorf("exponential distribution requires a positive mean")
+		}
+		if l.Sigma != 0 {
+			return fmt.Errorf("sigma is not used by the %s distribution", l.Distribution)
+		}
+	case DistributionEmpirical:
+		if l.Sigma != 0 {
+			return fmt.Errorf("sigma is not used by the empirical distribution")
+		}
+		return validateEmpiricalTable(l)
+	default:
+		return fmt.Errorf("invalid distribution %q (supported: normal, lognormal, gamma, exponential, weibull, fixed, empirical)", l.Distribution)
+	}
+	if tables {
+		return fmt.Errorf("histogram and percentiles require the empirical distribution")
+	}
+	return nil
+}
+
+// validateEmpiricalTable checks that an empirical spec has exactly one well-formed table.
+func validateEmpiricalTable(l LatencySpec) error {
+	switch {
+	case len(l.Histogram) > 0 && len(l.Percentiles) > 0:
+		return fmt.Errorf("empirical distribution takes either histogram or percentiles, not both")
+	case len(l.Histogram) > 0:
+		var prev, total float64
+		for i, b := range l.Histogram {
+			if !(b.UpToMs > prev) || math.IsInf(b.UpToMs, 1) {
+				return fmt.Errorf("histogram bin %d: up_to_ms must be finite and increasing, got %v", i, b.UpToMs)
+			}
+			if !isFiniteNonNegative(b.Weight) {
+				return fmt.Errorf("histogram bin %d: weight must be non-negative, got %v", i, b.Weight)
+			}
+			prev = b.UpToMs
+			total += b.Weight
+		}
+		if total <= 0 {
+			return fmt.Errorf("histogram needs a bin with positive weight")
+		}
+	case len(l.Percentiles) > 0:
+		for i, pt := range l.Percentiles {
+			if pt.P < 0 || pt.P > 100 || math.IsNaN(pt.P) {
+				return fmt.Errorf("percentiles[%d]: p must be between 0 and 100, got %v", i, pt.P)
+			}
+			if !isFiniteNonNegative(pt.Ms) {
+				return fmt.Errorf("percentiles[%d]: ms must be non-negative, got %v", i, pt.Ms)
+			}
+			if i > 0 && (pt.P <= l.Percentiles[i-1].P || pt.Ms < l.Percentiles[i-1].Ms) {
+				return fmt.Errorf("percentiles[%d]: p must increase and ms must not decrease", i)
+			}
+		}
+	default:
+		return fmt.Errorf("empirical distribution requires a histogram or percentiles")
+	}
+	return nil
+}
+
+// validateNetworkLatencies checks the latency overlays of scenario.network.
+func validateNetworkLatencies(n *NetworkConfig) error {
+	if n == nil {
+		return nil
+	}
+	for _, f := range []struct {
+		name string
+		spec LatencySpec
+	}{
+		{"same_host_latency_ms", n.SameHostLatencyMs},
+		{"same_zone_latency_ms", n.SameZoneLatencyMs},
+		{"default_cross_zone_latency_ms", n.DefaultCrossZoneLatencyMs},
+		{"external_latency_ms", n.ExternalLatencyMs},
+	} {
+		if err := validateLatencySpec(f.spec); err != nil {
+			return fmt.Errorf("network.%s: %w", f.name, err)
+		}
+	}
+	from := make([]string, 0, len(n.CrossZoneLatencyMs))
+	for z := range n.CrossZoneLatencyMs {
+		from = append(from, z)
+	}
+	sort.Strings(from)
+	for _, a := range from {
+		to := make([]string, 0, len(n.CrossZoneLatencyMs[a]))
+		for z := range n.CrossZoneLatencyMs[a] {
+			to = append(to, z)
+		}
+		sort.Strings(to)
+		for _, b := range to {
+			if err := validateLatencySpec(n.CrossZoneLatencyMs[a][b]); err != nil {
+				return fmt.Errorf("network.cross_zone_latency_ms %s -> %s: %w", a, b, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/GoSim-25-26J-441/simulation-core/pkg/utils"
+)
+
+func sampleLatency(l LatencySpec, n int) []float64 {
+	rng := utils.NewRandSource(9)
+	out := make([]float64, n)
+	for i := range out {
+		out[i] = l.Sample(rng)
+	}
+	return out
+}
+
+func TestLatencySpecSampleMoments(t *testing.T) {
+	for _, l := range []LatencySpec{
+		{Mean: 20, Sigma: 5},
+		{Mean: 20, Sigma: 30, Distribution: DistributionLognormal},
+		{Mean: 20, Sigma: 10, Distribution: DistributionGamma},
+		{Mean: 20, Sigma: 40, Distribution: DistributionGamma}, // shape below 1
+		{Mean: 20, Distribution: DistributionExponential},
+		{Mean: 20, Sigma: 8, Distribution: DistributionWeibull},
+		{Mean: 20, Sigma: 40, Distribution: DistributionWeibull},
+	} {
+		samples := sampleLatency(l, 200000)
+		want := l.Sigma
+		if l.Distribution == DistributionExponential {
+			want = l.Mean
+		}
+		if m := utils.Mean(samples); math.Abs(m-l.Mean) > 0.03*l.Mean {
+			t.Fatalf("%+v: expected mean %v, got %v", l, l.Mean, m)
+		}
+		if sd := utils.StdDev(samples); math.Abs(sd-want) > 0.1*want {
+			t.Fatalf("%+v: expected standard deviation %v, got %v", l, want, sd)
+		}
+	}
+	for _, v := range sampleLatency(LatencySpec{Mean: 7, Distribution: DistributionFixed}, 10) {
+		if v != 7 {
+			t.Fatalf("expected a fixed latency of 7, got %v", v)
+		}
+	}
+}
+
+func TestLatencySpecNormalKeepsLegacySequence(t *testing.T) {
+	l := LatencySpec{Mean: 10, Sigma: 3}
+	a, b := utils.NewRandSource(4), utils.NewRandSource(4)
+	for i := 0; i < 100; i++ {
+		want := math.Max(b.NormFloat64(10, 3), 0)
+		if got := l.Sample(a); got != want {
+			t.Fatalf("draw %d: expected %v, got %v", i, want, got)
+		}
+	}
+}
+
+func TestLatencySpecEmpirical(t *testing.T) {
+	pct := LatencySpec{Distribution: DistributionEmpirical, Percentiles: []PercentilePoint{{P: 50, Ms: 10}, {P: 90, Ms: 30}, {P: 99, Ms: 200}}}
+	for _, tc := range []struct{ u, want float64 }{{0.2, 10}, {0.5, 10}, {0.7, 20}, {0.99, 200}, {0.999, 200}} {
+		if got := pct.empiricalQuantile(tc.u); math.Abs(got-tc.want) > 1e-9 {
+			t.Fatalf("quantile %v: expected %v, got %v", tc.u, tc.want, got)
+		}
+	}
+	// 0.5*10 + 0.4*20 + 0.09*115 + 0.01*200
+	if got, want := pct.MeanMs(), 25.35; math.Abs(got-want) > 1e-9 {
+		t.Fatalf("expected percentile table mean %v, got %v", want, got)
+	}
+	samples := sampleLatency(pct, 100000)
+	if p := utils.Percentile(samples, 90); math.Abs(p-30) > 1.5 {
+		t.Fatalf("expected p90 near 30, got %v", p)
+	}
+
+	hist := LatencySpec{Distribution: DistributionEmpirical, Histogram: []HistogramBin{{UpToMs: 10, Weight: 3}, {UpToMs: 100, Weight: 1}}}
+	if got := hist.MeanMs(); math.Abs(got-(0.75*5+0.25*55)) > 1e-9 {
+		t.Fatalf("unexpected histogram mean %v", got)
+	}
+	if got := hist.empiricalQuantile(0.875); math.Abs(got-55) > 1e-9 {
+		t.Fatalf("expected the upper bin midpoint at 0.875, got %v", got)
+	}
+
+	// A mean rescales the table.
+	scaled := hist
+	scaled.Mean = 2 * hist.MeanMs()
+	if m := utils.Mean(sampleLatency(scaled, 100000)); math.Abs(m-scaled.Mean) > 0.03*scaled.Mean {
+		t.Fatalf("expected a rescaled mean of %v, got %v", scaled.Mean, m)
+	}
+	if scaled.MeanMs() != scaled.Mean {
+		t.Fatalf("expected MeanMs to report the set mean")
+	}
+}
+
+const latencyScenario = `
+hosts:
+  - id: h1
+    cores: 2
+services:
+  - id: api
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /a
+        mean_cpu_ms: 5
+        cpu_sigma_ms: 10
+        cpu_distribution: lognormal
+        net_latency_ms: {mean: 2, sigma: 1, distribution: gamma}
+        downstream:
+          - to: db:/q
+            call_latency_ms:
+              distribution: empirical
+              percentiles: [{p: 50, ms: 1}, {p: 99, ms: 20}]
+  - id: db
+    replicas: 1
+    model: db_latency
+    endpoints:
+      - path: /q
+        mean_cpu_ms: 1
+        cpu_sigma_ms: 0
+        net_latency_ms: {mean: 0, sigma: 0}
+        io_ms:
+          distribution: empirical
+          histogram: [{up_to_ms: 5, weight: 9}, {up_to_ms: 50, weight: 1}]
+workload:
+  - from: client
+    to: api:/a
+    arrival:
+      type: poisson
+      rate_rps: 10
+`
+
+func TestValidateScenarioLatencyDistributions(t *testing.T) {
+	if _, err := ParseScenarioYAMLString(latencyScenario); err != nil {
+		t.Fatalf("expected latency distributions to be valid, got %v", err)
+	}
+	for _, tc := range []struct{ from, to, want string }{
+		{"cpu_distribution: lognormal", "cpu_distribution: cauchy", "cpu_distribution: invalid distribution \"cauchy\""},
+		{"mean_cpu_ms: 5", "mean_cpu_ms: 0", "cpu_distribution: lognormal distribution requires a positive mean"},
+		{"distribution: gamma", "distribution: exponential", "net_latency_ms: sigma is not used by the exponential distribution"},
+		{"{p: 50, ms: 1}, {p: 99, ms: 20}", "{p: 50, ms: 30}, {p: 99, ms: 20}", "percentiles[1]: p must increase and ms must not decrease"},
+		{"{p: 50, ms: 1}, {p: 99, ms: 20}", "{p: 50, ms: 1}, {p: 120, ms: 20}", "p must be between 0 and 100"},
+		{"percentiles: [{p: 50, ms: 1}, {p: 99, ms: 20}]", "mean: 3", "empirical distribution requires a histogram or percentiles"},
+		{"{up_to_ms: 5, weight: 9}, {up_to_ms: 50, weight: 1}", "{up_to_ms: 5, weight: 9}, {up_to_ms: 5, weight: 1}", "histogram bin 1: up_to_ms must be finite and increasing"},
+		{"{up_to_ms: 5, weight: 9}, {up_to_ms: 50, weight: 1}", "{up_to_ms: 5, weight: 0}", "histogram needs a bin with positive weight"},
+		{"          distribution: empirical\n          histogram", "          histogram", "io_ms: histogram and percentiles require the empirical distribution"},
+	} {
+		bad := strings.Replace(latencyScenario, tc.from, tc.to, 1)
+		if _, err := ParseScenarioYAMLString(bad); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %v", tc.to, tc.want, err)
+		}
+	}
+}
+
+func TestValidateScenarioNetworkLatencyDistribution(t *testing.T) {
+	bad := latencyScenario + `network:
+  cross_zone_latency_ms:
+    zone-a:
+      zone-b: {mean: 0, sigma: 2, distribution: weibull}
+`
+	if _, err := ParseScenarioYAMLString(bad); err == nil || !strings.Contains(err.Error(), "network.cross_zone_latency_ms zone-a -> zone-b: weibull distribution requires a positive mean") {
+		t.Fatalf("expected the cross-zone latency to be validated, got %v", err)
+	}
+}
+
+func TestWeibullShapeCacheIsBounded(t *testing.T) {
+	want := weibullShape(0.4)
+	for i := 0; i < 3*weibullShapeCacheSize; i++ {
+		weibullShape(0.1 + float64(i)*1e-3)
+	}
+	weibullShapesMu.RLock()
+	n := len(weibullShapes)
+	weibullShapesMu.RUnlock()
+	if n > weibullShapeCacheSize {
+		t.Fatalf("expected at most %d cached shapes, got %d", weibullShapeCacheSize, n)
+	}
+	if got := weibullShape(0.4); got != want {
+		t.Fatalf("expected the same shape after eviction, got %v want %v", got, want)
+	}
+}
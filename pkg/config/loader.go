@@ -179,6 +179,9 @@ func validatePolicies(p *Policies) error {
 		if p.Autoscaling.ScaleStep <= 0 {
 			return fmt.Errorf("autoscaling scale_step must be positive, got %d", p.Autoscaling.ScaleStep)
 		}
+		if err := validateAutoscalingControl(p.Autoscaling); err != nil {
+			return err
+		}
 	}
 
 	if p.Retries != nil {
@@ -198,6 +201,41 @@ func validatePolicies(p *Policies) error {
 		}
 	}
 
+	if p.CircuitBreaker != nil {
+		if err := validateCircuitBreaker(p.CircuitBreaker); err != nil {
+			return err
+		}
+	}
+
+	if p.RateLimiting != nil {
+		if err := validateRateLimiting(p.RateLimiting, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateAutoscalingControl validates HPA emulation fields (replica bounds and timing).
+func validateAutoscalingControl(a *AutoscalingPolicy) error {
+	if a.MinReplicas < 0 {
+		return fmt.Errorf("autoscaling min_replicas cannot be negative, got %d", a.MinReplicas)
+	}
+	if a.MaxReplicas < 0 {
+		return fmt.Errorf("autoscaling max_replicas cannot be negative, got %d", a.MaxReplicas)
+	}
+	if minR, maxR := a.EffectiveReplicaBounds(); maxR < minR {
+		return fmt.Errorf("autoscaling max_replicas (%d) must be >= min_replicas (%d)", maxR, minR)
+	}
+	if a.SyncPeriodMs < 0 {
+		return fmt.Errorf("autoscaling sync_period_ms cannot be negative, got %d", a.SyncPeriodMs)
+	}
+	if a.StabilizationWindowMs < 0 {
+		return fmt.Errorf("autoscaling stabilization_window_ms cannot be negative, got %d", a.StabilizationWindowMs)
+	}
+	if a.StartupDelayMs < 0 {
+		return fmt.Errorf("autoscaling startup_delay_ms cannot be negative, got %d", a.StartupDelayMs)
+	}
 	return nil
 }
 
@@ -224,10 +262,29 @@ func ValidateScenario(s *Scenario) error {
 		}
 	}
 
+	if err := validateNetworkLatencies(s.Network); err != nil {
+		return err
+	}
+
 	// Validate hosts
 	if len(s.Hosts) == 0 {
 		return fmt.Errorf("at least one host must be defined")
 	}
+	if err := validateHostTypes(s); err != nil {
+		return err
+	}
+	if err := validateCPULimits(s); err != nil {
+		return err
+	}
+	if err := validateScheduling(s); err != nil {
+		return err
+	}
+	if err := validateQueueLimits(s); err != nil {
+		return err
+	}
+	if err := validatePriorityClasses(s); err != nil {
+		return err
+	}
 	hostIDs := make(map[string]bool)
 	for _, host := range s.Hosts {
 		if host.ID == "" {
@@ -286,6 +343,14 @@ func ValidateScenario(s *Scenario) error {
 		if len(svc.Endpoints) == 0 {
 			return fmt.Errorf("service %s: at least one endpoint must be defined", svc.ID)
 		}
+		if svc.ExternalNetworkLatencyMs != nil {
+			if err := validateLatencySpec(*svc.ExternalNetworkLatencyMs); err != nil {
+				return fmt.Errorf("service %s: external_network_latency_ms: %w", svc.ID, err)
+			}
+		}
+		if err := ValidateServiceStartup(svc.ID, svc.Startup); err != nil {
+			return err
+		}
 
 		if svc.Behavior != nil {
 			b := svc.Behavior
@@ -309,6 +374,30 @@ func ValidateScenario(s *Scenario) error {
 				if c.MissLatencyMs.Mean < 0 || c.MissLatencyMs.Sigma < 0 {
 					return fmt.Errorf("service %s: behavior.cache.miss_latency_ms mean/sigma cannot be negative", svc.ID)
 				}
+				if err := validateLatencySpec(c.HitLatencyMs); err != nil {
+					return fmt.Errorf("service %s: behavior.cache.hit_latency_ms: %w", svc.ID, err)
+				}
+				if err := validateLatencySpec(c.MissLatencyMs); err != nil {
+					return fmt.Errorf("service %s: behavior.cache.miss_latency_ms: %w", svc.ID, err)
+				}
+			}
+		}
+
+		if err := validatePolicyOverrides(svc.Policies, policyLevelService); err != nil {
+			return fmt.Errorf("service %s: policies: %w", svc.ID, err)
+		}
+		if svc.Policies != nil && svc.Policies.Autoscaling != nil {
+			var globalAS *AutoscalingPolicy
+			if s.Policies != nil {
+				globalAS = s.Policies.Autoscaling
+			}
+			if as := EffectiveAutoscaling(globalAS, svc); as.Enabled {
+				if as.TargetCPUUtil <= 0 || as.ScaleStep <= 0 {
+					return fmt.Errorf("service %s: policies: autoscaling enabled without a positive target_cpu_util and scale_step", svc.ID)
+				}
+				if minR, maxR := as.EffectiveReplicaBounds(); maxR < minR {
+					return fmt.Errorf("service %s: policies: autoscaling max_replicas (%d) must be >= min_replicas (%d)", svc.ID, maxR, minR)
+				}
 			}
 		}
 
@@ -338,9 +427,28 @@ func ValidateScenario(s *Scenario) error {
 			if ep.IOMs.Mean < 0 || ep.IOMs.Sigma < 0 {
 				return fmt.Errorf("service %s, endpoint %s: io_ms mean/sigma cannot be negative", svc.ID, ep.Path)
 			}
+			if err := validateLatencySpec(ep.CPUSpec()); err != nil {
+				return fmt.Errorf("service %s, endpoint %s: cpu_distribution: %w", svc.ID, ep.Path, err)
+			}
+			if err := validateLatencySpec(ep.NetLatencyMs); err != nil {
+				return fmt.Errorf("service %s, endpoint %s: net_latency_ms: %w", svc.ID, ep.Path, err)
+			}
+			if err := validateLatencySpec(ep.IOMs); err != nil {
+				return fmt.Errorf("service %s, endpoint %s: io_ms: %w", svc.ID, ep.Path, err)
+			}
 			if ep.ConnectionPool < 0 {
 				return fmt.Errorf("service %s, endpoint %s: connection_pool cannot be negative", svc.ID, ep.Path)
 			}
+			if err := validatePolicyOverrides(ep.Policies, policyLevelEndpoint); err != nil {
+				return fmt.Errorf("service %s, endpoint %s: policies: %w", svc.ID, ep.Path, err)
+			}
+			var globalRL *RateLimitingPolicy
+			if s.Policies != nil {
+				globalRL = s.Policies.RateLimiting
+			}
+			if rl := EffectiveRateLimiting(globalRL, svc, ep); rl != nil && rl.Enabled && rl.RateLimitPerSecond <= 0 {
+				return fmt.Errorf("service %s, endpoint %s: rate_limiting enabled without a positive rate_limit_per_second", svc.ID, ep.Path)
+			}
 		}
 	}
 
@@ -383,6 +491,13 @@ func ValidateScenario(s *Scenario) error {
 		}
 	}
 
+	for i := range s.Services {
+		svc := &s.Services[i]
+		if err := ValidateCacheBehavior(svc, endpointRef, serviceKindByID); err != nil {
+			return err
+		}
+	}
+
 	validDownstreamModes := map[string]bool{
 		"": true, "sync": true, "async": true, "event": true,
 	}
@@ -424,13 +539,28 @@ func ValidateScenario(s *Scenario) error {
 				if ds.TimeoutMs < 0 {
 					return fmt.Errorf("service %s, endpoint %s: downstream timeout_ms cannot be negative", svc.ID, ep.Path)
 				}
+				if err := validateLatencySpec(ds.CallLatencyMs); err != nil {
+					return fmt.Errorf("service %s, endpoint %s: downstream to %q: call_latency_ms: %w", svc.ID, ep.Path, ds.To, err)
+				}
 				if ds.FailureRate < 0 || ds.FailureRate > 1 {
 					return fmt.Errorf("service %s, endpoint %s: downstream failure_rate must be in [0,1], got %v", svc.ID, ep.Path, ds.FailureRate)
 				}
 				if ds.DownstreamFractionCPU < 0 || ds.DownstreamFractionCPU > 1 {
 					return fmt.Errorf("service %s, endpoint %s: downstream_fraction_cpu must be in [0,1], got %v", svc.ID, ep.Path, ds.DownstreamFractionCPU)
 				}
+				if err := validatePolicyOverrides(ds.Policies, policyLevelCall); err != nil {
+					return fmt.Errorf("service %s, endpoint %s: downstream to %q: policies: %w", svc.ID, ep.Path, ds.To, err)
+				}
+				if err := validateConditions(ds.When); err != nil {
+					return fmt.Errorf("service %s, endpoint %s: downstream to %q: %w", svc.ID, ep.Path, ds.To, err)
+				}
+				if err := validateHedgePolicy(ds.Hedge); err != nil {
+					return fmt.Errorf("service %s, endpoint %s: downstream to %q: %w", svc.ID, ep.Path, ds.To, err)
+				}
 				tgtKind := serviceKindByID[tgtSvc]
+				if ds.Hedge != nil && (ds.IsAsync() || tgtKind == "queue" || tgtKind == "topic") {
+					return fmt.Errorf("service %s, endpoint %s: downstream to %q: hedge applies to sync calls only", svc.ID, ep.Path, ds.To)
+				}
 				if kind == "queue" && tgtKind != "queue" {
 					return fmt.Errorf("service %s, endpoint %s: downstream kind queue requires target service %s to have kind queue", svc.ID, ep.Path, tgtSvc)
 				}
@@ -444,12 +574,15 @@ func ValidateScenario(s *Scenario) error {
 					return fmt.Errorf("service %s, endpoint %s: downstream to topic service %s must use kind topic (or omit kind)", svc.ID, ep.Path, tgtSvc)
 				}
 			}
+			if err := validateExecution(ep, serviceKindByID); err != nil {
+				return fmt.Errorf("service %s, endpoint %s: %w", svc.ID, ep.Path, err)
+			}
 		}
 	}
 
 	// Validate workload
-	if len(s.Workload) == 0 {
-		return fmt.Errorf("at least one workload pattern must be defined")
+	if len(s.Workload) == 0 && len(s.Flows) == 0 {
+		return fmt.Errorf("at least one workload pattern or flow must be defined")
 	}
 	for i := range s.Workload {
 		wl := &s.Workload[i]
@@ -459,17 +592,9 @@ func ValidateScenario(s *Scenario) error {
 		if wl.To == "" {
 			return fmt.Errorf("workload %d: 'to' cannot be empty", i)
 		}
-		if wl.Arrival.Type == "" {
-			return fmt.Errorf("workload %d: arrival type cannot be empty", i)
-		}
-		norm, err := NormalizeArrivalType(wl.Arrival.Type)
-		if err != nil {
+		if err := validateArrival(&wl.Arrival, serviceIDs, endpointRef); err != nil {
 			return fmt.Errorf("workload %d: %w", i, err)
 		}
-		wl.Arrival.Type = norm
-		if wl.Arrival.RateRPS <= 0 {
-			return fmt.Errorf("workload %d: arrival rate_rps must be positive", i)
-		}
 		wlSvc, wlPath, err := parseDownstreamTargetForValidation(wl.To)
 		if err != nil {
 			return fmt.Errorf("workload %d: invalid to %q: %w", i, wl.To, err)
@@ -480,6 +605,41 @@ func ValidateScenario(s *Scenario) error {
 		if !endpointRef[wlSvc+":"+wlPath] {
 			return fmt.Errorf("workload %d: target endpoint %s:%s does not exist", i, wlSvc, wlPath)
 		}
+		if err := validateWeightedMetadata(wl.WeightedMetadata, wl.Metadata); err != nil {
+			return fmt.Errorf("workload %d: %w", i, err)
+		}
+	}
+	if err := validateFlows(s.Flows, serviceIDs, endpointRef); err != nil {
+		return err
+	}
+	if err := validateHostFailures(s.HostFailures, s.Hosts); err != nil {
+		return err
+	}
+	if err := validateFaults(s); err != nil {
+		return err
+	}
+
+	if s.Policies != nil {
+		if s.Policies.Autoscaling != nil {
+			if err := validateAutoscalingControl(s.Policies.Autoscaling); err != nil {
+				return fmt.Errorf("policies: %w", err)
+			}
+		}
+		if s.Policies.CircuitBreaker != nil {
+			if err := validateCircuitBreaker(s.Policies.CircuitBreaker); err != nil {
+				return fmt.Errorf("policies: %w", err)
+			}
+		}
+		if s.Policies.RateLimiting != nil {
+			if err := validateRateLimiting(s.Policies.RateLimiting, true); err != nil {
+				return fmt.Errorf("policies: %w", err)
+			}
+		}
+		if s.Policies.OOM != nil {
+			if err := validateOOMPolicy(s.Policies.OOM); err != nil {
+				return fmt.Errorf("policies: %w", err)
+			}
+		}
 	}
 
 	return nil
@@ -63,6 +63,30 @@ func TestValidatePoliciesBranches(t *testing.T) {
 			name: "autoscaling non-positive scale step",
 			p:    &Policies{Autoscaling: &AutoscalingPolicy{TargetCPUUtil: 0.5, ScaleStep: 0}},
 		},
+		{
+			name: "autoscaling max below min",
+			p:    &Policies{Autoscaling: &AutoscalingPolicy{TargetCPUUtil: 0.5, ScaleStep: 1, MinReplicas: 4, MaxReplicas: 2}},
+		},
+		{
+			name: "autoscaling negative sync period",
+			p:    &Policies{Autoscaling: &AutoscalingPolicy{TargetCPUUtil: 0.5, ScaleStep: 1, SyncPeriodMs: -1}},
+		},
+		{
+			name: "autoscaling negative stabilization window",
+			p:    &Policies{Autoscaling: &AutoscalingPolicy{TargetCPUUtil: 0.5, ScaleStep: 1, StabilizationWindowMs: -1}},
+		},
+		{
+			name: "autoscaling negative startup delay",
+			p:    &Policies{Autoscaling: &AutoscalingPolicy{TargetCPUUtil: 0.5, ScaleStep: 1, StartupDelayMs: -5}},
+		},
+		{
+			name: "circuit breaker negative failure threshold",
+			p:    &Policies{CircuitBreaker: &CircuitBreakerPolicy{Enabled: true, FailureThreshold: -1}},
+		},
+		{
+			name: "rate limiting enabled without rate",
+			p:    &Policies{RateLimiting: &RateLimitingPolicy{Enabled: true}},
+		},
 		{
 			name: "retries negative max",
 			p:    &Policies{Retries: &RetryPolicy{MaxRetries: -1, Backoff: "exponential", BaseMs: 10}},
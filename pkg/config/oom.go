@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultOOMRestartBackoff is the restart delay after a first OOM kill (Kubernetes CrashLoopBackOff).
+	DefaultOOMRestartBackoff = 10 * time.Second
+	// DefaultOOMMaxRestartBackoff caps the restart delay.
+	DefaultOOMMaxRestartBackoff = 5 * time.Minute
+	// DefaultOOMBackoffReset is how long a replica must run without a kill before the delay resets.
+	DefaultOOMBackoffReset = 10 * time.Minute
+)
+
+// IsEnabled reports whether memory limits are enforced.
+func (p *OOMPolicy) IsEnabled() bool {
+	return p != nil && p.Enabled
+}
+
+// RestartBackoff returns the restart delay after the kills-th kill in a row (1 for a first kill):
+// the base delay doubled per further kill, capped at the maximum.
+func (p *OOMPolicy) RestartBackoff(kills int) time.Duration {
+	base, maxDelay := DefaultOOMRestartBackoff, DefaultOOMMaxRestartBackoff
+	if p != nil && p.RestartBackoffMs > 0 {
+		base = time.Duration(p.RestartBackoffMs) * time.Millisecond
+	}
+	if p != nil && p.MaxRestartBackoffMs > 0 {
+		maxDelay = time.Duration(p.MaxRestartBackoffMs) * time.Millisecond
+	}
+	d := base
+	for i := 1; i < kills && d < maxDelay; i++ {
+		d *= 2
+	}
+	if d > maxDelay {
+		d = maxDelay
+	}
+	return d
+}
+
+// EffectiveBackoffReset returns the kill-free run time that resets the restart delay, with the default applied.
+func (p *OOMPolicy) EffectiveBackoffReset() time.Duration {
+	if p == nil || p.BackoffResetMs <= 0 {
+		return DefaultOOMBackoffReset
+	}
+	return time.Duration(p.BackoffResetMs) * time.Millisecond
+}
+
+func validateOOMPolicy(p *OOMPolicy) error {
+	if p.RestartBackoffMs < 0 {
+		return fmt.Errorf("oom restart_backoff_ms cannot be negative, got %d", p.RestartBackoffMs)
+	}
+	if p.MaxRestartBackoffMs < 0 {
+		return fmt.Errorf("oom max_restart_backoff_ms cannot be negative, got %d", p.MaxRestartBackoffMs)
+	}
+	if p.RestartBackoffMs > 0 && p.MaxRestartBackoffMs > 0 && p.MaxRestartBackoffMs < p.RestartBackoffMs {
+		return fmt.Errorf("oom max_restart_backoff_ms (%d) must be at least restart_backoff_ms (%d)", p.MaxRestartBackoffMs, p.RestartBackoffMs)
+	}
+	if p.BackoffResetMs < 0 {
+		return fmt.Errorf("oom backoff_reset_ms cannot be negative, got %d", p.BackoffResetMs)
+	}
+	return nil
+}
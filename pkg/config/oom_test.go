@@ -0,0 +1,48 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOOMPolicyRestartBackoff(t *testing.T) {
+	var defaults *OOMPolicy
+	for kills, want := range map[int]time.Duration{1: 10 * time.Second, 2: 20 * time.Second, 5: 160 * time.Second, 6: 5 * time.Minute, 20: 5 * time.Minute} {
+		if got := defaults.RestartBackoff(kills); got != want {
+			t.Fatalf("default RestartBackoff(%d) = %v, want %v", kills, got, want)
+		}
+	}
+	p := &OOMPolicy{RestartBackoffMs: 1000, MaxRestartBackoffMs: 3000, BackoffResetMs: 5000}
+	for kills, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 3 * time.Second} {
+		if got := p.RestartBackoff(kills); got != want {
+			t.Fatalf("RestartBackoff(%d) = %v, want %v", kills, got, want)
+		}
+	}
+	if got := p.EffectiveBackoffReset(); got != 5*time.Second {
+		t.Fatalf("EffectiveBackoffReset() = %v, want 5s", got)
+	}
+}
+
+func TestValidateOOMPolicy(t *testing.T) {
+	if err := validateOOMPolicy(&OOMPolicy{Enabled: true, RestartBackoffMs: 1000, MaxRestartBackoffMs: 8000}); err != nil {
+		t.Fatalf("expected valid oom policy, got %v", err)
+	}
+	tests := []struct {
+		name string
+		p    *OOMPolicy
+		want string
+	}{
+		{"negative backoff", &OOMPolicy{RestartBackoffMs: -1}, "oom restart_backoff_ms cannot be negative"},
+		{"cap below base", &OOMPolicy{RestartBackoffMs: 2000, MaxRestartBackoffMs: 1000}, "must be at least restart_backoff_ms"},
+		{"negative reset", &OOMPolicy{BackoffResetMs: -1}, "oom backoff_reset_ms cannot be negative"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateOOMPolicy(tc.p)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
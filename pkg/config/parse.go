@@ -27,12 +27,16 @@ func ParseConfigYAMLString(yamlText string) (*Config, error) {
 }
 
 // UnmarshalScenarioYAML parses YAML into a Scenario without semantic validation.
-// Use [ValidateScenario] for full checks, or [ParseScenarioYAML] for parse+validate.
+// Use [ValidateScenario] for full checks, or [ParseScenarioYAML] for parse+validate. Hosts with a
+// type take the cores and memory_gb they leave unset from it, and services' cpu_request is moved
+// into cpu_cores.
 func UnmarshalScenarioYAML(data []byte) (*Scenario, error) {
 	var scenario Scenario
 	if err := yaml.Unmarshal(data, &scenario); err != nil {
 		return nil, fmt.Errorf("failed to parse scenario yaml: %w", err)
 	}
+	applyHostTypeDefaults(&scenario)
+	applyCPURequestDefaults(&scenario)
 	return &scenario, nil
 }
 
@@ -0,0 +1,389 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultCircuitBreakerFailureThreshold is the consecutive failure count that opens a circuit.
+	DefaultCircuitBreakerFailureThreshold = 5
+	// DefaultCircuitBreakerSuccessThreshold is the half-open success count that closes a circuit.
+	DefaultCircuitBreakerSuccessThreshold = 1
+	// DefaultCircuitBreakerTimeout is how long an open circuit rejects requests before going half-open.
+	DefaultCircuitBreakerTimeout = 30 * time.Second
+)
+
+// PolicyOverrides holds per-service, per-endpoint or per-call policy blocks, resolved most-specific-first:
+// downstream call, then the callee endpoint, then the callee service, then scenario policies. Within an
+// override, an omitted enabled and zero-valued fields inherit from the enclosing block, so a partial
+// override only changes what it sets. Autoscaling may only be overridden per service, and a downstream
+// call may only override retries.
+type PolicyOverrides struct {
+	Autoscaling    *AutoscalingOverride    `yaml:"autoscaling,omitempty"`
+	Retries        *RetryOverride          `yaml:"retries,omitempty"`
+	CircuitBreaker *CircuitBreakerOverride `yaml:"circuit_breaker,omitempty"`
+	RateLimiting   *RateLimitingOverride   `yaml:"rate_limiting,omitempty"`
+}
+
+// AutoscalingOverride is a per-service autoscaling block (see AutoscalingPolicy). A nil Enabled inherits.
+type AutoscalingOverride struct {
+	Enabled               *bool   `yaml:"enabled,omitempty"`
+	TargetCPUUtil         float64 `yaml:"target_cpu_util,omitempty"`
+	ScaleStep             int     `yaml:"scale_step,omitempty"`
+	MinReplicas           int     `yaml:"min_replicas,omitempty"`
+	MaxReplicas           int     `yaml:"max_replicas,omitempty"`
+	SyncPeriodMs          int     `yaml:"sync_period_ms,omitempty"`
+	StabilizationWindowMs int     `yaml:"stabilization_window_ms,omitempty"`
+	StartupDelayMs        int     `yaml:"startup_delay_ms,omitempty"`
+}
+
+// RetryOverride is a service, endpoint or call retries block (see RetryPolicy). A nil Enabled inherits.
+type RetryOverride struct {
+	Enabled    *bool  `yaml:"enabled,omitempty"`
+	MaxRetries int    `yaml:"max_retries,omitempty"`
+	Backoff    string `yaml:"backoff,omitempty"`
+	BaseMs     int    `yaml:"base_ms,omitempty"`
+}
+
+// CircuitBreakerOverride is a service or endpoint circuit_breaker block (see CircuitBreakerPolicy).
+// A nil Enabled inherits.
+type CircuitBreakerOverride struct {
+	Enabled          *bool `yaml:"enabled,omitempty"`
+	FailureThreshold int   `yaml:"failure_threshold,omitempty"`
+	SuccessThreshold int   `yaml:"success_threshold,omitempty"`
+	TimeoutMs        int   `yaml:"timeout_ms,omitempty"`
+}
+
+// RateLimitingOverride is a service or endpoint rate_limiting block (see RateLimitingPolicy).
+// A nil Enabled inherits.
+type RateLimitingOverride struct {
+	Enabled            *bool `yaml:"enabled,omitempty"`
+	RateLimitPerSecond int   `yaml:"rate_limit_per_second,omitempty"`
+}
+
+// BoolPtr returns a pointer to v, for setting an override's enabled.
+func BoolPtr(v bool) *bool {
+	return &v
+}
+
+// EffectiveFailureThreshold returns the failure threshold with the default applied.
+func (p *CircuitBreakerPolicy) EffectiveFailureThreshold() int {
+	if p == nil || p.FailureThreshold <= 0 {
+		return DefaultCircuitBreakerFailureThreshold
+	}
+	return p.FailureThreshold
+}
+
+// EffectiveSuccessThreshold returns the half-open success threshold with the default applied.
+func (p *CircuitBreakerPolicy) EffectiveSuccessThreshold() int {
+	if p == nil || p.SuccessThreshold <= 0 {
+		return DefaultCircuitBreakerSuccessThreshold
+	}
+	return p.SuccessThreshold
+}
+
+// EffectiveTimeout returns the open-state duration with the default applied.
+func (p *CircuitBreakerPolicy) EffectiveTimeout() time.Duration {
+	if p == nil || p.TimeoutMs <= 0 {
+		return DefaultCircuitBreakerTimeout
+	}
+	return time.Duration(p.TimeoutMs) * time.Millisecond
+}
+
+// EffectiveCircuitBreaker resolves the circuit breaker block for an endpoint (most specific first).
+// svc and ep may be nil. Returns nil when no level configures a circuit breaker.
+func EffectiveCircuitBreaker(global *CircuitBreakerPolicy, svc *Service, ep *Endpoint) *CircuitBreakerPolicy {
+	out := global
+	if svc != nil && svc.Policies != nil {
+		out = mergeCircuitBreaker(out, svc.Policies.CircuitBreaker)
+	}
+	if ep != nil && ep.Policies != nil {
+		out = mergeCircuitBreaker(out, ep.Policies.CircuitBreaker)
+	}
+	return out
+}
+
+// EffectiveRateLimiting resolves the rate limiting block for an endpoint (most specific first).
+// svc and ep may be nil. Returns nil when no level configures rate limiting.
+func EffectiveRateLimiting(global *RateLimitingPolicy, svc *Service, ep *Endpoint) *RateLimitingPolicy {
+	out := global
+	if svc != nil && svc.Policies != nil {
+		out = mergeRateLimiting(out, svc.Policies.RateLimiting)
+	}
+	if ep != nil && ep.Policies != nil {
+		out = mergeRateLimiting(out, ep.Policies.RateLimiting)
+	}
+	return out
+}
+
+// EffectiveRetries resolves the retry block for a call to ep on svc (most specific first: call, endpoint,
+// service, global). Any of svc, ep and call may be nil. Returns nil when no level configures retries.
+func EffectiveRetries(global *RetryPolicy, svc *Service, ep *Endpoint, call *DownstreamCall) *RetryPolicy {
+	out := global
+	if svc != nil && svc.Policies != nil {
+		out = mergeRetries(out, svc.Policies.Retries)
+	}
+	if ep != nil && ep.Policies != nil {
+		out = mergeRetries(out, ep.Policies.Retries)
+	}
+	if call != nil && call.Policies != nil {
+		out = mergeRetries(out, call.Policies.Retries)
+	}
+	return out
+}
+
+// EffectiveAutoscaling resolves the autoscaling block for svc (service override, then global).
+// sync_period_ms is controller-wide and always comes from global. Returns nil when neither level configures autoscaling.
+func EffectiveAutoscaling(global *AutoscalingPolicy, svc *Service) *AutoscalingPolicy {
+	var out *AutoscalingPolicy
+	if global != nil {
+		g := *global
+		out = &g
+	}
+	if svc == nil || svc.Policies == nil || svc.Policies.Autoscaling == nil {
+		return out
+	}
+	child := svc.Policies.Autoscaling
+	if out == nil {
+		out = &AutoscalingPolicy{}
+	}
+	if child.Enabled != nil {
+		out.Enabled = *child.Enabled
+	}
+	if child.TargetCPUUtil > 0 {
+		out.TargetCPUUtil = child.TargetCPUUtil
+	}
+	if child.ScaleStep > 0 {
+		out.ScaleStep = child.ScaleStep
+	}
+	if child.MinReplicas > 0 {
+		out.MinReplicas = child.MinReplicas
+	}
+	if child.MaxReplicas > 0 {
+		out.MaxReplicas = child.MaxReplicas
+	}
+	if child.StabilizationWindowMs > 0 {
+		out.StabilizationWindowMs = child.StabilizationWindowMs
+	}
+	if child.StartupDelayMs > 0 {
+		out.StartupDelayMs = child.StartupDelayMs
+	}
+	return out
+}
+
+func mergeRetries(parent *RetryPolicy, child *RetryOverride) *RetryPolicy {
+	if child == nil {
+		return parent
+	}
+	out := &RetryPolicy{}
+	if parent != nil {
+		*out = *parent
+	}
+	if child.Enabled != nil {
+		out.Enabled = *child.Enabled
+	}
+	if child.MaxRetries > 0 {
+		out.MaxRetries = child.MaxRetries
+	}
+	if child.Backoff != "" {
+		out.Backoff = child.Backoff
+	}
+	if child.BaseMs > 0 {
+		out.BaseMs = child.BaseMs
+	}
+	return out
+}
+
+func mergeCircuitBreaker(parent *CircuitBreakerPolicy, child *CircuitBreakerOverride) *CircuitBreakerPolicy {
+	if child == nil {
+		return parent
+	}
+	out := &CircuitBreakerPolicy{}
+	if parent != nil {
+		*out = *parent
+	}
+	if child.Enabled != nil {
+		out.Enabled = *child.Enabled
+	}
+	if child.FailureThreshold > 0 {
+		out.FailureThreshold = child.FailureThreshold
+	}
+	if child.SuccessThreshold > 0 {
+		out.SuccessThreshold = child.SuccessThreshold
+	}
+	if child.TimeoutMs > 0 {
+		out.TimeoutMs = child.TimeoutMs
+	}
+	return out
+}
+
+func mergeRateLimiting(parent *RateLimitingPolicy, child *RateLimitingOverride) *RateLimitingPolicy {
+	if child == nil {
+		return parent
+	}
+	out := &RateLimitingPolicy{}
+	if parent != nil {
+		*out = *parent
+	}
+	if child.Enabled != nil {
+		out.Enabled = *child.Enabled
+	}
+	if child.RateLimitPerSecond > 0 {
+		out.RateLimitPerSecond = child.RateLimitPerSecond
+	}
+	return out
+}
+
+// HasCircuitBreakerConfig reports whether the scenario configures a circuit breaker at any level.
+func HasCircuitBreakerConfig(s *Scenario) bool {
+	if s == nil {
+		return false
+	}
+	if s.Policies != nil && s.Policies.CircuitBreaker != nil {
+		return true
+	}
+	for i := range s.Services {
+		svc := &s.Services[i]
+		if svc.Policies != nil && svc.Policies.CircuitBreaker != nil {
+			return true
+		}
+		for j := range svc.Endpoints {
+			if p := svc.Endpoints[j].Policies; p != nil && p.CircuitBreaker != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// HasRateLimitingConfig reports whether the scenario configures rate limiting at any level.
+func HasRateLimitingConfig(s *Scenario) bool {
+	if s == nil {
+		return false
+	}
+	if s.Policies != nil && s.Policies.RateLimiting != nil {
+		return true
+	}
+	for i := range s.Services {
+		svc := &s.Services[i]
+		if svc.Policies != nil && svc.Policies.RateLimiting != nil {
+			return true
+		}
+		for j := range svc.Endpoints {
+			if p := svc.Endpoints[j].Policies; p != nil && p.RateLimiting != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateCircuitBreaker validates a circuit_breaker block.
+func validateCircuitBreaker(cb *CircuitBreakerPolicy) error {
+	if cb.FailureThreshold < 0 {
+		return fmt.Errorf("circuit_breaker failure_threshold cannot be negative, got %d", cb.FailureThreshold)
+	}
+	if cb.SuccessThreshold < 0 {
+		return fmt.Errorf("circuit_breaker success_threshold cannot be negative, got %d", cb.SuccessThreshold)
+	}
+	if cb.TimeoutMs < 0 {
+		return fmt.Errorf("circuit_breaker timeout_ms cannot be negative, got %d", cb.TimeoutMs)
+	}
+	return nil
+}
+
+// validateRateLimiting validates a rate_limiting block. requireRate is false for overrides,
+// which may inherit rate_limit_per_second from an enclosing block.
+func validateRateLimiting(rl *RateLimitingPolicy, requireRate bool) error {
+	if rl.RateLimitPerSecond < 0 {
+		return fmt.Errorf("rate_limiting rate_limit_per_second cannot be negative, got %d", rl.RateLimitPerSecond)
+	}
+	if requireRate && rl.Enabled && rl.RateLimitPerSecond == 0 {
+		return fmt.Errorf("rate_limiting rate_limit_per_second must be positive when enabled")
+	}
+	return nil
+}
+
+// validateRetryOverride validates a retries override block; backoff may be omitted to inherit.
+func validateRetryOverride(r *RetryOverride) error {
+	if r.MaxRetries < 0 {
+		return fmt.Errorf("retries max_retries cannot be negative, got %d", r.MaxRetries)
+	}
+	switch r.Backoff {
+	case "", "exponential", "linear", "constant":
+	default:
+		return fmt.Errorf("invalid backoff type: %s (must be exponential, linear, or constant)", r.Backoff)
+	}
+	if r.BaseMs < 0 {
+		return fmt.Errorf("retries base_ms cannot be negative, got %d", r.BaseMs)
+	}
+	return nil
+}
+
+// validateAutoscalingOverride validates a per-service autoscaling block; zero fields inherit from scenario policies.
+func validateAutoscalingOverride(a *AutoscalingOverride) error {
+	if a.TargetCPUUtil < 0 || a.TargetCPUUtil > 1 {
+		return fmt.Errorf("autoscaling target_cpu_util must be between 0 and 1, got %f", a.TargetCPUUtil)
+	}
+	if a.ScaleStep < 0 {
+		return fmt.Errorf("autoscaling scale_step cannot be negative, got %d", a.ScaleStep)
+	}
+	if a.SyncPeriodMs != 0 {
+		return fmt.Errorf("autoscaling sync_period_ms is controller-wide and can only be set in scenario policies")
+	}
+	return validateAutoscalingControl(&AutoscalingPolicy{
+		MinReplicas:           a.MinReplicas,
+		MaxReplicas:           a.MaxReplicas,
+		StabilizationWindowMs: a.StabilizationWindowMs,
+		StartupDelayMs:        a.StartupDelayMs,
+	})
+}
+
+// policyOverrideLevel identifies where a PolicyOverrides block appears (for allowed fields and messages).
+type policyOverrideLevel int
+
+const (
+	policyLevelService policyOverrideLevel = iota
+	policyLevelEndpoint
+	policyLevelCall
+)
+
+// validatePolicyOverrides validates a service, endpoint or downstream call policies block.
+func validatePolicyOverrides(p *PolicyOverrides, level policyOverrideLevel) error {
+	if p == nil {
+		return nil
+	}
+	if p.Autoscaling != nil {
+		if level != policyLevelService {
+			return fmt.Errorf("autoscaling can only be overridden per service")
+		}
+		if err := validateAutoscalingOverride(p.Autoscaling); err != nil {
+			return err
+		}
+	}
+	if p.Retries != nil {
+		if err := validateRetryOverride(p.Retries); err != nil {
+			return err
+		}
+	}
+	if level == policyLevelCall && (p.CircuitBreaker != nil || p.RateLimiting != nil) {
+		return fmt.Errorf("downstream calls only support a retries override (circuit_breaker and rate_limiting apply to the callee endpoint)")
+	}
+	if p.CircuitBreaker != nil {
+		cb := p.CircuitBreaker
+		if err := validateCircuitBreaker(&CircuitBreakerPolicy{
+			FailureThreshold: cb.FailureThreshold,
+			SuccessThreshold: cb.SuccessThreshold,
+			TimeoutMs:        cb.TimeoutMs,
+		}); err != nil {
+			return err
+		}
+	}
+	if p.RateLimiting != nil {
+		if err := validateRateLimiting(&RateLimitingPolicy{RateLimitPerSecond: p.RateLimiting.RateLimitPerSecond}, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const resiliencePoliciesYAML = `
+hosts:
+  - id: h1
+    cores: 4
+services:
+  - id: api
+    replicas: 1
+    model: cpu
+    policies:
+      circuit_breaker: {enabled: true, failure_threshold: 10}
+    endpoints:
+      - path: /x
+        mean_cpu_ms: 5
+        cpu_sigma_ms: 1
+        downstream: []
+        net_latency_ms: {mean: 1, sigma: 0}
+        policies:
+          circuit_breaker: {enabled: true, timeout_ms: 2000}
+          rate_limiting: {enabled: true, rate_limit_per_second: 20}
+      - path: /y
+        mean_cpu_ms: 5
+        cpu_sigma_ms: 1
+        downstream: []
+        net_latency_ms: {mean: 1, sigma: 0}
+workload:
+  - from: client
+    to: api:/x
+    arrival: {type: poisson, rate_rps: 10}
+policies:
+  circuit_breaker:
+    enabled: true
+    failure_threshold: 3
+    success_threshold: 2
+    timeout_ms: 5000
+  rate_limiting:
+    enabled: false
+    rate_limit_per_second: 100
+`
+
+func TestParseScenarioResiliencePolicies(t *testing.T) {
+	sc, err := ParseScenarioYAMLString(resiliencePoliciesYAML)
+	if err != nil {
+		t.Fatalf("ParseScenarioYAMLString: %v", err)
+	}
+	cb := sc.Policies.CircuitBreaker
+	if cb == nil || !cb.Enabled || cb.FailureThreshold != 3 || cb.SuccessThreshold != 2 || cb.TimeoutMs != 5000 {
+		t.Fatalf("unexpected circuit_breaker: %+v", cb)
+	}
+	if rl := sc.Policies.RateLimiting; rl == nil || rl.Enabled || rl.RateLimitPerSecond != 100 {
+		t.Fatalf("unexpected rate_limiting: %+v", rl)
+	}
+	if !HasCircuitBreakerConfig(sc) || !HasRateLimitingConfig(sc) {
+		t.Fatal("expected circuit breaker and rate limiting config to be detected")
+	}
+}
+
+func TestEffectiveResiliencePoliciesMostSpecificFirst(t *testing.T) {
+	sc, err := ParseScenarioYAMLString(resiliencePoliciesYAML)
+	if err != nil {
+		t.Fatalf("ParseScenarioYAMLString: %v", err)
+	}
+	svc := &sc.Services[0]
+	epX, epY := &svc.Endpoints[0], &svc.Endpoints[1]
+
+	cbX := EffectiveCircuitBreaker(sc.Policies.CircuitBreaker, svc, epX)
+	if cbX.FailureThreshold != 10 || cbX.SuccessThreshold != 2 || cbX.EffectiveTimeout() != 2*time.Second {
+		t.Fatalf("endpoint /x should take timeout from endpoint, threshold from service, success from global: %+v", cbX)
+	}
+	cbY := EffectiveCircuitBreaker(sc.Policies.CircuitBreaker, svc, epY)
+	if cbY.FailureThreshold != 10 || cbY.TimeoutMs != 5000 {
+		t.Fatalf("endpoint /y should use service override over global: %+v", cbY)
+	}
+	if cb := EffectiveCircuitBreaker(nil, nil, nil); cb != nil {
+		t.Fatalf("expected nil with no config, got %+v", cb)
+	}
+
+	rlX := EffectiveRateLimiting(sc.Policies.RateLimiting, svc, epX)
+	if !rlX.Enabled || rlX.RateLimitPerSecond != 20 {
+		t.Fatalf("endpoint override should enable rate limiting at 20/s: %+v", rlX)
+	}
+	if rlY := EffectiveRateLimiting(sc.Policies.RateLimiting, svc, epY); rlY.Enabled {
+		t.Fatalf("endpoint /y should inherit disabled global rate limiting: %+v", rlY)
+	}
+}
+
+func TestCircuitBreakerPolicyEffectiveDefaults(t *testing.T) {
+	var nilPolicy *CircuitBreakerPolicy
+	if nilPolicy.EffectiveFailureThreshold() != DefaultCircuitBreakerFailureThreshold ||
+		nilPolicy.EffectiveSuccessThreshold() != DefaultCircuitBreakerSuccessThreshold ||
+		nilPolicy.EffectiveTimeout() != DefaultCircuitBreakerTimeout {
+		t.Fatal("nil policy should use defaults")
+	}
+}
+
+func TestPartialPolicyOverrideInheritsEnabled(t *testing.T) {
+	global := &CircuitBreakerPolicy{Enabled: true, FailureThreshold: 3}
+	svc := &Service{ID: "api", Policies: &PolicyOverrides{
+		CircuitBreaker: &CircuitBreakerOverride{TimeoutMs: 2000},
+		RateLimiting:   &RateLimitingOverride{RateLimitPerSecond: 50},
+		Retries:        &RetryOverride{MaxRetries: 4},
+	}}
+	if cb := EffectiveCircuitBreaker(global, svc, nil); !cb.Enabled || cb.FailureThreshold != 3 || cb.TimeoutMs != 2000 {
+		t.Fatalf("a circuit breaker override without enabled should stay enabled: %+v", cb)
+	}
+	if rl := EffectiveRateLimiting(&RateLimitingPolicy{Enabled: true, RateLimitPerSecond: 10}, svc, nil); !rl.Enabled || rl.RateLimitPerSecond != 50 {
+		t.Fatalf("a rate limiting override without enabled should stay enabled: %+v", rl)
+	}
+	if r := EffectiveRetries(&RetryPolicy{Enabled: true, MaxRetries: 1}, svc, nil, nil); !r.Enabled || r.MaxRetries != 4 {
+		t.Fatalf("a retries override without enabled should stay enabled: %+v", r)
+	}
+	if r := EffectiveRetries(nil, svc, nil, nil); r.Enabled {
+		t.Fatalf("an override without enabled and nothing to inherit should be disabled: %+v", r)
+	}
+}
+
+func TestValidateScenarioResiliencePolicies(t *testing.T) {
+	cases := []struct {
+		name    string
+		mutate  func(*Scenario)
+		wantErr string
+	}{
+		{"negative global failure threshold", func(s *Scenario) { s.Policies.CircuitBreaker.FailureThreshold = -1 }, "failure_threshold"},
+		{"negative global timeout", func(s *Scenario) { s.Policies.CircuitBreaker.TimeoutMs = -1 }, "timeout_ms"},
+		{"enabled global rate without rate", func(s *Scenario) {
+			s.Policies.RateLimiting.Enabled = true
+			s.Policies.RateLimiting.RateLimitPerSecond = 0
+		}, "rate_limit_per_second"},
+		{"negative service success threshold", func(s *Scenario) {
+			s.Services[0].Policies.CircuitBreaker.SuccessThreshold = -2
+		}, "service api: policies"},
+		{"negative endpoint rate", func(s *Scenario) {
+			s.Services[0].Endpoints[0].Policies.RateLimiting.RateLimitPerSecond = -5
+		}, "endpoint /x: policies"},
+		{"enabled override with nothing to inherit", func(s *Scenario) {
+			s.Policies.RateLimiting = nil
+			s.Services[0].Endpoints[0].Policies.RateLimiting.RateLimitPerSecond = 0
+		}, "rate_limit_per_second"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, err := ParseScenarioYAMLString(resiliencePoliciesYAML)
+			if err != nil {
+				t.Fatalf("ParseScenarioYAMLString: %v", err)
+			}
+			tc.mutate(sc)
+			err = ValidateScenario(sc)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+const hopPoliciesYAML = `
+hosts:
+  - id: h1
+    cores: 4
+services:
+  - id: api
+    replicas: 1
+    model: cpu
+    policies:
+      autoscaling: {enabled: true, max_replicas: 8}
+    endpoints:
+      - path: /x
+        mean_cpu_ms: 5
+        net_latency_ms: {mean: 1, sigma: 0}
+        downstream:
+          - to: db:/q
+            policies:
+              retries: {enabled: true, max_retries: 5}
+  - id: db
+    replicas: 1
+    model: cpu
+    policies:
+      retries: {enabled: false}
+    endpoints:
+      - path: /q
+        mean_cpu_ms: 5
+        net_latency_ms: {mean: 1, sigma: 0}
+        policies:
+          retries: {enabled: true, base_ms: 50}
+workload:
+  - from: client
+    to: api:/x
+    arrival: {type: poisson, rate_rps: 10}
+policies:
+  autoscaling:
+    enabled: false
+    target_cpu_util: 0.6
+    scale_step: 1
+    sync_period_ms: 5000
+  retries:
+    enabled: true
+    max_retries: 2
+    backoff: linear
+    base_ms: 10
+`
+
+func TestEffectiveRetriesMostSpecificFirst(t *testing.T) {
+	sc, err := ParseScenarioYAMLString(hopPoliciesYAML)
+	if err != nil {
+		t.Fatalf("ParseScenarioYAMLString: %v", err)
+	}
+	if err := ValidateScenario(sc); err != nil {
+		t.Fatalf("ValidateScenario: %v", err)
+	}
+	api, db := &sc.Services[0], &sc.Services[1]
+	ep := &db.Endpoints[0]
+	call := &api.Endpoints[0].Downstream[0]
+
+	r := EffectiveRetries(sc.Policies.Retries, db, ep, call)
+	if !r.Enabled || r.MaxRetries != 5 || r.BaseMs != 50 || r.Backoff != "linear" {
+		t.Fatalf("call override should take max_retries from call, base_ms from endpoint, backoff from global: %+v", r)
+	}
+	if r := EffectiveRetries(sc.Policies.Retries, db, ep, nil); !r.Enabled || r.MaxRetries != 2 {
+		t.Fatalf("endpoint override should re-enable retries with global max_retries: %+v", r)
+	}
+	if r := EffectiveRetries(sc.Policies.Retries, db, nil, nil); r.Enabled {
+		t.Fatalf("service override should disable retries: %+v", r)
+	}
+	if r := EffectiveRetries(nil, nil, nil, nil); r != nil {
+		t.Fatalf("expected nil with no config, got %+v", r)
+	}
+}
+
+func TestEffectiveAutoscalingPerService(t *testing.T) {
+	sc, err := ParseScenarioYAMLString(hopPoliciesYAML)
+	if err != nil {
+		t.Fatalf("ParseScenarioYAMLString: %v", err)
+	}
+	a := EffectiveAutoscaling(sc.Policies.Autoscaling, &sc.Services[0])
+	if !a.Enabled || a.TargetCPUUtil != 0.6 || a.ScaleStep != 1 || a.MaxReplicas != 8 || a.SyncPeriodMs != 5000 {
+		t.Fatalf("service override should enable autoscaling and inherit the rest from global: %+v", a)
+	}
+	if a := EffectiveAutoscaling(sc.Policies.Autoscaling, &sc.Services[1]); a.Enabled {
+		t.Fatalf("service without override should use disabled global: %+v", a)
+	}
+}
+
+func TestPartialAutoscalingOverrideInheritsEnabled(t *testing.T) {
+	var po PolicyOverrides
+	if err := yaml.Unmarshal([]byte("autoscaling:\n  max_replicas: 10\n"), &po); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	global := &AutoscalingPolicy{Enabled: true, TargetCPUUtil: 0.7, ScaleStep: 2, MaxReplicas: 4, SyncPeriodMs: 3000}
+	svc := &Service{ID: "api", Policies: &po}
+	if a := EffectiveAutoscaling(global, svc); !a.Enabled || a.MaxReplicas != 10 || a.TargetCPUUtil != 0.7 || a.ScaleStep != 2 || a.SyncPeriodMs != 3000 {
+		t.Fatalf("an autoscaling override without enabled should keep global autoscaling on: %+v", a)
+	}
+	if a := EffectiveAutoscaling(nil, svc); a.Enabled || a.MaxReplicas != 10 {
+		t.Fatalf("an override without enabled and nothing to inherit should be disabled: %+v", a)
+	}
+	po.Autoscaling.Enabled = BoolPtr(false)
+	if a := EffectiveAutoscaling(global, svc); a.Enabled {
+		t.Fatalf("enabled: false should turn autoscaling off for the service: %+v", a)
+	}
+}
+
+func TestValidateScenarioHopPolicies(t *testing.T) {
+	cases := []struct {
+		name    string
+		mutate  func(*Scenario)
+		wantErr string
+	}{
+		{"autoscaling on endpoint", func(s *Scenario) {
+			s.Services[1].Endpoints[0].Policies.Autoscaling = &AutoscalingOverride{Enabled: BoolPtr(true)}
+		}, "autoscaling can only be overridden per service"},
+		{"sync period on service override", func(s *Scenario) {
+			s.Services[0].Policies.Autoscaling.SyncPeriodMs = 1000
+		}, "sync_period_ms"},
+		{"circuit breaker on call", func(s *Scenario) {
+			s.Services[0].Endpoints[0].Downstream[0].Policies.CircuitBreaker = &CircuitBreakerOverride{Enabled: BoolPtr(true)}
+		}, "downstream to \"db:/q\": policies"},
+		{"invalid backoff on call", func(s *Scenario) {
+			s.Services[0].Endpoints[0].Downstream[0].Policies.Retries.Backoff = "random"
+		}, "invalid backoff"},
+		{"enabled autoscaling without target", func(s *Scenario) {
+			s.Policies.Autoscaling = nil
+		}, "service api"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sc, err := ParseScenarioYAMLString(hopPoliciesYAML)
+			if err != nil {
+				t.Fatalf("ParseScenarioYAMLString: %v", err)
+			}
+			tc.mutate(sc)
+			err = ValidateScenario(sc)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
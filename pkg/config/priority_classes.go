@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PriorityClassNamed returns the scenario priority class called name.
+func (s *Scenario) PriorityClassNamed(name string) (PriorityClass, bool) {
+	if name == "" {
+		return PriorityClass{}, false
+	}
+	for _, pc := range s.PriorityClasses {
+		if pc.Name == name {
+			return pc, true
+		}
+	}
+	return PriorityClass{}, false
+}
+
+// ResolvePriorityClass returns the priority class of a workload or flow: priorityClass when set,
+// else the class named like trafficClass, else "" (no class, priority 0).
+func (s *Scenario) ResolvePriorityClass(priorityClass, trafficClass string) string {
+	if priorityClass != "" {
+		return priorityClass
+	}
+	if _, ok := s.PriorityClassNamed(trafficClass); ok {
+		return trafficClass
+	}
+	return ""
+}
+
+// ShedsOnQueueLength reports whether a load shedding rule of the service watches the instance queue.
+func (svc *Service) ShedsOnQueueLength() bool {
+	for _, r := range svc.LoadShedding {
+		if r.QueueThreshold > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func validatePriorityClasses(s *Scenario) error {
+	names := make(map[string]bool, len(s.PriorityClasses))
+	for i, pc := range s.PriorityClasses {
+		if strings.TrimSpace(pc.Name) == "" {
+			return fmt.Errorf("priority_classes[%d]: name cannot be empty", i)
+		}
+		if names[pc.Name] {
+			return fmt.Errorf("duplicate priority class: %s", pc.Name)
+		}
+		names[pc.Name] = true
+	}
+	for i, wl := range s.Workload {
+		if wl.PriorityClass != "" && !names[wl.PriorityClass] {
+			return fmt.Errorf("workload %d: unknown priority_class %q", i, wl.PriorityClass)
+		}
+	}
+	for _, f := range s.Flows {
+		if f.PriorityClass != "" && !names[f.PriorityClass] {
+			return fmt.Errorf("flow %s: unknown priority_class %q", f.ID, f.PriorityClass)
+		}
+	}
+	for i := range s.Services {
+		svc := &s.Services[i]
+		ruled := make(map[string]bool, len(svc.LoadShedding))
+		for _, r := range svc.LoadShedding {
+			if !names[r.PriorityClass] {
+				return fmt.Errorf("service %s: load_shedding names unknown priority_class %q", svc.ID, r.PriorityClass)
+			}
+			if ruled[r.PriorityClass] {
+				return fmt.Errorf("service %s: duplicate load_shedding rule for priority class %s", svc.ID, r.PriorityClass)
+			}
+			ruled[r.PriorityClass] = true
+			if r.UtilizationThreshold != 0 && !(r.UtilizationThreshold > 0 && r.UtilizationThreshold <= 1) {
+				return fmt.Errorf("service %s: load_shedding utilization_threshold must be in (0, 1], got %v", svc.ID, r.UtilizationThreshold)
+			}
+			if r.QueueThreshold < 0 {
+				return fmt.Errorf("service %s: load_shedding queue_threshold cannot be negative", svc.ID)
+			}
+			if r.UtilizationThreshold == 0 && r.QueueThreshold == 0 {
+				return fmt.Errorf("service %s: load_shedding rule for %s needs utilization_threshold or queue_threshold", svc.ID, r.PriorityClass)
+			}
+		}
+		if len(svc.LoadShedding) == 0 {
+			continue
+		}
+		if kind := strings.ToLower(strings.TrimSpace(svc.Kind)); kind == "queue" || kind == "topic" {
+			return fmt.Errorf("service %s: load_shedding does not apply to kind %s", svc.ID, kind)
+		}
+		if svc.ShedsOnQueueLength() && svc.Scheduling.EffectiveDiscipline() == SchedulingProcessorSharing {
+			return fmt.Errorf("service %s: processor_sharing scheduling has no instance queue for load_shedding queue_threshold", svc.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package config
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestResolvePriorityClass(t *testing.T) {
+	s := &Scenario{PriorityClasses: []PriorityClass{{Name: "critical", Priority: 100}, {Name: "background"}}}
+	for _, tc := range []struct{ priorityClass, trafficClass, want string }{
+		{"critical", "background", "critical"},
+		{"", "background", "background"},
+		{"", "replay", ""},
+		{"", "", ""},
+	} {
+		if got := s.ResolvePriorityClass(tc.priorityClass, tc.trafficClass); got != tc.want {
+			t.Fatalf("ResolvePriorityClass(%q, %q) = %q, want %q", tc.priorityClass, tc.trafficClass, got, tc.want)
+		}
+	}
+	if pc, ok := s.PriorityClassNamed("critical"); !ok || pc.Priority != 100 {
+		t.Fatalf("expected critical at priority 100, got %+v %v", pc, ok)
+	}
+}
+
+func TestValidatePriorityClasses(t *testing.T) {
+	classes := []PriorityClass{{Name: "critical", Priority: 100}, {Name: "batch"}}
+	tests := []struct {
+		name string
+		s    Scenario
+		want string
+	}{
+		{"duplicate class", Scenario{PriorityClasses: []PriorityClass{{Name: "a"}, {Name: "a"}}}, "duplicate priority class"},
+		{"unnamed class", Scenario{PriorityClasses: []PriorityClass{{Priority: 1}}}, "name cannot be empty"},
+		{"unknown workload class", Scenario{PriorityClasses: classes, Workload: []WorkloadPattern{{PriorityClass: "gold"}}}, `unknown priority_class "gold"`},
+		{"unknown flow class", Scenario{PriorityClasses: classes, Flows: []Flow{{ID: "f", PriorityClass: "gold"}}}, `flow f: unknown priority_class`},
+		{"unknown rule class", Scenario{PriorityClasses: classes, Services: []Service{{ID: "a", LoadShedding: []LoadShedRule{{PriorityClass: "gold", QueueThreshold: 1}}}}}, "load_shedding names unknown"},
+		{"no threshold", Scenario{PriorityClasses: classes, Services: []Service{{ID: "a", LoadShedding: []LoadShedRule{{PriorityClass: "batch"}}}}}, "needs utilization_threshold or queue_threshold"},
+		{"utilization above 1", Scenario{PriorityClasses: classes, Services: []Service{{ID: "a", LoadShedding: []LoadShedRule{{PriorityClass: "batch", UtilizationThreshold: 80}}}}}, "must be in (0, 1]"},
+		{"negative utilization", Scenario{PriorityClasses: classes, Services: []Service{{ID: "a", LoadShedding: []LoadShedRule{{PriorityClass: "batch", UtilizationThreshold: -0.5, QueueThreshold: 1}}}}}, "must be in (0, 1]"},
+		{"NaN utilization", Scenario{PriorityClasses: classes, Services: []Service{{ID: "a", LoadShedding: []LoadShedRule{{PriorityClass: "batch", UtilizationThreshold: math.NaN()}}}}}, "must be in (0, 1]"},
+		{"duplicate rule", Scenario{PriorityClasses: classes, Services: []Service{{ID: "a", LoadShedding: []LoadShedRule{{PriorityClass: "batch", QueueThreshold: 1}, {PriorityClass: "batch", QueueThreshold: 2}}}}}, "duplicate load_shedding rule"},
+		{"processor sharing queue", Scenario{PriorityClasses: classes, Services: []Service{{ID: "a", Scheduling: &Scheduling{Discipline: SchedulingProcessorSharing}, LoadShedding: []LoadShedRule{{PriorityClass: "batch", QueueThreshold: 1}}}}}, "no instance queue"},
+		{"broker", Scenario{PriorityClasses: classes, Services: []Service{{ID: "a", Kind: "topic", LoadShedding: []LoadShedRule{{PriorityClass: "batch", UtilizationThreshold: 0.5}}}}}, "does not apply to kind topic"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validatePriorityClasses(&tc.s)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+
+	s := &Scenario{
+		PriorityClasses: classes,
+		Services: []Service{{
+			ID: "a", Scheduling: &Scheduling{Discipline: SchedulingProcessorSharing},
+			LoadShedding: []LoadShedRule{{PriorityClass: "batch", UtilizationThreshold: 0.7}},
+		}},
+	}
+	if err := validatePriorityClasses(s); err != nil {
+		t.Fatalf("utilization shedding should apply to processor sharing: %v", err)
+	}
+	if s.Services[0].ShedsOnQueueLength() {
+		t.Fatalf("a utilization rule does not watch the queue")
+	}
+}
@@ -42,7 +42,7 @@ func EffectiveQueueBehavior(q *QueueBehavior) *QueueBehavior {
 	if strings.TrimSpace(q.ConsumerTarget) != "" {
 		out.ConsumerTarget = strings.TrimSpace(q.ConsumerTarget)
 	}
-	if q.DeliveryLatencyMs.Mean > 0 || q.DeliveryLatencyMs.Sigma > 0 {
+	if q.DeliveryLatencyMs.IsSet() {
 		out.DeliveryLatencyMs = q.DeliveryLatencyMs
 	}
 	if q.AckTimeoutMs > 0 {
@@ -83,6 +83,9 @@ func ValidateQueueBehavior(svcID string, q *QueueBehavior, endpointRef map[strin
 	if q.DeliveryLatencyMs.Mean < 0 || q.DeliveryLatencyMs.Sigma < 0 {
 		return fmt.Errorf("service %s: behavior.queue.delivery_latency_ms mean/sigma cannot be negative", svcID)
 	}
+	if err := validateLatencySpec(q.DeliveryLatencyMs); err != nil {
+		return fmt.Errorf("service %s: behavior.queue.delivery_latency_ms: %w", svcID, err)
+	}
 	if q.AckTimeoutMs < 0 {
 		return fmt.Errorf("service %s: behavior.queue.ack_timeout_ms cannot be negative", svcID)
 	}
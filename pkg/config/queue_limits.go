@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Instance queue full policies (on_queue_full).
+const (
+	// QueueFullReject fails the arriving request with queue_full.
+	QueueFullReject = "reject"
+	// QueueFullDropOldest fails the longest-waiting request with queue_full and queues the arriving one.
+	QueueFullDropOldest = "drop_oldest"
+)
+
+// IsSet reports whether the limits bound the queue length or the queue wait.
+func (q QueueLimits) IsSet() bool {
+	return q.MaxQueueLength > 0 || q.MaxQueueWaitMs > 0
+}
+
+// EffectiveOnQueueFull returns on_queue_full, or reject when unset.
+func (q QueueLimits) EffectiveOnQueueFull() string {
+	if q.OnQueueFull == "" {
+		return QueueFullReject
+	}
+	return q.OnQueueFull
+}
+
+// EffectiveQueueLimits returns the instance queue limits for requests to ep: each endpoint setting
+// overrides the service's.
+func (svc *Service) EffectiveQueueLimits(ep *Endpoint) QueueLimits {
+	q := svc.QueueLimits
+	if ep == nil {
+		return q
+	}
+	if ep.MaxQueueLength > 0 {
+		q.MaxQueueLength = ep.MaxQueueLength
+	}
+	if ep.MaxQueueWaitMs > 0 {
+		q.MaxQueueWaitMs = ep.MaxQueueWaitMs
+	}
+	if ep.OnQueueFull != "" {
+		q.OnQueueFull = ep.OnQueueFull
+	}
+	return q
+}
+
+// HasQueueLimits reports whether the service or one of its endpoints limits the instance queue.
+func (svc *Service) HasQueueLimits() bool {
+	if svc.QueueLimits.IsSet() {
+		return true
+	}
+	for i := range svc.Endpoints {
+		if svc.Endpoints[i].QueueLimits.IsSet() {
+			return true
+		}
+	}
+	return false
+}
+
+func validateQueueLimits(s *Scenario) error {
+	check := func(where string, q QueueLimits) error {
+		if q.MaxQueueLength < 0 {
+			return fmt.Errorf("%s: max_queue_length cannot be negative", where)
+		}
+		if q.MaxQueueWaitMs < 0 {
+			return fmt.Errorf("%s: max_queue_wait_ms cannot be negative", where)
+		}
+		switch q.OnQueueFull {
+		case "", QueueFullReject, QueueFullDropOldest:
+		default:
+			return fmt.Errorf("%s: on_queue_full must be reject or drop_oldest, got %q", where, q.OnQueueFull)
+		}
+		return nil
+	}
+	for i := range s.Services {
+		svc := &s.Services[i]
+		if err := check("service "+svc.ID, svc.QueueLimits); err != nil {
+			return err
+		}
+		for j := range svc.Endpoints {
+			ep := &svc.Endpoints[j]
+			if err := check(fmt.Sprintf("service %s endpoint %s", svc.ID, ep.Path), ep.QueueLimits); err != nil {
+				return err
+			}
+		}
+		if !svc.HasQueueLimits() {
+			continue
+		}
+		if svc.Scheduling.EffectiveDiscipline() == SchedulingProcessorSharing {
+			return fmt.Errorf("service %s: processor_sharing scheduling has no instance queue to limit", svc.ID)
+		}
+		if kind := strings.ToLower(strings.TrimSpace(svc.Kind)); kind == "queue" || kind == "topic" {
+			return fmt.Errorf("service %s: instance queue limits do not apply to kind %s", svc.ID, kind)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEffectiveQueueLimits(t *testing.T) {
+	svc := &Service{
+		ID:          "a",
+		QueueLimits: QueueLimits{MaxQueueLength: 10, MaxQueueWaitMs: 200},
+		Endpoints: []Endpoint{
+			{Path: "/x"},
+			{Path: "/y", QueueLimits: QueueLimits{MaxQueueWaitMs: 50, OnQueueFull: QueueFullDropOldest}},
+		},
+	}
+	if q := svc.EffectiveQueueLimits(&svc.Endpoints[0]); q.MaxQueueLength != 10 || q.MaxQueueWaitMs != 200 || q.EffectiveOnQueueFull() != QueueFullReject {
+		t.Fatalf("an endpoint without limits should use the service's, got %+v", q)
+	}
+	if q := svc.EffectiveQueueLimits(&svc.Endpoints[1]); q.MaxQueueLength != 10 || q.MaxQueueWaitMs != 50 || q.EffectiveOnQueueFull() != QueueFullDropOldest {
+		t.Fatalf("endpoint settings should override the service's, got %+v", q)
+	}
+	if !svc.HasQueueLimits() {
+		t.Fatalf("expected the service to limit its queue")
+	}
+	if (&Service{ID: "b", Endpoints: []Endpoint{{Path: "/x", QueueLimits: QueueLimits{OnQueueFull: QueueFullReject}}}}).HasQueueLimits() {
+		t.Fatalf("on_queue_full alone does not limit the queue")
+	}
+}
+
+func TestValidateQueueLimits(t *testing.T) {
+	tests := []struct {
+		name string
+		svc  Service
+		want string
+	}{
+		{"negative length", Service{ID: "a", QueueLimits: QueueLimits{MaxQueueLength: -1}}, "max_queue_length cannot be negative"},
+		{"negative wait", Service{ID: "a", Endpoints: []Endpoint{{Path: "/x", QueueLimits: QueueLimits{MaxQueueWaitMs: -5}}}}, "endpoint /x: max_queue_wait_ms cannot be negative"},
+		{"unknown policy", Service{ID: "a", QueueLimits: QueueLimits{MaxQueueLength: 1, OnQueueFull: "drop_newest"}}, "on_queue_full must be reject or drop_oldest"},
+		{"processor sharing", Service{ID: "a", QueueLimits: QueueLimits{MaxQueueLength: 1}, Scheduling: &Scheduling{Discipline: SchedulingProcessorSharing}}, "no instance queue to limit"},
+		{"broker", Service{ID: "a", Kind: "Queue", QueueLimits: QueueLimits{MaxQueueWaitMs: 10}}, "do not apply to kind queue"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateQueueLimits(&Scenario{Services: []Service{tc.svc}})
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+
+	s := &Scenario{Services: []Service{{ID: "a", QueueLimits: QueueLimits{MaxQueueLength: 5, OnQueueFull: QueueFullDropOldest}, Scheduling: &Scheduling{Discipline: SchedulingCoDel}}}}
+	if err := validateQueueLimits(s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultDiurnalPeriodSeconds is the diurnal period when period_seconds is unset.
+const DefaultDiurnalPeriodSeconds = 86400
+
+// rateProfileIntegrationSteps is the number of midpoint steps ExpectedArrivals integrates over.
+const rateProfileIntegrationSteps = 2000
+
+// RateAt returns the rate t seconds after the start of the run. base is the arrival's rate_rps,
+// used when the profile has neither points nor a diurnal curve.
+func (p *RateProfile) RateAt(t, base float64) float64 {
+	r := p.baseRateAt(t, base)
+	for _, s := range p.Spikes {
+		if t >= s.AtSeconds && t < s.AtSeconds+s.DurationSeconds {
+			r *= s.Multiplier
+		}
+	}
+	return r
+}
+
+func (p *RateProfile) baseRateAt(t, base float64) float64 {
+	switch {
+	case len(p.Points) > 0:
+		pts := p.Points
+		i := sort.Search(len(pts), func(i int) bool { return pts[i].AtSeconds > t })
+		if i == 0 {
+			return pts[0].RateRPS
+		}
+		if i == len(pts) || strings.EqualFold(p.Interpolation, "step") {
+			return pts[i-1].RateRPS
+		}
+		a, b := pts[i-1], pts[i]
+		return a.RateRPS + (b.RateRPS-a.RateRPS)*(t-a.AtSeconds)/(b.AtSeconds-a.AtSeconds)
+	case p.Diurnal != nil:
+		d := p.Diurnal
+		period := d.PeriodSeconds
+		if period <= 0 {
+			period = DefaultDiurnalPeriodSeconds
+		}
+		phase := 2 * math.Pi * (t - d.PeakAtSeconds) / period
+		return d.TroughRPS + (d.PeakRPS-d.TroughRPS)*(1+math.Cos(phase))/2
+	default:
+		return base
+	}
+}
+
+// MaxRate returns an upper bound of RateAt over all times, the envelope rate for thinning.
+func (p *RateProfile) MaxRate(base float64) float64 {
+	var peak float64
+	switch {
+	case len(p.Points) > 0:
+		for _, pt := range p.Points {
+			peak = math.Max(peak, pt.RateRPS)
+		}
+	case p.Diurnal != nil:
+		peak = math.Max(p.Diurnal.PeakRPS, p.Diurnal.TroughRPS)
+	default:
+		peak = base
+	}
+	// The spike product only changes at spike boundaries, so its maximum is taken at one of them.
+	spike := 1.0
+	for _, s := range p.Spikes {
+		for _, at := range []float64{s.AtSeconds, s.AtSeconds + s.DurationSeconds} {
+			prod := 1.0
+			for _, o := range p.Spikes {
+				if at >= o.AtSeconds && at < o.AtSeconds+o.DurationSeconds {
+					prod *= o.Multiplier
+				}
+			}
+			spike = math.Max(spike, prod)
+		}
+	}
+	return peak * spike
+}
+
+// ExpectedArrivals integrates the rate over the first duration of the run.
+func (p *RateProfile) ExpectedArrivals(duration time.Duration, base float64) float64 {
+	sec := duration.Seconds()
+	if sec <= 0 {
+		return 0
+	}
+	step := sec / rateProfileIntegrationSteps
+	var total float64
+	for i := 0; i < rateProfileIntegrationSteps; i++ {
+		total += p.RateAt((float64(i)+0.5)*step, base) * step
+	}
+	return total
+}
+
+// validateRateProfile checks a rate profile; base is the arrival's rate_rps.
+func validateRateProfile(p *RateProfile, base float64) error {
+	if len(p.Points) > 0 && p.Diurnal != nil {
+		return fmt.Errorf("rate_profile: points and diurnal are mutually exclusive")
+	}
+	switch strings.ToLower(p.Interpolation) {
+	case "", "linear", "step":
+	default:
+		return fmt.Errorf("rate_profile: interpolation must be linear or step, got %q", p.Interpolation)
+	}
+	if p.Interpolation != "" && len(p.Points) == 0 {
+		return fmt.Errorf("rate_profile: interpolation requires points")
+	}
+	for i, pt := range p.Points {
+		if !isFiniteNonNegative(pt.AtSeconds) || !isFiniteNonNegative(pt.RateRPS) {
+			return fmt.Errorf("rate_profile: point %d: at_seconds and rate_rps must be non-negative", i)
+		}
+		if i > 0 && pt.AtSeconds <= p.Points[i-1].AtSeconds {
+			return fmt.Errorf("rate_profile: point %d: at_seconds must be strictly increasing", i)
+		}
+	}
+	if d := p.Diurnal; d != nil {
+		if !isFiniteNonNegative(d.PeriodSeconds) || !isFiniteNonNegative(d.TroughRPS) || !isFiniteNonNegative(d.PeakRPS) || math.IsNaN(d.PeakAtSeconds) || math.IsInf(d.PeakAtSeconds, 0) {
+			return fmt.Errorf("rate_profile: diurnal period_seconds, peak_rps and trough_rps must be non-negative")
+		}
+		if d.PeakRPS < d.TroughRPS {
+			return fmt.Errorf("rate_profile: diurnal peak_rps %v is below trough_rps %v", d.PeakRPS, d.TroughRPS)
+		}
+	}
+	if len(p.Points) == 0 && p.Diurnal == nil && base <= 0 {
+		return fmt.Errorf("rate_profile: rate_rps must be positive when the profile has no points or diurnal curve")
+	}
+	for i, s := range p.Spikes {
+		if !isFiniteNonNegative(s.AtSeconds) || !isFiniteNonNegative(s.Multiplier) || !isFiniteNonNegative(s.DurationSeconds) || s.DurationSeconds == 0 {
+			return fmt.Errorf("rate_profile: spike %d: at_seconds and multiplier must be non-negative and duration_seconds positive", i)
+		}
+	}
+	if p.MaxRate(base) <= 0 {
+		return fmt.Errorf("rate_profile: rate is zero at all times")
+	}
+	return nil
+}
+
+func isFiniteNonNegative(v float64) bool {
+	return v >= 0 && !math.IsInf(v, 1)
+}
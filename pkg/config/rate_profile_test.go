@@ -0,0 +1,111 @@
+package config
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateProfileRateAt(t *testing.T) {
+	linear := &RateProfile{Points: []RatePoint{{AtSeconds: 10, RateRPS: 10}, {AtSeconds: 20, RateRPS: 30}}}
+	for _, tc := range []struct{ t, want float64 }{{0, 10}, {10, 10}, {15, 20}, {20, 30}, {99, 30}} {
+		if got := linear.RateAt(tc.t, 0); got != tc.want {
+			t.Fatalf("linear at %v: got %v want %v", tc.t, got, tc.want)
+		}
+	}
+	step := &RateProfile{Interpolation: "step", Points: linear.Points}
+	if got := step.RateAt(15, 0); got != 10 {
+		t.Fatalf("step at 15: got %v want 10", got)
+	}
+
+	diurnal := &RateProfile{Diurnal: &DiurnalProfile{PeriodSeconds: 100, PeakRPS: 50, TroughRPS: 10, PeakAtSeconds: 25}}
+	for _, tc := range []struct{ t, want float64 }{{25, 50}, {75, 10}, {50, 30}, {125, 50}} {
+		if got := diurnal.RateAt(tc.t, 0); math.Abs(got-tc.want) > 1e-9 {
+			t.Fatalf("diurnal at %v: got %v want %v", tc.t, got, tc.want)
+		}
+	}
+
+	spiky := &RateProfile{Spikes: []RateSpike{{AtSeconds: 5, DurationSeconds: 5, Multiplier: 4}, {AtSeconds: 8, DurationSeconds: 4, Multiplier: 0.5}}}
+	for _, tc := range []struct{ t, want float64 }{{0, 10}, {5, 40}, {8, 20}, {10, 5}, {12, 10}} {
+		if got := spiky.RateAt(tc.t, 10); got != tc.want {
+			t.Fatalf("spikes at %v: got %v want %v", tc.t, got, tc.want)
+		}
+	}
+	if got := spiky.MaxRate(10); got != 40 {
+		t.Fatalf("expected spike envelope 40, got %v", got)
+	}
+	if got := diurnal.MaxRate(0); got != 50 {
+		t.Fatalf("expected diurnal envelope 50, got %v", got)
+	}
+}
+
+func TestRateProfileExpectedArrivals(t *testing.T) {
+	ramp := &RateProfile{Points: []RatePoint{{AtSeconds: 0, RateRPS: 0}, {AtSeconds: 100, RateRPS: 100}}}
+	// Area under a 0..100 rps ramp over 100s.
+	if got := ramp.ExpectedArrivals(100*time.Second, 0); math.Abs(got-5000) > 1 {
+		t.Fatalf("expected about 5000 arrivals, got %v", got)
+	}
+	day := &RateProfile{Diurnal: &DiurnalProfile{PeakRPS: 30, TroughRPS: 10}}
+	if got := day.ExpectedArrivals(24*time.Hour, 0); math.Abs(got-20*86400) > 20 {
+		t.Fatalf("expected a full day to average 20 rps, got %v arrivals", got)
+	}
+}
+
+func TestValidateRateProfile(t *testing.T) {
+	cases := []struct {
+		profile RateProfile
+		base    float64
+		want    string
+	}{
+		{RateProfile{Points: []RatePoint{{AtSeconds: 0, RateRPS: 1}}, Diurnal: &DiurnalProfile{PeakRPS: 1}}, 0, "mutually exclusive"},
+		{RateProfile{Points: []RatePoint{{AtSeconds: 5, RateRPS: 1}, {AtSeconds: 5, RateRPS: 2}}}, 0, "strictly increasing"},
+		{RateProfile{Points: []RatePoint{{AtSeconds: 0, RateRPS: -1}}}, 0, "non-negative"},
+		{RateProfile{Points: []RatePoint{{AtSeconds: 0, RateRPS: 1}}, Interpolation: "cubic"}, 0, "interpolation"},
+		{RateProfile{Diurnal: &DiurnalProfile{PeakRPS: 1, TroughRPS: 2}}, 0, "below trough"},
+		{RateProfile{Spikes: []RateSpike{{AtSeconds: 1, Multiplier: 2}}}, 5, "duration_seconds"},
+		{RateProfile{Spikes: []RateSpike{{AtSeconds: 1, DurationSeconds: 1, Multiplier: 2}}}, 0, "rate_rps must be positive"},
+		{RateProfile{Points: []RatePoint{{AtSeconds: 0, RateRPS: 0}}}, 0, "zero at all times"},
+	}
+	for i, tc := range cases {
+		if err := validateRateProfile(&tc.profile, tc.base); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("case %d: expected error containing %q, got %v", i, tc.want, err)
+		}
+	}
+	ok := &RateProfile{Points: []RatePoint{{AtSeconds: 0, RateRPS: 0}, {AtSeconds: 60, RateRPS: 100}}, Interpolation: "step"}
+	if err := validateRateProfile(ok, 0); err != nil {
+		t.Fatalf("expected valid profile, got %v", err)
+	}
+}
+
+func TestValidateScenarioRateProfileRequiresPoisson(t *testing.T) {
+	scenario := `
+hosts:
+  - id: h1
+    cores: 2
+services:
+  - id: api
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /a
+        mean_cpu_ms: 1
+        cpu_sigma_ms: 0
+workload:
+  - from: client
+    to: api:/a
+    arrival:
+      type: poisson
+      rate_profile:
+        diurnal:
+          peak_rps: 40
+          trough_rps: 5
+`
+	if _, err := ParseScenarioYAMLString(scenario); err != nil {
+		t.Fatalf("expected profile without rate_rps to be valid, got %v", err)
+	}
+	bad := strings.Replace(scenario, "type: poisson", "type: constant", 1)
+	if _, err := ParseScenarioYAMLString(bad); err == nil || !strings.Contains(err.Error(), "requires arrival type poisson") {
+		t.Fatalf("expected rate_profile on constant arrivals to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,385 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Replay trace formats accepted by [ArrivalSpec.ReplayFormat].
+const (
+	ReplayFormatCSV   = "csv"
+	ReplayFormatJSONL = "jsonl"
+)
+
+// maxReplayLineBytes bounds one JSONL trace line.
+const maxReplayLineBytes = 1 << 20
+
+// ReplayRecord is one timestamped request of a replay trace.
+type ReplayRecord struct {
+	// Offset is the request time relative to the earliest request of the trace.
+	Offset time.Duration
+	// ServiceID and EndpointPath are the record's target; both are empty when the record has no
+	// target and the workload pattern's to applies.
+	ServiceID    string
+	EndpointPath string
+	// Metadata is copied into request metadata on top of the workload pattern's metadata.
+	Metadata map[string]string
+}
+
+// ReplayTraceFormat returns the trace format of a replay arrival: replay_format when set, otherwise
+// derived from the replay_file extension (.csv, .jsonl, .ndjson).
+func ReplayTraceFormat(a ArrivalSpec) (string, error) {
+	format := strings.TrimSpace(strings.ToLower(a.ReplayFormat))
+	if format == "" && a.ReplayFile != "" {
+		switch strings.ToLower(filepath.Ext(a.ReplayFile)) {
+		case ".csv":
+			format = ReplayFormatCSV
+		case ".jsonl", ".ndjson":
+			format = ReplayFormatJSONL
+		}
+	}
+	switch format {
+	case ReplayFormatCSV, ReplayFormatJSONL:
+		return format, nil
+	case "":
+		return "", fmt.Errorf("replay_format is required (csv or jsonl) when it cannot be derived from replay_file")
+	default:
+		return "", fmt.Errorf("invalid replay_format %q (supported: csv, jsonl)", a.ReplayFormat)
+	}
+}
+
+// replayDir is the directory replay_file paths resolve in; empty disables replay_file.
+var replayDir atomic.Pointer[string]
+
+// SetReplayDir sets the directory that replay_file paths are confined to. Scenarios reach the
+// server over its APIs, so replay_file is rejected until a directory is set; an empty dir disables
+// it again.
+func SetReplayDir(dir string) {
+	dir = strings.TrimSpace(dir)
+	replayDir.Store(&dir)
+}
+
+// openReplayFile opens name inside the replay directory. name must be a local path: absolute
+// paths, ".." elements and symlinks leading out of the directory are rejected.
+func openReplayFile(name string) (*os.File, error) {
+	dir := ""
+	if p := replayDir.Load(); p != nil {
+		dir = *p
+	}
+	if dir == "" {
+		return nil, fmt.Errorf("replay_file is disabled because no replay directory is configured; use replay_data")
+	}
+	clean := filepath.Clean(strings.TrimSpace(name))
+	if !filepath.IsLocal(clean) {
+		return nil, fmt.Errorf("replay_file %q must be a relative path inside the replay directory", name)
+	}
+	f, err := os.OpenInRoot(dir, clean)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay file: %w", err)
+	}
+	return f, nil
+}
+
+// replayTrace holds the parsed records of a validated replay arrival, so the trace is read once
+// per scenario load and shared by every copy of the spec.
+type replayTrace struct {
+	records []ReplayRecord
+}
+
+// LoadReplayTrace returns the trace of a replay arrival. A validated spec returns the records
+// parsed during validation; otherwise the trace is read from replay_data or replay_file (relative
+// to the replay directory, see [SetReplayDir]). The records are shared and must not be modified.
+func LoadReplayTrace(a ArrivalSpec) ([]ReplayRecord, error) {
+	if a.replay != nil {
+		return a.replay.records, nil
+	}
+	hasFile := strings.TrimSpace(a.ReplayFile) != ""
+	hasData := strings.TrimSpace(a.ReplayData) != ""
+	if hasFile == hasData {
+		return nil, fmt.Errorf("replay arrival requires exactly one of replay_file or replay_data")
+	}
+	format, err := ReplayTraceFormat(a)
+	if err != nil {
+		return nil, err
+	}
+	if hasData {
+		return ParseReplayTrace(strings.NewReader(a.ReplayData), format)
+	}
+	f, err := openReplayFile(a.ReplayFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	records, err := ParseReplayTrace(f, format)
+	if err != nil {
+		return nil, fmt.Errorf("replay file %s: %w", a.ReplayFile, err)
+	}
+	return records, nil
+}
+
+// ParseReplayTrace parses a CSV or JSONL trace and returns its records sorted by offset
+// (records with the same timestamp keep their trace order).
+//
+// CSV traces start with a header row naming a "timestamp" column and an optional "target" column;
+// every other column is metadata (empty cells are skipped). JSONL traces hold one object per line
+// with "timestamp", an optional "target" and an optional "metadata" object; other top-level scalar
+// fields are metadata too. Timestamps are seconds (fractional allowed, e.g. Unix time) or RFC 3339.
+func ParseReplayTrace(r io.Reader, format string) ([]ReplayRecord, error) {
+	var (
+		stamps  []int64
+		records []ReplayRecord
+		err     error
+	)
+	switch format {
+	case ReplayFormatCSV:
+		stamps, records, err = parseReplayCSV(r)
+	case ReplayFormatJSONL:
+		stamps, records, err = parseReplayJSONL(r)
+	default:
+		return nil, fmt.Errorf("invalid replay format %q (supported: csv, jsonl)", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("replay trace has no records")
+	}
+	first := stamps[0]
+	for _, ns := range stamps {
+		first = min(first, ns)
+	}
+	for i := range records {
+		records[i].Offset = time.Duration(stamps[i] - first)
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Offset < records[j].Offset })
+	return records, nil
+}
+
+func parseReplayCSV(r io.Reader) ([]int64, []ReplayRecord, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("replay csv header: %w", err)
+	}
+	tsCol, targetCol := -1, -1
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+		switch strings.ToLower(header[i]) {
+		case "timestamp":
+			tsCol = i
+		case "target":
+			targetCol = i
+		}
+	}
+	if tsCol < 0 {
+		return nil, nil, fmt.Errorf("replay csv header must name a timestamp column")
+	}
+
+	var stamps []int64
+	var records []ReplayRecord
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("replay csv: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		ns, err := parseReplayTimestamp(row[tsCol])
+		if err != nil {
+			return nil, nil, fmt.Errorf("replay csv line %d: %w", line, err)
+		}
+		rec := ReplayRecord{}
+		if targetCol >= 0 {
+			if rec.ServiceID, rec.EndpointPath, err = parseReplayTarget(row[targetCol]); err != nil {
+				return nil, nil, fmt.Errorf("replay csv line %d: %w", line, err)
+			}
+		}
+		for i, v := range row {
+			if i == tsCol || i == targetCol || strings.TrimSpace(v) == "" {
+				continue
+			}
+			if rec.Metadata == nil {
+				rec.Metadata = make(map[string]string)
+			}
+			rec.Metadata[header[i]] = strings.TrimSpace(v)
+		}
+		stamps = append(stamps, ns)
+		records = append(records, rec)
+	}
+	return stamps, records, nil
+}
+
+func parseReplayJSONL(r io.Reader) ([]int64, []ReplayRecord, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), maxReplayLineBytes)
+	var stamps []int64
+	var records []ReplayRecord
+	line := 0
+	for sc.Scan() {
+		line++
+		raw := bytes.TrimSpace(sc.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var obj map[string]any
+		if err := dec.Decode(&obj); err != nil {
+			return nil, nil, fmt.Errorf("replay jsonl line %d: %w", line, err)
+		}
+		ts, ok := obj["timestamp"]
+		if !ok {
+			return nil, nil, fmt.Errorf("replay jsonl line %d: timestamp is required", line)
+		}
+		tsText, ok := replayScalarString(ts)
+		if !ok {
+			return nil, nil, fmt.Errorf("replay jsonl line %d: timestamp must be a number or string", line)
+		}
+		ns, err := parseReplayTimestamp(tsText)
+		if err != nil {
+			return nil, nil, fmt.Errorf("replay jsonl line %d: %w", line, err)
+		}
+		rec := ReplayRecord{}
+		for k, v := range obj {
+			switch k {
+			case "timestamp":
+				continue
+			case "target":
+				target, ok := v.(string)
+				if !ok {
+					return nil, nil, fmt.Errorf("replay jsonl line %d: target must be a string", line)
+				}
+				if rec.ServiceID, rec.EndpointPath, err = parseReplayTarget(target); err != nil {
+					return nil, nil, fmt.Errorf("replay jsonl line %d: %w", line, err)
+				}
+			case "metadata":
+				md, ok := v.(map[string]any)
+				if !ok {
+					return nil, nil, fmt.Errorf("replay jsonl line %d: metadata must be an object", line)
+				}
+				for mk, mv := range md {
+					if err := setReplayMetadata(&rec, mk, mv); err != nil {
+						return nil, nil, fmt.Errorf("replay jsonl line %d: metadata: %w", line, err)
+					}
+				}
+			default:
+				if err := setReplayMetadata(&rec, k, v); err != nil {
+					return nil, nil, fmt.Errorf("replay jsonl line %d: %w", line, err)
+				}
+			}
+		}
+		stamps = append(stamps, ns)
+		records = append(records, rec)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, nil, fmt.Errorf("replay jsonl: %w", err)
+	}
+	return stamps, records, nil
+}
+
+func setReplayMetadata(rec *ReplayRecord, key string, v any) error {
+	if v == nil {
+		return nil
+	}
+	s, ok := replayScalarString(v)
+	if !ok {
+		return fmt.Errorf("field %q must be a string, number or boolean", key)
+	}
+	if rec.Metadata == nil {
+		rec.Metadata = make(map[string]string)
+	}
+	rec.Metadata[key] = s
+	return nil
+}
+
+func replayScalarString(v any) (string, bool) {
+	switch x := v.(type) {
+	case string:
+		return x, true
+	case json.Number:
+		return x.String(), true
+	case bool:
+		return strconv.FormatBool(x), true
+	default:
+		return "", false
+	}
+}
+
+// parseReplayTarget parses an optional record target; an empty target leaves the pattern's to in effect.
+func parseReplayTarget(target string) (serviceID, path string, err error) {
+	if strings.TrimSpace(target) == "" {
+		return "", "", nil
+	}
+	serviceID, path, err = parseDownstreamTargetForValidation(target)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid target %q: %w", target, err)
+	}
+	return serviceID, path, nil
+}
+
+// parseReplayTimestamp returns a timestamp in nanoseconds: a number of seconds or an RFC 3339 time.
+func parseReplayTimestamp(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("timestamp cannot be empty")
+	}
+	if sec, err := strconv.ParseFloat(s, 64); err == nil {
+		if math.IsNaN(sec) || math.IsInf(sec, 0) || math.Abs(sec) > float64(math.MaxInt64)/float64(time.Second) {
+			return 0, fmt.Errorf("timestamp %q is out of range", s)
+		}
+		return int64(sec * float64(time.Second)), nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, fmt.Errorf("timestamp %q is neither seconds nor RFC 3339", s)
+	}
+	return t.UnixNano(), nil
+}
+
+// validateReplayArrival checks the replay fields of a and that every record target is a known endpoint.
+func validateReplayArrival(a *ArrivalSpec, serviceIDs, endpointRef map[string]bool) error {
+	if a.TimeScale < 0 || math.IsNaN(a.TimeScale) || math.IsInf(a.TimeScale, 0) {
+		return fmt.Errorf("arrival time_scale must be a positive number, got %v", a.TimeScale)
+	}
+	if a.Amplification < 0 || math.IsNaN(a.Amplification) || math.IsInf(a.Amplification, 0) {
+		return fmt.Errorf("arrival amplification must be a non-negative number, got %v", a.Amplification)
+	}
+	a.replay = nil
+	records, err := LoadReplayTrace(*a)
+	if err != nil {
+		return err
+	}
+	a.replay = &replayTrace{records: records}
+	for i := range records {
+		rec := &records[i]
+		if rec.ServiceID == "" {
+			continue
+		}
+		if !serviceIDs[rec.ServiceID] {
+			return fmt.Errorf("replay record %d: target service %s does not exist", i, rec.ServiceID)
+		}
+		if !endpointRef[rec.ServiceID+":"+rec.EndpointPath] {
+			return fmt.Errorf("replay record %d: target endpoint %s:%s does not exist", i, rec.ServiceID, rec.EndpointPath)
+		}
+	}
+	return nil
+}
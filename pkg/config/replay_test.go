@@ -0,0 +1,182 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseReplayTraceCSV(t *testing.T) {
+	trace := "timestamp,target,tenant_id,region\n" +
+		"100.5,api:/orders,t1,eu\n" +
+		"100.0,,t2,\n" +
+		"101.0,db:/q,,us\n"
+	recs, err := ParseReplayTrace(strings.NewReader(trace), ReplayFormatCSV)
+	if err != nil {
+		t.Fatalf("ParseReplayTrace: %v", err)
+	}
+	if len(recs) != 3 {
+		t.Fatalf("expected 3 records, got %d", len(recs))
+	}
+	// Sorted by time, offsets relative to the earliest record.
+	if recs[0].Offset != 0 || recs[1].Offset != 500*time.Millisecond || recs[2].Offset != time.Second {
+		t.Fatalf("unexpected offsets %v %v %v", recs[0].Offset, recs[1].Offset, recs[2].Offset)
+	}
+	if recs[0].ServiceID != "" || recs[0].Metadata["tenant_id"] != "t2" || len(recs[0].Metadata) != 1 {
+		t.Fatalf("unexpected first record %+v", recs[0])
+	}
+	if recs[1].ServiceID != "api" || recs[1].EndpointPath != "/orders" || recs[1].Metadata["region"] != "eu" {
+		t.Fatalf("unexpected second record %+v", recs[1])
+	}
+}
+
+func TestParseReplayTraceJSONL(t *testing.T) {
+	trace := `{"timestamp": "2026-01-01T00:00:02Z", "target": "api:/a", "metadata": {"tenant_id": "t1", "tier": 2}}
+
+{"timestamp": "2026-01-01T00:00:00.250Z", "user": "u1", "beta": true}
+`
+	recs, err := ParseReplayTrace(strings.NewReader(trace), ReplayFormatJSONL)
+	if err != nil {
+		t.Fatalf("ParseReplayTrace: %v", err)
+	}
+	if len(recs) != 2 || recs[1].Offset != 1750*time.Millisecond {
+		t.Fatalf("unexpected records %+v", recs)
+	}
+	if recs[0].Metadata["user"] != "u1" || recs[0].Metadata["beta"] != "true" {
+		t.Fatalf("expected top-level fields as metadata, got %v", recs[0].Metadata)
+	}
+	if recs[1].ServiceID != "api" || recs[1].Metadata["tenant_id"] != "t1" || recs[1].Metadata["tier"] != "2" {
+		t.Fatalf("unexpected record %+v", recs[1])
+	}
+}
+
+func TestParseReplayTraceErrors(t *testing.T) {
+	cases := []struct {
+		format, trace, want string
+	}{
+		{ReplayFormatCSV, "time,target\n1,api\n", "timestamp column"},
+		{ReplayFormatCSV, "timestamp\nyesterday\n", "line 2"},
+		{ReplayFormatCSV, "timestamp\n", "no records"},
+		{ReplayFormatJSONL, `{"target": "api"}`, "timestamp is required"},
+		{ReplayFormatJSONL, `{"timestamp": 1, "target": "api:"}`, "invalid target"},
+		{ReplayFormatJSONL, `{"timestamp": 1, "tags": ["a"]}`, "must be a string"},
+		{"xml", "", "invalid replay format"},
+	}
+	for _, tc := range cases {
+		if _, err := ParseReplayTrace(strings.NewReader(tc.trace), tc.format); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s %q: expected error containing %q, got %v", tc.format, tc.trace, tc.want, err)
+		}
+	}
+}
+
+func TestLoadReplayTraceFromFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "traces"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "traces", "trace.jsonl")
+	if err := os.WriteFile(path, []byte(`{"timestamp": 3}`+"\n"+`{"timestamp": 4}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadReplayTrace(ArrivalSpec{Type: "replay", ReplayFile: "traces/trace.jsonl"}); err == nil || !strings.Contains(err.Error(), "no replay directory") {
+		t.Fatalf("expected replay_file to be disabled without a replay directory, got %v", err)
+	}
+	SetReplayDir(dir)
+	t.Cleanup(func() { SetReplayDir("") })
+
+	recs, err := LoadReplayTrace(ArrivalSpec{Type: "replay", ReplayFile: "traces/./trace.jsonl"})
+	if err != nil {
+		t.Fatalf("LoadReplayTrace: %v", err)
+	}
+	if len(recs) != 2 || recs[1].Offset != time.Second {
+		t.Fatalf("unexpected records %+v", recs)
+	}
+	for _, name := range []string{path, "../trace.jsonl", "traces/../../trace.jsonl"} {
+		if _, err := LoadReplayTrace(ArrivalSpec{Type: "replay", ReplayFile: name}); err == nil || !strings.Contains(err.Error(), "relative path inside") {
+			t.Fatalf("expected %q to be rejected as outside the replay directory, got %v", name, err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(t.TempDir(), "elsewhere.jsonl"), filepath.Join(dir, "escape.jsonl")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadReplayTrace(ArrivalSpec{Type: "replay", ReplayFile: "escape.jsonl"}); err == nil {
+		t.Fatal("expected a symlink leading out of the replay directory to be rejected")
+	}
+	if _, err := LoadReplayTrace(ArrivalSpec{Type: "replay", ReplayFile: "traces/trace.jsonl", ReplayData: "timestamp\n1\n"}); err == nil {
+		t.Fatal("expected error when both replay_file and replay_data are set")
+	}
+	if _, err := LoadReplayTrace(ArrivalSpec{Type: "replay", ReplayData: "timestamp\n1\n"}); err == nil || !strings.Contains(err.Error(), "replay_format") {
+		t.Fatalf("expected inline data without a format to be rejected, got %v", err)
+	}
+}
+
+func TestValidatedReplayArrivalKeepsItsTrace(t *testing.T) {
+	dir := t.TempDir()
+	SetReplayDir(dir)
+	t.Cleanup(func() { SetReplayDir("") })
+	path := filepath.Join(dir, "trace.csv")
+	if err := os.WriteFile(path, []byte("timestamp\n1\n2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	a := ArrivalSpec{Type: "replay", ReplayFile: "trace.csv"}
+	if err := ValidateArrivalSpec(&a); err != nil {
+		t.Fatalf("ValidateArrivalSpec: %v", err)
+	}
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	copied := a
+	recs, err := LoadReplayTrace(copied)
+	if err != nil || len(recs) != 2 {
+		t.Fatalf("expected the trace parsed during validation, got %d records (%v)", len(recs), err)
+	}
+}
+
+func TestValidateScenarioReplayWorkload(t *testing.T) {
+	base := `
+hosts:
+  - id: h1
+    cores: 2
+services:
+  - id: api
+    replicas: 1
+    model: cpu
+    endpoints:
+      - path: /a
+        mean_cpu_ms: 1
+        cpu_sigma_ms: 0
+workload:
+  - from: client
+    to: api:/a
+    arrival:
+      type: replay
+      replay_format: csv
+      time_scale: 2
+      amplification: 1.5
+      replay_data: |
+        timestamp,target,tenant_id
+        0,api:/a,t1
+        1,,t2
+`
+	s, err := ParseScenarioYAMLString(base)
+	if err != nil {
+		t.Fatalf("expected valid replay workload without rate_rps, got %v", err)
+	}
+	if s.Workload[0].Arrival.Type != "replay" {
+		t.Fatalf("unexpected arrival type %q", s.Workload[0].Arrival.Type)
+	}
+
+	for _, tc := range []struct{ from, to, want string }{
+		{"0,api:/a,t1", "0,api:/missing,t1", "target endpoint api:/missing does not exist"},
+		{"0,api:/a,t1", "0,db:/x,t1", "target service db does not exist"},
+		{"time_scale: 2", "time_scale: -1", "time_scale"},
+		{"amplification: 1.5", "amplification: -2", "amplification"},
+	} {
+		bad := strings.Replace(base, tc.from, tc.to, 1)
+		if _, err := ParseScenarioYAMLString(bad); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%s: expected error containing %q, got %v", tc.to, tc.want, err)
+		}
+	}
+}
@@ -1,16 +1,79 @@
 package config
 
+import "regexp"
+
 // Scenario represents a complete simulation scenario and is the primary
 // configuration format for simulation runs (see pkg/config/doc.go).
 type Scenario struct {
 	Metadata         *ScenarioMetadata `yaml:"metadata,omitempty"`
 	SimulationLimits *SimulationLimits `yaml:"simulation_limits,omitempty"`
 	// Network holds optional multi-zone latency overlays for downstream service calls.
-	Network  *NetworkConfig    `yaml:"network,omitempty"`
-	Hosts    []Host            `yaml:"hosts"`
-	Services []Service         `yaml:"services"`
-	Workload []WorkloadPattern `yaml:"workload"`
-	Policies *Policies         `yaml:"policies,omitempty"`
+	Network *NetworkConfig `yaml:"network,omitempty"`
+	// HostTypes (optional) is the instance-type catalog hosts pick from with Host.Type.
+	HostTypes []HostType        `yaml:"host_types,omitempty"`
+	Hosts     []Host            `yaml:"hosts"`
+	Services  []Service         `yaml:"services"`
+	Workload  []WorkloadPattern `yaml:"workload"`
+	// Flows are multi-step user sessions (e.g. browse -> add-to-cart -> checkout) arriving alongside Workload.
+	Flows    []Flow    `yaml:"flows,omitempty"`
+	Policies *Policies `yaml:"policies,omitempty"`
+	// HostFailures (optional) take hosts or whole zones down during the run.
+	HostFailures []HostFailure `yaml:"host_failures,omitempty"`
+	// Faults (optional) is a chaos experiment timeline: faults injected into targets for a window of the run.
+	Faults []Fault `yaml:"faults,omitempty"`
+	// PriorityClasses (optional) name request priorities that workloads and flows pick with
+	// priority_class. The class is carried to downstream calls.
+	PriorityClasses []PriorityClass `yaml:"priority_classes,omitempty"`
+}
+
+// PriorityClass is a named request priority. Priority scheduling serves higher classes first and
+// load shedding sheds lower classes first.
+type PriorityClass struct {
+	Name     string `yaml:"name"`
+	Priority int    `yaml:"priority"`
+}
+
+// HostFailure takes one host, or every host in a zone, down at a simulation time. Instances on a
+// failed host die with their in-flight work; replacements are scheduled on the remaining hosts.
+type HostFailure struct {
+	Host string `yaml:"host,omitempty"`
+	Zone string `yaml:"zone,omitempty"`
+	// AtMs is when the hosts fail, from the start of the run.
+	AtMs float64 `yaml:"at_ms"`
+	// RecoverAfterMs brings the hosts back that long after the failure; 0 keeps them down.
+	RecoverAfterMs float64 `yaml:"recover_after_ms,omitempty"`
+	// RescheduleDelayMs is how long the scheduler takes to place replacements for the lost
+	// instances (failure detection plus scheduling); 0 places them at once.
+	RescheduleDelayMs float64 `yaml:"reschedule_delay_ms,omitempty"`
+}
+
+// Fault is one step of a chaos experiment: a fault of Type injected into Target from AtMs for
+// DurationMs. See FaultLatency and the other fault types for the fields each one reads.
+type Fault struct {
+	// ID names the fault in metrics and annotations; it defaults to "<type>-<n>", n counting from 1.
+	ID     string      `yaml:"id,omitempty"`
+	Type   string      `yaml:"type"`
+	AtMs   float64     `yaml:"at_ms"`
+	Target FaultTarget `yaml:"target"`
+	// DurationMs is how long the fault lasts. Instances killed by kill_instances restart when it ends.
+	DurationMs float64 `yaml:"duration_ms"`
+
+	LatencyMs   float64 `yaml:"latency_ms,omitempty"`   // latency
+	FailureRate float64 `yaml:"failure_rate,omitempty"` // failure_rate
+	Instances   int     `yaml:"instances,omitempty"`    // kill_instances (default 1)
+	CPUSteal    float64 `yaml:"cpu_steal,omitempty"`    // cpu_steal: share of the host CPU taken, in (0, 1)
+	Messages    int     `yaml:"messages,omitempty"`     // queue_fill (default the queue capacity)
+}
+
+// FaultTarget selects what a fault hits. Service (with an optional Endpoint, and From for the calls
+// of one caller only) targets latency, failure_rate, kill_instances and queue_fill; Host targets
+// cpu_steal; Zones, exactly two, targets partition.
+type FaultTarget struct {
+	Service  string   `yaml:"service,omitempty"`
+	Endpoint string   `yaml:"endpoint,omitempty"`
+	From     string   `yaml:"from,omitempty"`
+	Host     string   `yaml:"host,omitempty"`
+	Zones    []string `yaml:"zones,omitempty"`
 }
 
 // NetworkConfig models optional topology-aware overlays on downstream hop network latency.
@@ -44,13 +107,28 @@ type ScenarioMetadata struct {
 
 // Host represents a physical host
 type Host struct {
-	ID       string            `yaml:"id"`
+	ID string `yaml:"id"`
+	// Type (optional) names a host_types entry; cores and memory_gb default to the type's.
+	Type     string            `yaml:"type,omitempty"`
 	Cores    int               `yaml:"cores"`
 	MemoryGB int               `yaml:"memory_gb,omitempty"` // Optional; 0 means use simulator default (16 GB)
 	Zone     string            `yaml:"zone,omitempty"`
 	Labels   map[string]string `yaml:"labels,omitempty"`
 }
 
+// HostType is an instance type of the host catalog (e.g. a compute-optimized Graviton family):
+// its capacity, per-core speed and hourly price.
+type HostType struct {
+	ID       string `yaml:"id"`
+	Cores    int    `yaml:"cores"`
+	MemoryGB int    `yaml:"memory_gb,omitempty"`
+	// SpeedFactor is the per-core speed relative to a baseline core: CPU work on the type takes
+	// 1/speed_factor as long. 0 means 1.
+	SpeedFactor float64 `yaml:"speed_factor,omitempty"`
+	// PricePerHour is what one host of the type costs per hour.
+	PricePerHour float64 `yaml:"price_per_hour,omitempty"`
+}
+
 // Service represents a microservice
 type Service struct {
 	ID       string  `yaml:"id"`
@@ -59,6 +137,12 @@ type Service struct {
 	Replicas int     `yaml:"replicas"`
 	Model    string  `yaml:"model"` // cpu, mixed, db_latency
 	CPUCores float64 `yaml:"cpu_cores,omitempty"`
+	// CPURequest (optional) is the Kubernetes name of cpu_cores, the cores reserved on the host at
+	// placement. It is moved into CPUCores when the scenario is parsed.
+	CPURequest float64 `yaml:"cpu_request,omitempty"`
+	// CPULimit (optional) caps the instance's CPU use at this many cores per 100ms CFS period. Below
+	// it, the instance bursts onto idle host cores; past it, its work is throttled to the next period.
+	CPULimit float64 `yaml:"cpu_limit,omitempty"`
 	MemoryMB float64 `yaml:"memory_mb,omitempty"`
 	// ExternalNetworkLatencyMs (optional) overrides scenario.network.external_latency_ms for this service when kind is external.
 	ExternalNetworkLatencyMs *LatencySpec     `yaml:"external_network_latency_ms,omitempty"`
@@ -66,7 +150,66 @@ type Service struct {
 	Behavior                 *ServiceBehavior `yaml:"behavior,omitempty"`
 	Placement                *PlacementPolicy `yaml:"placement,omitempty"`
 	Routing                  *RoutingPolicy   `yaml:"routing,omitempty"`
-	Endpoints                []Endpoint       `yaml:"endpoints"`
+	// Startup (optional) delays and slows replicas added by scaling until they are ready and warm.
+	Startup *ServiceStartup `yaml:"startup,omitempty"`
+	// Scheduling (optional) sets how each instance orders the requests waiting for its CPU (default fcfs).
+	Scheduling *Scheduling `yaml:"scheduling,omitempty"`
+	// QueueLimits (optional) bounds the queue of requests waiting for each instance.
+	QueueLimits `yaml:",inline"`
+	// LoadShedding (optional) rejects requests of low priority classes while an instance is overloaded.
+	LoadShedding []LoadShedRule `yaml:"load_shedding,omitempty"`
+	// Policies (optional) overrides scenario-wide policies for this service (autoscaling) and every endpoint of it.
+	Policies  *PolicyOverrides `yaml:"policies,omitempty"`
+	Endpoints []Endpoint       `yaml:"endpoints"`
+}
+
+// ServiceStartup models the lag before a replica added by scaling serves traffic at full speed.
+// Replicas present at the start of a run are ready and warm.
+type ServiceStartup struct {
+	// DelayMs is the time to start the process (image pull, boot).
+	DelayMs LatencySpec `yaml:"delay_ms,omitempty"`
+	// ReadinessDelayMs is added after the process starts, before the readiness probe passes.
+	ReadinessDelayMs float64 `yaml:"readiness_delay_ms,omitempty"`
+	// WarmupMs is how long a ready replica runs slower while caches and JIT warm up.
+	WarmupMs float64 `yaml:"warmup_ms,omitempty"`
+	// WarmupCPUMultiplier scales CPU time when the replica becomes ready (>= 1, default 1); it
+	// decays linearly to 1 over WarmupMs.
+	WarmupCPUMultiplier float64 `yaml:"warmup_cpu_multiplier,omitempty"`
+}
+
+// Scheduling is the discipline an instance uses to share its CPU between requests.
+type Scheduling struct {
+	// Discipline is fcfs (default), processor_sharing, priority, adaptive_lifo or codel.
+	Discipline string `yaml:"discipline"`
+	// TargetDelayMs is the longest a codel instance lets a request wait once it is overloaded (default 5).
+	TargetDelayMs float64 `yaml:"target_delay_ms,omitempty"`
+	// IntervalMs is how long the queue of a codel or adaptive_lifo instance must stay non-empty
+	// before the instance counts as overloaded (default 100).
+	IntervalMs float64 `yaml:"interval_ms,omitempty"`
+	// PriorityKey is the request metadata key holding the numeric priority used by the priority
+	// discipline (default "priority"; higher first).
+	PriorityKey string `yaml:"priority_key,omitempty"`
+}
+
+// QueueLimits bounds the queue of requests waiting for an instance. Endpoint settings override the service's.
+type QueueLimits struct {
+	// MaxQueueLength caps the requests waiting for each instance (0: unbounded).
+	MaxQueueLength int `yaml:"max_queue_length,omitempty"`
+	// MaxQueueWaitMs fails a request with queue_timeout once it has waited this long (0: no limit).
+	MaxQueueWaitMs float64 `yaml:"max_queue_wait_ms,omitempty"`
+	// OnQueueFull is reject (default: the arriving request fails with queue_full) or drop_oldest (the
+	// longest-waiting request fails with queue_full and the arriving one joins the queue).
+	OnQueueFull string `yaml:"on_queue_full,omitempty"`
+}
+
+// LoadShedRule sheds requests of PriorityClass, and of every lower class, that reach an instance
+// while its CPU utilization or queue length is at a threshold.
+type LoadShedRule struct {
+	PriorityClass string `yaml:"priority_class"`
+	// UtilizationThreshold is the instance CPU utilization (0-1] at which requests are shed.
+	UtilizationThreshold float64 `yaml:"utilization_threshold,omitempty"`
+	// QueueThreshold is the number of requests waiting for the instance at which requests are shed.
+	QueueThreshold int `yaml:"queue_threshold,omitempty"`
 }
 
 // PlacementPolicy defines optional topology-aware placement preferences/constraints.
@@ -150,6 +293,26 @@ type CacheBehavior struct {
 	HitRate       float64     `yaml:"hit_rate,omitempty"`
 	HitLatencyMs  LatencySpec `yaml:"hit_latency_ms,omitempty"`
 	MissLatencyMs LatencySpec `yaml:"miss_latency_ms,omitempty"`
+	// KeySpace (optional) decides hits by looking keys up in a simulated cache instead of hit_rate.
+	KeySpace *CacheKeySpace `yaml:"key_space,omitempty"`
+	// MissCall (optional, "serviceID:path") is called synchronously on a miss to load the value,
+	// which is written back to the cache when the miss completes.
+	MissCall string `yaml:"miss_call,omitempty"`
+	// CoalesceMisses makes a miss on a key whose load is already in flight wait for that load
+	// instead of loading the value again (requires key_space).
+	CoalesceMisses bool `yaml:"coalesce_misses,omitempty"`
+}
+
+// CacheKeySpace models the keys requested from a cache and the entries it holds. Keys are drawn
+// from a Zipf popularity distribution unless the request carries one in KeyFrom metadata. The
+// cache is shared by the replicas of the service.
+type CacheKeySpace struct {
+	Keys         int     `yaml:"keys"`                    // number of distinct keys
+	ZipfS        float64 `yaml:"zipf_s,omitempty"`        // popularity skew s > 0; default 1
+	CapacityKeys int     `yaml:"capacity_keys,omitempty"` // entries held before eviction; 0 = unbounded
+	TTLMs        float64 `yaml:"ttl_ms,omitempty"`        // entry lifetime from write-back; 0 = no expiry
+	Eviction     string  `yaml:"eviction,omitempty"`      // lru (default) or lfu
+	KeyFrom      string  `yaml:"key_from,omitempty"`      // request metadata key holding the cache key
 }
 
 // ScalingPolicy declares which scaling dimensions are allowed for batch/online optimizers.
@@ -172,6 +335,36 @@ type Endpoint struct {
 	Routing         *RoutingPolicy   `yaml:"routing,omitempty"`
 	Downstream      []DownstreamCall `yaml:"downstream"`
 	NetLatencyMs    LatencySpec      `yaml:"net_latency_ms"`
+	// Policies (optional) overrides service and scenario-wide policies for calls served by this endpoint.
+	Policies *PolicyOverrides `yaml:"policies,omitempty"`
+	// CPUDistribution shapes CPU time like LatencySpec.Distribution, with mean_cpu_ms and cpu_sigma_ms
+	// as mean and sigma; the tables apply to empirical.
+	CPUDistribution string            `yaml:"cpu_distribution,omitempty"`
+	CPUHistogram    []HistogramBin    `yaml:"cpu_histogram,omitempty"`
+	CPUPercentiles  []PercentilePoint `yaml:"cpu_percentiles,omitempty"`
+	// Execution (optional) orders the endpoint's sync downstream calls into stages. Sync calls not
+	// listed run in parallel in the first stage; without it every sync call runs in parallel.
+	Execution []ExecutionStage `yaml:"execution,omitempty"`
+	// QueueLimits (optional) overrides the service's instance queue limits for this endpoint's requests.
+	QueueLimits `yaml:",inline"`
+}
+
+// ExecutionStage is one step of an endpoint's sync fan-out. Stages run one after another; the groups
+// of a stage run in parallel and the stage ends when all of them have resolved. Calls and Quorum are
+// shorthand for a stage with a single group.
+type ExecutionStage struct {
+	Calls  []string         `yaml:"calls,omitempty"`
+	Quorum int              `yaml:"quorum,omitempty"`
+	Groups []ExecutionGroup `yaml:"groups,omitempty"`
+}
+
+// ExecutionGroup is a set of sync downstream calls, named by their `to` target, issued together.
+// The group succeeds once Quorum of the issued calls succeed (default, or when fewer were issued: all
+// of them); calls still in flight are then ignored by the caller. It fails as soon as the quorum can
+// no longer be reached. Quorum counts repeated calls (call_count_mean) separately.
+type ExecutionGroup struct {
+	Calls  []string `yaml:"calls"`
+	Quorum int      `yaml:"quorum,omitempty"`
 }
 
 // RoutingPolicy configures request-to-instance routing/load-balancing behavior.
@@ -205,12 +398,64 @@ type DownstreamCall struct {
 	PartitionKey string `yaml:"partition_key,omitempty"`
 	// PartitionKeyFrom names a key in the parent request Metadata whose string value is used as the partition key (e.g. tenant_id).
 	PartitionKeyFrom string `yaml:"partition_key_from,omitempty"`
+	// Policies (optional) overrides the retry policy for this call edge only (most specific level).
+	Policies *PolicyOverrides `yaml:"policies,omitempty"`
+	// When (optional) limits the call to requests whose metadata matches every condition.
+	When []MetadataCondition `yaml:"when,omitempty"`
+	// Hedge (optional, sync calls only) sends extra attempts of the call while it is still outstanding.
+	Hedge *HedgePolicy `yaml:"hedge,omitempty"`
+}
+
+// HedgePolicy sends another attempt of a sync call that has not answered after a delay, up to
+// MaxHedges times; the first successful attempt answers the call. The delay is DelayMs, or the
+// DelayPercentile of the call's recent latencies once enough have been seen (DelayMs until then).
+type HedgePolicy struct {
+	DelayMs         float64 `yaml:"delay_ms,omitempty"`
+	DelayPercentile float64 `yaml:"delay_percentile,omitempty"` // e.g. 95
+	MaxHedges       int     `yaml:"max_hedges,omitempty"`       // default 1
+	// CancelLosers (default true) drops losing attempts that have not started and skips the
+	// downstream calls of those still processing.
+	CancelLosers *bool `yaml:"cancel_losers,omitempty"`
+}
+
+// MetadataCondition matches one request metadata value. Every operator that is set must hold, and a
+// request without the key never matches.
+type MetadataCondition struct {
+	Key    string   `yaml:"key"`
+	Equals *string  `yaml:"equals,omitempty"`
+	In     []string `yaml:"in,omitempty"`
+	Regex  string   `yaml:"regex,omitempty"` // RE2 syntax, unanchored
+	// Numeric comparisons; the value must parse as a number.
+	GT  *float64 `yaml:"gt,omitempty"`
+	GTE *float64 `yaml:"gte,omitempty"`
+	LT  *float64 `yaml:"lt,omitempty"`
+	LTE *float64 `yaml:"lte,omitempty"`
+
+	re *regexp.Regexp // Regex, compiled by validation
 }
 
 // LatencySpec represents latency with mean and standard deviation
 type LatencySpec struct {
 	Mean  float64 `yaml:"mean"`
 	Sigma float64 `yaml:"sigma"`
+	// Distribution shapes samples: normal (default, truncated at zero), lognormal, gamma, weibull
+	// (matched to mean and sigma), exponential (mean), fixed (mean) or empirical (histogram or percentiles).
+	Distribution string            `yaml:"distribution,omitempty"`
+	Histogram    []HistogramBin    `yaml:"histogram,omitempty"`   // empirical: latency histogram
+	Percentiles  []PercentilePoint `yaml:"percentiles,omitempty"` // empirical: percentile table
+}
+
+// HistogramBin is one bin of an empirical histogram: values from the previous bin's bound (0 for
+// the first bin) up to UpToMs, uniformly spread, with relative weight (e.g. a count).
+type HistogramBin struct {
+	UpToMs float64 `yaml:"up_to_ms"`
+	Weight float64 `yaml:"weight"`
+}
+
+// PercentilePoint is one row of an empirical percentile table.
+type PercentilePoint struct {
+	P  float64 `yaml:"p"` // Percentile in [0, 100]
+	Ms float64 `yaml:"ms"`
 }
 
 // WorkloadPattern represents a workload entry point
@@ -218,18 +463,128 @@ type WorkloadPattern struct {
 	From         string `yaml:"from"`
 	SourceKind   string `yaml:"source_kind,omitempty"`   // e.g. client
 	TrafficClass string `yaml:"traffic_class,omitempty"` // ingress, background, replay
+	// PriorityClass names the scenario priority class of the requests (default: the class named
+	// like TrafficClass, if any).
+	PriorityClass string `yaml:"priority_class,omitempty"`
 	// Metadata is copied into request metadata for arrivals generated from this workload pattern.
 	Metadata map[string]string `yaml:"metadata,omitempty"`
 	To       string            `yaml:"to"`
 	Arrival  ArrivalSpec       `yaml:"arrival"`
+	// WeightedMetadata draws a value per arrival for each key, with probability proportional to its weight.
+	WeightedMetadata map[string]map[string]float64 `yaml:"weighted_metadata,omitempty"`
+}
+
+// Flow is a multi-step user session workload. Sessions arrive by Arrival and walk Steps in order:
+// each step's request is issued once the previous one has finished and a think time has passed.
+type Flow struct {
+	ID           string `yaml:"id"`
+	From         string `yaml:"from,omitempty"` // Caller label for the session requests (default: the flow id)
+	SourceKind   string `yaml:"source_kind,omitempty"`
+	TrafficClass string `yaml:"traffic_class,omitempty"`
+	// PriorityClass names the priority class of the session requests (default: the class named like TrafficClass, if any).
+	PriorityClass string `yaml:"priority_class,omitempty"`
+	// Metadata is copied into the request metadata of every step, along with session_id.
+	Metadata map[string]string `yaml:"metadata,omitempty"`
+	Arrival  ArrivalSpec       `yaml:"arrival"` // Session arrivals (closed: users run sessions back to back)
+	Steps    []FlowStep        `yaml:"steps"`
+	// WeightedMetadata draws a value per session for each key, carried by all of its steps.
+	WeightedMetadata map[string]map[string]float64 `yaml:"weighted_metadata,omitempty"`
+}
+
+// FlowStep is one request of a flow session.
+type FlowStep struct {
+	Name string `yaml:"name,omitempty"` // Label in flow metrics (default: the target)
+	To   string `yaml:"to"`
+	// Probability that a session which finished the previous step continues to this one (default 1);
+	// the rest abandon. Not allowed on the first step.
+	Probability *float64    `yaml:"probability,omitempty"`
+	ThinkTimeMs LatencySpec `yaml:"think_time_ms,omitempty"` // Pause after the previous step finishes
+	// Metadata joins the session metadata from this step on, so later steps carry it too.
+	Metadata map[string]string `yaml:"metadata,omitempty"`
 }
 
 // ArrivalSpec represents arrival process specification
 type ArrivalSpec struct {
-	Type                 string  `yaml:"type"`                             // poisson, uniform, normal, bursty, constant
+	Type                 string  `yaml:"type"`                             // poisson, uniform, normal, bursty, constant, pareto, lognormal, mmpp, self_similar, replay, closed
 	RateRPS              float64 `yaml:"rate_rps"`                         // Mean/constant rate in requests per second
 	StdDevRPS            float64 `yaml:"stddev_rps,omitempty"`             // Standard deviation for normal distribution
 	BurstRateRPS         float64 `yaml:"burst_rate_rps,omitempty"`         // Rate during bursts (for bursty type)
 	BurstDurationSeconds float64 `yaml:"burst_duration_seconds,omitempty"` // Duration of burst periods
 	QuietDurationSeconds float64 `yaml:"quiet_duration_seconds,omitempty"` // Duration of quiet periods between bursts
+	ReplayFile           string  `yaml:"replay_file,omitempty"`            // Trace file for replay type (CSV or JSONL)
+	ReplayData           string  `yaml:"replay_data,omitempty"`            // Inline trace for replay type (instead of replay_file)
+	ReplayFormat         string  `yaml:"replay_format,omitempty"`          // csv or jsonl (default: from the replay_file extension)
+	TimeScale            float64 `yaml:"time_scale,omitempty"`             // Replay speed-up: 2 plays the trace twice as fast (default 1)
+	Amplification        float64 `yaml:"amplification,omitempty"`          // Replay traffic multiplier: 3 sends 3x the requests (default 1)
+	// RateProfile makes a poisson arrival non-homogeneous: the rate follows the profile over simulation time.
+	RateProfile *RateProfile `yaml:"rate_profile,omitempty"`
+	// Closed type: Users virtual users each issue a request, wait for it to finish and think before the next.
+	Users         int         `yaml:"users,omitempty"`
+	ThinkTimeMs   LatencySpec `yaml:"think_time_ms,omitempty"`   // Think time between a user's requests
+	RampUpSeconds float64     `yaml:"ramp_up_seconds,omitempty"` // Users start evenly spread over this window (default: all at once)
+	// Heavy-tailed renewal types: inter-arrival times have mean 1/rate_rps.
+	ParetoShape    float64 `yaml:"pareto_shape,omitempty"`    // pareto: tail index alpha, > 1 (default 1.5)
+	InterarrivalCV float64 `yaml:"interarrival_cv,omitempty"` // lognormal: coefficient of variation of inter-arrival times (default 1)
+	// MMPP is the Markov-modulated Poisson process of the mmpp type.
+	MMPP *MMPPSpec `yaml:"mmpp,omitempty"`
+	// SelfSimilar tunes the self_similar type (defaults apply when omitted); rate_rps is its mean rate.
+	SelfSimilar *SelfSimilarSpec `yaml:"self_similar,omitempty"`
+
+	// replay caches the trace of a validated replay arrival (see LoadReplayTrace).
+	replay *replayTrace
+}
+
+// MMPPSpec is a Markov-modulated Poisson process: arrivals are Poisson at the rate of the current
+// state, which is held for an exponential time and then left for another state drawn from the
+// transition matrix.
+type MMPPSpec struct {
+	States []MMPPState `yaml:"states"`
+	// Transitions[i][j] is the probability of moving to state j when state i ends; rows sum to 1.
+	// Default: the other states with equal probability.
+	Transitions  [][]float64 `yaml:"transitions,omitempty"`
+	InitialState int         `yaml:"initial_state,omitempty"`
+}
+
+// MMPPState is one state of an MMPP.
+type MMPPState struct {
+	RateRPS             float64 `yaml:"rate_rps"`
+	MeanDurationSeconds float64 `yaml:"mean_duration_seconds"` // Mean sojourn time in the state
+}
+
+// SelfSimilarSpec is an aggregate of on/off sources with heavy-tailed (Pareto) on and off periods,
+// which is asymptotically self-similar with the given Hurst parameter.
+type SelfSimilarSpec struct {
+	Hurst             float64 `yaml:"hurst,omitempty"`               // In (0.5, 1); higher is burstier over long time scales (default 0.8)
+	Sources           int     `yaml:"sources,omitempty"`             // Number of on/off sources (default 32)
+	MeanPeriodSeconds float64 `yaml:"mean_period_seconds,omitempty"` // Mean on and off period (default 1)
+}
+
+// RateProfile is a time-varying arrival rate. The base curve is a schedule of points or a diurnal
+// sinusoid (rate_rps when neither is set); spikes multiply it over their windows.
+type RateProfile struct {
+	Points        []RatePoint     `yaml:"points,omitempty"`
+	Interpolation string          `yaml:"interpolation,omitempty"` // linear (default) or step
+	Diurnal       *DiurnalProfile `yaml:"diurnal,omitempty"`
+	Spikes        []RateSpike     `yaml:"spikes,omitempty"`
+}
+
+// RatePoint is one point of a rate schedule; the rate is held before the first and after the last point.
+type RatePoint struct {
+	AtSeconds float64 `yaml:"at_seconds"` // Seconds since the start of the run
+	RateRPS   float64 `yaml:"rate_rps"`
+}
+
+// DiurnalProfile is a sinusoidal rate between trough and peak.
+type DiurnalProfile struct {
+	PeriodSeconds float64 `yaml:"period_seconds,omitempty"` // Default 86400 (one day)
+	PeakRPS       float64 `yaml:"peak_rps"`
+	TroughRPS     float64 `yaml:"trough_rps"`
+	PeakAtSeconds float64 `yaml:"peak_at_seconds,omitempty"` // Seconds since the start of the run at which the peak falls
+}
+
+// RateSpike multiplies the rate over [at_seconds, at_seconds+duration_seconds).
+type RateSpike struct {
+	AtSeconds       float64 `yaml:"at_seconds"`
+	DurationSeconds float64 `yaml:"duration_seconds"`
+	Multiplier      float64 `yaml:"multiplier"`
 }
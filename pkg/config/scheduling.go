@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Scheduling disciplines of service instances.
+const (
+	// SchedulingFCFS serves requests in arrival order, one CPU interval after the other.
+	SchedulingFCFS = "fcfs"
+	// SchedulingProcessorSharing runs every admitted request at once, each at an equal share of the cores.
+	SchedulingProcessorSharing = "processor_sharing"
+	// SchedulingPriority serves the waiting request with the highest priority first.
+	SchedulingPriority = "priority"
+	// SchedulingAdaptiveLIFO serves in arrival order, and newest first while the instance is overloaded.
+	SchedulingAdaptiveLIFO = "adaptive_lifo"
+	// SchedulingCoDel serves in arrival order, and drops requests that waited past the target delay
+	// while the instance is overloaded.
+	SchedulingCoDel = "codel"
+)
+
+const (
+	defaultSchedulingTargetDelayMs = 5
+	defaultSchedulingIntervalMs    = 100
+	defaultSchedulingPriorityKey   = "priority"
+)
+
+// EffectiveDiscipline returns the scheduling discipline of the service (fcfs when unset).
+func (s *Scheduling) EffectiveDiscipline() string {
+	if s == nil || s.Discipline == "" {
+		return SchedulingFCFS
+	}
+	return s.Discipline
+}
+
+// QueuesRequests reports whether requests finding the instance busy wait in its request queue and
+// are picked by the discipline, rather than being reserved CPU behind the work already there.
+func (s *Scheduling) QueuesRequests() bool {
+	switch s.EffectiveDiscipline() {
+	case SchedulingPriority, SchedulingAdaptiveLIFO, SchedulingCoDel:
+		return true
+	}
+	return false
+}
+
+// EffectiveTargetDelayMs returns target_delay_ms, or 5 when unset.
+func (s *Scheduling) EffectiveTargetDelayMs() float64 {
+	if s == nil || s.TargetDelayMs <= 0 {
+		return defaultSchedulingTargetDelayMs
+	}
+	return s.TargetDelayMs
+}
+
+// EffectiveIntervalMs returns interval_ms, or 100 when unset.
+func (s *Scheduling) EffectiveIntervalMs() float64 {
+	if s == nil || s.IntervalMs <= 0 {
+		return defaultSchedulingIntervalMs
+	}
+	return s.IntervalMs
+}
+
+// EffectivePriorityKey returns priority_key, or "priority" when unset.
+func (s *Scheduling) EffectivePriorityKey() string {
+	if s == nil || s.PriorityKey == "" {
+		return defaultSchedulingPriorityKey
+	}
+	return s.PriorityKey
+}
+
+// ServiceScheduling returns the scheduling of svc. A service without a scheduling block uses
+// priority scheduling when the scenario declares priority classes, so that its queue serves
+// higher classes first; otherwise it is nil (fcfs).
+func (s *Scenario) ServiceScheduling(svc *Service) *Scheduling {
+	if svc.Scheduling != nil || len(s.PriorityClasses) == 0 {
+		return svc.Scheduling
+	}
+	if kind := strings.ToLower(strings.TrimSpace(svc.Kind)); kind == "queue" || kind == "topic" {
+		return nil
+	}
+	return &Scheduling{Discipline: SchedulingPriority}
+}
+
+func validateScheduling(s *Scenario) error {
+	for i := range s.Services {
+		svc := &s.Services[i]
+		sc := svc.Scheduling
+		if sc == nil {
+			continue
+		}
+		switch sc.EffectiveDiscipline() {
+		case SchedulingFCFS, SchedulingProcessorSharing, SchedulingPriority, SchedulingAdaptiveLIFO, SchedulingCoDel:
+		default:
+			return fmt.Errorf("service %s: unknown scheduling discipline %q (fcfs, processor_sharing, priority, adaptive_lifo or codel)", svc.ID, sc.Discipline)
+		}
+		if sc.TargetDelayMs < 0 {
+			return fmt.Errorf("service %s: scheduling.target_delay_ms cannot be negative", svc.ID)
+		}
+		if sc.IntervalMs < 0 {
+			return fmt.Errorf("service %s: scheduling.interval_ms cannot be negative", svc.ID)
+		}
+		if sc.EffectiveDiscipline() == SchedulingProcessorSharing && svc.CPULimit > 0 {
+			return fmt.Errorf("service %s: processor_sharing scheduling does not support cpu_limit", svc.ID)
+		}
+	}
+	return nil
+}
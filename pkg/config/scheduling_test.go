@@ -0,0 +1,67 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchedulingDefaults(t *testing.T) {
+	var unset *Scheduling
+	if unset.EffectiveDiscipline() != SchedulingFCFS || unset.QueuesRequests() {
+		t.Fatalf("a service without scheduling should be fcfs")
+	}
+	sc := &Scheduling{Discipline: SchedulingCoDel}
+	if !sc.QueuesRequests() || sc.EffectiveTargetDelayMs() != 5 || sc.EffectiveIntervalMs() != 100 || sc.EffectivePriorityKey() != "priority" {
+		t.Fatalf("unexpected codel defaults: %+v", sc)
+	}
+	if (&Scheduling{Discipline: SchedulingProcessorSharing}).QueuesRequests() {
+		t.Fatalf("processor sharing does not queue requests")
+	}
+}
+
+func TestValidateScheduling(t *testing.T) {
+	tests := []struct {
+		name string
+		svc  Service
+		want string
+	}{
+		{"unknown discipline", Service{ID: "a", Scheduling: &Scheduling{Discipline: "lifo"}}, "unknown scheduling discipline"},
+		{"negative target", Service{ID: "a", Scheduling: &Scheduling{Discipline: SchedulingCoDel, TargetDelayMs: -1}}, "target_delay_ms cannot be negative"},
+		{"negative interval", Service{ID: "a", Scheduling: &Scheduling{Discipline: SchedulingAdaptiveLIFO, IntervalMs: -1}}, "interval_ms cannot be negative"},
+		{"processor sharing with limit", Service{ID: "a", CPULimit: 2, Scheduling: &Scheduling{Discipline: SchedulingProcessorSharing}}, "does not support cpu_limit"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateScheduling(&Scenario{Services: []Service{tc.svc}})
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+
+	s := &Scenario{Services: []Service{{ID: "a", Scheduling: &Scheduling{Discipline: SchedulingPriority, PriorityKey: "tier"}}}}
+	if err := validateScheduling(s); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if keys := s.PropagatedMetadataKeys(); len(keys) != 1 || keys[0] != "tier" {
+		t.Fatalf("the priority key should follow requests downstream, got %v", keys)
+	}
+}
+
+func TestServiceSchedulingDefaultsToPriorityWithClasses(t *testing.T) {
+	fcfs := &Scheduling{Discipline: SchedulingFCFS}
+	s := &Scenario{Services: []Service{{ID: "a"}, {ID: "b", Scheduling: fcfs}, {ID: "q", Kind: "queue"}}}
+	if sc := s.ServiceScheduling(&s.Services[0]); sc != nil {
+		t.Fatalf("expected fcfs without priority classes, got %+v", sc)
+	}
+	s.PriorityClasses = []PriorityClass{{Name: "checkout", Priority: 1}}
+	if sc := s.ServiceScheduling(&s.Services[0]); sc.EffectiveDiscipline() != SchedulingPriority {
+		t.Fatalf("expected priority scheduling once classes are declared, got %s", sc.EffectiveDiscipline())
+	}
+	if sc := s.ServiceScheduling(&s.Services[1]); sc != fcfs {
+		t.Fatalf("an explicit scheduling block should be kept, got %+v", sc)
+	}
+	if sc := s.ServiceScheduling(&s.Services[2]); sc != nil {
+		t.Fatalf("queue services should keep no scheduling, got %+v", sc)
+	}
+}
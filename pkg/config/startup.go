@@ -0,0 +1,41 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+// WarmupCPUFactor returns the CPU cost multiplier of a replica sinceReady after it became ready.
+func (s *ServiceStartup) WarmupCPUFactor(sinceReady time.Duration) float64 {
+	if s == nil || s.WarmupMs <= 0 || s.WarmupCPUMultiplier <= 1 || sinceReady < 0 {
+		return 1
+	}
+	left := 1 - float64(sinceReady)/(s.WarmupMs*float64(time.Millisecond))
+	if left <= 0 {
+		return 1
+	}
+	return 1 + (s.WarmupCPUMultiplier-1)*left
+}
+
+// ValidateServiceStartup checks the startup settings of a service.
+func ValidateServiceStartup(svcID string, s *ServiceStartup) error {
+	if s == nil {
+		return nil
+	}
+	if s.DelayMs.Mean < 0 || s.DelayMs.Sigma < 0 {
+		return fmt.Errorf("service %s: startup.delay_ms mean/sigma cannot be negative", svcID)
+	}
+	if err := validateLatencySpec(s.DelayMs); err != nil {
+		return fmt.Errorf("service %s: startup.delay_ms: %w", svcID, err)
+	}
+	if !isFiniteNonNegative(s.ReadinessDelayMs) {
+		return fmt.Errorf("service %s: startup.readiness_delay_ms must be non-negative, got %v", svcID, s.ReadinessDelayMs)
+	}
+	if !isFiniteNonNegative(s.WarmupMs) {
+		return fmt.Errorf("service %s: startup.warmup_ms must be non-negative, got %v", svcID, s.WarmupMs)
+	}
+	if !isFiniteNonNegative(s.WarmupCPUMultiplier) || (s.WarmupCPUMultiplier != 0 && s.WarmupCPUMultiplier < 1) {
+		return fmt.Errorf("service %s: startup.warmup_cpu_multiplier must be at least 1, got %v", svcID, s.WarmupCPUMultiplier)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServiceStartupWarmupCPUFactor(t *testing.T) {
+	s := &ServiceStartup{WarmupMs: 1000, WarmupCPUMultiplier: 2}
+	for _, tc := range []struct {
+		since time.Duration
+		want  float64
+	}{{0, 2}, {250 * time.Millisecond, 1.75}, {time.Second, 1}, {time.Minute, 1}} {
+		if got := s.WarmupCPUFactor(tc.since); math.Abs(got-tc.want) > 1e-9 {
+			t.Fatalf("WarmupCPUFactor(%v) = %v, want %v", tc.since, got, tc.want)
+		}
+	}
+	if got := (&ServiceStartup{WarmupMs: 1000}).WarmupCPUFactor(0); got != 1 {
+		t.Fatalf("expected no warm-up cost without a multiplier, got %v", got)
+	}
+}
+
+func TestValidateServiceStartup(t *testing.T) {
+	valid := &ServiceStartup{
+		DelayMs:             LatencySpec{Mean: 5000, Sigma: 1000, Distribution: DistributionLognormal},
+		ReadinessDelayMs:    2000,
+		WarmupMs:            30000,
+		WarmupCPUMultiplier: 2.5,
+	}
+	if err := ValidateServiceStartup("api", valid); err != nil {
+		t.Fatalf("expected valid startup, got %v", err)
+	}
+	tests := []struct {
+		name string
+		s    *ServiceStartup
+		want string
+	}{
+		{"negative delay", &ServiceStartup{DelayMs: LatencySpec{Mean: -1}}, "startup.delay_ms mean/sigma cannot be negative"},
+		{"negative readiness", &ServiceStartup{ReadinessDelayMs: -1}, "startup.readiness_delay_ms must be non-negative"},
+		{"infinite warmup", &ServiceStartup{WarmupMs: math.Inf(1)}, "startup.warmup_ms must be non-negative"},
+		{"multiplier below one", &ServiceStartup{WarmupCPUMultiplier: 0.5}, "startup.warmup_cpu_multiplier must be at least 1"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateServiceStartup("api", tc.s)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
@@ -35,7 +35,7 @@ func EffectiveTopicBehavior(t *TopicBehavior) *TopicBehavior {
 	} else if t.Capacity > 0 {
 		out.Capacity = t.Capacity
 	}
-	if t.DeliveryLatencyMs.Mean > 0 || t.DeliveryLatencyMs.Sigma > 0 {
+	if t.DeliveryLatencyMs.IsSet() {
 		out.DeliveryLatencyMs = t.DeliveryLatencyMs
 	}
 	if strings.TrimSpace(t.PublishAck) != "" {
@@ -67,6 +67,9 @@ func ValidateTopicBehavior(svcID string, t *TopicBehavior, endpointRef map[strin
 	if t.DeliveryLatencyMs.Mean < 0 || t.DeliveryLatencyMs.Sigma < 0 {
 		return fmt.Errorf("service %s: behavior.topic.delivery_latency_ms mean/sigma cannot be negative", svcID)
 	}
+	if err := validateLatencySpec(t.DeliveryLatencyMs); err != nil {
+		return fmt.Errorf("service %s: behavior.topic.delivery_latency_ms: %w", svcID, err)
+	}
 	eff := EffectiveTopicBehavior(t)
 	if len(eff.Subscribers) == 0 {
 		return fmt.Errorf("service %s: behavior.topic.subscribers must be non-empty", svcID)
@@ -64,15 +64,31 @@ type Workload struct {
 
 // Policies represents simulation policies
 type Policies struct {
-	Autoscaling *AutoscalingPolicy `yaml:"autoscaling,omitempty"`
-	Retries     *RetryPolicy       `yaml:"retries,omitempty"`
+	Autoscaling    *AutoscalingPolicy    `yaml:"autoscaling,omitempty"`
+	Retries        *RetryPolicy          `yaml:"retries,omitempty"`
+	CircuitBreaker *CircuitBreakerPolicy `yaml:"circuit_breaker,omitempty"`
+	RateLimiting   *RateLimitingPolicy   `yaml:"rate_limiting,omitempty"`
+	OOM            *OOMPolicy            `yaml:"oom,omitempty"`
 }
 
-// AutoscalingPolicy represents autoscaling configuration
+// AutoscalingPolicy represents autoscaling configuration. When enabled on a Scenario,
+// the simulator emulates a Kubernetes HPA: every sync period it samples instance CPU,
+// applies the policy, and scales replicas (see EffectiveSyncPeriod and friends for defaults).
 type AutoscalingPolicy struct {
 	Enabled       bool    `yaml:"enabled"`
 	TargetCPUUtil float64 `yaml:"target_cpu_util"`
 	ScaleStep     int     `yaml:"scale_step"`
+	// MinReplicas / MaxReplicas bound the replica count chosen by the policy (defaults 1 / 100).
+	MinReplicas int `yaml:"min_replicas,omitempty"`
+	MaxReplicas int `yaml:"max_replicas,omitempty"`
+	// SyncPeriodMs is the simulated interval between metric syncs / policy evaluations (default 15000).
+	SyncPeriodMs int `yaml:"sync_period_ms,omitempty"`
+	// StabilizationWindowMs holds scale-down at the highest recommendation seen within the
+	// window, preventing flapping (default 0: no stabilization).
+	StabilizationWindowMs int `yaml:"stabilization_window_ms,omitempty"`
+	// StartupDelayMs is the simulated time between a scale-up decision and new replicas
+	// entering rotation (pod scheduling + container start).
+	StartupDelayMs int `yaml:"startup_delay_ms,omitempty"`
 }
 
 // RetryPolicy represents retry configuration
@@ -83,6 +99,39 @@ type RetryPolicy struct {
 	BaseMs     int    `yaml:"base_ms"`
 }
 
+// CircuitBreakerPolicy represents circuit breaker configuration. Circuits are tracked per
+// service/endpoint; zero thresholds/timeout fall back to defaults (see EffectiveFailureThreshold).
+type CircuitBreakerPolicy struct {
+	Enabled bool `yaml:"enabled"`
+	// FailureThreshold is the number of consecutive failures that opens the circuit (default 5).
+	FailureThreshold int `yaml:"failure_threshold,omitempty"`
+	// SuccessThreshold is the number of half-open successes that closes the circuit (default 1).
+	SuccessThreshold int `yaml:"success_threshold,omitempty"`
+	// TimeoutMs is how long an open circuit rejects requests before going half-open (default 30000).
+	TimeoutMs int `yaml:"timeout_ms,omitempty"`
+}
+
+// RateLimitingPolicy represents token-bucket rate limiting configuration, applied per service/endpoint.
+type RateLimitingPolicy struct {
+	Enabled            bool `yaml:"enabled"`
+	RateLimitPerSecond int  `yaml:"rate_limit_per_second"`
+}
+
+// OOMPolicy enforces memory limits. When enabled, a replica whose in-flight memory exceeds its
+// memory_mb is OOM-killed, as is the replica using the most memory on a host whose replicas exceed the
+// host memory (hosts no longer reject work with memory_capacity). Replicas of a service without
+// memory_mb have no limit of their own. The requests of a killed replica fail with reason oom_killed
+// and it restarts after a CrashLoopBackOff delay (see RestartBackoff).
+type OOMPolicy struct {
+	Enabled bool `yaml:"enabled"`
+	// RestartBackoffMs is the restart delay after a first kill; it doubles with each further kill (default 10000).
+	RestartBackoffMs int `yaml:"restart_backoff_ms,omitempty"`
+	// MaxRestartBackoffMs caps the restart delay (default 300000).
+	MaxRestartBackoffMs int `yaml:"max_restart_backoff_ms,omitempty"`
+	// BackoffResetMs is how long a restarted replica must run without a kill before the delay resets (default 600000).
+	BackoffResetMs int `yaml:"backoff_reset_ms,omitempty"`
+}
+
 // Optimization represents optimization configuration
 type Optimization struct {
 	Enabled       bool   `yaml:"enabled"`
@@ -83,6 +83,8 @@ type RunMetrics struct {
 	EndpointRequestStats []EndpointRequestStats `json:"endpoint_request_stats,omitempty"`
 	// InstanceRouteStats is optional per-instance routing selection totals.
 	InstanceRouteStats []InstanceRouteStats `json:"instance_route_stats,omitempty"`
+	// FlowStats is per-flow session outcomes when the scenario defines flows.
+	FlowStats []FlowStats `json:"flow_stats,omitempty"`
 	// Topology routing rollups.
 	LocalityHitRate            float64 `json:"locality_hit_rate,omitempty"`
 	CrossZoneRequestCountTotal int64   `json:"cross_zone_request_count_total,omitempty"`
@@ -100,6 +102,34 @@ type RunMetrics struct {
 	// Aggregate topology penalty across all network classes (from topology_latency_penalty_ms).
 	TopologyLatencyPenaltyMsTotal float64 `json:"topology_latency_penalty_ms_total,omitempty"`
 	TopologyLatencyPenaltyMsMean  float64 `json:"topology_latency_penalty_ms_mean,omitempty"`
+	// Hedged request rollups (downstream calls with a hedge policy). HedgeRate is hedge_requests_total /
+	// hedged_calls_total; the latency gain is over calls won by a hedge whose first attempt still completed.
+	HedgedCallsTotal       int64   `json:"hedged_calls_total,omitempty"`
+	HedgeRequestsTotal     int64   `json:"hedge_requests_total,omitempty"`
+	HedgeRate              float64 `json:"hedge_rate,omitempty"`
+	HedgeWinsTotal         int64   `json:"hedge_wins_total,omitempty"`
+	HedgeCancelledTotal    int64   `json:"hedge_cancelled_total,omitempty"`
+	HedgeWastedCPUMsTotal  float64 `json:"hedge_wasted_cpu_ms_total,omitempty"`
+	HedgeLatencyGainMsMean float64 `json:"hedge_latency_gain_ms_mean,omitempty"`
+	HedgeLatencyGainMsP95  float64 `json:"hedge_latency_gain_ms_p95,omitempty"`
+	// Memory limit enforcement (policies.oom): instances OOM-killed and restarts after a kill.
+	OOMKillsTotal         int64 `json:"oom_kills_total,omitempty"`
+	InstanceRestartsTotal int64 `json:"instance_restarts_total,omitempty"`
+	// Host failure injection (scenario host_failures). OutageAvailability is the fraction of the
+	// outage_requests (ingress arrivals while a host was down) that did not fail.
+	HostFailuresTotal         int64   `json:"host_failures_total,omitempty"`
+	InstancesLostTotal        int64   `json:"instances_lost_total,omitempty"`
+	InstancesRescheduledTotal int64   `json:"instances_rescheduled_total,omitempty"`
+	OutageRequests            int64   `json:"outage_requests,omitempty"`
+	OutageFailedRequests      int64   `json:"outage_failed_requests,omitempty"`
+	OutageAvailability        float64 `json:"outage_availability,omitempty"`
+	// CFS throttling of services with a cpu_limit: time work waited with its quota used up, and
+	// throttled periods, in total and per instance.
+	CPUThrottledMsTotal      float64                    `json:"cpu_throttled_ms_total,omitempty"`
+	CPUThrottledPeriodsTotal int64                      `json:"cpu_throttled_periods_total,omitempty"`
+	InstanceCPUThrottleStats []InstanceCPUThrottleStats `json:"instance_cpu_throttle_stats,omitempty"`
+	// Ingress requests, failures, load shedding and latency per scenario priority class.
+	PriorityClassStats []PriorityClassStats `json:"priority_class_stats,omitempty"`
 }
 
 // EndpointRequestStats aggregates ingress/hop request and error counts for one endpoint (from collector labels).
@@ -138,6 +168,64 @@ type InstanceRouteStats struct {
 	SelectionCount int64  `json:"selection_count"`
 }
 
+// InstanceCPUThrottleStats is the CFS throttling of one instance over the run.
+type InstanceCPUThrottleStats struct {
+	ServiceName      string  `json:"service_name"`
+	InstanceID       string  `json:"instance_id"`
+	ThrottledMs      float64 `json:"throttled_ms"`
+	ThrottledPeriods int64   `json:"throttled_periods"`
+}
+
+// PriorityClassStats is the ingress traffic of one scenario priority class over the run.
+type PriorityClassStats struct {
+	PriorityClass string `json:"priority_class"`
+	// Requests counts ingress arrivals of the class and FailedRequests those that failed, shed ones included.
+	Requests       int64   `json:"requests"`
+	FailedRequests int64   `json:"failed_requests"`
+	ErrorRate      float64 `json:"error_rate"`
+	// ShedAttempts counts calls of the class shed by load shedding: at ingress or downstream, retries
+	// included, so one ingress request can be shed more than once.
+	ShedAttempts int64 `json:"shed_attempts"`
+	// End-to-end latency of the ingress requests of the class.
+	LatencyP50Ms  float64 `json:"latency_p50_ms,omitempty"`
+	LatencyP95Ms  float64 `json:"latency_p95_ms,omitempty"`
+	LatencyP99Ms  float64 `json:"latency_p99_ms,omitempty"`
+	LatencyMeanMs float64 `json:"latency_mean_ms,omitempty"`
+}
+
+// FlowStats aggregates the sessions of one flow started during the run.
+type FlowStats struct {
+	FlowID            string `json:"flow_id"`
+	Sessions          int64  `json:"sessions"`
+	CompletedSessions int64  `json:"completed_sessions"`
+	AbandonedSessions int64  `json:"abandoned_sessions"`
+	FailedSessions    int64  `json:"failed_sessions"`
+	// InProgressSessions counts sessions still running when the run ended (a step in flight or
+	// its next step due after the end).
+	InProgressSessions int64 `json:"in_progress_sessions"`
+	// CompletionRate is completed_sessions / sessions.
+	CompletionRate float64 `json:"completion_rate"`
+	// Session duration (first arrival to last completion) of completed sessions.
+	SessionDurationP50Ms  float64         `json:"session_duration_p50_ms,omitempty"`
+	SessionDurationP95Ms  float64         `json:"session_duration_p95_ms,omitempty"`
+	SessionDurationP99Ms  float64         `json:"session_duration_p99_ms,omitempty"`
+	SessionDurationMeanMs float64         `json:"session_duration_mean_ms,omitempty"`
+	Steps                 []FlowStepStats `json:"steps,omitempty"`
+}
+
+// FlowStepStats is the funnel of one flow step: how many sessions finished it and how many ended there.
+type FlowStepStats struct {
+	Step string `json:"step"`
+	// Requests counts finished requests of this step.
+	Requests int64 `json:"requests"`
+	// Abandoned counts sessions that finished this step and did not continue to the next one.
+	Abandoned int64 `json:"abandoned"`
+	// Failed counts sessions that ended because this step's request failed.
+	Failed int64 `json:"failed"`
+	// AbandonmentRate is abandoned / requests.
+	AbandonmentRate float64 `json:"abandonment_rate"`
+}
+
 // HostMetrics holds utilization observed on a host (when the simulator records host-level gauges).
 type HostMetrics struct {
 	HostID            string  `json:"host_id"`
@@ -172,6 +260,50 @@ type ServiceMetrics struct {
 	ProcessingLatencyMeanMs float64 `json:"processing_latency_mean_ms,omitempty"`
 }
 
+// SummaryStat summarizes one metric across independent replications.
+type SummaryStat struct {
+	N      int     `json:"n"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"`
+	// CI95HalfWidth is the half-width of the 95% Student t confidence interval for the mean.
+	CI95HalfWidth float64 `json:"ci95_half_width"`
+	CI95Low       float64 `json:"ci95_low"`
+	CI95High      float64 `json:"ci95_high"`
+}
+
+// ReplicationMetrics holds replication statistics for the headline metrics of a run, service or endpoint.
+// ErrorRate is errors / requests of the scope; ThroughputRPS is requests over simulated time.
+type ReplicationMetrics struct {
+	LatencyP50    SummaryStat `json:"latency_p50_ms"`
+	LatencyP95    SummaryStat `json:"latency_p95_ms"`
+	LatencyP99    SummaryStat `json:"latency_p99_ms"`
+	LatencyMean   SummaryStat `json:"latency_mean_ms"`
+	ThroughputRPS SummaryStat `json:"throughput_rps"`
+	ErrorRate     SummaryStat `json:"error_rate"`
+}
+
+// EndpointReplicationMetrics holds replication statistics for one service endpoint.
+type EndpointReplicationMetrics struct {
+	ServiceName  string `json:"service_name"`
+	EndpointPath string `json:"endpoint_path"`
+	ReplicationMetrics
+}
+
+// ReplicationSummary aggregates RunMetrics from independent replications of one scenario.
+type ReplicationSummary struct {
+	Replications int     `json:"replications"`
+	Seeds        []int64 `json:"seeds"`
+	// TargetRelativePrecision is the requested CI half-width / |mean| (0 when no stopping rule was set).
+	TargetRelativePrecision float64 `json:"target_relative_precision,omitempty"`
+	// MaxRelativeHalfWidth is the largest CI half-width / |mean| over the run-level latencies and throughput.
+	MaxRelativeHalfWidth float64 `json:"max_relative_half_width"`
+	// PrecisionReached reports whether MaxRelativeHalfWidth is within TargetRelativePrecision.
+	PrecisionReached bool                           `json:"precision_reached"`
+	Run              ReplicationMetrics             `json:"run"`
+	Services         map[string]*ReplicationMetrics `json:"services,omitempty"`
+	Endpoints        []EndpointReplicationMetrics   `json:"endpoints,omitempty"`
+}
+
 // RequestStatus represents the status of a request
 type RequestStatus string
 
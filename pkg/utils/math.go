@@ -84,6 +84,37 @@ func StdDev(values []float64) float64 {
 	return math.Sqrt(Variance(values))
 }
 
+// SampleStdDev calculates the sample (n-1) standard deviation of a slice of float64 values
+func SampleStdDev(values []float64) float64 {
+	n := len(values)
+	if n < 2 {
+		return 0
+	}
+	return math.Sqrt(Variance(values) * float64(n) / float64(n-1))
+}
+
+// studentT975 holds the two-sided 95% Student t critical values for 1..30 degrees of freedom.
+var studentT975 = [...]float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// StudentTCritical95 returns the two-sided 95% critical value of the Student t distribution
+// with df degrees of freedom (tabulated up to 30, Cornish-Fisher expansion beyond)
+func StudentTCritical95(df int) float64 {
+	if df <= 0 {
+		return math.Inf(1)
+	}
+	if df <= len(studentT975) {
+		return studentT975[df-1]
+	}
+	const z = 1.959964
+	v := float64(df)
+	z3, z5 := z*z*z, z*z*z*z*z
+	return z + (z3+z)/(4*v) + (5*z5+16*z3+3*z)/(96*v*v)
+}
+
 // Percentile calculates the percentile of a slice of float64 values
 // percentile should be between 0 and 100
 func Percentile(values []float64, percentile float64) float64 {
@@ -165,6 +165,38 @@ func TestStdDev(t *testing.T) {
 	}
 }
 
+func TestSampleStdDev(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5}
+	// Sample variance of 1,2,3,4,5 is 2.5
+	if got := SampleStdDev(values); math.Abs(got-math.Sqrt(2.5)) > 1e-9 {
+		t.Errorf("SampleStdDev(%v) = %f, expected %f", values, got, math.Sqrt(2.5))
+	}
+	if got := SampleStdDev([]float64{3}); got != 0 {
+		t.Errorf("SampleStdDev of one value should be 0, got %f", got)
+	}
+}
+
+func TestStudentTCritical95(t *testing.T) {
+	tests := []struct {
+		df       int
+		expected float64
+	}{
+		{1, 12.706},
+		{4, 2.776},
+		{30, 2.042},
+		{60, 2.000},
+		{120, 1.980},
+	}
+	for _, tt := range tests {
+		if got := StudentTCritical95(tt.df); math.Abs(got-tt.expected) > 1e-3 {
+			t.Errorf("StudentTCritical95(%d) = %f, expected %f", tt.df, got, tt.expected)
+		}
+	}
+	if !math.IsInf(StudentTCritical95(0), 1) {
+		t.Errorf("StudentTCritical95(0) should be +Inf")
+	}
+}
+
 func TestPercentile(t *testing.T) {
 	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
 
@@ -97,6 +97,55 @@ func (r *RandSource) UniformFloat64(min, max float64) float64 {
 	return min + r.rng.Float64()*(max-min)
 }
 
+// ParetoFloat64 returns a Pareto-distributed random number with scale xm (the minimum) and shape alpha
+func (r *RandSource) ParetoFloat64(xm, alpha float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Inverse transform; 1-U is in (0, 1] so the draw stays finite.
+	return xm / math.Pow(1-r.rng.Float64(), 1/alpha)
+}
+
+// LogNormalFloat64 returns a log-normally distributed random number whose logarithm has mean mu and stddev sigma
+func (r *RandSource) LogNormalFloat64(mu, sigma float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return math.Exp(r.rng.NormFloat64()*sigma + mu)
+}
+
+// GammaFloat64 returns a gamma-distributed random number with shape k and scale theta
+func (r *RandSource) GammaFloat64(k, theta float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	boost := 1.0
+	if k < 1 {
+		// Gamma(k) = Gamma(k+1) * U^(1/k)
+		boost = math.Pow(1-r.rng.Float64(), 1/k)
+		k++
+	}
+	// Marsaglia and Tsang's method
+	d := k - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := r.rng.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := r.rng.Float64()
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v * theta * boost
+		}
+	}
+}
+
+// WeibullFloat64 returns a Weibull-distributed random number with shape k and scale lambda
+func (r *RandSource) WeibullFloat64(k, lambda float64) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return lambda * math.Pow(-math.Log(1-r.rng.Float64()), 1/k)
+}
+
 // Global default random source
 var defaultRand = NewRandSource(0)
 var defaultRandMu sync.Mutex
@@ -172,6 +172,65 @@ func TestRandSourceUniformFloat64(t *testing.T) {
 	}
 }
 
+func TestRandSourceParetoFloat64(t *testing.T) {
+	rng := NewRandSource(12345)
+	xm, alpha := 2.0, 3.0
+
+	samples := make([]float64, 20000)
+	for i := range samples {
+		samples[i] = rng.ParetoFloat64(xm, alpha)
+		if samples[i] < xm {
+			t.Fatalf("ParetoFloat64 returned %f below the scale %f", samples[i], xm)
+		}
+	}
+	// Mean is alpha*xm/(alpha-1) = 3.
+	if m := Mean(samples); math.Abs(m-3) > 0.1 {
+		t.Errorf("ParetoFloat64 mean %f not close to expected 3", m)
+	}
+}
+
+func TestRandSourceLogNormalFloat64(t *testing.T) {
+	rng := NewRandSource(12345)
+
+	samples := make([]float64, 20000)
+	for i := range samples {
+		samples[i] = rng.LogNormalFloat64(0, 0.5)
+		if samples[i] <= 0 {
+			t.Fatalf("LogNormalFloat64 returned non-positive %f", samples[i])
+		}
+	}
+	// Mean is exp(mu + sigma^2/2).
+	if m, want := Mean(samples), math.Exp(0.125); math.Abs(m-want) > 0.02 {
+		t.Errorf("LogNormalFloat64 mean %f not close to expected %f", m, want)
+	}
+}
+
+func TestRandSourceGammaAndWeibull(t *testing.T) {
+	rng := NewRandSource(12345)
+	for _, k := range []float64{0.5, 2, 9} {
+		samples := make([]float64, 20000)
+		for i := range samples {
+			samples[i] = rng.GammaFloat64(k, 3)
+		}
+		// Mean k*theta, variance k*theta^2.
+		if m := Mean(samples); math.Abs(m-3*k)/(3*k) > 0.03 {
+			t.Errorf("GammaFloat64(%v, 3) mean %f not close to expected %f", k, m, 3*k)
+		}
+		if sd, want := StdDev(samples), 3*math.Sqrt(k); math.Abs(sd-want)/want > 0.05 {
+			t.Errorf("GammaFloat64(%v, 3) stddev %f not close to expected %f", k, sd, want)
+		}
+	}
+
+	samples := make([]float64, 20000)
+	for i := range samples {
+		samples[i] = rng.WeibullFloat64(2, 10)
+	}
+	// Mean lambda*Gamma(1+1/k).
+	if m, want := Mean(samples), 10*math.Gamma(1.5); math.Abs(m-want) > 0.15 {
+		t.Errorf("WeibullFloat64 mean %f not close to expected %f", m, want)
+	}
+}
+
 func TestGlobalRandFunctions(t *testing.T) {
 	SetSeed(12345)
 